@@ -0,0 +1,528 @@
+// Part of the Antha language
+// Copyright (C) 2015 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package doe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/fit"
+)
+
+// A Model is a polynomial in the factors of a design. Each term is the
+// product of the factors with the given indices; the intercept has none.
+type Model struct {
+	Factors []string
+	Terms   [][]int
+}
+
+// NewModel returns the model of an order in factors
+func NewModel(factors []string, order ModelOrder) Model {
+	m := Model{Factors: factors, Terms: [][]int{{}}}
+	for i := range factors {
+		m.Terms = append(m.Terms, []int{i})
+	}
+	if order >= Interactions {
+		for i := range factors {
+			for j := i + 1; j < len(factors); j++ {
+				m.Terms = append(m.Terms, []int{i, j})
+			}
+		}
+	}
+	if order >= Quadratic {
+		for i := range factors {
+			m.Terms = append(m.Terms, []int{i, i})
+		}
+	}
+	return m
+}
+
+// TermName returns the name of a term of the model, e.g., Intercept, A, A*B
+// or A^2
+func (m Model) TermName(term []int) string {
+	if len(term) == 0 {
+		return "Intercept"
+	}
+	if len(term) == 2 && term[0] == term[1] {
+		return m.Factors[term[0]] + "^2"
+	}
+	var names []string
+	for _, i := range term {
+		names = append(names, m.Factors[i])
+	}
+	return strings.Join(names, "*")
+}
+
+// row returns the values of the terms of the model for coded factor values
+func (m Model) row(x []float64) []float64 {
+	row := make([]float64, len(m.Terms))
+	for i, term := range m.Terms {
+		row[i] = 1
+		for _, f := range term {
+			row[i] *= x[f]
+		}
+	}
+	return row
+}
+
+func (m Model) without(t int) Model {
+	ret := Model{Factors: m.Factors}
+	for i, term := range m.Terms {
+		if i != t {
+			ret.Terms = append(ret.Terms, term)
+		}
+	}
+	return ret
+}
+
+// contains returns true if term u is of higher order than term t and
+// includes all of its factors, e.g., A*B or A^2 contain A
+func contains(u, t []int) bool {
+	if len(u) <= len(t) {
+		return false
+	}
+	for _, f := range t {
+		found := false
+		for _, g := range u {
+			if f == g {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// A Coefficient is the estimated coefficient of a term of a model in coded
+// units, i.e., with each factor scaled to lie between -1 and 1
+type Coefficient struct {
+	Term     string
+	Estimate float64
+	StdErr   float64
+	T        float64
+	P        float64
+}
+
+// An ANOVARow is a source of variation in an analysis of variance. F and P
+// are NaN for the residual and total rows.
+type ANOVARow struct {
+	Source string
+	DF     int
+	SS     float64
+	MS     float64
+	F      float64
+	P      float64
+}
+
+// A ModelFit is a model fitted to the responses of runs by least squares
+type ModelFit struct {
+	Model        Model
+	Response     string
+	Coefficients []Coefficient
+	// ANOVA has a row for the model, each term, the residual and the total.
+	// If runs are replicated, the residual is split into lack of fit and
+	// pure error.
+	ANOVA             []ANOVARow
+	RSquared          float64
+	AdjustedRSquared  float64
+	PredictedRSquared float64
+	// Residual diagnostics by run
+	Fitted               []float64
+	Residuals            []float64
+	Leverage             []float64
+	StudentizedResiduals []float64
+
+	ranges []factorRange
+	x      [][]float64
+	y      []float64
+}
+
+// designFactors returns the numeric factors which vary between runs
+func designFactors(runs []Run) ([]string, error) {
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("no runs")
+	}
+	var ret []string
+	for _, name := range runs[0].Factordescriptors {
+		levels := make(map[float64]bool)
+		numeric := true
+		for _, run := range runs {
+			v, err := run.GetFactorValue(name)
+			if err != nil {
+				return nil, err
+			}
+			f, ok := toFloat(v)
+			if !ok {
+				numeric = false
+				break
+			}
+			levels[f] = true
+		}
+		if !numeric {
+			return nil, fmt.Errorf("factor %s is not numeric", name)
+		}
+		if len(levels) > 1 {
+			ret = append(ret, name)
+		}
+	}
+	return ret, nil
+}
+
+// FitResponseSurface fits a model of an order, i.e., MainEffects,
+// Interactions or Quadratic, to a response of runs. All of the numeric
+// factors which vary between runs are included.
+func FitResponseSurface(runs []Run, response string, order ModelOrder) (*ModelFit, error) {
+	factors, err := designFactors(runs)
+	if err != nil {
+		return nil, err
+	}
+	return FitModel(runs, response, NewModel(factors, order))
+}
+
+// FitModel fits a model to a response of runs
+func FitModel(runs []Run, response string, model Model) (*ModelFit, error) {
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("no runs")
+	}
+
+	mf := &ModelFit{Model: model, Response: response}
+	for _, name := range model.Factors {
+		r := factorRange{low: math.Inf(1), high: math.Inf(-1)}
+		for _, run := range runs {
+			v, err := run.GetFactorValue(name)
+			if err != nil {
+				return nil, err
+			}
+			f, ok := toFloat(v)
+			if !ok {
+				return nil, fmt.Errorf("factor %s has non-numeric value %v in run %d", name, v, run.RunNumber)
+			}
+			r.low = math.Min(r.low, f)
+			r.high = math.Max(r.high, f)
+		}
+		if r.low == r.high {
+			return nil, fmt.Errorf("factor %s does not vary between runs", name)
+		}
+		mf.ranges = append(mf.ranges, r)
+	}
+
+	for _, run := range runs {
+		v, err := run.GetResponseValue(response)
+		if err != nil {
+			return nil, err
+		}
+		y, ok := toFloat(v)
+		if !ok {
+			return nil, fmt.Errorf("response %s has non-numeric value %v in run %d", response, v, run.RunNumber)
+		}
+		x := make([]float64, len(model.Factors))
+		for j, name := range model.Factors {
+			v, _ := run.GetFactorValue(name)
+			f, _ := toFloat(v)
+			x[j] = mf.ranges[j].coded(f)
+		}
+		mf.x = append(mf.x, x)
+		mf.y = append(mf.y, y)
+	}
+
+	if err := mf.fit(); err != nil {
+		return nil, err
+	}
+	return mf, nil
+}
+
+func (mf *ModelFit) rows(model Model) [][]float64 {
+	rows := make([][]float64, len(mf.x))
+	for i, x := range mf.x {
+		rows[i] = model.row(x)
+	}
+	return rows
+}
+
+func (mf *ModelFit) fit() error {
+	reg, err := fit.Regress(mf.rows(mf.Model), mf.y)
+	if ae, ok := err.(*fit.AliasError); ok {
+		return fmt.Errorf("cannot fit model to %s: %s", mf.Response, ae.Explain(func(i int) string {
+			return mf.Model.TermName(mf.Model.Terms[i])
+		}))
+	} else if err != nil {
+		return fmt.Errorf("cannot fit model to %s: %s", mf.Response, err)
+	}
+
+	n := len(mf.y)
+	p := len(mf.Model.Terms)
+	mse := math.NaN()
+	if reg.DF > 0 {
+		mse = reg.RSS / float64(reg.DF)
+	}
+
+	var mean, tss float64
+	for _, y := range mf.y {
+		mean += y
+	}
+	mean /= float64(n)
+	for _, y := range mf.y {
+		tss += (y - mean) * (y - mean)
+	}
+
+	mf.Coefficients = nil
+	for i, term := range mf.Model.Terms {
+		c := Coefficient{
+			Term:     mf.Model.TermName(term),
+			Estimate: reg.Coefficients[i],
+			StdErr:   math.Sqrt(mse * reg.XtXInv[i][i]),
+		}
+		c.T = c.Estimate / c.StdErr
+		c.P = math.NaN()
+		if reg.DF > 0 {
+			c.P = 2 * (1 - fit.StudentTCDF(math.Abs(c.T), reg.DF))
+		}
+		mf.Coefficients = append(mf.Coefficients, c)
+	}
+
+	pvalue := func(ms float64, df int) (float64, float64) {
+		if reg.DF <= 0 || df <= 0 {
+			return math.NaN(), math.NaN()
+		}
+		f := ms / mse
+		return f, 1 - fit.FCDF(f, df, reg.DF)
+	}
+	row := func(source string, df int, ss float64) ANOVARow {
+		r := ANOVARow{Source: source, DF: df, SS: ss, MS: ss / float64(df)}
+		r.F, r.P = pvalue(r.MS, df)
+		return r
+	}
+
+	mf.ANOVA = []ANOVARow{row("Model", p-1, tss-reg.RSS)}
+	for i, term := range mf.Model.Terms {
+		if len(term) == 0 {
+			continue
+		}
+		reduced, err := fit.Regress(mf.rows(mf.Model.without(i)), mf.y)
+		if err != nil {
+			return err
+		}
+		mf.ANOVA = append(mf.ANOVA, row(mf.Model.TermName(term), 1, reduced.RSS-reg.RSS))
+	}
+	nan := math.NaN()
+	mf.ANOVA = append(mf.ANOVA, ANOVARow{Source: "Residual", DF: reg.DF, SS: reg.RSS, MS: mse, F: nan, P: nan})
+
+	// pure error from replicated runs
+	groups := make(map[string][]float64)
+	for i, x := range mf.x {
+		key := fmt.Sprint(x)
+		groups[key] = append(groups[key], mf.y[i])
+	}
+	var pureSS float64
+	var pureDF int
+	for _, ys := range groups {
+		var m float64
+		for _, y := range ys {
+			m += y
+		}
+		m /= float64(len(ys))
+		for _, y := range ys {
+			pureSS += (y - m) * (y - m)
+		}
+		pureDF += len(ys) - 1
+	}
+	if lofDF := reg.DF - pureDF; pureDF > 0 && lofDF > 0 {
+		pureMS := pureSS / float64(pureDF)
+		lof := ANOVARow{Source: "Lack of fit", DF: lofDF, SS: reg.RSS - pureSS}
+		lof.MS = lof.SS / float64(lofDF)
+		lof.F = lof.MS / pureMS
+		lof.P = 1 - fit.FCDF(lof.F, lofDF, pureDF)
+		mf.ANOVA = append(mf.ANOVA, lof, ANOVARow{Source: "Pure error", DF: pureDF, SS: pureSS, MS: pureMS, F: nan, P: nan})
+	}
+	mf.ANOVA = append(mf.ANOVA, ANOVARow{Source: "Total", DF: n - 1, SS: tss, MS: tss / float64(n-1), F: nan, P: nan})
+
+	mf.Fitted = reg.Fitted
+	mf.Residuals = reg.Residuals
+	mf.Leverage = nil
+	mf.StudentizedResiduals = nil
+	var press float64
+	for i, row := range mf.rows(mf.Model) {
+		h := reg.Leverage(row)
+		mf.Leverage = append(mf.Leverage, h)
+		mf.StudentizedResiduals = append(mf.StudentizedResiduals, reg.Residuals[i]/math.Sqrt(mse*(1-h)))
+		press += math.Pow(reg.Residuals[i]/(1-h), 2)
+	}
+
+	mf.RSquared = 1 - reg.RSS/tss
+	mf.AdjustedRSquared = 1 - mse/(tss/float64(n-1))
+	mf.PredictedRSquared = 1 - press/tss
+	return nil
+}
+
+// Reduce removes terms from the model in turn, least significant first,
+// until all remaining terms have p-values of at most alpha. Terms which are
+// contained in remaining higher order terms, e.g., A when A*B remains, are
+// kept so that the model stays hierarchical.
+func (mf *ModelFit) Reduce(alpha float64) (*ModelFit, error) {
+	cur := mf
+	for {
+		worst, worstP := -1, alpha
+		for i, term := range cur.Model.Terms {
+			if len(term) == 0 {
+				continue
+			}
+			needed := false
+			for _, u := range cur.Model.Terms {
+				if contains(u, term) {
+					needed = true
+					break
+				}
+			}
+			if p := cur.Coefficients[i].P; !needed && p > worstP {
+				worst, worstP = i, p
+			}
+		}
+		if worst < 0 {
+			return cur, nil
+		}
+
+		next := &ModelFit{
+			Model:    cur.Model.without(worst),
+			Response: cur.Response,
+			ranges:   cur.ranges,
+			x:        cur.x,
+			y:        cur.y,
+		}
+		if err := next.fit(); err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+}
+
+// Predict returns the response predicted by the model for factor values
+func (mf *ModelFit) Predict(values map[string]float64) (float64, error) {
+	x := make([]float64, len(mf.Model.Factors))
+	for j, name := range mf.Model.Factors {
+		v, ok := values[name]
+		if !ok {
+			return 0, fmt.Errorf("no value for factor %s", name)
+		}
+		x[j] = mf.ranges[j].coded(v)
+	}
+	return mf.predictCoded(x), nil
+}
+
+func (mf *ModelFit) predictCoded(x []float64) float64 {
+	var y float64
+	for i, v := range mf.Model.row(x) {
+		y += v * mf.Coefficients[i].Estimate
+	}
+	return y
+}
+
+// Optimum returns a run with the factor values within the range of the runs
+// which maximise, or minimise, the predicted response, and the response
+// predicted there. The optimum is found by a grid search followed by a
+// pattern search from the best grid points.
+func (mf *ModelFit) Optimum(maximise bool) (Run, float64, error) {
+	k := len(mf.Model.Factors)
+	if k == 0 {
+		return Run{}, 0, fmt.Errorf("model has no factors")
+	}
+	sign := -1.0
+	if maximise {
+		sign = 1.0
+	}
+	score := func(x []float64) float64 {
+		return sign * mf.predictCoded(x)
+	}
+
+	// grid of at most about 10000 points
+	levels := int(math.Max(2, math.Floor(math.Pow(10000, 1/float64(k)))))
+	if levels > 21 {
+		levels = 21
+	}
+	type point struct {
+		x     []float64
+		score float64
+	}
+	var grid []point
+	idx := make([]int, k)
+	for {
+		x := make([]float64, k)
+		for j, i := range idx {
+			x[j] = -1 + 2*float64(i)/float64(levels-1)
+		}
+		grid = append(grid, point{x, score(x)})
+
+		j := 0
+		for ; j < k; j++ {
+			idx[j]++
+			if idx[j] < levels {
+				break
+			}
+			idx[j] = 0
+		}
+		if j == k {
+			break
+		}
+	}
+	sort.SliceStable(grid, func(i, j int) bool { return grid[i].score > grid[j].score })
+
+	best := grid[0]
+	for s := 0; s < 5 && s < len(grid); s++ {
+		x := append([]float64(nil), grid[s].x...)
+		cur := grid[s].score
+		for step := 1 / float64(levels-1); step > 1.0e-7; step /= 2 {
+			for improved := true; improved; {
+				improved = false
+				for j := range x {
+					for _, d := range []float64{-step, step} {
+						old := x[j]
+						x[j] = math.Max(-1, math.Min(1, old+d))
+						if sc := score(x); sc > cur+1.0e-12 {
+							cur = sc
+							improved = true
+						} else {
+							x[j] = old
+						}
+					}
+				}
+			}
+		}
+		if cur > best.score {
+			best = point{x, cur}
+		}
+	}
+
+	var run Run
+	for j, name := range mf.Model.Factors {
+		run = AddNewFactorFieldandValue(run, name, mf.ranges[j].actual(best.x[j]))
+	}
+	predicted := mf.predictCoded(best.x)
+	run = AddNewResponseFieldandValue(run, mf.Response, predicted)
+	run.RunNumber = 1
+	run.StdNumber = 1
+	return run, predicted, nil
+}
@@ -0,0 +1,193 @@
+package doe
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// surface is a quadratic in coded A and B with no effect of C
+func surface(x []float64) float64 {
+	a, b := x[0], x[1]
+	return 10 + 2*a - 3*b + 1.5*a*b - 4*a*a - 2*b*b
+}
+
+func responseRuns(t *testing.T) []Run {
+	factors := numericFactors()
+	runs, err := BoxBehnken(factors, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ranges, _ := numericRanges(factors)
+	for i := range runs {
+		x := make([]float64, len(factors))
+		for j := range factors {
+			v, _ := toFloat(runs[i].Setpoints[j])
+			x[j] = ranges[j].coded(v)
+		}
+		runs[i] = AddNewResponseFieldandValue(runs[i], "Yield", surface(x)+0.05*math.Sin(float64(5*i)))
+	}
+	return runs
+}
+
+func anovaRow(mf *ModelFit, source string) (ANOVARow, bool) {
+	for _, r := range mf.ANOVA {
+		if r.Source == source {
+			return r, true
+		}
+	}
+	return ANOVARow{}, false
+}
+
+func TestFitResponseSurface(t *testing.T) {
+	mf, err := FitResponseSurface(responseRuns(t), "Yield", Quadratic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 10, len(mf.Coefficients); e != f {
+		t.Fatalf("expecting %d coefficients found %d", e, f)
+	}
+
+	expected := map[string]float64{
+		"Intercept":      10,
+		"Temperature":    2,
+		"pH":             -3,
+		"Temperature*pH": 1.5,
+		"Temperature^2":  -4,
+		"pH^2":           -2,
+		"Glucose":        0,
+	}
+	for _, c := range mf.Coefficients {
+		e, ok := expected[c.Term]
+		if !ok {
+			continue
+		}
+		if math.Abs(c.Estimate-e) > 0.1 {
+			t.Errorf("%s: expecting %g found %g", c.Term, e, c.Estimate)
+		}
+		if e != 0 && c.P > 0.001 {
+			t.Errorf("%s: expecting significant effect found p = %g", c.Term, c.P)
+		}
+	}
+	if mf.RSquared < 0.99 {
+		t.Errorf("expecting R^2 near 1 found %g", mf.RSquared)
+	}
+
+	if _, ok := anovaRow(mf, "Pure error"); !ok {
+		t.Error("expecting pure error from replicated centre points")
+	}
+	total, _ := anovaRow(mf, "Total")
+	if e, f := 14, total.DF; e != f {
+		t.Errorf("expecting %d total degrees of freedom found %d", e, f)
+	}
+	if e, f := 15, len(mf.StudentizedResiduals); e != f {
+		t.Errorf("expecting %d residuals found %d", e, f)
+	}
+
+	y, err := mf.Predict(map[string]float64{"Temperature": 31, "pH": 7, "Glucose": 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(y-10) > 0.1 {
+		t.Errorf("expecting prediction at centre of 10 found %g", y)
+	}
+	if _, err := mf.Predict(map[string]float64{"Temperature": 31}); err == nil {
+		t.Error("expecting error for missing factor")
+	}
+}
+
+func TestReduceModel(t *testing.T) {
+	mf, err := FitResponseSurface(responseRuns(t), "Yield", Quadratic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reduced, err := mf.Reduce(0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var terms []string
+	for _, c := range reduced.Coefficients {
+		terms = append(terms, c.Term)
+		if c.Term != "Intercept" && c.P > 0.05 {
+			t.Errorf("expecting only significant terms found %s with p = %g", c.Term, c.P)
+		}
+	}
+	for _, term := range terms {
+		if term == "Glucose^2" || term == "pH*Glucose" || term == "Temperature*Glucose" {
+			t.Errorf("expecting %s to be removed from %v", term, terms)
+		}
+	}
+	if len(terms) >= len(mf.Coefficients) {
+		t.Errorf("expecting terms to be removed found %v", terms)
+	}
+	if reduced.PredictedRSquared < mf.PredictedRSquared {
+		t.Errorf("expecting reduced model to predict better: %g < %g", reduced.PredictedRSquared, mf.PredictedRSquared)
+	}
+}
+
+func TestOptimum(t *testing.T) {
+	mf, err := FitResponseSurface(responseRuns(t), "Yield", Quadratic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run, y, err := mf.Optimum(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// stationary point of the surface in coded units
+	ranges, _ := numericRanges(numericFactors())
+	for j, e := range []float64{0.1176, -0.7059} {
+		v, _ := toFloat(run.Setpoints[j])
+		if f := ranges[j].coded(v); math.Abs(e-f) > 0.03 {
+			t.Errorf("%s: expecting coded optimum %g found %g", run.Factordescriptors[j], e, f)
+		}
+	}
+	if e := surface([]float64{0.1176, -0.7059}); math.Abs(e-y) > 0.1 {
+		t.Errorf("expecting maximum %g found %g", e, y)
+	}
+	if r, err := run.GetResponseValue("Yield"); err != nil || r.(float64) != y {
+		t.Errorf("expecting predicted response in run found %v", r)
+	}
+
+	// the minimum is at a corner of the design space
+	run, _, err = mf.Optimum(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _ := toFloat(run.Setpoints[0])
+	if c := ranges[0].coded(v); math.Abs(math.Abs(c)-1) > 1.0e-6 {
+		t.Errorf("expecting minimum at the limit of Temperature found %g", v)
+	}
+}
+
+func TestFitAliasedModel(t *testing.T) {
+	var factors []DOEPair
+	var names []string
+	for _, name := range []string{"F0", "F1", "F2", "F3"} {
+		factors = append(factors, Pair(name, []interface{}{0.1, 0.3}))
+		names = append(names, name)
+	}
+	// resolution IV, so two factor interactions are aliased in pairs
+	runs, err := FractionalFactorial(factors, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 8, len(runs); e != f {
+		t.Fatalf("expecting %d runs found %d", e, f)
+	}
+	for i := range runs {
+		runs[i] = AddNewResponseFieldandValue(runs[i], "Yield", float64(i))
+	}
+
+	model := NewModel(names, MainEffects)
+	model.Terms = append(model.Terms, []int{0, 1}, []int{2, 3})
+	_, err = FitModel(runs, "Yield", model)
+	if err == nil {
+		t.Fatal("expecting error for aliased interactions")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "F2*F3 is aliased with F0*F1") {
+		t.Errorf("expecting error to name the aliased terms found %q", msg)
+	}
+}
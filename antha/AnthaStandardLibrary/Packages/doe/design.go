@@ -0,0 +1,647 @@
+// Part of the Antha language
+// Copyright (C) 2015 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package doe
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// A Constraint returns false for runs with combinations of factor levels
+// which cannot be used
+type Constraint func(run Run) bool
+
+// ModelOrder is the order of polynomial model a design is built for
+type ModelOrder int
+
+const (
+	// MainEffects models have a term for each factor
+	MainEffects ModelOrder = iota
+	// Interactions models add terms for each two factor interaction
+	Interactions
+	// Quadratic models add squared terms for each factor
+	Quadratic
+)
+
+func toFloat(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case float32:
+		return float64(t), true
+	case int:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	default:
+		return 0, false
+	}
+}
+
+// factorRange is the range of a numeric factor, which maps coded levels
+// between -1 and 1 onto actual levels
+type factorRange struct {
+	low, high float64
+}
+
+func (r factorRange) actual(coded float64) float64 {
+	return (r.high+r.low)/2 + coded*(r.high-r.low)/2
+}
+
+func (r factorRange) coded(actual float64) float64 {
+	return (actual - (r.high+r.low)/2) / ((r.high - r.low) / 2)
+}
+
+// numericRanges returns the ranges of the levels of numeric factors
+func numericRanges(factors []DOEPair) ([]factorRange, error) {
+	var ret []factorRange
+	for _, f := range factors {
+		if len(f.Levels) == 0 {
+			return nil, fmt.Errorf("factor %s has no levels", f.Factor)
+		}
+		r := factorRange{low: math.Inf(1), high: math.Inf(-1)}
+		for _, l := range f.Levels {
+			v, ok := toFloat(l)
+			if !ok {
+				return nil, fmt.Errorf("factor %s has non-numeric level %v", f.Factor, l)
+			}
+			r.low = math.Min(r.low, v)
+			r.high = math.Max(r.high, v)
+		}
+		if r.low == r.high {
+			return nil, fmt.Errorf("factor %s must have more than one level", f.Factor)
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+func checkTwoLevels(factors []DOEPair) error {
+	if len(factors) == 0 {
+		return fmt.Errorf("no factors")
+	}
+	for _, f := range factors {
+		if len(f.Levels) != 2 {
+			return fmt.Errorf("factor %s must have 2 levels, low and high, but has %d", f.Factor, len(f.Levels))
+		}
+	}
+	return nil
+}
+
+// twoLevelRuns returns runs with each factor at its first level where the
+// sign is negative and its second where positive
+func twoLevelRuns(factors []DOEPair, signs [][]int) []Run {
+	runs := make([]Run, len(signs))
+	for i, row := range signs {
+		for j, f := range factors {
+			level := f.Levels[0]
+			if row[j] > 0 {
+				level = f.Levels[1]
+			}
+			runs[i] = AddNewFactorFieldandValue(runs[i], f.Factor, level)
+		}
+		runs[i].RunNumber = i + 1
+		runs[i].StdNumber = i + 1
+	}
+	return runs
+}
+
+// codedRuns returns runs with each numeric factor at the actual level for a
+// coded level
+func codedRuns(factors []DOEPair, ranges []factorRange, coded [][]float64) []Run {
+	runs := make([]Run, len(coded))
+	for i, row := range coded {
+		for j, f := range factors {
+			runs[i] = AddNewFactorFieldandValue(runs[i], f.Factor, ranges[j].actual(row[j]))
+		}
+		runs[i].RunNumber = i + 1
+		runs[i].StdNumber = i + 1
+	}
+	return runs
+}
+
+func popcount(x uint) int {
+	n := 0
+	for ; x != 0; x &= x - 1 {
+		n++
+	}
+	return n
+}
+
+// generators finds generators for a two level fractional factorial design of
+// n factors in 2^k runs with at least the given resolution. Each generator is
+// the set of base factors whose product gives an added factor.
+func generators(n, k, resolution int) ([]uint, bool) {
+	var candidates []uint
+	for g := uint(1); g < 1<<uint(k); g++ {
+		if popcount(g)+1 >= resolution {
+			candidates = append(candidates, g)
+		}
+	}
+	// prefer longer words which give higher resolution
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return popcount(candidates[i]) > popcount(candidates[j])
+	})
+
+	budget := 100000
+	chosen := make([]uint, 0, n-k)
+	var search func(from int, words []uint) bool
+	search = func(from int, words []uint) bool {
+		if len(chosen) == n-k {
+			return true
+		}
+		for c := from; c < len(candidates); c++ {
+			if budget--; budget < 0 {
+				return false
+			}
+			word := candidates[c] | 1<<uint(k+len(chosen))
+			next := append([]uint{word}, words...)
+			ok := true
+			for _, w := range words {
+				x := w ^ word
+				if popcount(x) < resolution {
+					ok = false
+					break
+				}
+				next = append(next, x)
+			}
+			if !ok {
+				continue
+			}
+			chosen = append(chosen, candidates[c])
+			if search(c+1, next) {
+				return true
+			}
+			chosen = chosen[:len(chosen)-1]
+		}
+		return false
+	}
+
+	if !search(0, nil) {
+		return nil, false
+	}
+	return chosen, true
+}
+
+// FractionalFactorial returns the smallest two level fractional factorial
+// design for factors with the given resolution, e.g., 3 for resolution III in
+// which main effects are not aliased with each other, or 5 for resolution V in
+// which two factor interactions are not aliased with each other. Each factor
+// must have two levels, low and high. Runs are in standard order.
+func FractionalFactorial(factors []DOEPair, resolution int) ([]Run, error) {
+	if err := checkTwoLevels(factors); err != nil {
+		return nil, err
+	}
+	if resolution < 3 {
+		return nil, fmt.Errorf("resolution must be at least 3 but is %d", resolution)
+	}
+
+	n := len(factors)
+	for k := 1; k <= n; k++ {
+		gens, ok := generators(n, k, resolution)
+		if !ok {
+			continue
+		}
+
+		var signs [][]int
+		for r := 0; r < 1<<uint(k); r++ {
+			row := make([]int, n)
+			for j := 0; j < k; j++ {
+				row[j] = -1
+				if r&(1<<uint(j)) != 0 {
+					row[j] = 1
+				}
+			}
+			for i, g := range gens {
+				s := 1
+				for j := 0; j < k; j++ {
+					if g&(1<<uint(j)) != 0 {
+						s *= row[j]
+					}
+				}
+				row[k+i] = s
+			}
+			signs = append(signs, row)
+		}
+		return twoLevelRuns(factors, signs), nil
+	}
+	return nil, fmt.Errorf("cannot find a design of resolution %d for %d factors", resolution, n)
+}
+
+// plackettBurmanRows are the first rows of the cyclic Plackett-Burman designs
+var plackettBurmanRows = map[int]string{
+	8:  "+++-+--",
+	12: "++-+++---+-",
+	16: "++++-+-++--+---",
+	20: "++--++++-+-+----++-",
+	24: "+++++-+-++--++--+-+----",
+}
+
+// PlackettBurman returns a Plackett-Burman screening design for up to 23
+// factors in the smallest multiple of 4 runs, and at least 8, greater than
+// the number of factors. Each factor must have two levels, low and high.
+func PlackettBurman(factors []DOEPair) ([]Run, error) {
+	if err := checkTwoLevels(factors); err != nil {
+		return nil, err
+	}
+
+	n := 8
+	for n <= len(factors) {
+		n += 4
+	}
+	first, ok := plackettBurmanRows[n]
+	if !ok {
+		return nil, fmt.Errorf("Plackett-Burman designs are available for up to 23 factors not %d", len(factors))
+	}
+
+	var signs [][]int
+	for r := 0; r < n-1; r++ {
+		row := make([]int, len(factors))
+		for j := range row {
+			row[j] = -1
+			if first[(j-r+n-1)%(n-1)] == '+' {
+				row[j] = 1
+			}
+		}
+		signs = append(signs, row)
+	}
+	last := make([]int, len(factors))
+	for j := range last {
+		last[j] = -1
+	}
+	signs = append(signs, last)
+
+	return twoLevelRuns(factors, signs), nil
+}
+
+// BoxBehnken returns a Box-Behnken design for three or more numeric factors:
+// each pair of factors at the corners of their ranges with the others at their
+// centres, plus centrePoints runs at the centre of all factors.
+func BoxBehnken(factors []DOEPair, centrePoints int) ([]Run, error) {
+	if len(factors) < 3 {
+		return nil, fmt.Errorf("Box-Behnken designs need at least 3 factors but have %d", len(factors))
+	}
+	ranges, err := numericRanges(factors)
+	if err != nil {
+		return nil, err
+	}
+
+	k := len(factors)
+	var coded [][]float64
+	for i := 0; i < k; i++ {
+		for j := i + 1; j < k; j++ {
+			for _, s := range [][2]float64{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+				row := make([]float64, k)
+				row[i], row[j] = s[0], s[1]
+				coded = append(coded, row)
+			}
+		}
+	}
+	for c := 0; c < centrePoints; c++ {
+		coded = append(coded, make([]float64, k))
+	}
+	return codedRuns(factors, ranges, coded), nil
+}
+
+// CentralComposite returns a central composite design for two or more numeric
+// factors: a full factorial at the limits of their ranges, axial runs at
+// alpha times the half range from the centre and centrePoints runs at the
+// centre. Alpha of 1 gives a face centred design; if alpha is zero the
+// rotatable value, (2^k)^1/4, is used. Axial runs lie outside the ranges of
+// the factors if alpha is greater than 1.
+func CentralComposite(factors []DOEPair, alpha float64, centrePoints int) ([]Run, error) {
+	if len(factors) < 2 {
+		return nil, fmt.Errorf("central composite designs need at least 2 factors but have %d", len(factors))
+	}
+	ranges, err := numericRanges(factors)
+	if err != nil {
+		return nil, err
+	}
+
+	k := len(factors)
+	if alpha <= 0 {
+		alpha = math.Pow(math.Pow(2, float64(k)), 0.25)
+	}
+
+	var coded [][]float64
+	for r := 0; r < 1<<uint(k); r++ {
+		row := make([]float64, k)
+		for j := range row {
+			row[j] = -1
+			if r&(1<<uint(j)) != 0 {
+				row[j] = 1
+			}
+		}
+		coded = append(coded, row)
+	}
+	for j := 0; j < k; j++ {
+		for _, a := range []float64{-alpha, alpha} {
+			row := make([]float64, k)
+			row[j] = a
+			coded = append(coded, row)
+		}
+	}
+	for c := 0; c < centrePoints; c++ {
+		coded = append(coded, make([]float64, k))
+	}
+	return codedRuns(factors, ranges, coded), nil
+}
+
+// LatinHypercube returns a Latin hypercube design of n runs for numeric
+// factors: the range of each factor is divided into n intervals and each
+// interval is sampled once. Of a number of random designs, the one whose
+// closest runs are furthest apart is returned. The same seed gives the same
+// design.
+func LatinHypercube(factors []DOEPair, n int, seed int64) ([]Run, error) {
+	if n < 2 {
+		return nil, fmt.Errorf("Latin hypercube designs need at least 2 runs")
+	}
+	ranges, err := numericRanges(factors)
+	if err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	k := len(factors)
+
+	var best [][]float64
+	bestDist := -1.0
+	for try := 0; try < 50; try++ {
+		unit := make([][]float64, n)
+		for i := range unit {
+			unit[i] = make([]float64, k)
+		}
+		for j := 0; j < k; j++ {
+			for i, p := range rnd.Perm(n) {
+				unit[i][j] = (float64(p) + rnd.Float64()) / float64(n)
+			}
+		}
+
+		minDist := math.Inf(1)
+		for a := 0; a < n; a++ {
+			for b := a + 1; b < n; b++ {
+				var d float64
+				for j := 0; j < k; j++ {
+					x := unit[a][j] - unit[b][j]
+					d += x * x
+				}
+				minDist = math.Min(minDist, d)
+			}
+		}
+		if minDist > bestDist {
+			best, bestDist = unit, minDist
+		}
+	}
+
+	coded := make([][]float64, n)
+	for i, row := range best {
+		coded[i] = make([]float64, k)
+		for j, u := range row {
+			coded[i][j] = 2*u - 1
+		}
+	}
+	return codedRuns(factors, ranges, coded), nil
+}
+
+// logDet returns the log of the determinant of a symmetric positive definite
+// matrix or -Inf if it is singular
+func logDet(m [][]float64) float64 {
+	n := len(m)
+	a := make([][]float64, n)
+	for i := range m {
+		a[i] = append([]float64(nil), m[i]...)
+	}
+	var ld float64
+	for c := 0; c < n; c++ {
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(a[r][c]) > math.Abs(a[p][c]) {
+				p = r
+			}
+		}
+		if math.Abs(a[p][c]) < 1.0e-12 {
+			return math.Inf(-1)
+		}
+		a[c], a[p] = a[p], a[c]
+		ld += math.Log(math.Abs(a[c][c]))
+		for r := c + 1; r < n; r++ {
+			f := a[r][c] / a[c][c]
+			for k := c; k < n; k++ {
+				a[r][k] -= f * a[c][k]
+			}
+		}
+	}
+	return ld
+}
+
+// information returns X^T X for the model rows of the chosen candidates
+func information(rows [][]float64, chosen []int) [][]float64 {
+	p := len(rows[0])
+	m := make([][]float64, p)
+	for i := range m {
+		m[i] = make([]float64, p)
+	}
+	for _, c := range chosen {
+		for i := 0; i < p; i++ {
+			for j := 0; j < p; j++ {
+				m[i][j] += rows[c][i] * rows[c][j]
+			}
+		}
+	}
+	return m
+}
+
+// ridge is added to the diagonal of information matrices so that they can be
+// inverted before there are enough runs to fit a model
+const ridge = 1.0e-6
+
+// regularizedInverse returns the inverse of X^T X + ridge I for the model
+// rows of the chosen candidates
+func regularizedInverse(rows [][]float64, chosen []int) [][]float64 {
+	m := information(rows, chosen)
+	p := len(m)
+	inv := make([][]float64, p)
+	for i := range m {
+		m[i][i] += ridge
+		inv[i] = make([]float64, p)
+		inv[i][i] = 1
+	}
+	// Gauss-Jordan elimination; the matrix is positive definite so no
+	// pivoting is needed
+	for c := 0; c < p; c++ {
+		f := 1 / m[c][c]
+		for k := 0; k < p; k++ {
+			m[c][k] *= f
+			inv[c][k] *= f
+		}
+		for r := 0; r < p; r++ {
+			if r == c || m[r][c] == 0 {
+				continue
+			}
+			g := m[r][c]
+			for k := 0; k < p; k++ {
+				m[r][k] -= g * m[c][k]
+				inv[r][k] -= g * inv[c][k]
+			}
+		}
+	}
+	return inv
+}
+
+func dot(x, y []float64) float64 {
+	var s float64
+	for i := range x {
+		s += x[i] * y[i]
+	}
+	return s
+}
+
+func mulVec(a [][]float64, x []float64) []float64 {
+	ret := make([]float64, len(a))
+	for i := range a {
+		ret[i] = dot(a[i], x)
+	}
+	return ret
+}
+
+// DOptimal returns a design of n runs chosen from all combinations of the
+// levels of numeric factors which satisfy the constraints, if any, so as to
+// maximise the determinant of the information matrix of a model of the given
+// order. Runs may be repeated. The design is found by sequential selection
+// followed by Fedorov exchange so is not guaranteed to be globally optimal.
+func DOptimal(factors []DOEPair, order ModelOrder, n int, constraints ...Constraint) ([]Run, error) {
+	ranges, err := numericRanges(factors)
+	if err != nil {
+		return nil, err
+	}
+	if order == Quadratic {
+		for _, f := range factors {
+			if len(f.Levels) < 3 {
+				return nil, fmt.Errorf("factor %s needs at least 3 levels for a quadratic model", f.Factor)
+			}
+		}
+	}
+
+	var candidates []Run
+	for _, run := range AllCombinations(factors) {
+		ok := true
+		for _, c := range constraints {
+			if !c(run) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			candidates = append(candidates, run)
+		}
+	}
+
+	var names []string
+	for _, f := range factors {
+		names = append(names, f.Factor)
+	}
+	model := NewModel(names, order)
+
+	rows := make([][]float64, len(candidates))
+	for i, run := range candidates {
+		x := make([]float64, len(factors))
+		for j := range factors {
+			v, _ := toFloat(run.Setpoints[j])
+			x[j] = ranges[j].coded(v)
+		}
+		rows[i] = model.row(x)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no combinations of levels satisfy the constraints")
+	}
+	p := len(rows[0])
+	if n < p {
+		return nil, fmt.Errorf("need at least %d runs to fit the model but asked for %d", p, n)
+	}
+
+	// sequential selection of the candidate which most increases the
+	// determinant, which is the one of largest variance x^T M^-1 x
+	var chosen []int
+	for len(chosen) < n {
+		minv := regularizedInverse(rows, chosen)
+		best, bestVar := 0, math.Inf(-1)
+		for c, x := range rows {
+			if v := dot(x, mulVec(minv, x)); v > bestVar {
+				best, bestVar = c, v
+			}
+		}
+		chosen = append(chosen, best)
+	}
+
+	// Fedorov exchange. Exchanging chosen row i for candidate j multiplies
+	// the determinant by (1 + d(j))(1 - d(i)) + d(i, j)^2 where
+	// d(i, j) = x_i^T M^-1 x_j, so each exchange is scored without
+	// recomputing the determinant.
+	for pass := 0; pass < 100; pass++ {
+		minv := regularizedInverse(rows, chosen)
+		mx := make([][]float64, len(rows))
+		d := make([]float64, len(rows))
+		for c, x := range rows {
+			mx[c] = mulVec(minv, x)
+			d[c] = dot(x, mx[c])
+		}
+
+		bestI, bestC, bestGain := -1, -1, 1.0e-9
+		for i, old := range chosen {
+			for c := range rows {
+				if c == old {
+					continue
+				}
+				dij := dot(rows[old], mx[c])
+				r := (1+d[c])*(1-d[old]) + dij*dij
+				if r <= 0 {
+					continue
+				}
+				if gain := math.Log(r); gain > bestGain {
+					bestI, bestC, bestGain = i, c, gain
+				}
+			}
+		}
+		if bestI < 0 {
+			break
+		}
+		chosen[bestI] = bestC
+	}
+
+	current := logDet(information(rows, chosen))
+	if math.IsInf(current, -1) {
+		return nil, fmt.Errorf("cannot find a design which can fit the model from the allowed combinations of levels")
+	}
+
+	sort.Ints(chosen)
+	runs := make([]Run, len(chosen))
+	for i, c := range chosen {
+		runs[i] = Copy(candidates[c])
+		runs[i].RunNumber = i + 1
+		runs[i].StdNumber = i + 1
+	}
+	return runs, nil
+}
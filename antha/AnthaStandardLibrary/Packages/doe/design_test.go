@@ -0,0 +1,317 @@
+package doe
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func twoLevelFactors(n int) []DOEPair {
+	var ret []DOEPair
+	for i := 0; i < n; i++ {
+		ret = append(ret, Pair(fmt.Sprintf("F%d", i+1), []interface{}{-1.0, 1.0}))
+	}
+	return ret
+}
+
+// columns returns the setpoints of runs by factor
+func columns(t *testing.T, runs []Run) [][]float64 {
+	cols := make([][]float64, len(runs[0].Setpoints))
+	for _, run := range runs {
+		for j, s := range run.Setpoints {
+			v, ok := toFloat(s)
+			if !ok {
+				t.Fatalf("non-numeric setpoint %v", s)
+			}
+			cols[j] = append(cols[j], v)
+		}
+	}
+	return cols
+}
+
+func checkOrthogonal(t *testing.T, name string, runs []Run) {
+	cols := columns(t, runs)
+	for i := range cols {
+		var sum float64
+		for _, v := range cols[i] {
+			sum += v
+		}
+		if sum != 0 {
+			t.Errorf("%s: factor %d is not balanced", name, i+1)
+		}
+		for j := i + 1; j < len(cols); j++ {
+			if d := dot(cols[i], cols[j]); d != 0 {
+				t.Errorf("%s: factors %d and %d are not orthogonal", name, i+1, j+1)
+			}
+		}
+	}
+}
+
+func TestFractionalFactorial(t *testing.T) {
+	for _, tc := range []struct {
+		factors    int
+		resolution int
+		runs       int
+	}{
+		{3, 3, 4},
+		{7, 3, 8},
+		{4, 4, 8},
+		{5, 5, 16},
+		{6, 4, 16},
+		{3, 5, 8},
+	} {
+		name := fmt.Sprintf("2^%d resolution %d", tc.factors, tc.resolution)
+		runs, err := FractionalFactorial(twoLevelFactors(tc.factors), tc.resolution)
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if e, f := tc.runs, len(runs); e != f {
+			t.Errorf("%s: expecting %d runs found %d", name, e, f)
+		}
+		checkOrthogonal(t, name, runs)
+
+		if tc.resolution >= 5 {
+			// two factor interactions are orthogonal to main effects
+			cols := columns(t, runs)
+			for a := range cols {
+				for b := a + 1; b < len(cols); b++ {
+					ab := make([]float64, len(runs))
+					for r := range ab {
+						ab[r] = cols[a][r] * cols[b][r]
+					}
+					for c := range cols {
+						if dot(ab, cols[c]) != 0 {
+							t.Errorf("%s: interaction %d%d is aliased with %d", name, a+1, b+1, c+1)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if _, err := FractionalFactorial([]DOEPair{Pair("A", []interface{}{1, 2, 3})}, 3); err == nil {
+		t.Error("expecting error for factor with 3 levels")
+	}
+}
+
+func TestPlackettBurman(t *testing.T) {
+	for _, tc := range []struct {
+		factors int
+		runs    int
+	}{
+		{7, 8},
+		{11, 12},
+		{12, 16},
+		{19, 20},
+		{23, 24},
+	} {
+		name := fmt.Sprintf("PB %d factors", tc.factors)
+		runs, err := PlackettBurman(twoLevelFactors(tc.factors))
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if e, f := tc.runs, len(runs); e != f {
+			t.Errorf("%s: expecting %d runs found %d", name, e, f)
+		}
+		checkOrthogonal(t, name, runs)
+	}
+
+	if _, err := PlackettBurman(twoLevelFactors(24)); err == nil {
+		t.Error("expecting error for 24 factors")
+	}
+}
+
+func numericFactors() []DOEPair {
+	return []DOEPair{
+		Pair("Temperature", []interface{}{25.0, 37.0}),
+		Pair("pH", []interface{}{6.0, 7.0, 8.0}),
+		Pair("Glucose", []interface{}{0, 10}),
+	}
+}
+
+func checkWithin(t *testing.T, name string, runs []Run, factors []DOEPair, scale float64) {
+	ranges, err := numericRanges(factors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, run := range runs {
+		for j, s := range run.Setpoints {
+			v, _ := toFloat(s)
+			if c := ranges[j].coded(v); math.Abs(c) > scale+1.0e-9 {
+				t.Errorf("%s: %s = %g is outside its range", name, factors[j].Factor, v)
+			}
+		}
+	}
+}
+
+func TestBoxBehnken(t *testing.T) {
+	runs, err := BoxBehnken(numericFactors(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 15, len(runs); e != f {
+		t.Errorf("expecting %d runs found %d", e, f)
+	}
+	checkWithin(t, "Box-Behnken", runs, numericFactors(), 1)
+	if e, f := 31.0, runs[len(runs)-1].Setpoints[0]; e != f {
+		t.Errorf("expecting centre point at %v found %v", e, f)
+	}
+}
+
+func TestCentralComposite(t *testing.T) {
+	factors := numericFactors()[:2]
+	runs, err := CentralComposite(factors, 0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 4+4+5, len(runs); e != f {
+		t.Errorf("expecting %d runs found %d", e, f)
+	}
+	checkWithin(t, "rotatable", runs, factors, math.Sqrt2)
+	if e, f := 31.0-6*math.Sqrt2, runs[4].Setpoints[0].(float64); math.Abs(e-f) > 1.0e-9 {
+		t.Errorf("expecting axial point at %g found %g", e, f)
+	}
+
+	runs, err = CentralComposite(factors, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkWithin(t, "face centred", runs, factors, 1)
+}
+
+func TestLatinHypercube(t *testing.T) {
+	factors := numericFactors()
+	runs, err := LatinHypercube(factors, 10, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 10, len(runs); e != f {
+		t.Errorf("expecting %d runs found %d", e, f)
+	}
+	checkWithin(t, "Latin hypercube", runs, factors, 1)
+
+	ranges, _ := numericRanges(factors)
+	for j, col := range columns(t, runs) {
+		seen := make(map[int]bool)
+		for _, v := range col {
+			interval := int(math.Floor((ranges[j].coded(v) + 1) / 2 * 10))
+			if seen[interval] {
+				t.Errorf("factor %s sampled twice in interval %d", factors[j].Factor, interval)
+			}
+			seen[interval] = true
+		}
+	}
+
+	again, _ := LatinHypercube(factors, 10, 42)
+	for i := range runs {
+		if eq, _ := runs[i].EqualTo(again[i]); !eq {
+			t.Errorf("expecting the same design from the same seed")
+			break
+		}
+	}
+}
+
+func TestDOptimal(t *testing.T) {
+	factors := []DOEPair{
+		Pair("A", []interface{}{0.0, 5.0, 10.0}),
+		Pair("B", []interface{}{1.0, 2.0, 3.0}),
+	}
+	notBothHigh := func(run Run) bool {
+		a, _ := run.GetFactorValue("A")
+		b, _ := run.GetFactorValue("B")
+		return !(a.(float64) == 10 && b.(float64) == 3)
+	}
+
+	runs, err := DOptimal(factors, Quadratic, 8, notBothHigh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 8, len(runs); e != f {
+		t.Errorf("expecting %d runs found %d", e, f)
+	}
+	for _, run := range runs {
+		if !notBothHigh(run) {
+			t.Errorf("run %d violates constraint: %v", run.RunNumber, run.Setpoints)
+		}
+	}
+
+	// the main effects design of 4 runs is the 2^2 factorial
+	runs, err = DOptimal(factors, MainEffects, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkWithin(t, "D-optimal", runs, factors, 1)
+	for _, col := range columns(t, runs) {
+		for _, v := range col {
+			if v == 5 || v == 2 {
+				t.Errorf("expecting corner points only found %v", col)
+			}
+		}
+	}
+
+	if _, err := DOptimal(factors, Quadratic, 5); err == nil {
+		t.Error("expecting error for too few runs")
+	}
+}
+
+func TestDOptimalExchange(t *testing.T) {
+	var factors []DOEPair
+	var names []string
+	for _, name := range []string{"A", "B", "C"} {
+		factors = append(factors, Pair(name, []interface{}{1.0, 2.0, 3.0}))
+		names = append(names, name)
+	}
+	runs, err := DOptimal(factors, Quadratic, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ranges, err := numericRanges(factors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := NewModel(names, Quadratic)
+	row := func(run Run) []float64 {
+		x := make([]float64, len(factors))
+		for j := range factors {
+			v, _ := toFloat(run.Setpoints[j])
+			x[j] = ranges[j].coded(v)
+		}
+		return model.row(x)
+	}
+	var rows [][]float64
+	var chosen []int
+	for i, run := range runs {
+		rows = append(rows, row(run))
+		chosen = append(chosen, i)
+	}
+	for _, run := range AllCombinations(factors) {
+		rows = append(rows, row(run))
+	}
+
+	// no exchange of a single run improves the design
+	current := logDet(information(rows, chosen))
+	for i := range chosen {
+		for c := len(runs); c < len(rows); c++ {
+			old := chosen[i]
+			chosen[i] = c
+			if d := logDet(information(rows, chosen)); d > current+1.0e-6 {
+				t.Errorf("exchanging run %d for %v improves log determinant from %g to %g", i+1, rows[c], current, d)
+			}
+			chosen[i] = old
+		}
+	}
+
+	// large designs are found quickly
+	var five []DOEPair
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		five = append(five, Pair(name, []interface{}{1.0, 2.0, 3.0, 4.0, 5.0}))
+	}
+	if runs, err := DOptimal(five, Quadratic, 42); err != nil {
+		t.Error(err)
+	} else if e, f := 42, len(runs); e != f {
+		t.Errorf("expecting %d runs found %d", e, f)
+	}
+}
@@ -0,0 +1,363 @@
+// Part of the Antha language
+// Copyright (C) 2015 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package doe
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// gaussianProcess is a Gaussian process regression with a squared
+// exponential kernel of unit variance on inputs scaled to [0, 1] and
+// standardized outputs
+type gaussianProcess struct {
+	lengthScale float64
+	noise       float64
+	x           [][]float64
+	y           []float64
+	chol        [][]float64
+	alpha       []float64
+}
+
+func (gp *gaussianProcess) kernel(a, b []float64) float64 {
+	var d float64
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Exp(-0.5 * d / (gp.lengthScale * gp.lengthScale))
+}
+
+// cholesky returns the lower triangular factor of a positive definite matrix
+func cholesky(a [][]float64) ([][]float64, bool) {
+	n := len(a)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			s := a[i][j]
+			for k := 0; k < j; k++ {
+				s -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if s <= 0 {
+					return nil, false
+				}
+				l[i][i] = math.Sqrt(s)
+			} else {
+				l[i][j] = s / l[j][j]
+			}
+		}
+	}
+	return l, true
+}
+
+// forward solves l z = b for lower triangular l
+func forward(l [][]float64, b []float64) []float64 {
+	z := make([]float64, len(b))
+	for i := range b {
+		s := b[i]
+		for k := 0; k < i; k++ {
+			s -= l[i][k] * z[k]
+		}
+		z[i] = s / l[i][i]
+	}
+	return z
+}
+
+// backward solves l^T z = b for lower triangular l
+func backward(l [][]float64, b []float64) []float64 {
+	n := len(b)
+	z := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := b[i]
+		for k := i + 1; k < n; k++ {
+			s -= l[k][i] * z[k]
+		}
+		z[i] = s / l[i][i]
+	}
+	return z
+}
+
+// condition conditions the process on its data and returns the log marginal
+// likelihood
+func (gp *gaussianProcess) condition() (float64, bool) {
+	n := len(gp.x)
+	k := make([][]float64, n)
+	for i := range k {
+		k[i] = make([]float64, n)
+		for j := range k[i] {
+			k[i][j] = gp.kernel(gp.x[i], gp.x[j])
+		}
+		k[i][i] += gp.noise
+	}
+	l, ok := cholesky(k)
+	if !ok {
+		return math.Inf(-1), false
+	}
+	gp.chol = l
+	gp.alpha = backward(l, forward(l, gp.y))
+
+	lml := -0.5 * float64(n) * math.Log(2*math.Pi)
+	for i := range gp.y {
+		lml -= 0.5*gp.y[i]*gp.alpha[i] + math.Log(l[i][i])
+	}
+	return lml, true
+}
+
+// predict returns the mean and standard deviation of the process at x
+func (gp *gaussianProcess) predict(x []float64) (float64, float64) {
+	ks := make([]float64, len(gp.x))
+	var mu float64
+	for i, xi := range gp.x {
+		ks[i] = gp.kernel(x, xi)
+		mu += ks[i] * gp.alpha[i]
+	}
+	v := forward(gp.chol, ks)
+	variance := 1.0
+	for _, vi := range v {
+		variance -= vi * vi
+	}
+	return mu, math.Sqrt(math.Max(variance, 1.0e-12))
+}
+
+// fitGaussianProcess chooses the length scale and noise which maximise the
+// marginal likelihood of the data
+func fitGaussianProcess(x [][]float64, y []float64) (*gaussianProcess, error) {
+	var best *gaussianProcess
+	bestLML := math.Inf(-1)
+	for _, ls := range []float64{0.05, 0.1, 0.2, 0.3, 0.5, 0.75, 1, 1.5, 2} {
+		for _, noise := range []float64{1.0e-6, 1.0e-4, 1.0e-2, 1.0e-1} {
+			gp := &gaussianProcess{lengthScale: ls, noise: noise, x: x, y: y}
+			if lml, ok := gp.condition(); ok && lml > bestLML {
+				best, bestLML = gp, lml
+			}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("cannot fit a Gaussian process to the responses")
+	}
+	return best, nil
+}
+
+// expectedImprovement returns the expected amount by which a point with a
+// predicted mean and standard deviation improves on the best value so far
+func expectedImprovement(mu, sigma, best float64) float64 {
+	const xi = 0.01
+	z := (mu - best - xi) / sigma
+	cdf := 0.5 * math.Erfc(-z/math.Sqrt2)
+	pdf := math.Exp(-0.5*z*z) / math.Sqrt(2*math.Pi)
+	return (mu-best-xi)*cdf + sigma*pdf
+}
+
+// A SequentialOptimiser proposes batches of runs to optimise a response
+// from the responses of previous runs. While there are fewer than
+// InitialRuns previous runs with responses, a Latin hypercube design is
+// proposed; after that, runs are chosen by the expected improvement of a
+// Gaussian process model of the response. Factors must be numeric; their
+// lowest and highest levels bound the search.
+type SequentialOptimiser struct {
+	Factors  []DOEPair
+	Response string
+	Maximise bool
+	// InitialRuns is the number of runs before the model is used; if zero,
+	// 2 runs per factor
+	InitialRuns int
+	// Seed makes proposals reproducible
+	Seed int64
+}
+
+// observations returns the scaled factor values and responses of runs with
+// a numeric response
+func (o SequentialOptimiser) observations(history []Run, ranges []factorRange) ([][]float64, []float64, error) {
+	var xs [][]float64
+	var ys []float64
+	for _, run := range history {
+		v, err := run.GetResponseValue(o.Response)
+		if err != nil {
+			continue
+		}
+		y, ok := toFloat(v)
+		if !ok || math.IsNaN(y) {
+			continue
+		}
+		x := make([]float64, len(o.Factors))
+		for j, f := range o.Factors {
+			fv, err := run.GetFactorValue(f.Factor)
+			if err != nil {
+				return nil, nil, fmt.Errorf("run %d: %s", run.RunNumber, err)
+			}
+			v, ok := toFloat(fv)
+			if !ok {
+				return nil, nil, fmt.Errorf("run %d: factor %s has non-numeric value %v", run.RunNumber, f.Factor, fv)
+			}
+			x[j] = (ranges[j].coded(v) + 1) / 2
+		}
+		if !o.Maximise {
+			y = -y
+		}
+		xs = append(xs, x)
+		ys = append(ys, y)
+	}
+	return xs, ys, nil
+}
+
+// Propose returns the next batch of runs given the previous runs. Runs are
+// numbered on from the previous runs.
+func (o SequentialOptimiser) Propose(history []Run, batch int) ([]Run, error) {
+	if batch < 1 {
+		return nil, fmt.Errorf("batch size must be at least 1")
+	}
+	ranges, err := numericRanges(o.Factors)
+	if err != nil {
+		return nil, err
+	}
+	initial := o.InitialRuns
+	if initial == 0 {
+		initial = 2 * len(o.Factors)
+	}
+
+	first := 1
+	for _, run := range history {
+		if run.RunNumber >= first {
+			first = run.RunNumber + 1
+		}
+	}
+	number := func(runs []Run) []Run {
+		for i := range runs {
+			runs[i].RunNumber = first + i
+			runs[i].StdNumber = first + i
+		}
+		return runs
+	}
+
+	xs, ys, err := o.observations(history, ranges)
+	if err != nil {
+		return nil, err
+	}
+	if len(ys) < initial {
+		n := batch
+		if n < 2 {
+			n = 2
+		}
+		runs, err := LatinHypercube(o.Factors, n, o.Seed+int64(len(history)))
+		if err != nil {
+			return nil, err
+		}
+		return number(runs[:batch]), nil
+	}
+
+	// standardize responses
+	var mean, sd float64
+	for _, y := range ys {
+		mean += y
+	}
+	mean /= float64(len(ys))
+	for _, y := range ys {
+		sd += (y - mean) * (y - mean)
+	}
+	sd = math.Sqrt(sd / float64(len(ys)))
+	if sd == 0 {
+		sd = 1
+	}
+	std := make([]float64, len(ys))
+	best := math.Inf(-1)
+	for i, y := range ys {
+		std[i] = (y - mean) / sd
+		best = math.Max(best, std[i])
+	}
+
+	gp, err := fitGaussianProcess(xs, std)
+	if err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewSource(o.Seed + int64(len(history))))
+	k := len(o.Factors)
+	candidates := make([][]float64, 0, 3000)
+	for i := 0; i < 2000; i++ {
+		x := make([]float64, k)
+		for j := range x {
+			x[j] = rnd.Float64()
+		}
+		candidates = append(candidates, x)
+	}
+	// and around the best points so far
+	order := make([]int, len(std))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return std[order[a]] > std[order[b]] })
+	for _, i := range order[:int(math.Min(5, float64(len(order))))] {
+		for c := 0; c < 200; c++ {
+			x := make([]float64, k)
+			for j := range x {
+				x[j] = math.Max(0, math.Min(1, xs[i][j]+0.05*rnd.NormFloat64()))
+			}
+			candidates = append(candidates, x)
+		}
+	}
+
+	if batch > len(candidates) {
+		return nil, fmt.Errorf("cannot propose %d runs from %d candidates", batch, len(candidates))
+	}
+
+	// choose the batch one at a time, assuming each chosen point has its
+	// predicted response
+	var coded [][]float64
+	for len(coded) < batch {
+		bestEI, bestC := -1.0, -1
+		for c, x := range candidates {
+			mu, sigma := gp.predict(x)
+			if ei := expectedImprovement(mu, sigma, best); ei > bestEI {
+				bestEI, bestC = ei, c
+			}
+		}
+		if bestC < 0 {
+			return nil, fmt.Errorf("cannot choose run %d of %d: no candidate has a finite expected improvement", len(coded)+1, batch)
+		}
+		x := candidates[bestC]
+		mu, _ := gp.predict(x)
+
+		row := make([]float64, k)
+		for j := range x {
+			row[j] = 2*x[j] - 1
+		}
+		coded = append(coded, row)
+
+		gp.x = append(append([][]float64(nil), gp.x...), x)
+		gp.y = append(append([]float64(nil), gp.y...), mu)
+		if _, ok := gp.condition(); !ok {
+			gp.noise *= 10
+			if _, ok := gp.condition(); !ok {
+				return nil, fmt.Errorf("cannot condition Gaussian process on proposed runs")
+			}
+		}
+		candidates = append(candidates[:bestC], candidates[bestC+1:]...)
+	}
+
+	return number(codedRuns(o.Factors, ranges, coded)), nil
+}
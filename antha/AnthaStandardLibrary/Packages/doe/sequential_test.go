@@ -0,0 +1,98 @@
+package doe
+
+import (
+	"math"
+	"testing"
+)
+
+func evaluate(t *testing.T, runs []Run, response string, f func(x, y float64) float64) []Run {
+	for i, run := range runs {
+		x, err := run.GetFactorValue("X")
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := run.GetFactorValue("Y")
+		if err != nil {
+			t.Fatal(err)
+		}
+		runs[i] = AddNewResponseFieldandValue(run, response, f(x.(float64), y.(float64)))
+	}
+	return runs
+}
+
+func TestSequentialOptimiser(t *testing.T) {
+	factors := []DOEPair{
+		{Factor: "X", Levels: []interface{}{0.0, 10.0}},
+		{Factor: "Y", Levels: []interface{}{0.0, 10.0}},
+	}
+	cost := func(x, y float64) float64 {
+		return (x-3)*(x-3) + (y-7)*(y-7)
+	}
+	o := SequentialOptimiser{Factors: factors, Response: "Cost", Seed: 1}
+
+	var history []Run
+	for round := 0; round < 6; round++ {
+		runs, err := o.Propose(history, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 3 {
+			t.Fatalf("round %d: expected 3 runs, got %d", round, len(runs))
+		}
+		for i, run := range runs {
+			if e := len(history) + i + 1; run.RunNumber != e {
+				t.Errorf("round %d: expected run number %d, got %d", round, e, run.RunNumber)
+			}
+		}
+		history = append(history, evaluate(t, runs, "Cost", cost)...)
+	}
+
+	best := math.Inf(1)
+	for _, run := range history {
+		v, err := run.GetResponseValue("Cost")
+		if err != nil {
+			t.Fatal(err)
+		}
+		best = math.Min(best, v.(float64))
+	}
+	if best > 0.5 {
+		t.Errorf("expected cost near 0 after %d runs, best is %f", len(history), best)
+	}
+
+	if _, err := o.Propose(history, 10000); err == nil {
+		t.Error("expected error for batch larger than the number of candidates")
+	}
+}
+
+func TestSequentialOptimiserInitialDesign(t *testing.T) {
+	factors := []DOEPair{
+		{Factor: "X", Levels: []interface{}{0.0, 10.0}},
+		{Factor: "Y", Levels: []interface{}{0.0, 10.0}},
+	}
+	o := SequentialOptimiser{Factors: factors, Response: "Yield", Maximise: true, Seed: 3}
+
+	a, err := o.Propose(nil, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := o.Propose(nil, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range a {
+		for _, f := range []string{"X", "Y"} {
+			va, _ := a[i].GetFactorValue(f)
+			vb, _ := b[i].GetFactorValue(f)
+			if va != vb {
+				t.Errorf("run %d: proposals with the same seed differ in %s: %v and %v", i+1, f, va, vb)
+			}
+			if v := va.(float64); v < 0 || v > 10 {
+				t.Errorf("run %d: %s out of range: %f", i+1, f, v)
+			}
+		}
+	}
+
+	if _, err := o.Propose(nil, 0); err == nil {
+		t.Error("expected error for batch of 0")
+	}
+}
@@ -0,0 +1,309 @@
+// antha/AnthaStandardLibrary/Packages/enzymes/Diagnosticdigest.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package enzymes
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// DiagnosticDigestOptions control the planning of diagnostic digests
+type DiagnosticDigestOptions struct {
+	// MaxEnzymes is the largest number of enzymes in a digest
+	MaxEnzymes int
+	// MaxCuts is the largest number of times an enzyme may cut any construct
+	MaxCuts int
+	// MinBand and MaxBand are the sizes, in bp, of the smallest and largest
+	// bands which can be sized on a gel
+	MinBand int
+	MaxBand int
+	// Resolution is the smallest difference in the sizes of two bands, as a
+	// fraction of their size, by which they can be told apart
+	Resolution float64
+	// MaxDigests is the largest number of digests returned
+	MaxDigests int
+}
+
+// DefaultDiagnosticDigestOptions are suitable for a 1% agarose gel
+var DefaultDiagnosticDigestOptions = DiagnosticDigestOptions{
+	MaxEnzymes: 2,
+	MaxCuts:    6,
+	MinBand:    250,
+	MaxBand:    10000,
+	Resolution: 0.1,
+	MaxDigests: 5,
+}
+
+// A DiagnosticDigest is a digest whose bands tell an expected construct
+// apart from alternatives
+type DiagnosticDigest struct {
+	Enzymes []wtype.RestrictionEnzyme
+	// Constructs are the expected construct followed by the alternatives
+	Constructs []wtype.DNASequence
+	// Bands are the sizes of the fragments of each construct, largest first
+	Bands [][]int
+	// Differences are the numbers of bands which differ between the expected
+	// construct and each alternative
+	Differences []int
+}
+
+// EnzymeNames returns the names of the enzymes of the digest
+func (d DiagnosticDigest) EnzymeNames() []string {
+	var names []string
+	for _, enzyme := range d.Enzymes {
+		names = append(names, enzyme.Name())
+	}
+	return names
+}
+
+// Distinguishes returns whether the bands of every alternative differ from
+// those of the expected construct
+func (d DiagnosticDigest) Distinguishes() bool {
+	for _, n := range d.Differences {
+		if n == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (d DiagnosticDigest) String() string {
+	var lines []string
+	lines = append(lines, strings.Join(d.EnzymeNames(), " + "))
+	for i, construct := range d.Constructs {
+		line := fmt.Sprintf("%s: %v", construct.Nm, d.Bands[i])
+		if i > 0 {
+			line += fmt.Sprintf(" (%d bands differ)", d.Differences[i-1])
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PlanDiagnosticDigests finds the digests by candidate enzymes, alone or in
+// combinations of up to MaxEnzymes, whose bands best tell an expected
+// construct apart from likely wrong alternatives, e.g., the empty vector or a
+// reversed insert. Digests are ranked by the fewest bands differing from any
+// alternative, then by the total of bands differing and then by the fewest
+// enzymes. Bands which cannot be sized, or told apart from each other, are
+// disregarded. Fragment sizes are predicted from the positions of
+// recognition sites, so may differ from those of Digest by the distance
+// type IIs enzymes cut from their sites.
+func PlanDiagnosticDigests(expected wtype.DNASequence, alternatives []wtype.DNASequence, candidates []wtype.RestrictionEnzyme, opt DiagnosticDigestOptions) ([]DiagnosticDigest, error) {
+	if len(alternatives) == 0 {
+		return nil, fmt.Errorf("no alternatives to distinguish %s from", expected.Nm)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no candidate enzymes")
+	}
+
+	constructs := append([]wtype.DNASequence{expected}, alternatives...)
+
+	// the cuts of usable enzymes in each construct
+	var usable []wtype.RestrictionEnzyme
+	var cuts [][][]int
+	for _, enzyme := range candidates {
+		enzymeCuts := make([][]int, len(constructs))
+		total := 0
+		ok := true
+		for i, construct := range constructs {
+			sites := RestrictionSiteFinder(construct, enzyme)[0]
+			enzymeCuts[i] = sites.AllPositions()
+			total += len(enzymeCuts[i])
+			if len(enzymeCuts[i]) > opt.MaxCuts {
+				ok = false
+			}
+		}
+		if ok && total > 0 {
+			usable = append(usable, enzyme)
+			cuts = append(cuts, enzymeCuts)
+		}
+	}
+
+	var digests []DiagnosticDigest
+	for _, combination := range combinations(len(usable), opt.MaxEnzymes) {
+		d := DiagnosticDigest{Constructs: constructs}
+		for _, e := range combination {
+			d.Enzymes = append(d.Enzymes, usable[e])
+		}
+		for i, construct := range constructs {
+			var positions []int
+			for _, e := range combination {
+				positions = append(positions, cuts[e][i]...)
+			}
+			d.Bands = append(d.Bands, fragmentSizes(positions, len(construct.Seq), construct.Plasmid))
+		}
+
+		expectedBands := visibleBands(d.Bands[0], opt)
+		if len(expectedBands) == 0 {
+			continue
+		}
+		for _, bands := range d.Bands[1:] {
+			d.Differences = append(d.Differences, bandDifferences(expectedBands, visibleBands(bands, opt), opt.Resolution))
+		}
+		digests = append(digests, d)
+	}
+	if len(digests) == 0 {
+		return nil, fmt.Errorf("no candidate enzymes give bands of %s between %d and %d bp", expected.Nm, opt.MinBand, opt.MaxBand)
+	}
+
+	sort.SliceStable(digests, func(i, j int) bool {
+		a, b := digests[i], digests[j]
+		if minInt(a.Differences) != minInt(b.Differences) {
+			return minInt(a.Differences) > minInt(b.Differences)
+		}
+		if sumInt(a.Differences) != sumInt(b.Differences) {
+			return sumInt(a.Differences) > sumInt(b.Differences)
+		}
+		return len(a.Enzymes) < len(b.Enzymes)
+	})
+	if opt.MaxDigests > 0 && len(digests) > opt.MaxDigests {
+		digests = digests[:opt.MaxDigests]
+	}
+	return digests, nil
+}
+
+// combinations returns the combinations of 1 to k of n items
+func combinations(n, k int) [][]int {
+	var ret [][]int
+	var combine func(start int, current []int)
+	combine = func(start int, current []int) {
+		if len(current) > 0 {
+			ret = append(ret, append([]int(nil), current...))
+		}
+		if len(current) == k {
+			return
+		}
+		for i := start; i < n; i++ {
+			combine(i+1, append(current, i))
+		}
+	}
+	combine(0, nil)
+	return ret
+}
+
+// fragmentSizes returns the sizes of the fragments of a sequence cut at
+// positions, largest first
+func fragmentSizes(positions []int, length int, circular bool) []int {
+	sort.Ints(positions)
+	var cuts []int
+	for i, p := range positions {
+		if i == 0 || p != positions[i-1] {
+			cuts = append(cuts, p)
+		}
+	}
+	if len(cuts) == 0 {
+		return []int{length}
+	}
+
+	var sizes []int
+	for i := 1; i < len(cuts); i++ {
+		sizes = append(sizes, cuts[i]-cuts[i-1])
+	}
+	if circular {
+		sizes = append(sizes, length-cuts[len(cuts)-1]+cuts[0])
+	} else {
+		sizes = append(sizes, cuts[0], length-cuts[len(cuts)-1])
+	}
+
+	var ret []int
+	for _, size := range sizes {
+		if size > 0 {
+			ret = append(ret, size)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(ret)))
+	return ret
+}
+
+// visibleBands returns the bands, largest first, which can be sized; bands
+// which cannot be told apart run as one
+func visibleBands(sizes []int, opt DiagnosticDigestOptions) []int {
+	var bands []int
+	for _, size := range sizes {
+		if size < opt.MinBand || size > opt.MaxBand {
+			continue
+		}
+		if len(bands) != 0 && compareBands(bands[len(bands)-1], size, opt.Resolution) == 0 {
+			continue
+		}
+		bands = append(bands, size)
+	}
+	return bands
+}
+
+// compareBands returns 1 if a band of size a runs behind one of size b, -1 if
+// ahead and 0 if they cannot be told apart
+func compareBands(a, b int, resolution float64) int {
+	d := math.Log(float64(a)) - math.Log(float64(b))
+	switch {
+	case d > math.Log1p(resolution):
+		return 1
+	case -d > math.Log1p(resolution):
+		return -1
+	}
+	return 0
+}
+
+// bandDifferences returns the number of bands of either pattern, largest
+// first, which do not match a band of the other
+func bandDifferences(a, b []int, resolution float64) int {
+	n := 0
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch compareBands(a[i], b[j], resolution) {
+		case 1:
+			n++
+			i++
+		case -1:
+			n++
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return n + len(a) - i + len(b) - j
+}
+
+func minInt(xs []int) int {
+	m := math.MaxInt32
+	for _, x := range xs {
+		if x < m {
+			m = x
+		}
+	}
+	return m
+}
+
+func sumInt(xs []int) int {
+	s := 0
+	for _, x := range xs {
+		s += x
+	}
+	return s
+}
@@ -0,0 +1,506 @@
+// antha/AnthaStandardLibrary/Packages/enzymes/Homologyassembly.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package enzymes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// A HomologyMethod is a method of assembling DNA fragments whose ends
+// overlap: an exonuclease exposes single stranded ends which anneal to the
+// complementary end of the next fragment.
+type HomologyMethod struct {
+	Name string
+	// MinOverlap and MaxOverlap bound the length of designed overlaps
+	MinOverlap int
+	MaxOverlap int
+	// MinOverlapTm is the lowest melting temperature of designed overlaps;
+	// if nil, melting temperature is not considered
+	MinOverlapTm wunit.Temperature
+	// MinHomology is the length of the shortest homology between fragments
+	// which may anneal, used to detect mis-priming
+	MinHomology int
+}
+
+// Homology based assembly methods
+var (
+	Gibson = HomologyMethod{
+		Name:         "Gibson",
+		MinOverlap:   20,
+		MaxOverlap:   40,
+		MinOverlapTm: wunit.NewTemperature(48, "C"),
+		MinHomology:  12,
+	}
+	InFusion = HomologyMethod{
+		Name:        "In-Fusion",
+		MinOverlap:  15,
+		MaxOverlap:  15,
+		MinHomology: 10,
+	}
+	SLIC = HomologyMethod{
+		Name:         "SLIC",
+		MinOverlap:   25,
+		MaxOverlap:   40,
+		MinOverlapTm: wunit.NewTemperature(48, "C"),
+		MinHomology:  12,
+	}
+)
+
+// maxHomology is the length of the longest end homology searched for
+const maxHomology = 500
+
+// An EndHomology is a region of homology through which the 3' end of one
+// fragment may anneal to another
+type EndHomology struct {
+	// Upstream is the fragment whose 3' end anneals; names of fragments
+	// taking part as their reverse complement end in " (reverse)"
+	Upstream string
+	// Downstream is the fragment annealed to
+	Downstream string
+	// Sequence of the homology on the top strand of the upstream fragment
+	Sequence string
+	// Position of the homology in the downstream fragment, counting from 1;
+	// 1 where the fragments' ends overlap
+	Position int
+}
+
+// Internal returns true if the homology is to sequence within the
+// downstream fragment rather than its end
+func (h EndHomology) Internal() bool {
+	return h.Position != 1
+}
+
+// String returns a description of the homology
+func (h EndHomology) String() string {
+	if h.Internal() {
+		return fmt.Sprintf("3' end of %s to position %d of %s (%d bp: %s)", h.Upstream, h.Position, h.Downstream, len(h.Sequence), h.Sequence)
+	}
+	return fmt.Sprintf("%s to %s (%d bp: %s)", h.Upstream, h.Downstream, len(h.Sequence), h.Sequence)
+}
+
+// HomologyAssemblyResult is the result of simulating a homology based
+// assembly
+type HomologyAssemblyResult struct {
+	// Products which may form; the expected product first
+	Products []wtype.DNASequence
+	// Mispriming lists the homologies other than the designed junctions
+	Mispriming []EndHomology
+}
+
+// Alternatives returns the products other than the expected product
+func (r HomologyAssemblyResult) Alternatives() []wtype.DNASequence {
+	if len(r.Products) < 2 {
+		return nil
+	}
+	return r.Products[1:]
+}
+
+// homologyNode is a fragment in one orientation; node 2i is fragment i and
+// node 2i+1 its reverse complement
+type homologyNode struct {
+	name     string
+	seq      string
+	fragment wtype.DNASequence
+	reverse  bool
+}
+
+// homologyEdge is an end homology between two nodes of length overlap
+type homologyEdge struct {
+	from, to int
+	overlap  int
+}
+
+func homologyNodes(fragments []wtype.DNASequence) []homologyNode {
+	var nodes []homologyNode
+	for _, f := range fragments {
+		seq := strings.ToUpper(f.Sequence())
+		nodes = append(nodes,
+			homologyNode{name: f.Nm, seq: seq, fragment: f},
+			homologyNode{name: f.Nm + " (reverse)", seq: sequences.RevComp(seq), fragment: f, reverse: true})
+	}
+	return nodes
+}
+
+// longestEndOverlap returns the length of the longest suffix of up which is
+// a prefix of down, of at least min bases
+func longestEndOverlap(up, down string, min int) int {
+	max := len(up)
+	if len(down) < max {
+		max = len(down)
+	}
+	if max > maxHomology {
+		max = maxHomology
+	}
+	for k := max; k >= min && k > 0; k-- {
+		if up[len(up)-k:] == down[:k] {
+			return k
+		}
+	}
+	return 0
+}
+
+// homologyEdges returns the end homologies between nodes, including those
+// of a fragment with itself in the same orientation, which circularise it
+func homologyEdges(nodes []homologyNode, min int) []homologyEdge {
+	var edges []homologyEdge
+	for u := range nodes {
+		for v := range nodes {
+			if u/2 == v/2 && u != v {
+				continue
+			}
+			if k := longestEndOverlap(nodes[u].seq, nodes[v].seq, min); k > 0 {
+				if u == v && k == len(nodes[u].seq) {
+					continue
+				}
+				edges = append(edges, homologyEdge{from: u, to: v, overlap: k})
+			}
+		}
+	}
+	return edges
+}
+
+// mirror returns the edge formed by the other strands of the same homology
+func (e homologyEdge) mirror() homologyEdge {
+	return homologyEdge{from: e.to ^ 1, to: e.from ^ 1, overlap: e.overlap}
+}
+
+// canonicalPath returns a key identifying a path of nodes, or a cycle, up to
+// its reading direction and, for cycles, its starting node
+func canonicalPath(path []int, cycle bool) string {
+	key := func(p []int) string {
+		var s []string
+		for _, n := range p {
+			s = append(s, fmt.Sprint(n))
+		}
+		return strings.Join(s, ",")
+	}
+	mirrored := make([]int, len(path))
+	for i, n := range path {
+		mirrored[len(path)-1-i] = n ^ 1
+	}
+
+	var keys []string
+	for _, p := range [][]int{path, mirrored} {
+		if !cycle {
+			keys = append(keys, key(p))
+			continue
+		}
+		for r := range p {
+			keys = append(keys, key(append(append([]int(nil), p[r:]...), p[:r]...)))
+		}
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
+
+// joinNodes returns the sequence of a path of nodes and the start of each
+// node in it, counting from 0. The overlap of the last node with the first
+// is removed for cycles.
+func joinNodes(nodes []homologyNode, path []int, overlaps []int, cycle bool) (string, []int) {
+	var seq string
+	var starts []int
+	for i, n := range path {
+		if i == 0 {
+			starts = append(starts, 0)
+			seq = nodes[n].seq
+			continue
+		}
+		starts = append(starts, len(seq)-overlaps[i-1])
+		seq += nodes[n].seq[overlaps[i-1]:]
+	}
+	if cycle {
+		seq = seq[:len(seq)-overlaps[len(path)-1]]
+	}
+	return seq, starts
+}
+
+// annotateProduct makes the product of a path of nodes annotated with the
+// fragments, their features and the junctions between them
+func annotateProduct(name string, nodes []homologyNode, path []int, overlaps []int, cycle bool) wtype.DNASequence {
+	seq, starts := joinNodes(nodes, path, overlaps, cycle)
+
+	var product wtype.DNASequence
+	if cycle {
+		product = wtype.MakePlasmidDNASequence(name, seq)
+	} else {
+		product = wtype.MakeLinearDNASequence(name, seq)
+	}
+
+	wrap := func(p int) int {
+		return (p-1)%len(seq) + 1
+	}
+
+	var features []wtype.Feature
+	for i, n := range path {
+		node := nodes[n]
+		start := starts[i]
+		features = append(features, wtype.Feature{
+			Name:          node.fragment.Nm,
+			Class:         wtype.MISC_FEATURE,
+			Reverse:       node.reverse,
+			StartPosition: start + 1,
+			EndPosition:   wrap(start + len(node.seq)),
+			DNASeq:        node.seq,
+		})
+
+		for _, f := range node.fragment.Features {
+			s, e := f.Coordinates(wtype.IGNOREDIRECTION)
+			if node.reverse {
+				s, e = len(node.seq)-e+1, len(node.seq)-s+1
+			}
+			f.StartPosition, f.EndPosition = start+s, wrap(start+e)
+			if node.reverse {
+				f.Reverse = !f.Reverse
+			}
+			features = append(features, f)
+		}
+
+		if i+1 < len(path) || cycle {
+			next := path[(i+1)%len(path)]
+			o := overlaps[i]
+			if o == 0 {
+				continue
+			}
+			js := starts[(i+1)%len(path)]
+			features = append(features, wtype.Feature{
+				Name:          "junction " + node.name + "/" + nodes[next].name,
+				Class:         wtype.MISC_FEATURE,
+				StartPosition: js + 1,
+				EndPosition:   js + o,
+				DNASeq:        seq[js : js+o],
+			})
+		}
+	}
+	product.Features = features
+	return product
+}
+
+// maxProducts limits the number of alternative products found
+const maxProducts = 100
+
+// assemblyPaths returns the cycles, and for linear assemblies the paths
+// which cannot be extended at either end, which can form from the end
+// homologies. Each fragment is used at most once.
+func assemblyPaths(nnodes int, edges []homologyEdge, circular bool) (paths [][]int, overlaps [][]int, cycles []bool) {
+	out := make(map[int][]homologyEdge)
+	in := make(map[int][]homologyEdge)
+	for _, e := range edges {
+		out[e.from] = append(out[e.from], e)
+		in[e.to] = append(in[e.to], e)
+	}
+
+	seen := make(map[string]bool)
+	add := func(path, os []int, cycle bool) {
+		key := canonicalPath(path, cycle)
+		if seen[key] || len(paths) >= maxProducts {
+			return
+		}
+		seen[key] = true
+		paths = append(paths, append([]int(nil), path...))
+		overlaps = append(overlaps, append([]int(nil), os...))
+		cycles = append(cycles, cycle)
+	}
+
+	used := make(map[int]bool)
+	extendable := func(n int, es map[int][]homologyEdge, other func(homologyEdge) int) bool {
+		for _, e := range es[n] {
+			if !used[other(e)/2] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var path, os []int
+	var visit func(n int)
+	visit = func(n int) {
+		if len(paths) >= maxProducts {
+			return
+		}
+		for _, e := range out[n] {
+			if e.to == path[0] {
+				add(path, append(os, e.overlap), true)
+				continue
+			}
+			if used[e.to/2] {
+				continue
+			}
+			used[e.to/2] = true
+			path = append(path, e.to)
+			os = append(os, e.overlap)
+			visit(e.to)
+			path = path[:len(path)-1]
+			os = os[:len(os)-1]
+			used[e.to/2] = false
+		}
+		if circular || len(path) < 2 {
+			return
+		}
+		if !extendable(n, out, func(e homologyEdge) int { return e.to }) && !extendable(path[0], in, func(e homologyEdge) int { return e.from }) {
+			add(path, os, false)
+		}
+	}
+
+	for n := 0; n < nnodes; n++ {
+		used[n/2] = true
+		path = []int{n}
+		os = nil
+		visit(n)
+		used[n/2] = false
+	}
+	return
+}
+
+// internalHomologies returns homologies of the 3' ends of nodes within
+// other fragments, other than where the fragments' ends overlap
+func internalHomologies(nodes []homologyNode, edges []homologyEdge, min int) []EndHomology {
+	overlaps := make(map[[2]int]int)
+	for _, e := range edges {
+		overlaps[[2]int{e.from, e.to}] = e.overlap
+	}
+
+	var found []EndHomology
+	for u := range nodes {
+		if len(nodes[u].seq) < min {
+			continue
+		}
+		end := nodes[u].seq[len(nodes[u].seq)-min:]
+		for v := range nodes {
+			if v/2 == u/2 {
+				continue
+			}
+			seq := nodes[v].seq
+			for i := 0; i+len(end) <= len(seq); i++ {
+				j := strings.Index(seq[i:], end)
+				if j < 0 {
+					break
+				}
+				i += j
+				if i+len(end) == overlaps[[2]int{u, v}] {
+					continue
+				}
+				found = append(found, EndHomology{
+					Upstream:   nodes[u].name,
+					Downstream: nodes[v].name,
+					Sequence:   end,
+					Position:   i + 1,
+				})
+			}
+		}
+	}
+	return found
+}
+
+// SimulateHomologyAssembly simulates assembly of fragments by a homology
+// based method such as Gibson, In-Fusion or SLIC. Adjacent fragments must
+// share an end homology of at least the method's minimum overlap and, for
+// circular assemblies, so must the last and first fragments. The expected
+// product is annotated with the fragments and the junctions between them.
+//
+// Other homologies between fragment ends of at least the method's minimum
+// homology, or between the 3' end of a fragment and sequence within another
+// fragment, are reported as mis-priming, and any other products they could
+// form as alternatives.
+func SimulateHomologyAssembly(name string, fragmentsinorder []wtype.DNASequence, circular bool, method HomologyMethod) (result HomologyAssemblyResult, err error) {
+
+	if len(fragmentsinorder) == 0 {
+		return result, fmt.Errorf("no fragments to assemble")
+	}
+
+	nodes := homologyNodes(fragmentsinorder)
+	edges := homologyEdges(nodes, method.MinHomology)
+
+	overlap := func(u, v int) int {
+		for _, e := range edges {
+			if e.from == u && e.to == v {
+				return e.overlap
+			}
+		}
+		return 0
+	}
+
+	// the designed junctions and the same junctions on the other strand
+	designed := make(map[homologyEdge]bool)
+	var expected []int
+	var overlaps []int
+	njunctions := len(fragmentsinorder) - 1
+	if circular {
+		njunctions++
+	}
+	for i := 0; i < njunctions; i++ {
+		u, v := 2*i, 2*((i+1)%len(fragmentsinorder))
+		o := overlap(u, v)
+		if o < method.MinOverlap {
+			return result, fmt.Errorf("%s assembly %s: no overlap of at least %d bp between %s and %s", method.Name, name, method.MinOverlap, nodes[u].name, nodes[v].name)
+		}
+		e := homologyEdge{from: u, to: v, overlap: o}
+		designed[e] = true
+		designed[e.mirror()] = true
+		expected = append(expected, u)
+		overlaps = append(overlaps, o)
+	}
+	if !circular {
+		expected = append(expected, 2*(len(fragmentsinorder)-1))
+	}
+	result.Products = append(result.Products, annotateProduct(name, nodes, expected, overlaps, circular))
+
+	reported := make(map[homologyEdge]bool)
+	for _, e := range edges {
+		if designed[e] {
+			continue
+		}
+		// report homologies with the upstream fragment forwards if possible
+		if e.from%2 == 1 && e.mirror().from%2 == 0 {
+			e = e.mirror()
+		}
+		if reported[e] {
+			continue
+		}
+		reported[e] = true
+		result.Mispriming = append(result.Mispriming, EndHomology{
+			Upstream:   nodes[e.from].name,
+			Downstream: nodes[e.to].name,
+			Sequence:   nodes[e.from].seq[len(nodes[e.from].seq)-e.overlap:],
+			Position:   1,
+		})
+	}
+	result.Mispriming = append(result.Mispriming, internalHomologies(nodes, edges, method.MinHomology)...)
+
+	expectedKey := canonicalPath(expected, circular)
+	paths, pathOverlaps, cycles := assemblyPaths(len(nodes), edges, circular)
+	for i, path := range paths {
+		if cycles[i] == circular && canonicalPath(path, cycles[i]) == expectedKey {
+			continue
+		}
+		alt := annotateProduct(fmt.Sprintf("%s_alternative%d", name, len(result.Products)), nodes, path, pathOverlaps[i], cycles[i])
+		result.Products = append(result.Products, alt)
+	}
+
+	return result, nil
+}
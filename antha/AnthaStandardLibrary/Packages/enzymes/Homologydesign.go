@@ -0,0 +1,284 @@
+// antha/AnthaStandardLibrary/Packages/enzymes/Homologydesign.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package enzymes
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/oligos"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// An OverlapJunction is the overlap designed between two adjacent parts of
+// a homology based assembly
+type OverlapJunction struct {
+	Upstream    string
+	Downstream  string
+	Overlap     string
+	MeltingTemp wunit.Temperature
+	GCContent   float64
+	// UpstreamBases and DownstreamBases are the numbers of bases of the
+	// overlap from the upstream and downstream parts
+	UpstreamBases   int
+	DownstreamBases int
+}
+
+// OverlapAssemblyDesign is a design of a homology based assembly
+type OverlapAssemblyDesign struct {
+	Method    HomologyMethod
+	Junctions []OverlapJunction
+	// Primers are the forward and reverse primers to amplify each part; the
+	// 5' tails of the primers add the overlaps
+	Primers [][2]oligos.Primer
+	// Fragments are the amplified parts
+	Fragments []wtype.DNASequence
+	// Product is the assembled sequence annotated with the parts and
+	// junctions
+	Product wtype.DNASequence
+}
+
+// countBothStrands returns the number of occurrences of a sequence on either
+// strand of another; sequences are circular if plasmid is true
+func countBothStrands(seq, sub string, plasmid bool) int {
+	if plasmid && len(sub) > 1 {
+		seq += seq[:len(sub)-1]
+	}
+	count := func(sub string) int {
+		n := 0
+		for i := 0; i+len(sub) <= len(seq); i++ {
+			j := strings.Index(seq[i:], sub)
+			if j < 0 {
+				break
+			}
+			n++
+			i += j
+		}
+		return n
+	}
+	n := count(sub)
+	if rc := sequences.RevComp(sub); rc != sub {
+		n += count(rc)
+	}
+	return n
+}
+
+// designJunction chooses the overlap spanning the junction between two parts
+// at a position in a product. The shortest overlap meeting the method's
+// melting temperature is preferred, split evenly between the parts and with
+// a GC content near 50%. Overlaps and their ends must be unique in the
+// product so they cannot anneal elsewhere.
+func designJunction(up, down wtype.DNASequence, product string, circular bool, method HomologyMethod) (OverlapJunction, error) {
+	upSeq, downSeq := strings.ToUpper(up.Sequence()), strings.ToUpper(down.Sequence())
+
+	var best OverlapJunction
+	bestPenalty := math.Inf(1)
+	var lastErr error
+
+	for l := method.MinOverlap; l <= method.MaxOverlap; l++ {
+		for a := 0; a <= l; a++ {
+			b := l - a
+			if a > len(upSeq) || b > len(downSeq) {
+				continue
+			}
+			overlap := upSeq[len(upSeq)-a:] + downSeq[:b]
+
+			tm, err := oligos.MeltingTemp(wtype.MakeSingleStrandedDNASequence("overlap", overlap), oligos.DefaultConditions())
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if !method.MinOverlapTm.IsNil() && tm.SIValue() < method.MinOverlapTm.SIValue() {
+				continue
+			}
+
+			if countBothStrands(product, overlap, circular) != 1 {
+				continue
+			}
+			if method.MinHomology > 0 && method.MinHomology < l {
+				if countBothStrands(product, overlap[:method.MinHomology], circular) != 1 || countBothStrands(product, overlap[l-method.MinHomology:], circular) != 1 {
+					continue
+				}
+			}
+
+			gc := sequences.GCcontent(overlap)
+			penalty := float64(l) + 0.5*math.Abs(float64(a-b)) + 20*math.Max(0, math.Abs(gc-0.5)-0.1)
+			if penalty < bestPenalty {
+				bestPenalty = penalty
+				best = OverlapJunction{
+					Upstream:        up.Nm,
+					Downstream:      down.Nm,
+					Overlap:         overlap,
+					MeltingTemp:     tm,
+					GCContent:       gc,
+					UpstreamBases:   a,
+					DownstreamBases: b,
+				}
+			}
+		}
+	}
+
+	if math.IsInf(bestPenalty, 1) {
+		if lastErr != nil {
+			return best, fmt.Errorf("no overlap found between %s and %s: %s", up.Nm, down.Nm, lastErr)
+		}
+		return best, fmt.Errorf("no unique overlap of %d to %d bp with melting temp above %s found between %s and %s", method.MinOverlap, method.MaxOverlap, method.MinOverlapTm.ToString(), up.Nm, down.Nm)
+	}
+	return best, nil
+}
+
+// maxAnnealingLength is the length of the longest annealing region of
+// overlap primers
+const maxAnnealingLength = 40
+
+// annealingRegion returns the shortest region of at least 18 bases from the
+// start of a sequence with a melting temperature of at least tm in PCR
+// conditions
+func annealingRegion(seq string, tm wunit.Temperature) (string, wunit.Temperature, error) {
+	var last wunit.Temperature
+	for l := 18; l <= maxAnnealingLength && l <= len(seq); l++ {
+		t, err := oligos.MeltingTemp(wtype.MakeSingleStrandedDNASequence("primer", seq[:l]), oligos.PCRConditions())
+		if err != nil {
+			return "", t, err
+		}
+		if t.SIValue() >= tm.SIValue() {
+			return seq[:l], t, nil
+		}
+		last = t
+	}
+	if len(seq) < 18 {
+		return "", last, fmt.Errorf("sequence of %d bp too short to amplify", len(seq))
+	}
+	return "", last, fmt.Errorf("no annealing region found with melting temp above %s; longest reached %s", tm.ToString(), last.ToString())
+}
+
+// overlapPrimer makes a primer from its tail and annealing region
+func overlapPrimer(name, tail, annealing string, tm wunit.Temperature, reverse bool) oligos.Primer {
+	seq := tail + annealing
+	return oligos.Primer{
+		DNASequence: wtype.MakeSingleStrandedDNASequence(name, seq),
+		Length:      len(seq),
+		GCContent:   sequences.GCcontent(annealing),
+		Reverse:     reverse,
+		MeltingTemp: tm,
+	}
+}
+
+// DesignOverlapAssembly designs a homology based assembly of parts in order,
+// for example a linearised vector and inserts, by a method such as Gibson,
+// In-Fusion or SLIC. An overlap is chosen across each junction and primers
+// designed to amplify each part with tails adding the overlaps; the
+// annealing region of each primer has a melting temperature of at least
+// primerTm in PCR conditions. If circular, the last part is joined to the
+// first.
+//
+// The amplified parts are checked with SimulateHomologyAssembly; an error is
+// returned if the expected product would not form, or if mis-priming or
+// alternative products are predicted.
+func DesignOverlapAssembly(name string, partsinorder []wtype.DNASequence, circular bool, method HomologyMethod, primerTm wunit.Temperature) (design OverlapAssemblyDesign, err error) {
+
+	design.Method = method
+
+	if len(partsinorder) < 2 && !circular {
+		return design, fmt.Errorf("at least two parts needed for a linear assembly")
+	}
+	if len(partsinorder) == 0 {
+		return design, fmt.Errorf("no parts to assemble")
+	}
+
+	var product string
+	for _, part := range partsinorder {
+		product += strings.ToUpper(part.Sequence())
+	}
+
+	njunctions := len(partsinorder) - 1
+	if circular {
+		njunctions++
+	}
+	for i := 0; i < njunctions; i++ {
+		j, err := designJunction(partsinorder[i], partsinorder[(i+1)%len(partsinorder)], product, circular, method)
+		if err != nil {
+			return design, err
+		}
+		design.Junctions = append(design.Junctions, j)
+	}
+
+	for i, part := range partsinorder {
+		seq := strings.ToUpper(part.Sequence())
+
+		// the tails are the bases of the overlaps from the adjacent parts
+		var fwdTail, revTail string
+		if i > 0 || circular {
+			j := design.Junctions[(i+len(partsinorder)-1)%len(partsinorder)]
+			fwdTail = j.Overlap[:j.UpstreamBases]
+		}
+		if i < len(partsinorder)-1 || circular {
+			j := design.Junctions[i]
+			revTail = j.Overlap[j.UpstreamBases:]
+		}
+
+		fwdAnneal, fwdTm, err := annealingRegion(seq, primerTm)
+		if err != nil {
+			return design, fmt.Errorf("cannot design forward primer for %s: %s", part.Nm, err)
+		}
+		revAnneal, revTm, err := annealingRegion(sequences.RevComp(seq), primerTm)
+		if err != nil {
+			return design, fmt.Errorf("cannot design reverse primer for %s: %s", part.Nm, err)
+		}
+
+		design.Primers = append(design.Primers, [2]oligos.Primer{
+			overlapPrimer("primer_"+part.Nm+"_fwd", fwdTail, fwdAnneal, fwdTm, false),
+			overlapPrimer("primer_"+part.Nm+"_rev", sequences.RevComp(revTail), revAnneal, revTm, true),
+		})
+
+		fragment := wtype.MakeLinearDNASequence(part.Nm, fwdTail+seq+revTail)
+		for _, f := range part.Features {
+			f.StartPosition += len(fwdTail)
+			f.EndPosition += len(fwdTail)
+			fragment.Features = append(fragment.Features, f)
+		}
+		design.Fragments = append(design.Fragments, fragment)
+	}
+
+	result, err := SimulateHomologyAssembly(name, design.Fragments, circular, method)
+	if err != nil {
+		return design, err
+	}
+	design.Product = result.Products[0]
+
+	if len(result.Mispriming) != 0 {
+		var ms []string
+		for _, m := range result.Mispriming {
+			ms = append(ms, m.String())
+		}
+		return design, fmt.Errorf("mis-priming predicted in %s assembly %s: %s", method.Name, name, strings.Join(ms, "; "))
+	}
+	if alts := result.Alternatives(); len(alts) != 0 {
+		return design, fmt.Errorf("%d alternative products predicted for %s assembly %s", len(alts), method.Name, name)
+	}
+
+	return design, nil
+}
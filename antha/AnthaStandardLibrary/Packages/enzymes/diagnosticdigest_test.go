@@ -0,0 +1,121 @@
+// antha/AnthaStandardLibrary/Packages/enzymes/diagnosticdigest_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package enzymes
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/enzymes/lookup"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// acSequence returns a random sequence of As and Cs, which contains no sites
+// of the enzymes tested
+func acSequence(r *rand.Rand, n int) string {
+	bases := make([]byte, n)
+	for i := range bases {
+		bases[i] = "AC"[r.Intn(2)]
+	}
+	return string(bases)
+}
+
+func TestPlanDiagnosticDigests(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vector := acSequence(r, 3000)
+	insert := acSequence(r, 200) + "GGATCC" + acSequence(r, 594)
+
+	construct := func(name, insert string) wtype.DNASequence {
+		return wtype.MakePlasmidDNASequence(name, vector[:1000]+"GAATTC"+insert+"AAGCTT"+vector[1000:])
+	}
+	expected := construct("expected", insert)
+	alternatives := []wtype.DNASequence{
+		construct("empty vector", ""),
+		construct("reversed insert", wtype.RevComp(insert)),
+	}
+
+	var candidates []wtype.RestrictionEnzyme
+	for _, name := range []string{"EcoRI", "BamHI", "HindIII", "PstI"} {
+		enzyme, err := lookup.RestrictionEnzyme(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		candidates = append(candidates, enzyme)
+	}
+
+	digests, err := PlanDiagnosticDigests(expected, alternatives, candidates, DefaultDiagnosticDigestOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	best := digests[0]
+	if names := best.EnzymeNames(); !reflect.DeepEqual(names, []string{"EcoRI", "BamHI"}) {
+		t.Errorf("expected EcoRI and BamHI digest, got %v:\n%s", names, best)
+	}
+	if !best.Distinguishes() || !reflect.DeepEqual(best.Differences, []int{2, 3}) {
+		t.Errorf("expected 2 and 3 bands to differ from the alternatives, got %v", best.Differences)
+	}
+	if !reflect.DeepEqual(best.Bands[0], []int{3606, 206}) {
+		t.Errorf("expected bands of 3606 and 206 bp, got %v", best.Bands[0])
+	}
+	for _, d := range digests {
+		for _, enzyme := range d.EnzymeNames() {
+			if enzyme == "PstI" {
+				t.Errorf("expected PstI, which does not cut, not to be used:\n%s", d)
+			}
+		}
+	}
+
+	if _, err := PlanDiagnosticDigests(expected, nil, candidates, DefaultDiagnosticDigestOptions); err == nil {
+		t.Errorf("expected error without alternatives")
+	}
+}
+
+func TestFragmentSizes(t *testing.T) {
+	tests := []struct {
+		positions []int
+		circular  bool
+		sizes     []int
+	}{
+		{nil, true, []int{1000}},
+		{[]int{100}, true, []int{1000}},
+		{[]int{100}, false, []int{900, 100}},
+		{[]int{700, 100, 100}, true, []int{600, 400}},
+		{[]int{700, 100}, false, []int{600, 300, 100}},
+	}
+
+	for _, test := range tests {
+		if sizes := fragmentSizes(test.positions, 1000, test.circular); !reflect.DeepEqual(sizes, test.sizes) {
+			t.Errorf("cutting at %v (circular %t): expected %v, got %v", test.positions, test.circular, test.sizes, sizes)
+		}
+	}
+
+	opt := DefaultDiagnosticDigestOptions
+	if bands := visibleBands([]int{20000, 3000, 2900, 1000, 100}, opt); !reflect.DeepEqual(bands, []int{3000, 1000}) {
+		t.Errorf("expected bands of 3000 and 1000 bp, got %v", bands)
+	}
+	if n := bandDifferences([]int{3000, 1000}, []int{3100, 800}, opt.Resolution); n != 2 {
+		t.Errorf("expected 2 bands to differ, got %d", n)
+	}
+}
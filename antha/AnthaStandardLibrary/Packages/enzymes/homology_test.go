@@ -0,0 +1,137 @@
+package enzymes
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/seqtest"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func TestDesignOverlapAssembly(t *testing.T) {
+	parts := []wtype.DNASequence{
+		seqtest.Feature(t, "pBR322_replication_origin"),
+		seqtest.Feature(t, "ampicillin_resistance_gene_ORF"),
+		seqtest.Feature(t, "lacI_ORF"),
+	}
+
+	design, err := DesignOverlapAssembly("construct", parts, true, Gibson, wunit.NewTemperature(58, "C"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected string
+	for _, p := range parts {
+		expected += p.Seq
+	}
+	product := design.Product.Sequence()
+	if len(product) != len(expected) || !strings.Contains(product+product, expected) {
+		t.Errorf("expected product to be a rotation of the parts in order")
+	}
+	if !design.Product.Plasmid {
+		t.Error("expected circular product")
+	}
+
+	if len(design.Junctions) != 3 {
+		t.Fatalf("expected 3 junctions, got %d", len(design.Junctions))
+	}
+	for _, j := range design.Junctions {
+		if len(j.Overlap) < Gibson.MinOverlap || len(j.Overlap) > Gibson.MaxOverlap {
+			t.Errorf("overlap %s of %d bp outside %d to %d bp", j.Overlap, len(j.Overlap), Gibson.MinOverlap, Gibson.MaxOverlap)
+		}
+		if j.MeltingTemp.SIValue() < 48 {
+			t.Errorf("overlap %s melting temp %s below 48 ℃", j.Overlap, j.MeltingTemp.ToString())
+		}
+		if len(design.Product.GetFeatureByName("junction "+j.Upstream+"/"+j.Downstream)) != 1 {
+			t.Errorf("expected junction %s/%s to be annotated, got %v", j.Upstream, j.Downstream, design.Product.FeatureNames())
+		}
+	}
+
+	for i, primers := range design.Primers {
+		fwd, rev := primers[0].Sequence(), primers[1].Sequence()
+		if !strings.Contains(product+product, fwd) {
+			t.Errorf("forward primer %s for %s not found in product", fwd, parts[i].Nm)
+		}
+		if !strings.Contains(product+product, sequences.RevComp(rev)) {
+			t.Errorf("reverse primer %s for %s not found in product", rev, parts[i].Nm)
+		}
+		if !primers[1].Reverse {
+			t.Errorf("expected second primer for %s to be reverse", parts[i].Nm)
+		}
+	}
+}
+
+// fragments of common plasmid features with designed overlaps o1 between A
+// and B and o2 between B and C; C ends with the start of A
+func homologyFragments(t *testing.T) (a, b, c wtype.DNASequence, o1 string) {
+	rnd := rand.New(rand.NewSource(2))
+	o1, o2, o3 := seqtest.RandomDNA(rnd, 25), seqtest.RandomDNA(rnd, 25), seqtest.RandomDNA(rnd, 25)
+	a = wtype.MakeLinearDNASequence("A", o3+seqtest.Feature(t, "pBR322_replication_origin").Seq+o1)
+	b = wtype.MakeLinearDNASequence("B", o1+seqtest.Feature(t, "ampicillin_resistance_gene_ORF").Seq+o2)
+	c = wtype.MakeLinearDNASequence("C", o2+seqtest.Feature(t, "lacI_ORF").Seq+o3)
+	return
+}
+
+func TestSimulateHomologyAssembly(t *testing.T) {
+	a, b, c, _ := homologyFragments(t)
+
+	result, err := SimulateHomologyAssembly("circle", []wtype.DNASequence{a, b, c}, true, Gibson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Products) != 1 || len(result.Mispriming) != 0 {
+		t.Errorf("expected a single product with no mis-priming, got %d products and %v", len(result.Products), result.Mispriming)
+	}
+	if e, f := len(a.Seq)+len(b.Seq)+len(c.Seq)-3*25, len(result.Products[0].Sequence()); e != f {
+		t.Errorf("expected product of %d bp, got %d", e, f)
+	}
+
+	// as a linear assembly the homology between C and A is mis-priming
+	// which could circularise the product
+	result, err = SimulateHomologyAssembly("linear", []wtype.DNASequence{a, b, c}, false, Gibson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Mispriming) != 1 || result.Mispriming[0].Upstream != "C" || result.Mispriming[0].Downstream != "A" {
+		t.Errorf("expected mis-priming of C to A, got %v", result.Mispriming)
+	}
+	alts := result.Alternatives()
+	if len(alts) == 0 {
+		t.Fatal("expected alternative products")
+	}
+	var circular bool
+	for _, alt := range alts {
+		circular = circular || alt.Plasmid
+	}
+	if !circular {
+		t.Error("expected a circular alternative product")
+	}
+
+	if _, err := SimulateHomologyAssembly("broken", []wtype.DNASequence{a, c}, false, Gibson); err == nil {
+		t.Error("expected error for fragments without overlap")
+	}
+}
+
+func TestSimulateHomologyAssemblyInternal(t *testing.T) {
+	a, b, c, o1 := homologyFragments(t)
+	// the 3' end of A also anneals within C
+	end := o1[len(o1)-Gibson.MinHomology:]
+	c.Seq = c.Seq[:200] + end + c.Seq[200:]
+
+	result, err := SimulateHomologyAssembly("circle", []wtype.DNASequence{a, b, c}, true, Gibson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, m := range result.Mispriming {
+		if m.Upstream == "A" && m.Downstream == "C" && m.Internal() && m.Position == 201 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected 3' end of A to anneal at position 201 of C, got %v", result.Mispriming)
+	}
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/enzymes"
 	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/enzymes/lookup"
 	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/parse/genbank"
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wutil"
 )
@@ -238,6 +239,30 @@ func FastaSerial(makeinanthapath bool, dir string, seqs []wtype.DNASequence) (wt
 	return anthafile, filename, err
 }
 
+// Genbank exports a sequence with its features to a file in genbank format.
+func Genbank(dir string, seq wtype.DNASequence) (wtype.File, string, error) {
+	var anthafile wtype.File
+	filename := filepath.Join(anthapath.Path(), fmt.Sprintf("%s_%s.gb", dir, seq.Name()))
+
+	contents, err := genbank.AnnotatedSeqToGenbank(seq)
+	if err != nil {
+		return anthafile, "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0644); err != nil {
+		return anthafile, "", err
+	}
+
+	if err := ioutil.WriteFile(filename, contents, 0644); err != nil {
+		return anthafile, "", err
+	}
+
+	anthafile.Name = filename
+	err = anthafile.WriteAll(contents)
+
+	return anthafile, filename, err
+}
+
 // FastaAndSeqReports simultaneously exports multiple Fasta files and summary files for a TypeIIs assembly design.
 func FastaAndSeqReports(assemblyparameters enzymes.Assemblyparameters) (fastafiles []wtype.File, summaryfiles []wtype.File, err error) {
 
@@ -0,0 +1,120 @@
+package fit
+
+import (
+	"math"
+)
+
+// betacf evaluates the continued fraction for the incomplete beta function
+func betacf(a, b, x float64) float64 {
+	const (
+		maxIter = 300
+		eps     = 3.0e-14
+		fpmin   = 1.0e-300
+	)
+	qab, qap, qam := a+b, a+1.0, a-1.0
+	c := 1.0
+	d := 1.0 - qab*x/qap
+	if math.Abs(d) < fpmin {
+		d = fpmin
+	}
+	d = 1.0 / d
+	h := d
+	for m := 1; m <= maxIter; m++ {
+		fm := float64(m)
+		m2 := 2.0 * fm
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1.0 + aa*d
+		if math.Abs(d) < fpmin {
+			d = fpmin
+		}
+		c = 1.0 + aa/c
+		if math.Abs(c) < fpmin {
+			c = fpmin
+		}
+		d = 1.0 / d
+		h *= d * c
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1.0 + aa*d
+		if math.Abs(d) < fpmin {
+			d = fpmin
+		}
+		c = 1.0 + aa/c
+		if math.Abs(c) < fpmin {
+			c = fpmin
+		}
+		d = 1.0 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1.0) < eps {
+			break
+		}
+	}
+	return h
+}
+
+// regIncBeta returns the regularized incomplete beta function I_x(a, b)
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	bt := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1.0-x))
+	if x < (a+1.0)/(a+b+2.0) {
+		return bt * betacf(a, b, x) / a
+	}
+	return 1.0 - bt*betacf(b, a, 1.0-x)/b
+}
+
+// StudentTCDF returns the cumulative distribution function of Student's t
+// distribution with df degrees of freedom
+func StudentTCDF(t float64, df int) float64 {
+	v := float64(df)
+	p := 0.5 * regIncBeta(0.5*v, 0.5, v/(v+t*t))
+	if t > 0 {
+		return 1.0 - p
+	}
+	return p
+}
+
+// StudentTQuantile returns the value of t below which a fraction p of
+// Student's t distribution with df degrees of freedom lies
+func StudentTQuantile(p float64, df int) float64 {
+	if p <= 0 {
+		return math.Inf(-1)
+	}
+	if p >= 1 {
+		return math.Inf(1)
+	}
+	if p < 0.5 {
+		return -StudentTQuantile(1.0-p, df)
+	}
+
+	lo, hi := 0.0, 1.0
+	for StudentTCDF(hi, df) < p {
+		hi *= 2
+	}
+	for i := 0; i < 200 && hi-lo > 1.0e-12*hi; i++ {
+		mid := 0.5 * (lo + hi)
+		if StudentTCDF(mid, df) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return 0.5 * (lo + hi)
+}
+
+// FCDF returns the cumulative distribution function of the F distribution
+// with d1 and d2 degrees of freedom
+func FCDF(f float64, d1, d2 int) float64 {
+	if f <= 0 {
+		return 0
+	}
+	v1, v2 := float64(d1), float64(d2)
+	return regIncBeta(0.5*v1, 0.5*v2, v1*f/(v1*f+v2))
+}
@@ -0,0 +1,305 @@
+// Package fit fits models to data by weighted least squares and estimates the
+// uncertainty of the fitted parameters
+package fit
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Model returns the value at x of a model with parameters params
+type Model func(x float64, params []float64) float64
+
+// A Parameter is a fitted parameter with its standard error and confidence
+// interval
+type Parameter struct {
+	Value  float64
+	StdErr float64
+	Lower  float64
+	Upper  float64
+}
+
+func (p Parameter) String() string {
+	return fmt.Sprintf("%g (%g, %g)", p.Value, p.Lower, p.Upper)
+}
+
+// Options control how a model is fitted
+type Options struct {
+	// Weights of each point; if nil all points have weight 1
+	Weights []float64
+	// Confidence level of the parameter intervals; if zero 0.95
+	Confidence float64
+	// MaxIterations of the solver; if zero 200
+	MaxIterations int
+	// Lower and Upper bounds of the parameters, if not nil
+	Lower []float64
+	Upper []float64
+}
+
+// A Result is a fitted model
+type Result struct {
+	Params []Parameter
+	// Covariance of the parameters
+	Covariance [][]float64
+	// RSS is the weighted residual sum of squares
+	RSS float64
+	// RSquared is the weighted coefficient of determination
+	RSquared float64
+	// DF is the residual degrees of freedom
+	DF         int
+	Iterations int
+
+	model Model
+}
+
+// Values returns the fitted parameter values
+func (r *Result) Values() []float64 {
+	ret := make([]float64, len(r.Params))
+	for i, p := range r.Params {
+		ret[i] = p.Value
+	}
+	return ret
+}
+
+// Predict returns the value of the fitted model at x
+func (r *Result) Predict(x float64) float64 {
+	return r.model(x, r.Values())
+}
+
+type problem struct {
+	model   Model
+	x, y, w []float64
+	lower   []float64
+	upper   []float64
+}
+
+func (p *problem) rss(params []float64) float64 {
+	var s float64
+	for i := range p.x {
+		r := p.y[i] - p.model(p.x[i], params)
+		s += p.w[i] * r * r
+	}
+	return s
+}
+
+func (p *problem) clamp(params []float64) {
+	for i := range params {
+		if p.lower != nil && params[i] < p.lower[i] {
+			params[i] = p.lower[i]
+		}
+		if p.upper != nil && params[i] > p.upper[i] {
+			params[i] = p.upper[i]
+		}
+	}
+}
+
+// jacobian returns the derivatives of the model at each point with respect to
+// each parameter by central differences
+func (p *problem) jacobian(params []float64) [][]float64 {
+	jac := make([][]float64, len(p.x))
+	for i := range jac {
+		jac[i] = make([]float64, len(params))
+	}
+	q := make([]float64, len(params))
+	for j := range params {
+		h := 1.0e-6 * math.Max(math.Abs(params[j]), 1.0e-3)
+		copy(q, params)
+		q[j] = params[j] + h
+		for i, x := range p.x {
+			jac[i][j] = p.model(x, q)
+		}
+		q[j] = params[j] - h
+		for i, x := range p.x {
+			jac[i][j] = (jac[i][j] - p.model(x, q)) / (2 * h)
+		}
+	}
+	return jac
+}
+
+// normal returns J^T W J and J^T W r
+func (p *problem) normal(params []float64) ([][]float64, []float64) {
+	jac := p.jacobian(params)
+	n := len(params)
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	g := make([]float64, n)
+	for k, x := range p.x {
+		r := p.y[k] - p.model(x, params)
+		for i := 0; i < n; i++ {
+			g[i] += p.w[k] * jac[k][i] * r
+			for j := 0; j < n; j++ {
+				a[i][j] += p.w[k] * jac[k][i] * jac[k][j]
+			}
+		}
+	}
+	return a, g
+}
+
+// LeastSquares fits a model to points (x, y) starting from initial parameter
+// values using the Levenberg-Marquardt method. There must be at least as many
+// points as parameters; if there are no more, standard errors are NaN.
+func LeastSquares(model Model, x, y []float64, initial []float64, opts Options) (*Result, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("have %d x values but %d y values", len(x), len(y))
+	}
+	if len(x) < len(initial) {
+		return nil, fmt.Errorf("cannot fit %d parameters to %d points", len(initial), len(x))
+	}
+	if opts.Weights != nil && len(opts.Weights) != len(x) {
+		return nil, fmt.Errorf("have %d points but %d weights", len(x), len(opts.Weights))
+	}
+	if opts.Lower != nil && len(opts.Lower) != len(initial) || opts.Upper != nil && len(opts.Upper) != len(initial) {
+		return nil, fmt.Errorf("bounds must be given for all %d parameters", len(initial))
+	}
+	if opts.Confidence == 0 {
+		opts.Confidence = 0.95
+	}
+	if opts.MaxIterations == 0 {
+		opts.MaxIterations = 200
+	}
+
+	p := &problem{model: model, x: x, y: y, w: opts.Weights, lower: opts.Lower, upper: opts.Upper}
+	if p.w == nil {
+		p.w = make([]float64, len(x))
+		for i := range p.w {
+			p.w[i] = 1.0
+		}
+	}
+
+	params := append([]float64(nil), initial...)
+	p.clamp(params)
+	rss := p.rss(params)
+	if math.IsNaN(rss) || math.IsInf(rss, 0) {
+		return nil, fmt.Errorf("model cannot be evaluated at initial parameters %v", initial)
+	}
+
+	lambda := 1.0e-3
+	trial := make([]float64, len(params))
+	iter := 0
+	for ; iter < opts.MaxIterations; iter++ {
+		a, g := p.normal(params)
+		improved := false
+		for lambda < 1.0e16 {
+			damped := make([][]float64, len(a))
+			for i := range a {
+				damped[i] = append([]float64(nil), a[i]...)
+				damped[i][i] += lambda * math.Max(a[i][i], 1.0e-12)
+			}
+			delta, err := solve(damped, g)
+			if err != nil {
+				lambda *= 10
+				continue
+			}
+			for i := range params {
+				trial[i] = params[i] + delta[i]
+			}
+			p.clamp(trial)
+			if trss := p.rss(trial); trss <= rss {
+				converged := rss-trss <= 1.0e-12*(rss+1.0e-12)
+				copy(params, trial)
+				rss = trss
+				lambda /= 10
+				improved = !converged
+				break
+			}
+			lambda *= 10
+		}
+		if !improved {
+			break
+		}
+	}
+
+	return p.result(params, rss, iter, opts.Confidence)
+}
+
+func (p *problem) result(params []float64, rss float64, iter int, confidence float64) (*Result, error) {
+	res := &Result{
+		Params:     make([]Parameter, len(params)),
+		RSS:        rss,
+		DF:         len(p.x) - len(params),
+		Iterations: iter,
+		model:      p.model,
+	}
+
+	var sw, swy float64
+	for i := range p.y {
+		sw += p.w[i]
+		swy += p.w[i] * p.y[i]
+	}
+	var tss float64
+	for i := range p.y {
+		d := p.y[i] - swy/sw
+		tss += p.w[i] * d * d
+	}
+	if tss > 0 {
+		res.RSquared = 1.0 - rss/tss
+	}
+
+	a, _ := p.normal(params)
+	cov, err := invert(a)
+	if err != nil {
+		return nil, fmt.Errorf("parameters cannot be determined from the data: %s", err)
+	}
+
+	s2 := math.NaN()
+	t := math.NaN()
+	if res.DF > 0 {
+		s2 = rss / float64(res.DF)
+		t = StudentTQuantile(0.5+0.5*confidence, res.DF)
+	}
+	for i := range cov {
+		for j := range cov[i] {
+			cov[i][j] *= s2
+		}
+	}
+	res.Covariance = cov
+
+	for i, v := range params {
+		se := math.Sqrt(cov[i][i])
+		res.Params[i] = Parameter{
+			Value:  v,
+			StdErr: se,
+			Lower:  v - t*se,
+			Upper:  v + t*se,
+		}
+	}
+	return res, nil
+}
+
+// Linear fits a straight line, y = params[0] + params[1] x, to points
+func Linear(x, y []float64, opts Options) (*Result, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("have %d x values but %d y values", len(x), len(y))
+	}
+	if opts.Weights != nil && len(opts.Weights) != len(x) {
+		return nil, fmt.Errorf("have %d points but %d weights", len(x), len(opts.Weights))
+	}
+	w := opts.Weights
+	var sw, sx, sy, sxx, sxy float64
+	for i := range x {
+		wi := 1.0
+		if w != nil {
+			wi = w[i]
+		}
+		sw += wi
+		sx += wi * x[i]
+		sy += wi * y[i]
+		sxx += wi * x[i] * x[i]
+		sxy += wi * x[i] * y[i]
+	}
+	det := sw*sxx - sx*sx
+	if det == 0 {
+		return nil, fmt.Errorf("cannot fit a line to points with one x value")
+	}
+	slope := (sw*sxy - sx*sy) / det
+	intercept := (sy - slope*sx) / sw
+
+	return LeastSquares(LinearModel, x, y, []float64{intercept, slope}, opts)
+}
+
+// LinearModel is the model fitted by Linear
+func LinearModel(x float64, params []float64) float64 {
+	return params[0] + params[1]*x
+}
@@ -0,0 +1,156 @@
+package fit
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestStudentTQuantile(t *testing.T) {
+	for _, tc := range []struct {
+		p  float64
+		df int
+		t  float64
+	}{
+		{0.975, 1, 12.7062},
+		{0.975, 5, 2.5706},
+		{0.95, 10, 1.8125},
+		{0.025, 30, -2.0423},
+	} {
+		if f := StudentTQuantile(tc.p, tc.df); math.Abs(f-tc.t) > 1.0e-3 {
+			t.Errorf("t(%g, %d): expecting %g found %g", tc.p, tc.df, tc.t, f)
+		}
+	}
+}
+
+func TestLinear(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2.1, 3.9, 6.2, 7.8, 10.1}
+	res, err := Linear(x, y, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 1.99, res.Params[1].Value; math.Abs(e-f) > 1.0e-6 {
+		t.Errorf("expecting slope %g found %g", e, f)
+	}
+	if e, f := 0.05, res.Params[0].Value; math.Abs(e-f) > 1.0e-6 {
+		t.Errorf("expecting intercept %g found %g", e, f)
+	}
+	// standard error of slope is sqrt(s^2 / Sxx)
+	if e, f := math.Sqrt(0.107/3/10), res.Params[1].StdErr; math.Abs(e-f) > 1.0e-6 {
+		t.Errorf("expecting standard error %g found %g", e, f)
+	}
+	if p := res.Params[1]; p.Lower > 1.99 || p.Upper < 1.99 || p.Upper-p.Lower > 1 {
+		t.Errorf("unexpected confidence interval %v", p)
+	}
+	if e, f := 3, res.DF; e != f {
+		t.Errorf("expecting %d degrees of freedom found %d", e, f)
+	}
+
+	for _, w := range [][]float64{{1, 1, 1, 1}, {1, 1, 1, 1, 1, 1}} {
+		if _, err := Linear(x, y, Options{Weights: w}); err == nil {
+			t.Errorf("expecting error for %d weights of %d points", len(w), len(x))
+		}
+		if _, err := LeastSquares(LinearModel, x, y, []float64{0, 1}, Options{Weights: w}); err == nil {
+			t.Errorf("expecting error for %d weights of %d points", len(w), len(x))
+		}
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	model := func(x float64, p []float64) float64 {
+		return p[0] * math.Exp(-p[1]*x)
+	}
+	var x, y []float64
+	for i := 0; i < 20; i++ {
+		xi := float64(i) / 4
+		x = append(x, xi)
+		y = append(y, model(xi, []float64{5, 0.7})*(1+0.01*math.Sin(float64(i))))
+	}
+
+	res, err := LeastSquares(model, x, y, []float64{1, 0.1}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := res.Params[0].Value; math.Abs(f-5) > 0.05 {
+		t.Errorf("expecting amplitude 5 found %g", f)
+	}
+	if f := res.Params[1].Value; math.Abs(f-0.7) > 0.01 {
+		t.Errorf("expecting rate 0.7 found %g", f)
+	}
+	if res.RSquared < 0.99 {
+		t.Errorf("expecting good fit found R^2 %g", res.RSquared)
+	}
+	if e, f := model(1, res.Values()), res.Predict(1); e != f {
+		t.Errorf("expecting prediction %g found %g", e, f)
+	}
+
+	bounded, err := LeastSquares(model, x, y, []float64{1, 0.1}, Options{Upper: []float64{4, 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := bounded.Params[0].Value; f > 4 {
+		t.Errorf("expecting amplitude bounded by 4 found %g", f)
+	}
+
+	if _, err := LeastSquares(model, x[:1], y[:1], []float64{1, 0.1}, Options{}); err == nil {
+		t.Error("expecting error for too few points")
+	}
+}
+
+func TestFCDF(t *testing.T) {
+	// upper 5% points of the F distribution
+	for _, tc := range []struct {
+		f      float64
+		d1, d2 int
+	}{
+		{161.45, 1, 1},
+		{5.1174, 1, 9},
+		{3.3258, 5, 10},
+	} {
+		if p := FCDF(tc.f, tc.d1, tc.d2); math.Abs(p-0.95) > 1.0e-4 {
+			t.Errorf("F(%g; %d, %d): expecting 0.95 found %g", tc.f, tc.d1, tc.d2, p)
+		}
+	}
+}
+
+func TestRegress(t *testing.T) {
+	var x [][]float64
+	var y []float64
+	for i := 0; i < 10; i++ {
+		a, b := float64(i%3), float64(i/3)
+		x = append(x, []float64{1, a, b})
+		y = append(y, 1+2*a-3*b+0.01*math.Sin(float64(i)))
+	}
+	r, err := Regress(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, e := range []float64{1, 2, -3} {
+		if f := r.Coefficients[i]; math.Abs(e-f) > 0.02 {
+			t.Errorf("coefficient %d: expecting %g found %g", i, e, f)
+		}
+	}
+	if e, f := 7, r.DF; e != f {
+		t.Errorf("expecting %d degrees of freedom found %d", e, f)
+	}
+
+	if _, err := Regress([][]float64{{1, 1}, {1, 1}, {1, 1}}, []float64{1, 2, 3}); err == nil {
+		t.Error("expecting error for aliased terms")
+	}
+
+	// the last term is the sum of the second and third up to rounding
+	x = nil
+	for i := 0; i < 10; i++ {
+		a, b := 0.1*float64(i%3), 0.3*float64(i/3)
+		x = append(x, []float64{1, a, b, 0.1 + a + b - 0.1})
+	}
+	_, err = Regress(x, y)
+	ae, ok := err.(*AliasError)
+	if !ok {
+		t.Fatalf("expecting alias error found %v", err)
+	}
+	if e, f := []Alias{{Term: 3, With: []int{1, 2}}}, ae.Aliases; !reflect.DeepEqual(e, f) {
+		t.Errorf("expecting aliases %v found %v", e, f)
+	}
+}
@@ -0,0 +1,69 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+)
+
+// solve solves a x = b by Gaussian elimination with partial pivoting. a and b
+// are not modified.
+func solve(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	m := make([][]float64, n)
+	for i := range a {
+		m[i] = make([]float64, n+1)
+		copy(m[i], a[i])
+		m[i][n] = b[i]
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < 1.0e-300 {
+			return nil, fmt.Errorf("singular matrix")
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for row := col + 1; row < n; row++ {
+			f := m[row][col] / m[col][col]
+			for k := col; k <= n; k++ {
+				m[row][k] -= f * m[col][k]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		s := m[row][n]
+		for k := row + 1; k < n; k++ {
+			s -= m[row][k] * x[k]
+		}
+		x[row] = s / m[row][row]
+	}
+	return x, nil
+}
+
+// invert returns the inverse of a square matrix
+func invert(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	inv := make([][]float64, n)
+	for i := range inv {
+		inv[i] = make([]float64, n)
+	}
+	for col := 0; col < n; col++ {
+		e := make([]float64, n)
+		e[col] = 1.0
+		x, err := solve(a, e)
+		if err != nil {
+			return nil, err
+		}
+		for row := 0; row < n; row++ {
+			inv[row][col] = x[row]
+		}
+	}
+	return inv, nil
+}
@@ -0,0 +1,198 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// A Regression is a linear model, y = X b, fitted by ordinary least squares
+type Regression struct {
+	Coefficients []float64
+	// XtXInv is the inverse of X^T X, i.e., the covariance of the
+	// coefficients divided by the residual variance
+	XtXInv    [][]float64
+	Fitted    []float64
+	Residuals []float64
+	// RSS is the residual sum of squares
+	RSS float64
+	// DF is the residual degrees of freedom
+	DF int
+}
+
+// Regress fits a linear model to observations y, where each row of x holds
+// the values of the terms of the model for an observation
+func Regress(x [][]float64, y []float64) (*Regression, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("have %d rows but %d observations", len(x), len(y))
+	}
+	if len(x) == 0 {
+		return nil, fmt.Errorf("no observations")
+	}
+	p := len(x[0])
+	if len(x) < p {
+		return nil, fmt.Errorf("cannot fit %d terms to %d observations", p, len(x))
+	}
+
+	xtx := make([][]float64, p)
+	for i := range xtx {
+		xtx[i] = make([]float64, p)
+	}
+	xty := make([]float64, p)
+	for k, row := range x {
+		if len(row) != p {
+			return nil, fmt.Errorf("row %d has %d terms not %d", k, len(row), p)
+		}
+		for i := 0; i < p; i++ {
+			xty[i] += row[i] * y[k]
+			for j := 0; j < p; j++ {
+				xtx[i][j] += row[i] * row[j]
+			}
+		}
+	}
+
+	if as := aliases(x, p); len(as) != 0 {
+		return nil, &AliasError{Aliases: as}
+	}
+
+	inv, err := invert(xtx)
+	if err != nil {
+		return nil, fmt.Errorf("terms of the model cannot be estimated independently: %s", err)
+	}
+
+	r := &Regression{
+		Coefficients: make([]float64, p),
+		XtXInv:       inv,
+		DF:           len(y) - p,
+	}
+	for i := 0; i < p; i++ {
+		for j := 0; j < p; j++ {
+			r.Coefficients[i] += inv[i][j] * xty[j]
+		}
+	}
+	for k, row := range x {
+		var f float64
+		for i, v := range row {
+			f += v * r.Coefficients[i]
+		}
+		r.Fitted = append(r.Fitted, f)
+		r.Residuals = append(r.Residuals, y[k]-f)
+		r.RSS += (y[k] - f) * (y[k] - f)
+	}
+	return r, nil
+}
+
+// An Alias is a term of a model whose values are a linear combination of
+// those of earlier terms, so its effect cannot be told apart from theirs.
+// Terms are indices of columns of the model.
+type Alias struct {
+	Term int
+	With []int
+}
+
+// An AliasError is returned by Regress if some terms of a model cannot be
+// estimated independently
+type AliasError struct {
+	Aliases []Alias
+}
+
+func (e *AliasError) Error() string {
+	return e.Explain(func(i int) string { return fmt.Sprintf("term %d", i) })
+}
+
+// Explain describes the aliases using the given names of terms
+func (e *AliasError) Explain(name func(int) string) string {
+	var descs []string
+	for _, a := range e.Aliases {
+		if len(a.With) == 0 {
+			descs = append(descs, fmt.Sprintf("%s is zero for every observation", name(a.Term)))
+			continue
+		}
+		var with []string
+		for _, t := range a.With {
+			with = append(with, name(t))
+		}
+		descs = append(descs, fmt.Sprintf("%s is aliased with %s", name(a.Term), strings.Join(with, ", ")))
+	}
+	return "terms of the model cannot be estimated independently: " + strings.Join(descs, "; ")
+}
+
+// aliasTolerance is the fraction of the norm of a column which must be
+// independent of earlier columns for it not to be aliased with them
+const aliasTolerance = 1.0e-8
+
+// aliases returns the columns of x which are linear combinations of earlier
+// columns. Columns are orthogonalised in turn by modified Gram-Schmidt, so x
+// = Q R, and a column is aliased if too little of its norm remains.
+func aliases(x [][]float64, p int) []Alias {
+	var q [][]float64 // orthonormal basis of the independent columns
+	var basis []int   // column from which each basis vector came
+	r := make([][]float64, p)
+	var ret []Alias
+	for j := 0; j < p; j++ {
+		v := make([]float64, len(x))
+		for i := range x {
+			v[i] = x[i][j]
+		}
+		norm := math.Sqrt(dotVec(v, v))
+		for _, qk := range q {
+			c := dotVec(qk, v)
+			r[j] = append(r[j], c)
+			for i := range v {
+				v[i] -= c * qk[i]
+			}
+		}
+		rest := math.Sqrt(dotVec(v, v))
+		if rest <= aliasTolerance*norm {
+			ret = append(ret, Alias{Term: j, With: aliasedWith(r, basis, r[j], norm)})
+			continue
+		}
+		for i := range v {
+			v[i] /= rest
+		}
+		r[j] = append(r[j], rest)
+		q = append(q, v)
+		basis = append(basis, j)
+	}
+	return ret
+}
+
+// aliasedWith returns the columns whose combination gives a column with
+// coefficients rj in the orthonormal basis, by back substitution in R
+func aliasedWith(r [][]float64, basis []int, rj []float64, norm float64) []int {
+	b := make([]float64, len(basis))
+	for m := len(basis) - 1; m >= 0; m-- {
+		s := rj[m]
+		for k := m + 1; k < len(basis); k++ {
+			s -= b[k] * r[basis[k]][m]
+		}
+		b[m] = s / r[basis[m]][m]
+	}
+	var with []int
+	for k, c := range b {
+		// ignore contributions which are negligible next to the column
+		if math.Abs(c)*math.Sqrt(dotVec(r[basis[k]], r[basis[k]])) > aliasTolerance*norm {
+			with = append(with, basis[k])
+		}
+	}
+	return with
+}
+
+func dotVec(x, y []float64) float64 {
+	var s float64
+	for i := range x {
+		s += x[i] * y[i]
+	}
+	return s
+}
+
+// Leverage returns the leverage of a row of terms, x^T (X^T X)^-1 x
+func (r *Regression) Leverage(row []float64) float64 {
+	var h float64
+	for i := range row {
+		for j := range row {
+			h += row[i] * r.XtXInv[i][j] * row[j]
+		}
+	}
+	return h
+}
@@ -0,0 +1,161 @@
+// antha/AnthaStandardLibrary/Packages/gel/gel.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package gel simulates agarose gel electrophoresis of DNA fragments, e.g.,
+// of restriction digests, and renders images of the gels for reports.
+package gel
+
+import (
+	"math"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/enzymes"
+)
+
+// loadedMass is the mass of DNA, in ng, loaded in each lane of a digest
+const loadedMass = 500.0
+
+// A Band is a DNA fragment of a size, in bp, and mass, in ng
+type Band struct {
+	Size int
+	Mass float64
+}
+
+// A Lane is the bands of a sample
+type Lane struct {
+	Name  string
+	Bands []Band
+}
+
+// A Gel is an agarose gel run with a ladder in its first lane
+type Gel struct {
+	// Percentage is the agarose concentration, % w/v
+	Percentage float64
+	Ladder     Ladder
+	Lanes      []Lane
+}
+
+// separation is the range of sizes, in bp, of linear DNA separated by gels
+// of an agarose percentage
+var separation = []struct {
+	percentage float64
+	min, max   int
+}{
+	{0.5, 1000, 30000},
+	{0.7, 800, 12000},
+	{1.0, 500, 10000},
+	{1.2, 400, 7000},
+	{1.5, 200, 3000},
+	{2.0, 50, 2000},
+}
+
+// Range returns the sizes, in bp, of the smallest and largest fragments the
+// gel separates, interpolating between known percentages
+func (g Gel) Range() (min, max int) {
+	first, last := separation[0], separation[len(separation)-1]
+	switch {
+	case g.Percentage <= first.percentage:
+		return first.min, first.max
+	case g.Percentage >= last.percentage:
+		return last.min, last.max
+	}
+
+	for i := 1; i < len(separation); i++ {
+		lo, hi := separation[i-1], separation[i]
+		if g.Percentage > hi.percentage {
+			continue
+		}
+		f := (g.Percentage - lo.percentage) / (hi.percentage - lo.percentage)
+		interpolate := func(a, b int) int {
+			return int(math.Exp(math.Log(float64(a)) + f*(math.Log(float64(b))-math.Log(float64(a)))))
+		}
+		return interpolate(lo.min, hi.min), interpolate(lo.max, hi.max)
+	}
+	return last.min, last.max
+}
+
+// Migration returns the distance a fragment of a size runs as a fraction of
+// the length of the gel. Within the range of the gel distance falls linearly
+// with the log of size; larger fragments are compressed near the wells.
+func (g Gel) Migration(size int) float64 {
+	min, max := g.Range()
+	f := (math.Log(float64(max)) - math.Log(float64(size))) / (math.Log(float64(max)) - math.Log(float64(min)))
+	return math.Max(0.03, math.Min(0.99, 0.1+0.8*f))
+}
+
+// ChoosePercentage returns the agarose percentage which best separates
+// fragments of sizes
+func ChoosePercentage(sizes []int) float64 {
+	best := 0
+	for i, s := range separation {
+		if better(s.min, s.max, separation[best].min, separation[best].max, sizes) {
+			best = i
+		}
+	}
+	return separation[best].percentage
+}
+
+// better returns whether the range min to max covers more sizes than the
+// range bestMin to bestMax or covers as many more tightly
+func better(min, max, bestMin, bestMax int, sizes []int) bool {
+	n, bestN := covered(min, max, sizes), covered(bestMin, bestMax, sizes)
+	if n != bestN {
+		return n > bestN
+	}
+	return float64(max)/float64(min) < float64(bestMax)/float64(bestMin)
+}
+
+func covered(min, max int, sizes []int) int {
+	n := 0
+	for _, size := range sizes {
+		if size >= min && size <= max {
+			n++
+		}
+	}
+	return n
+}
+
+// DigestGel returns a gel of the fragments of each construct of a diagnostic
+// digest, with a ladder and agarose percentage chosen to resolve them. The
+// fragments of each construct are equimolar.
+func DigestGel(d enzymes.DiagnosticDigest) Gel {
+	var sizes []int
+	var lanes []Lane
+	for i, construct := range d.Constructs {
+		total := 0
+		for _, size := range d.Bands[i] {
+			total += size
+		}
+
+		lane := Lane{Name: construct.Nm}
+		for _, size := range d.Bands[i] {
+			lane.Bands = append(lane.Bands, Band{Size: size, Mass: loadedMass * float64(size) / float64(total)})
+			sizes = append(sizes, size)
+		}
+		lanes = append(lanes, lane)
+	}
+
+	return Gel{
+		Percentage: ChoosePercentage(sizes),
+		Ladder:     ChooseLadder(sizes),
+		Lanes:      lanes,
+	}
+}
@@ -0,0 +1,110 @@
+// antha/AnthaStandardLibrary/Packages/gel/gel_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package gel
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestMigration(t *testing.T) {
+	g := Gel{Percentage: 1}
+	last := 0.0
+	for _, size := range []int{20000, 10000, 3000, 1000, 500, 100} {
+		m := g.Migration(size)
+		if m < last {
+			t.Errorf("%d bp migrated %f, less than larger fragments (%f)", size, m, last)
+		}
+		last = m
+	}
+	if g.Migration(3000) == g.Migration(1000) {
+		t.Errorf("3000 and 1000 bp not separated")
+	}
+
+	min, max := Gel{Percentage: 0.8}.Range()
+	if min >= 800 || min <= 500 || max >= 12000 || max <= 10000 {
+		t.Errorf("expected range of 0.8%% gel between 0.7%% and 1%%, got %d-%d", min, max)
+	}
+}
+
+func TestChooseLadder(t *testing.T) {
+	tests := []struct {
+		Sizes  []int
+		Ladder string
+	}{
+		{[]int{6000, 3000, 1200}, "1 kb ladder"},
+		{[]int{1200, 600, 250}, "100 bp ladder"},
+		{[]int{8000, 300}, "1 kb plus ladder"},
+	}
+	for _, test := range tests {
+		if l := ChooseLadder(test.Sizes); l.Name != test.Ladder {
+			t.Errorf("%v: expected %s, got %s", test.Sizes, test.Ladder, l.Name)
+		}
+	}
+
+	if p := ChoosePercentage([]int{600, 300, 150}); p != 2 {
+		t.Errorf("expected 2%% gel for small fragments, got %f", p)
+	}
+}
+
+func TestExport(t *testing.T) {
+	g := Gel{
+		Percentage: 1,
+		Ladder:     Ladders[0],
+		Lanes: []Lane{
+			{Name: "expected", Bands: []Band{{3000, 300}, {1000, 100}}},
+			{Name: "empty"},
+		},
+	}
+
+	f, err := g.Export("digest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Name != "digest.png" {
+		t.Errorf("expected digest.png, got %s", f.Name)
+	}
+
+	data, err := f.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 2*margin+3*laneWidth+2*laneGap || b.Dy() != 2*margin+wellHeight+runLength {
+		t.Errorf("unexpected image size %v", b)
+	}
+
+	// the expected lane has a band where the 3 kb ladder band is
+	y := margin + wellHeight + int(g.Migration(3000)*runLength)
+	x := margin + laneWidth + laneGap + laneWidth/2
+	if r, _, _, _ := img.At(x, y).RGBA(); r>>8 < 200 {
+		t.Errorf("no band at 3000 bp")
+	}
+	if r, _, _, _ := img.At(x, y+20).RGBA(); r>>8 > 100 {
+		t.Errorf("unexpected band below 3000 bp")
+	}
+}
@@ -0,0 +1,84 @@
+// antha/AnthaStandardLibrary/Packages/gel/ladders.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package gel
+
+// A Ladder is a DNA size standard
+type Ladder struct {
+	Name  string
+	Bands []Band
+}
+
+// Range returns the sizes of the smallest and largest bands of the ladder
+func (l Ladder) Range() (min, max int) {
+	for i, band := range l.Bands {
+		if i == 0 || band.Size < min {
+			min = band.Size
+		}
+		if band.Size > max {
+			max = band.Size
+		}
+	}
+	return
+}
+
+// Ladders are common DNA size standards with the masses, in ng, of their
+// bands in a typical lane. Brighter reference bands help orientation.
+var Ladders = []Ladder{
+	{
+		Name: "1 kb ladder",
+		Bands: []Band{
+			{10000, 40}, {8000, 40}, {6000, 50}, {5000, 40}, {4000, 35},
+			{3000, 120}, {2000, 50}, {1500, 35}, {1000, 40}, {500, 40},
+		},
+	},
+	{
+		Name: "1 kb plus ladder",
+		Bands: []Band{
+			{10000, 40}, {8000, 40}, {6000, 40}, {5000, 40}, {4000, 40},
+			{3000, 100}, {2000, 40}, {1500, 40}, {1000, 100}, {700, 30},
+			{500, 30}, {400, 30}, {300, 30}, {200, 30}, {100, 30},
+		},
+	},
+	{
+		Name: "100 bp ladder",
+		Bands: []Band{
+			{1500, 45}, {1200, 35}, {1000, 95}, {900, 27}, {800, 24},
+			{700, 21}, {600, 18}, {500, 97}, {400, 38}, {300, 29},
+			{200, 25}, {100, 48},
+		},
+	},
+}
+
+// ChooseLadder returns the ladder which best covers fragments of sizes,
+// i.e., which covers the most, most tightly
+func ChooseLadder(sizes []int) Ladder {
+	best := Ladders[0]
+	for _, ladder := range Ladders[1:] {
+		min, max := ladder.Range()
+		bestMin, bestMax := best.Range()
+		if better(min, max, bestMin, bestMax, sizes) {
+			best = ladder
+		}
+	}
+	return best
+}
@@ -0,0 +1,109 @@
+// antha/AnthaStandardLibrary/Packages/gel/render.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package gel
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	anthaimage "github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/image"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// dimensions, in pixels, of rendered gels
+const (
+	margin     = 16
+	laneWidth  = 36
+	laneGap    = 12
+	wellHeight = 6
+	runLength  = 360
+)
+
+// saturation is the mass, in ng, of the brightest band
+const saturation = 100.0
+
+var (
+	background = color.NRGBA{R: 24, G: 22, B: 28, A: 255}
+	well       = color.NRGBA{R: 64, G: 60, B: 70, A: 255}
+	stain      = color.NRGBA{R: 255, G: 236, B: 214, A: 255}
+)
+
+// Image renders the gel as if imaged under UV, with the ladder in the first
+// lane. Band brightness is proportional to mass and bands blur as they
+// become heavier.
+func (g Gel) Image() *image.NRGBA {
+	lanes := append([]Lane{{Name: g.Ladder.Name, Bands: g.Ladder.Bands}}, g.Lanes...)
+	width := 2*margin + len(lanes)*laneWidth + (len(lanes)-1)*laneGap
+	height := 2*margin + wellHeight + runLength
+
+	intensity := make([]float64, width*height)
+	for l, lane := range lanes {
+		x0 := margin + l*(laneWidth+laneGap)
+		for _, band := range lane.Bands {
+			y := float64(margin+wellHeight) + g.Migration(band.Size)*runLength
+			brightness := math.Min(1, band.Mass/saturation)
+			sigma := 1.2 + 1.5*brightness
+			for dy := -int(4 * sigma); dy <= int(4*sigma); dy++ {
+				row := int(y) + dy
+				if row < 0 || row >= height {
+					continue
+				}
+				v := brightness * math.Exp(-float64(dy*dy)/(2*sigma*sigma))
+				for x := x0 + 2; x < x0+laneWidth-2; x++ {
+					intensity[row*width+x] += v
+				}
+			}
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, blend(background, stain, math.Min(1, intensity[y*width+x])))
+		}
+	}
+	for l := range lanes {
+		x0 := margin + l*(laneWidth+laneGap)
+		for y := margin; y < margin+wellHeight; y++ {
+			for x := x0; x < x0+laneWidth; x++ {
+				img.SetNRGBA(x, y, well)
+			}
+		}
+	}
+
+	return img
+}
+
+func blend(a, b color.NRGBA, f float64) color.NRGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + f*(float64(y)-float64(x)))
+	}
+	return color.NRGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: 255}
+}
+
+// Export renders the gel to file for inclusion in reports. The image format
+// is derived from the filename extension and is PNG if there is none.
+func (g Gel) Export(fileName string) (wtype.File, error) {
+	return anthaimage.Export(g.Image(), fileName)
+}
@@ -29,13 +29,9 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"net/http"
-	"os"
-	"path/filepath"
+	"net/url"
 	"strings"
 
-	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/AnthaPath"
 	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/search"
 )
 
@@ -68,7 +64,9 @@ If you enter 'recursive' as the first part name, the returned XML will include d
 */
 
 const (
-	registryFile = "iGem_registry.txt"
+	registryHost = "parts.igem.org"
+	registryURL  = "http://" + registryHost
+	allParts     = "fasta/parts/All_Parts"
 )
 
 // Part Name classifications
@@ -133,7 +131,7 @@ func MakeFastaURL(partname string) (Urlstring string) {
 	// see comment above for structure
 	//<domain> = substance | compound | assay | <other inputs>
 
-	level1 := "http://parts.igem.org"
+	level1 := registryURL
 	// http://parts.igem.org/fasta/parts/all
 	array := make([]string, 0)
 	array = append(array, level1, "fasta", "parts", partname)
@@ -154,7 +152,7 @@ func MakeXMLURL(partnames []string) (Urlstring string) {
 	}
 	partconcat := strings.Join(parts, ".")
 
-	level1 := "http://parts.igem.org"
+	level1 := registryURL
 
 	array := make([]string, 0)
 	array = append(array, level1, "xml", partconcat)
@@ -164,51 +162,45 @@ func MakeXMLURL(partnames []string) (Urlstring string) {
 	return Urlstring
 }
 
+// registryPath returns the path of a page of the registry from its URL, e.g.,
+// xml/part.BBa_B0034 for http://parts.igem.org/xml/part.BBa_B0034
+func registryPath(Urlstring string) (string, error) {
+	u, err := url.Parse(Urlstring)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(u.Host, registryHost) {
+		return "", fmt.Errorf("%s is not a page of the iGem registry at %s", Urlstring, registryHost)
+	}
+	path := strings.TrimPrefix(u.Path, "/")
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path, nil
+}
+
+// SlurpOutput returns the response of the registry to a URL of one of its
+// pages from the DefaultProvider. It panics if the URL is not of the registry
+// or the request fails.
 func SlurpOutput(Urlstring string) (output []byte) {
 	fmt.Println("Slurping...", Urlstring)
 
-	res, err := http.Get(Urlstring)
+	path, err := registryPath(Urlstring)
+	if err != nil {
+		panic(err)
+	}
+	output, err = DefaultProvider.Fetch(path)
 	if err != nil {
 		panic(err)
 	}
-
-	output, err = ioutil.ReadAll(res.Body) // this is a slow step!
 
 	return output
 }
 
+// makeRegistryfile returns the FASTA file of all parts of the registry
+// (FYI: >34MB file)
 func makeRegistryfile() ([]byte, error) {
-	file := filepath.Join(anthapath.Path(), registryFile)
-
-	if _, err := os.Stat(file); os.IsNotExist(err) {
-		if err := os.MkdirAll(filepath.Dir(file), 0777); err != nil {
-			return nil, err
-		}
-		// FYI: >34MB file
-		res, err := http.Get("http://parts.igem.org/fasta/parts/All_Parts")
-		if err != nil {
-			return nil, err
-		}
-		defer res.Body.Close()
-
-		f, err := os.Create(file)
-		if err != nil {
-			return nil, err
-		}
-		defer f.Close()
-
-		var buf bytes.Buffer
-		if _, err := io.Copy(&buf, res.Body); err != nil {
-			return nil, err
-		}
-
-		if err := ioutil.WriteFile(file, buf.Bytes(), 0666); err != nil {
-			return nil, err
-		}
-		return buf.Bytes(), nil
-	}
-
-	return ioutil.ReadFile(file)
+	return DefaultProvider.Fetch(allParts)
 }
 
 type FastaPart struct {
@@ -0,0 +1,129 @@
+// antha/AnthaStandardLibrary/Packages/igem/igem_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package igem
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/remote"
+)
+
+var fixtures = Fixtures{
+	"xml/part.BBa_B0034": []byte(`<rsbpml>
+ <part_list>
+  <part>
+   <part_id>151</part_id>
+   <part_name>BBa_B0034</part_name>
+   <part_short_desc>RBS (Elowitz 1999) -- defines RBS efficiency</part_short_desc>
+   <part_type>RBS</part_type>
+   <sequences>
+    <seq_data>
+aaagaggaga
+aa
+</seq_data>
+   </sequences>
+  </part>
+ </part_list>
+</rsbpml>`),
+	allParts: []byte(`>BBa_B0034 A 151 RBS RBS (Elowitz 1999) -- defines RBS efficiency
+aaagaggagaaa
+>BBa_B0015 A 187 Terminator double terminator (B0010-B0012)
+ccaggcatcaaataaaacgaaaggctcagtcgaaagactgggcctttcgttttatctgttgtttgtcggtgaacgctctctactagagtcacactggctcaccttcgggtgggcctttctgcgtttata
+`),
+}
+
+func TestGetSequence(t *testing.T) {
+	defer func(p Provider) {
+		DefaultProvider = p
+	}(DefaultProvider)
+	DefaultProvider = fixtures
+
+	if seq := GetSequence("BBa_B0034"); seq != "AAAGAGGAGAAA" {
+		t.Errorf("expected sequence AAAGAGGAGAAA, got %s", seq)
+	}
+	if desc := GetDescription("BBa_B0034"); desc != "RBS (Elowitz 1999) -- defines RBS efficiency" {
+		t.Errorf("unexpected description %s", desc)
+	}
+}
+
+func TestFilterRegistry(t *testing.T) {
+	defer func(p Provider) {
+		DefaultProvider = p
+	}(DefaultProvider)
+	DefaultProvider = fixtures
+
+	ids, descriptions, err := FilterRegistry("GENERIC", []string{"terminator"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "BBa_B0015" || descriptions["BBa_B0015"] == "" {
+		t.Errorf("expected BBa_B0015, got %v", ids)
+	}
+
+	if n := CountPartsinRegistryContaining([]string{"RBS"}); n != 1 {
+		t.Errorf("expected 1 part containing RBS, got %d", n)
+	}
+}
+
+func TestRegistryPath(t *testing.T) {
+	for url, expected := range map[string]string{
+		MakeXMLURL([]string{"BBa_B0034"}):              "xml/part.BBa_B0034",
+		MakeFastaURL("All_Parts"):                      allParts,
+		"https://parts.igem.org/xml/part.BBa_B0034":    "xml/part.BBa_B0034",
+		"http://PARTS.igem.org/cgi/partsdb/x.cgi?id=1": "cgi/partsdb/x.cgi?id=1",
+	} {
+		if path, err := registryPath(url); err != nil || path != expected {
+			t.Errorf("%s: expected %s, got %s: %v", url, expected, path, err)
+		}
+	}
+	if _, err := registryPath("http://example.com/xml/part.BBa_B0034"); err == nil {
+		t.Errorf("expected error for URL which is not of the registry")
+	}
+}
+
+func TestMigrateRegistryFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "igem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(f string) {
+		oldRegistryFile = f
+	}(oldRegistryFile)
+	oldRegistryFile = filepath.Join(dir, "iGem_registry.txt")
+	if err := ioutil.WriteFile(oldRegistryFile, fixtures[allParts], 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := Cached(Fixtures{}, &remote.Cache{Dir: filepath.Join(dir, "cache")})
+	if data, err := provider.Fetch(allParts); err != nil || string(data) != string(fixtures[allParts]) {
+		t.Errorf("expected registry file to be served from the cache, got %d bytes: %v", len(data), err)
+	}
+	if _, err := os.Stat(oldRegistryFile); !os.IsNotExist(err) {
+		t.Errorf("expected registry file to be removed, got %v", err)
+	}
+}
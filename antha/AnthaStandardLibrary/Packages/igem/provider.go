@@ -0,0 +1,100 @@
+// antha/AnthaStandardLibrary/Packages/igem/provider.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package igem
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/AnthaPath"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/remote"
+)
+
+// A Provider fetches pages of the iGem parts registry by their path, e.g.,
+// xml/part.BBa_B0034 or fasta/parts/All_Parts
+type Provider interface {
+	Fetch(path string) ([]byte, error)
+}
+
+// DefaultProvider is the provider used to look up parts. By default pages of
+// the registry are cached in the antha directory.
+var DefaultProvider Provider = Cached(Registry{URL: registryURL}, remote.NewCache("igem"))
+
+// oldRegistryFile is where the FASTA file of all parts was kept before pages
+// of the registry were cached
+var oldRegistryFile = filepath.Join(anthapath.Path(), "iGem_registry.txt")
+
+// Registry fetches pages of the parts registry at a URL
+type Registry struct {
+	URL string
+}
+
+// Fetch requests a page of the registry
+func (r Registry) Fetch(path string) ([]byte, error) {
+	return remote.HTTPGet(r.URL + "/" + path)
+}
+
+type cached struct {
+	provider Provider
+	cache    *remote.Cache
+}
+
+// Cached returns a provider which caches the pages fetched by another
+func Cached(p Provider, cache *remote.Cache) Provider {
+	return cached{provider: p, cache: cache}
+}
+
+func (c cached) Fetch(path string) ([]byte, error) {
+	if path == allParts {
+		if err := c.migrate(); err != nil {
+			return nil, err
+		}
+	}
+	return c.cache.Get(path, func() ([]byte, error) {
+		return c.provider.Fetch(path)
+	})
+}
+
+// migrate moves the FASTA file of all parts from oldRegistryFile into the
+// cache, so that it is neither downloaded again nor left behind
+func (c cached) migrate() error {
+	data, err := ioutil.ReadFile(oldRegistryFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := c.cache.Put(allParts, data); err != nil {
+		return err
+	}
+	return os.Remove(oldRegistryFile)
+}
+
+// Fixtures are pages keyed by path, for use in tests
+type Fixtures remote.Fixtures
+
+// Fetch returns the page of a path
+func (f Fixtures) Fetch(path string) ([]byte, error) {
+	return remote.Fixtures(f).Get(path)
+}
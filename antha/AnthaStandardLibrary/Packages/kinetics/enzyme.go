@@ -0,0 +1,117 @@
+package kinetics
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/fit"
+)
+
+// A RateFit is a straight line fitted to the start of a time course. Times
+// are in minutes.
+type RateFit struct {
+	// Rate is the initial rate of change per minute
+	Rate fit.Parameter
+	// Intercept is the value at time zero
+	Intercept fit.Parameter
+	Fit       *fit.Result
+}
+
+// InitialRate fits a straight line to the readings of a time course taken
+// within window of the first reading. If window is zero all of the readings
+// are used.
+func InitialRate(times []time.Duration, values []float64, window time.Duration) (*RateFit, error) {
+	if len(times) != len(values) {
+		return nil, fmt.Errorf("have %d times but %d values", len(times), len(values))
+	}
+	if len(times) == 0 {
+		return nil, fmt.Errorf("no readings to fit")
+	}
+
+	start := times[0]
+	for _, t := range times {
+		if t < start {
+			start = t
+		}
+	}
+
+	var x, y []float64
+	for i, t := range times {
+		if window == 0 || t-start <= window {
+			x = append(x, t.Minutes())
+			y = append(y, values[i])
+		}
+	}
+	if len(x) < 3 {
+		return nil, fmt.Errorf("need at least 3 readings to fit an initial rate but have %d", len(x))
+	}
+
+	res, err := fit.Linear(x, y, fit.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &RateFit{
+		Rate:      res.Params[1],
+		Intercept: res.Params[0],
+		Fit:       res,
+	}, nil
+}
+
+// A MichaelisMentenFit is the Michaelis-Menten model fitted to the initial
+// rates of reaction at a series of substrate concentrations. Vmax is in units
+// of the rates and Km in units of the concentrations.
+type MichaelisMentenFit struct {
+	Vmax fit.Parameter
+	Km   fit.Parameter
+	Fit  *fit.Result
+}
+
+// Predict returns the rate at a substrate concentration
+func (m *MichaelisMentenFit) Predict(substrate float64) float64 {
+	return m.Fit.Predict(substrate)
+}
+
+func michaelisMenten(s float64, p []float64) float64 {
+	return p[0] * s / (p[1] + s)
+}
+
+// FitMichaelisMenten fits the Michaelis-Menten model, v = Vmax [S] / (Km + [S]),
+// to rates of reaction at substrate concentrations
+func FitMichaelisMenten(substrate, rates []float64) (*MichaelisMentenFit, error) {
+	if len(substrate) != len(rates) {
+		return nil, fmt.Errorf("have %d concentrations but %d rates", len(substrate), len(rates))
+	}
+	if len(substrate) < 3 {
+		return nil, fmt.Errorf("need at least 3 rates to fit Michaelis-Menten but have %d", len(substrate))
+	}
+
+	// start from the highest rate and the concentration with rate nearest
+	// half of it
+	vmax := rates[0]
+	for _, v := range rates {
+		vmax = math.Max(vmax, v)
+	}
+	km := substrate[0]
+	best := math.Inf(1)
+	for i, s := range substrate {
+		if d := math.Abs(rates[i] - vmax/2); d < best {
+			km, best = s, d
+		}
+	}
+	if km <= 0 {
+		km = 1
+	}
+
+	res, err := fit.LeastSquares(michaelisMenten, substrate, rates, []float64{vmax, km}, fit.Options{
+		Lower: []float64{0, 0},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot fit Michaelis-Menten: %s", err)
+	}
+	return &MichaelisMentenFit{
+		Vmax: res.Params[0],
+		Km:   res.Params[1],
+		Fit:  res,
+	}, nil
+}
@@ -0,0 +1,145 @@
+// Package kinetics fits growth and enzyme kinetic models to plate reader time
+// courses
+package kinetics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/fit"
+)
+
+// A GrowthModel is a sigmoidal model of growth
+type GrowthModel int
+
+// Growth models in the parameterization of Zwietering et al. (1990), which
+// gives the lag time and maximum growth rate directly
+const (
+	Logistic GrowthModel = iota
+	Gompertz
+)
+
+func (m GrowthModel) String() string {
+	switch m {
+	case Logistic:
+		return "logistic"
+	case Gompertz:
+		return "Gompertz"
+	default:
+		return fmt.Sprintf("GrowthModel(%d)", int(m))
+	}
+}
+
+// model returns the growth model as a function of time in hours. The
+// parameters are the baseline, capacity, maximum rate and lag.
+func (m GrowthModel) model() fit.Model {
+	return func(t float64, p []float64) float64 {
+		y0, k, mu, lag := p[0], p[1], p[2], p[3]
+		a := k - y0
+		if m == Gompertz {
+			return y0 + a*math.Exp(-math.Exp(mu*math.E/a*(lag-t)+1))
+		}
+		return y0 + a/(1+math.Exp(4*mu/a*(lag-t)+2))
+	}
+}
+
+// A GrowthFit is a growth model fitted to a time course. Times are in hours.
+type GrowthFit struct {
+	Model GrowthModel
+	// Baseline is the value before growth
+	Baseline fit.Parameter
+	// Capacity is the value growth tends to, i.e., the carrying capacity
+	Capacity fit.Parameter
+	// MaxRate is the maximum rate of increase per hour
+	MaxRate fit.Parameter
+	// Lag is the lag time in hours
+	Lag fit.Parameter
+	Fit *fit.Result
+}
+
+// LagTime returns the lag time as a duration
+func (g *GrowthFit) LagTime() time.Duration {
+	return hoursToDuration(g.Lag.Value)
+}
+
+// Predict returns the value of the fitted model at a time
+func (g *GrowthFit) Predict(t time.Duration) float64 {
+	return g.Fit.Predict(t.Hours())
+}
+
+func hoursToDuration(h float64) time.Duration {
+	return time.Duration(h * float64(time.Hour))
+}
+
+func hours(times []time.Duration) []float64 {
+	ret := make([]float64, len(times))
+	for i, t := range times {
+		ret[i] = t.Hours()
+	}
+	return ret
+}
+
+// growthGuess estimates initial parameters from the data: the baseline and
+// capacity from its extremes and the rate and lag from the steepest slope
+func growthGuess(t, y []float64) []float64 {
+	idx := make([]int, len(t))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return t[idx[i]] < t[idx[j]] })
+
+	y0, k := y[idx[0]], y[idx[0]]
+	for _, v := range y {
+		y0 = math.Min(y0, v)
+		k = math.Max(k, v)
+	}
+
+	mu, lag := 0.0, 0.0
+	for i := 1; i < len(idx); i++ {
+		dt := t[idx[i]] - t[idx[i-1]]
+		if dt <= 0 {
+			continue
+		}
+		if s := (y[idx[i]] - y[idx[i-1]]) / dt; s > mu {
+			mu = s
+			lag = t[idx[i-1]] - (y[idx[i-1]]-y0)/s
+		}
+	}
+	if mu == 0 {
+		mu = (k - y0) / math.Max(t[idx[len(idx)-1]]-t[idx[0]], 1.0)
+	}
+	return []float64{y0, k, mu, math.Max(lag, 0)}
+}
+
+// FitGrowth fits a growth model to a time course, e.g., of OD. Replicates may
+// be fitted together by including all of their readings.
+func FitGrowth(model GrowthModel, times []time.Duration, values []float64) (*GrowthFit, error) {
+	if model != Logistic && model != Gompertz {
+		return nil, fmt.Errorf("unknown growth model %v", model)
+	}
+	if len(times) != len(values) {
+		return nil, fmt.Errorf("have %d times but %d values", len(times), len(values))
+	}
+	if len(times) < 5 {
+		return nil, fmt.Errorf("need at least 5 readings to fit growth but have %d", len(times))
+	}
+
+	t := hours(times)
+	res, err := fit.LeastSquares(model.model(), t, values, growthGuess(t, values), fit.Options{
+		Lower: []float64{math.Inf(-1), math.Inf(-1), 0, math.Inf(-1)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot fit %v growth: %s", model, err)
+	}
+
+	return &GrowthFit{
+		Model:    model,
+		Baseline: res.Params[0],
+		Capacity: res.Params[1],
+		MaxRate:  res.Params[2],
+		Lag:      res.Params[3],
+		Fit:      res,
+	}, nil
+}
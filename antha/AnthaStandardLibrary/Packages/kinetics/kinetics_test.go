@@ -0,0 +1,173 @@
+package kinetics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader/dataset"
+)
+
+// noise returns a small deterministic perturbation
+func noise(i int) float64 {
+	return 0.005 * math.Sin(float64(7*i))
+}
+
+func growthCourse(model GrowthModel, params []float64, offset float64) ([]time.Duration, []float64) {
+	var times []time.Duration
+	var values []float64
+	f := model.model()
+	for i := 0; i <= 48; i++ {
+		t := time.Duration(i) * 15 * time.Minute
+		times = append(times, t)
+		values = append(values, f(t.Hours(), params)+offset+noise(i))
+	}
+	return times, values
+}
+
+func TestFitGrowth(t *testing.T) {
+	for _, model := range []GrowthModel{Logistic, Gompertz} {
+		params := []float64{0.05, 1.2, 0.4, 2.5}
+		times, values := growthCourse(model, params, 0)
+
+		g, err := FitGrowth(model, times, values)
+		if err != nil {
+			t.Fatalf("%v: %s", model, err)
+		}
+		for _, p := range []struct {
+			name     string
+			expected float64
+			fitted   float64
+			lower    float64
+			upper    float64
+		}{
+			{"capacity", 1.2, g.Capacity.Value, g.Capacity.Lower, g.Capacity.Upper},
+			{"max rate", 0.4, g.MaxRate.Value, g.MaxRate.Lower, g.MaxRate.Upper},
+			{"lag", 2.5, g.Lag.Value, g.Lag.Lower, g.Lag.Upper},
+		} {
+			if math.Abs(p.expected-p.fitted) > 0.05*p.expected {
+				t.Errorf("%v: expecting %s %g found %g", model, p.name, p.expected, p.fitted)
+			}
+			if !(p.lower < p.fitted && p.fitted < p.upper) {
+				t.Errorf("%v: %s %g outside its confidence interval (%g, %g)", model, p.name, p.fitted, p.lower, p.upper)
+			}
+		}
+		if d := g.LagTime() - 150*time.Minute; d > 10*time.Minute || d < -10*time.Minute {
+			t.Errorf("%v: expecting lag time 2h30m found %v", model, g.LagTime())
+		}
+	}
+
+	if _, err := FitGrowth(Logistic, []time.Duration{0, 1}, []float64{0, 1}); err == nil {
+		t.Error("expecting error for too few readings")
+	}
+}
+
+func TestInitialRate(t *testing.T) {
+	var times []time.Duration
+	var values []float64
+	for i := 0; i < 20; i++ {
+		t := time.Duration(i) * time.Minute
+		times = append(times, t)
+		// linear for the first 5 minutes then saturating
+		values = append(values, 10+100*(1-math.Exp(-0.02*t.Minutes()))+noise(i))
+	}
+
+	r, err := InitialRate(times, values, 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(r.Rate.Value-2) > 0.1 {
+		t.Errorf("expecting initial rate 2 per minute found %g", r.Rate.Value)
+	}
+	if e, f := 6, len(r.Fit.Params)+r.Fit.DF; e != f {
+		t.Errorf("expecting %d readings in window found %d", e, f)
+	}
+}
+
+func TestFitMichaelisMenten(t *testing.T) {
+	substrate := []float64{0.5, 1, 2, 5, 10, 20, 50}
+	var rates []float64
+	for i, s := range substrate {
+		rates = append(rates, michaelisMenten(s, []float64{12, 3.5})*(1+noise(i)))
+	}
+
+	m, err := FitMichaelisMenten(substrate, rates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(m.Vmax.Value-12) > 0.2 {
+		t.Errorf("expecting Vmax 12 found %v", m.Vmax)
+	}
+	if math.Abs(m.Km.Value-3.5) > 0.2 {
+		t.Errorf("expecting Km 3.5 found %v", m.Km)
+	}
+	if !(m.Km.Lower < m.Km.Value && m.Km.Value < m.Km.Upper) {
+		t.Errorf("expecting Km within its confidence interval found %v", m.Km)
+	}
+}
+
+func TestFitGrowthCurves(t *testing.T) {
+	data := dataset.NewExportData("test")
+	add := func(well string, times []time.Duration, values []float64) {
+		for i := range times {
+			data.Add(well, dataset.PRMeasurement{
+				EWavelength: 600,
+				RWavelength: 600,
+				Reading:     values[i],
+				Timestamp:   times[i],
+			})
+		}
+	}
+
+	times, fast := growthCourse(Logistic, []float64{0, 1.0, 0.5, 1}, 0.04)
+	add("A1", times, fast)
+	add("A2", times, fast)
+	_, slow := growthCourse(Logistic, []float64{0, 0.8, 0.2, 3}, 0.04)
+	add("B1", times, slow)
+	for i := range times {
+		add("H12", times[i:i+1], []float64{0.04})
+	}
+
+	l := Layout{
+		Blanks: []string{"H12"},
+		Groups: map[string][]string{
+			"fast": {"A1", "A2"},
+			"slow": {"B1"},
+		},
+	}
+
+	fits, err := FitGrowthCurves(data, l, Absorbance(600), Logistic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := fits["fast"].MaxRate.Value; math.Abs(f-0.5) > 0.02 {
+		t.Errorf("expecting fast max rate 0.5 found %g", f)
+	}
+	if f := fits["slow"].Lag.Value; math.Abs(f-3) > 0.2 {
+		t.Errorf("expecting slow lag 3 found %g", f)
+	}
+	if f := fits["fast"].Baseline.Value; math.Abs(f) > 0.01 {
+		t.Errorf("expecting blank corrected baseline 0 found %g", f)
+	}
+
+	if _, err := FitGrowthCurves(data, Layout{Groups: map[string][]string{"none": {"C1"}}}, Absorbance(600), Logistic); err == nil {
+		t.Error("expecting error for well with no readings")
+	}
+}
+
+// emptyData has no readings for any well
+type emptyData struct{}
+
+func (emptyData) TimeCourse(well string, ex, em, script int) ([]time.Duration, []float64, error) {
+	return nil, nil, nil
+}
+
+func TestEmptyBlank(t *testing.T) {
+	l := Layout{
+		Blanks: []string{"H12"},
+		Groups: map[string][]string{"a": {"A1"}},
+	}
+	if _, _, err := l.Replicates(emptyData{}, "a", Absorbance(600)); err == nil {
+		t.Error("expecting error for blank with no readings")
+	}
+}
@@ -0,0 +1,158 @@
+package kinetics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader/dataset"
+)
+
+// A Layout groups the wells of a plate into replicates and blanks
+type Layout struct {
+	// Blanks are averaged and subtracted from every other well
+	Blanks []string
+	// Groups of replicate wells by name, e.g., by sample or condition
+	Groups map[string][]string
+}
+
+// A Channel selects the readings of a time course by wavelength and, if
+// Script is greater than zero, by script
+type Channel struct {
+	Ex     int
+	Em     int
+	Script int
+}
+
+// Absorbance returns the channel for absorbance readings at a wavelength
+func Absorbance(wavelength int) Channel {
+	return Channel{Ex: wavelength, Em: wavelength}
+}
+
+// GroupNames returns the names of the groups of the layout in order
+func (l Layout) GroupNames() []string {
+	var names []string
+	for name := range l.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type course struct {
+	times  []time.Duration
+	values []float64
+}
+
+// at returns the value of a time course at a time by linear interpolation
+func (c course) at(t time.Duration) float64 {
+	n := len(c.times)
+	i := sort.Search(n, func(i int) bool { return c.times[i] >= t })
+	switch {
+	case i == 0:
+		return c.values[0]
+	case i == n:
+		return c.values[n-1]
+	}
+	t0, t1 := c.times[i-1], c.times[i]
+	f := float64(t-t0) / float64(t1-t0)
+	return c.values[i-1] + f*(c.values[i]-c.values[i-1])
+}
+
+// blank returns the average time course of the blank wells, or nil if the
+// layout has no blanks. An error is returned if the blank wells have no
+// readings.
+func (l Layout) blank(data dataset.TimeCourseData, ch Channel) (*course, error) {
+	if len(l.Blanks) == 0 {
+		return nil, nil
+	}
+
+	sums := make(map[time.Duration]float64)
+	counts := make(map[time.Duration]int)
+	for _, well := range l.Blanks {
+		times, values, err := data.TimeCourse(well, ch.Ex, ch.Em, ch.Script)
+		if err != nil {
+			return nil, fmt.Errorf("blank %s: %s", well, err)
+		}
+		for i, t := range times {
+			sums[t] += values[i]
+			counts[t]++
+		}
+	}
+
+	if len(sums) == 0 {
+		return nil, fmt.Errorf("no readings of blanks %v", l.Blanks)
+	}
+
+	c := &course{}
+	for t := range sums {
+		c.times = append(c.times, t)
+	}
+	sort.Slice(c.times, func(i, j int) bool { return c.times[i] < c.times[j] })
+	for _, t := range c.times {
+		c.values = append(c.values, sums[t]/float64(counts[t]))
+	}
+	return c, nil
+}
+
+// Replicates returns the pooled readings of the wells of a group, with the
+// average of the blanks at the same time subtracted. Blanks are interpolated
+// if wells are read at different times.
+func (l Layout) Replicates(data dataset.TimeCourseData, group string, ch Channel) (times []time.Duration, values []float64, err error) {
+	wells, ok := l.Groups[group]
+	if !ok {
+		return nil, nil, fmt.Errorf("no group %s in layout", group)
+	}
+	blank, err := l.blank(data, ch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, well := range wells {
+		ts, vs, err := data.TimeCourse(well, ch.Ex, ch.Em, ch.Script)
+		if err != nil {
+			return nil, nil, fmt.Errorf("group %s: %s", group, err)
+		}
+		for i, t := range ts {
+			v := vs[i]
+			if blank != nil {
+				v -= blank.at(t)
+			}
+			times = append(times, t)
+			values = append(values, v)
+		}
+	}
+	return times, values, nil
+}
+
+// FitGrowthCurves fits a growth model to the blank corrected replicates of
+// each group of a layout
+func FitGrowthCurves(data dataset.TimeCourseData, l Layout, ch Channel, model GrowthModel) (map[string]*GrowthFit, error) {
+	ret := make(map[string]*GrowthFit)
+	for _, group := range l.GroupNames() {
+		times, values, err := l.Replicates(data, group, ch)
+		if err != nil {
+			return nil, err
+		}
+		if ret[group], err = FitGrowth(model, times, values); err != nil {
+			return nil, fmt.Errorf("group %s: %s", group, err)
+		}
+	}
+	return ret, nil
+}
+
+// InitialRates fits initial rates to the blank corrected replicates of each
+// group of a layout using the readings within window of the start
+func InitialRates(data dataset.TimeCourseData, l Layout, ch Channel, window time.Duration) (map[string]*RateFit, error) {
+	ret := make(map[string]*RateFit)
+	for _, group := range l.GroupNames() {
+		times, values, err := l.Replicates(data, group, ch)
+		if err != nil {
+			return nil, err
+		}
+		if ret[group], err = InitialRate(times, values, window); err != nil {
+			return nil, fmt.Errorf("group %s: %s", group, err)
+		}
+	}
+	return ret, nil
+}
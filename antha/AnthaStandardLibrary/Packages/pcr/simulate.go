@@ -0,0 +1,370 @@
+package pcr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// BindingOptions sets how closely a primer must match the template to bind
+type BindingOptions struct {
+	// ThreePrimeMatch is the number of bases at the 3' end of a primer which
+	// must match the template exactly
+	ThreePrimeMatch int
+	// MaxMismatches is the number of mismatches allowed in the rest of the
+	// binding region
+	MaxMismatches int
+	// MinBinding is the smallest number of bases, counted from the 3' end,
+	// which must bind; bases 5' of the binding region are treated as a tail
+	MinBinding int
+	// MaxProductLength is the longest amplicon which will be predicted
+	MaxProductLength int
+}
+
+// DefaultBindingOptions allow two mismatches away from the 10 bases at the 3'
+// end of a primer
+var DefaultBindingOptions = BindingOptions{
+	ThreePrimeMatch:  10,
+	MaxMismatches:    2,
+	MinBinding:       15,
+	MaxProductLength: 20000,
+}
+
+// A BindingSite is where a primer anneals to the template. Reverse sites are
+// where the primer anneals to the top strand and so primes synthesis of the
+// bottom strand.
+type BindingSite struct {
+	Primer     wtype.DNASequence
+	Reverse    bool
+	Start      int // first template position bound, in human friendly format
+	End        int // last template position bound, in human friendly format
+	Mismatches int
+	Tail       string // bases 5' of the binding region
+
+	pair int // which of the reaction's primer pair this is
+}
+
+// Length returns the number of bases of the primer which bind the template
+func (b BindingSite) Length() int {
+	return len(b.Primer.Seq) - len(b.Tail)
+}
+
+func (b BindingSite) String() string {
+	strand := "forward"
+	if b.Reverse {
+		strand = "reverse"
+	}
+	return fmt.Sprintf("%s (%s) at %d..%d with %d mismatches", b.Primer.Nm, strand, b.Start, b.End, b.Mismatches)
+}
+
+// An Amplicon is a predicted product of a PCR reaction
+type Amplicon struct {
+	wtype.DNASequence
+	Forward   BindingSite
+	Reverse   BindingSite
+	OffTarget bool
+}
+
+// SimulationResult is the outcome of a simulated PCR reaction. The intended
+// product, of the first primer binding forwards and the second in reverse
+// with the fewest mismatches, comes first in Amplicons.
+type SimulationResult struct {
+	Amplicons []Amplicon
+	Sites     []BindingSite
+	Warnings  []string
+}
+
+// Product returns the intended product of the reaction
+func (r SimulationResult) Product() (Amplicon, error) {
+	if len(r.Amplicons) == 0 || r.Amplicons[0].OffTarget {
+		return Amplicon{}, fmt.Errorf("no product expected")
+	}
+	return r.Amplicons[0], nil
+}
+
+// template gives circular or linear access to a template sequence
+type template struct {
+	seq     string
+	plasmid bool
+}
+
+// at returns the base at position i, counting from 0, and false if there is
+// no such position on a linear template
+func (t template) at(i int) (byte, bool) {
+	n := len(t.seq)
+	if t.plasmid {
+		return t.seq[((i%n)+n)%n], true
+	}
+	if i < 0 || i >= n {
+		return 0, false
+	}
+	return t.seq[i], true
+}
+
+func (t template) wrap(i int) int {
+	if !t.plasmid {
+		return i
+	}
+	n := len(t.seq)
+	return ((i % n) + n) % n
+}
+
+// extend compares a primer with the template from its 3' end, which pairs
+// with position three, towards its 5' end. It returns the length of the
+// binding region and the number of mismatches within it.
+func (t template) extend(primer string, three int, reverse bool, opt BindingOptions) (length, mismatches int) {
+	step := -1
+	if reverse {
+		primer = sequences.RevComp(primer)
+		step = 1
+	}
+	mm := 0
+	for k := 0; k < len(primer) && k < len(t.seq); k++ {
+		b, ok := t.at(three + step*k)
+		if !ok {
+			break
+		}
+		var p byte
+		if reverse {
+			p = primer[k]
+		} else {
+			p = primer[len(primer)-1-k]
+		}
+		if p != b {
+			if mm == opt.MaxMismatches {
+				break
+			}
+			mm++
+			continue
+		}
+		length, mismatches = k+1, mm
+	}
+	return
+}
+
+// bindingSites returns the sites where a primer binds the template on either
+// strand. Sites are seeded from exact matches of the 3' end of the primer.
+func bindingSites(t template, primer wtype.DNASequence, pair int, opt BindingOptions) []BindingSite {
+	p := strings.ToUpper(primer.Seq)
+	target := wtype.DNASequence{Seq: t.seq, Plasmid: t.plasmid}
+	seed := wtype.MakeLinearDNASequence(primer.Nm, p[len(p)-opt.ThreePrimeMatch:])
+
+	var sites []BindingSite
+	for _, pos := range sequences.FindAll(&target, &seed).Positions {
+		// the 3' end of the primer pairs with the last base of a forward
+		// match and the first base of a reverse one, both of which are
+		// the end position
+		three := t.wrap(pos.EndPosition - 1)
+		reverse := pos.Reverse
+		length, mm := t.extend(p, three, reverse, opt)
+		if length < opt.MinBinding {
+			continue
+		}
+		site := BindingSite{
+			Primer:     primer,
+			Reverse:    reverse,
+			Mismatches: mm,
+			Tail:       primer.Seq[:len(p)-length],
+			pair:       pair,
+		}
+		if reverse {
+			site.Start, site.End = three+1, t.wrap(three+length-1)+1
+		} else {
+			site.Start, site.End = t.wrap(three-length+1)+1, t.wrap(three)+1
+		}
+		sites = append(sites, site)
+	}
+	return sites
+}
+
+// threePrime returns the template position, counting from 0, paired with the
+// 3' end of the primer
+func (b BindingSite) threePrime() int {
+	if b.Reverse {
+		return b.Start - 1
+	}
+	return b.End - 1
+}
+
+// amplify returns the product of a pair of binding sites. The product is made
+// up of the whole forward primer, the template between the primers and the
+// reverse complement of the whole reverse primer, so that primer tails and
+// mismatches are incorporated. Template features within the product are
+// carried over.
+func amplify(name string, t template, features []wtype.Feature, fwd, rev BindingSite) wtype.DNASequence {
+	n := len(t.seq)
+	f3, r3 := fwd.threePrime(), rev.threePrime()
+	between := r3 - f3 - 1
+	if t.plasmid {
+		between = t.wrap(r3-f3) - 1
+	}
+
+	var middle []byte
+	for k := 1; k <= between; k++ {
+		b, _ := t.at(f3 + k)
+		middle = append(middle, b)
+	}
+	seq := fwd.Primer.Seq + string(middle) + sequences.RevComp(rev.Primer.Seq)
+	product := wtype.MakeLinearDNASequence(name, seq)
+
+	// template positions, counting from 0, of the first base of the product
+	// and the number of template bases it spans
+	first := t.wrap(fwd.Start - 1)
+	span := fwd.Length() + between + rev.Length()
+	offset := len(fwd.Tail)
+
+	for _, feat := range features {
+		lo, hi := feat.Coordinates(wtype.IGNOREDIRECTION, wtype.CODEFRIENDLY)
+		rlo, rhi := lo-first, hi-first
+		if t.plasmid {
+			rlo, rhi = t.wrap(lo-first), t.wrap(hi-first)
+		}
+		if rlo < 0 || rhi >= span || rlo > rhi || hi-lo >= n {
+			continue
+		}
+		carried := feat
+		carried.StartPosition, carried.EndPosition = rlo+offset+1, rhi+offset+1
+		if feat.StartPosition > feat.EndPosition {
+			carried.StartPosition, carried.EndPosition = carried.EndPosition, carried.StartPosition
+		}
+		product.Features = append(product.Features, carried)
+	}
+
+	product.Features = append(product.Features,
+		wtype.Feature{
+			Name:          fwd.Primer.Nm,
+			Class:         wtype.MISC_FEATURE,
+			StartPosition: 1,
+			EndPosition:   len(fwd.Primer.Seq),
+			DNASeq:        fwd.Primer.Seq,
+		},
+		wtype.Feature{
+			Name:          rev.Primer.Nm,
+			Class:         wtype.MISC_FEATURE,
+			Reverse:       true,
+			StartPosition: len(seq),
+			EndPosition:   len(seq) - len(rev.Primer.Seq) + 1,
+			DNASeq:        rev.Primer.Seq,
+		},
+	)
+	return product
+}
+
+// Simulate predicts the products of a PCR reaction. Every site where either
+// primer binds the template, on either strand, is found and every pair of a
+// forward and a downstream reverse site close enough together gives a
+// product. Products other than the intended one and extra binding sites of
+// the primers are reported as warnings.
+func Simulate(r Reaction, opt BindingOptions) (SimulationResult, error) {
+	var result SimulationResult
+
+	seq := strings.ToUpper(r.Template.Seq)
+	if len(seq) == 0 {
+		return result, fmt.Errorf("no template sequence for reaction %s", r.ReactionName)
+	}
+	if opt.ThreePrimeMatch < 1 {
+		return result, fmt.Errorf("at least one base at the 3' end of primers must match")
+	}
+	for _, p := range r.PrimerPair {
+		if len(p.Seq) < opt.ThreePrimeMatch || len(p.Seq) < opt.MinBinding {
+			return result, fmt.Errorf("primer %s is shorter than the %d bases which must bind", p.Nm, opt.MinBinding)
+		}
+	}
+
+	t := template{seq: seq, plasmid: r.Template.Plasmid}
+
+	var fwds, revs []BindingSite
+	for i, p := range r.PrimerPair {
+		sites := bindingSites(t, p, i, opt)
+		if len(sites) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("primer %s does not bind template %s", p.Nm, r.Template.Nm))
+		}
+
+		// the first primer is expected to bind forwards and the second in
+		// reverse, each at its best site
+		best := -1
+		for j, s := range sites {
+			if s.Reverse == (i == 1) && (best < 0 || s.Mismatches < sites[best].Mismatches) {
+				best = j
+			}
+		}
+		for j, s := range sites {
+			if s.Reverse {
+				revs = append(revs, s)
+			} else {
+				fwds = append(fwds, s)
+			}
+			if j != best {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("additional binding site: primer %s", s))
+			}
+		}
+		result.Sites = append(result.Sites, sites...)
+	}
+
+	name := r.ReactionName
+	if name == "" {
+		name = r.PrimerPair[0].Nm + "_" + r.PrimerPair[1].Nm + "_product"
+	}
+
+	for _, f := range fwds {
+		for _, rv := range revs {
+			f3, r3 := f.threePrime(), rv.threePrime()
+			d := r3 - f3
+			if t.plasmid {
+				d = t.wrap(d)
+			}
+			if d <= 0 {
+				continue
+			}
+			if len(f.Primer.Seq)+d-1+len(rv.Primer.Seq) > opt.MaxProductLength {
+				continue
+			}
+			result.Amplicons = append(result.Amplicons, Amplicon{
+				DNASequence: amplify(name, t, r.Template.Features, f, rv),
+				Forward:     f,
+				Reverse:     rv,
+				OffTarget:   true,
+			})
+		}
+	}
+
+	intended := -1
+	for i, a := range result.Amplicons {
+		if a.Forward.pair != 0 || a.Reverse.pair != 1 {
+			continue
+		}
+		if intended < 0 || a.Forward.Mismatches+a.Reverse.Mismatches < result.Amplicons[intended].Forward.Mismatches+result.Amplicons[intended].Reverse.Mismatches {
+			intended = i
+		}
+	}
+	if intended >= 0 {
+		result.Amplicons[intended].OffTarget = false
+	} else {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("no product of %s and %s from template %s", r.PrimerPair[0].Nm, r.PrimerPair[1].Nm, r.Template.Nm))
+	}
+
+	sort.SliceStable(result.Amplicons, func(i, j int) bool {
+		a, b := result.Amplicons[i], result.Amplicons[j]
+		if a.OffTarget != b.OffTarget {
+			return !a.OffTarget
+		}
+		return len(a.Seq) < len(b.Seq)
+	})
+
+	for _, a := range result.Amplicons {
+		if a.OffTarget {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("off-target product of %d bp from %s and %s", len(a.Seq), a.Forward, a.Reverse))
+		}
+	}
+
+	return result, nil
+}
+
+// Simulate predicts the products of the reaction with the default binding
+// options
+func (r Reaction) Simulate() (SimulationResult, error) {
+	return Simulate(r, DefaultBindingOptions)
+}
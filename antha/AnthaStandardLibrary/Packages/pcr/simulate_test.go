@@ -0,0 +1,176 @@
+package pcr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/seqtest"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+func TestSimulateLinear(t *testing.T) {
+	template := seqtest.Feature(t, "lacI_ORF")
+	seq := template.Seq
+	template.Features = []wtype.Feature{
+		{Name: "inside", Class: wtype.MISC_FEATURE, StartPosition: 301, EndPosition: 400},
+		{Name: "outside", Class: wtype.MISC_FEATURE, StartPosition: 801, EndPosition: 900},
+	}
+
+	// a tail which does not match the template upstream of the primer
+	mismatched := map[byte]byte{'A': 'C', 'C': 'G', 'G': 'T', 'T': 'A'}
+	var tail string
+	for i := 194; i < 200; i++ {
+		tail += string(mismatched[seq[i]])
+	}
+	fwd := wtype.MakeSingleStrandedDNASequence("fwd", tail+seq[200:220])
+	// a mismatch away from the 3' end
+	rev := []byte(sequences.RevComp(seq[580:600]))
+	if rev[2] == 'A' {
+		rev[2] = 'C'
+	} else {
+		rev[2] = 'A'
+	}
+	reverse := wtype.MakeSingleStrandedDNASequence("rev", string(rev))
+
+	result, err := Simulate(Reaction{Template: template, PrimerPair: [2]wtype.DNASequence{fwd, reverse}}, DefaultBindingOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	product, err := result.Product()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := tail + seq[200:580] + sequences.RevComp(string(rev))
+	if product.Seq != expected {
+		t.Errorf("expected product of %d bp, got %d bp", len(expected), len(product.Seq))
+	}
+	if product.Forward.Tail != tail || product.Reverse.Mismatches != 1 {
+		t.Errorf("unexpected binding sites %s and %s", product.Forward, product.Reverse)
+	}
+	if product.Forward.Start != 201 || product.Reverse.End != 600 {
+		t.Errorf("unexpected binding sites %s and %s", product.Forward, product.Reverse)
+	}
+
+	var found bool
+	for _, f := range product.Features {
+		switch f.Name {
+		case "inside":
+			found = true
+			if f.StartPosition != 301-200+len(tail) || f.EndPosition != 400-200+len(tail) {
+				t.Errorf("feature carried over to %d..%d", f.StartPosition, f.EndPosition)
+			}
+			if product.Seq[f.StartPosition-1:f.EndPosition] != seq[300:400] {
+				t.Errorf("feature sequence not carried over")
+			}
+		case "outside":
+			t.Errorf("feature outside product carried over")
+		}
+	}
+	if !found {
+		t.Errorf("feature inside product not carried over")
+	}
+	if len(result.Amplicons) != 1 || len(result.Warnings) != 0 {
+		t.Errorf("expected one product and no warnings, got %d products and warnings %v", len(result.Amplicons), result.Warnings)
+	}
+}
+
+func TestSimulatePlasmid(t *testing.T) {
+	var seq string
+	for _, name := range []string{"pBR322_replication_origin", "ampicillin_resistance_gene_ORF", "lacI_ORF"} {
+		seq += seqtest.Feature(t, name).Seq
+	}
+	template := wtype.MakePlasmidDNASequence("plasmid", seq)
+	template.Features = []wtype.Feature{
+		{Name: "origin", Class: wtype.MISC_FEATURE, StartPosition: 1, EndPosition: 50},
+	}
+
+	// the product spans the origin
+	n := len(seq)
+	fwd := wtype.MakeSingleStrandedDNASequence("fwd", seq[n-200:n-178])
+	rev := wtype.MakeSingleStrandedDNASequence("rev", sequences.RevComp(seq[180:200]))
+
+	result, err := Simulate(Reaction{Template: template, PrimerPair: [2]wtype.DNASequence{fwd, rev}}, DefaultBindingOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	product, err := result.Product()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := seq[n-200:] + seq[:200]; product.Seq != expected {
+		t.Errorf("expected product of %d bp, got %d bp", len(expected), len(product.Seq))
+	}
+	features := product.GetFeatureByName("origin")
+	if len(features) != 1 || features[0].StartPosition != 201 || features[0].EndPosition != 250 {
+		t.Errorf("origin feature not carried over: %v", features)
+	}
+}
+
+func TestSimulateOffTarget(t *testing.T) {
+	lacI := seqtest.Feature(t, "lacI_ORF").Seq
+	site := lacI[300:322]
+	// a second copy of the forward site in reverse downstream gives a product
+	// of the forward primer alone
+	seq := lacI[:1022] + sequences.RevComp(site) + lacI[1022:]
+	template := wtype.MakeLinearDNASequence("template", seq)
+
+	fwd := wtype.MakeSingleStrandedDNASequence("fwd", site)
+	rev := wtype.MakeSingleStrandedDNASequence("rev", sequences.RevComp(seq[700:722]))
+
+	result, err := Simulate(Reaction{Template: template, PrimerPair: [2]wtype.DNASequence{fwd, rev}}, DefaultBindingOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	product, err := result.Product()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := seq[300:722]; product.Seq != expected {
+		t.Errorf("expected product of %d bp, got %d bp", len(expected), len(product.Seq))
+	}
+
+	var offTarget int
+	for _, a := range result.Amplicons {
+		if a.OffTarget {
+			offTarget++
+			if a.Forward.Primer.Nm != "fwd" || a.Reverse.Primer.Nm != "fwd" {
+				t.Errorf("unexpected off-target product from %s and %s", a.Forward, a.Reverse)
+			}
+		}
+	}
+	if offTarget != 1 {
+		t.Errorf("expected 1 off-target product, got %d", offTarget)
+	}
+
+	var warned bool
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "off-target") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("no warning of off-target product in %v", result.Warnings)
+	}
+}
+
+func TestSimulateNoProduct(t *testing.T) {
+	template := seqtest.Feature(t, "ampicillin_resistance_gene_ORF")
+	seq := template.Seq
+
+	// both primers bind the same strand
+	fwd := wtype.MakeSingleStrandedDNASequence("fwd", seq[100:120])
+	rev := wtype.MakeSingleStrandedDNASequence("rev", seq[300:320])
+
+	result, err := Simulate(Reaction{Template: template, PrimerPair: [2]wtype.DNASequence{fwd, rev}}, DefaultBindingOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := result.Product(); err == nil {
+		t.Errorf("expected no product")
+	}
+	if len(result.Warnings) == 0 {
+		t.Errorf("expected warnings")
+	}
+}
@@ -0,0 +1,182 @@
+package dataset
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/montanaflynn/stats"
+)
+
+// ExportData represents readings parsed from the text export of a plate
+// reader, e.g., Tecan Magellan or Spark, BioTek Gen5 or SoftMax Pro.
+//
+// Absorbance readings have equal excitation and emission wavelengths and
+// luminescence readings have wavelengths of zero. Each block of readings in
+// the export is given a script number, starting at 1, so that repeated reads
+// at the same wavelengths, e.g., with different gains, can be told apart.
+type ExportData struct {
+	// Format of the export, e.g., Tecan
+	Format string
+	// Readings by well in A1 format
+	Readings map[string]PRMeasurementSet
+}
+
+// NewExportData returns an empty dataset for an export format
+func NewExportData(format string) *ExportData {
+	return &ExportData{
+		Format:   format,
+		Readings: make(map[string]PRMeasurementSet),
+	}
+}
+
+// Add adds a reading for a well which may be in A1 or A01 format
+func (data *ExportData) Add(well string, m PRMeasurement) {
+	if a1 := wtype.MakeWellCoords(well).FormatA1(); a1 != "" {
+		well = a1
+	}
+	data.Readings[well] = append(data.Readings[well], m)
+}
+
+// Wells returns the wells with readings in order, i.e., A1, A2, ...
+func (data *ExportData) Wells() []string {
+	var ret []string
+	for w := range data.Readings {
+		ret = append(ret, w)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := wtype.MakeWellCoords(ret[i]), wtype.MakeWellCoords(ret[j])
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return ret[i] < ret[j]
+	})
+	return ret
+}
+
+func (data *ExportData) readings(wellname string) (PRMeasurementSet, error) {
+	rs, ok := data.Readings[wellname]
+	if !ok {
+		if a1 := wtype.MakeWellCoords(wellname).FormatA1(); a1 != "" {
+			rs, ok = data.Readings[a1]
+		}
+	}
+	if !ok || len(rs) == 0 {
+		return nil, fmt.Errorf("no readings found for well %s", wellname)
+	}
+	return rs, nil
+}
+
+func (data *ExportData) average(wellname string, match func(PRMeasurement) bool, what string) (float64, error) {
+	rs, err := data.readings(wellname)
+	if err != nil {
+		return 0.0, err
+	}
+	var values []float64
+	for _, m := range rs {
+		if match(m) {
+			values = append(values, m.Reading)
+		}
+	}
+	if len(values) == 0 {
+		return 0.0, fmt.Errorf("no %s readings found for well %s", what, wellname)
+	}
+	return stats.Mean(values)
+}
+
+// ReadingsAsAverage returns the average of the readings for a well filtered
+// by time, given as a string such as "10m" or a time.Duration, or by emission
+// or excitation wavelength, given as an int.
+func (data *ExportData) ReadingsAsAverage(wellname string, emexortime platereader.FilterOption, fieldvalue interface{}) (average float64, err error) {
+	switch emexortime {
+	case platereader.TIME:
+		var d time.Duration
+		switch t := fieldvalue.(type) {
+		case time.Duration:
+			d = t
+		case string:
+			if d, err = time.ParseDuration(t); err != nil {
+				return 0.0, err
+			}
+		default:
+			return 0.0, fmt.Errorf("fieldvalue must be a time.Duration or string if emexortime is TIME")
+		}
+		return data.average(wellname, func(m PRMeasurement) bool {
+			return m.Timestamp == d
+		}, fmt.Sprintf("%v", d))
+	case platereader.EMWAVELENGTH, platereader.EXWAVELENGTH:
+		wavelength, ok := fieldvalue.(int)
+		if !ok {
+			return 0.0, fmt.Errorf("fieldvalue must be a wavelength if emexortime is set to EMWAVELENGTH or EXWAVELENGTH")
+		}
+		return data.average(wellname, func(m PRMeasurement) bool {
+			if emexortime == platereader.EMWAVELENGTH {
+				return m.RWavelength == wavelength
+			}
+			return m.EWavelength == wavelength
+		}, fmt.Sprintf("%d nm", wavelength))
+	default:
+		return 0.0, fmt.Errorf("unknown FilterOption %d", emexortime)
+	}
+}
+
+// Absorbance returns the average absorbance reading of a well at a
+// wavelength. Currently no additional options are supported.
+func (data *ExportData) Absorbance(wellname string, wavelength int, options ...interface{}) (average wtype.Absorbance, err error) {
+	raw, err := data.average(wellname, func(m PRMeasurement) bool {
+		return m.EWavelength == wavelength && m.RWavelength == wavelength
+	}, fmt.Sprintf("absorbance at %d nm", wavelength))
+
+	return wtype.Absorbance{
+		Reading:    raw,
+		Wavelength: float64(wavelength),
+	}, err
+}
+
+// Fluorescence returns the average fluorescence reading of a well at an
+// excitation and emission wavelength. Currently no additional options are
+// supported.
+func (data *ExportData) Fluorescence(wellname string, excitationWavelength, emissionWavelength int, options ...interface{}) (average float64, err error) {
+	return data.average(wellname, func(m PRMeasurement) bool {
+		return m.EWavelength == excitationWavelength && m.RWavelength == emissionWavelength
+	}, fmt.Sprintf("fluorescence at %d/%d nm", excitationWavelength, emissionWavelength))
+}
+
+// TimeCourse returns the readings of a well at an excitation and emission
+// wavelength in order of time. For absorbance, both wavelengths should be the
+// absorbance wavelength. If scriptnumber is greater than 0 only readings
+// from that block of the export are returned.
+func (data *ExportData) TimeCourse(wellname string, exWavelength int, emWavelength int, scriptnumber int) (xaxis []time.Duration, yaxis []float64, err error) {
+	rs, err := data.readings(wellname)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var matches PRMeasurementSet
+	for _, m := range rs {
+		if m.EWavelength != exWavelength || m.RWavelength != emWavelength {
+			continue
+		}
+		if scriptnumber > 0 && m.Script != scriptnumber {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	if len(matches) == 0 {
+		return nil, nil, fmt.Errorf("no readings found for well %s at excitation %d nm and emission %d nm", wellname, exWavelength, emWavelength)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Timestamp < matches[j].Timestamp
+	})
+	for _, m := range matches {
+		xaxis = append(xaxis, m.Timestamp)
+		yaxis = append(yaxis, m.Reading)
+	}
+	return xaxis, yaxis, nil
+}
@@ -0,0 +1,86 @@
+package parse
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader/dataset"
+)
+
+// Formats of plate reader data which can be detected from file content
+const (
+	Mars       = "Mars"
+	SpectraMax = "SpectraMax"
+)
+
+// DetectFormat returns the format of plate reader data from its content;
+// one of Mars, SpectraMax, Tecan, Gen5 or SoftMax
+func DetectFormat(contents []byte) (string, error) {
+	if bytes.HasPrefix(contents, []byte("PK\x03\x04")) {
+		return Mars, nil
+	}
+
+	text, err := exportText(contents)
+	if err != nil {
+		return "", err
+	}
+	head := text
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(head), "<?xml"):
+		return SpectraMax, nil
+	case strings.Contains(head, "##BLOCKS="):
+		return SoftMax, nil
+	case strings.Contains(head, "Gen5") || strings.Contains(head, "Reader Type:") || strings.Contains(head, "BioTek"):
+		return Gen5, nil
+	case strings.Contains(head, "Tecan") || strings.Contains(head, "i-control") || strings.Contains(head, "Magellan") || strings.Contains(head, "SparkControl") || strings.Contains(text, "\n<>"):
+		return Tecan, nil
+	}
+	return "", fmt.Errorf("cannot detect plate reader data format")
+}
+
+// ParsePlateReaderData parses plate reader data of any format which
+// DetectFormat recognises. Data from Mars can also be used as TimeCourseData
+// and data from text exports as FluorescenceData and TimeCourseData too.
+func ParsePlateReaderData(contents []byte) (dataset.AbsorbanceData, error) {
+	format, err := DetectFormat(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case Mars:
+		data, err := ParseMarsXLSXBinary(contents, 0)
+		if err != nil {
+			return nil, err
+		}
+		return data, nil
+	case SpectraMax:
+		if !bytes.HasPrefix(contents, utf16LEBOM) {
+			return nil, fmt.Errorf("SpectraMax XML must be UTF-16 encoded")
+		}
+		data, err := ParseSpectraMaxData(contents[len(utf16LEBOM):])
+		if err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	var data *dataset.ExportData
+	switch format {
+	case Tecan:
+		data, err = ParseTecanText(contents)
+	case Gen5:
+		data, err = ParseGen5Text(contents)
+	default:
+		data, err = ParseSoftMaxText(contents)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
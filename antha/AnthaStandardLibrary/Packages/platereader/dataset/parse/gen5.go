@@ -0,0 +1,150 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader/dataset"
+)
+
+// Gen5 is the format of text exports from BioTek Gen5
+const Gen5 = "Gen5"
+
+// gen5Label returns the wavelengths of a read from its label, e.g., "600",
+// "Read 1:485,528" or "Lum"
+func gen5Label(label string) (ex, em int, ok bool) {
+	if ex, em, ok = parseWavelengths(label); ok {
+		return
+	}
+	if strings.Contains(strings.ToLower(label), "lum") {
+		return 0, 0, true
+	}
+	return 0, 0, false
+}
+
+// ParseGen5Text parses the text export of BioTek Gen5. Each read is labelled
+// with its wavelengths and is exported either as a plate grid, for endpoint
+// reads, or with a row per time point, for kinetic reads.
+func ParseGen5Text(contents []byte) (*dataset.ExportData, error) {
+	text, err := exportText(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	data := dataset.NewExportData(Gen5)
+	var label string
+	script := 0
+	lines := textLines(text)
+
+	for i := 0; i < len(lines); i++ {
+		fields := splitFields(lines[i])
+		if blank(fields) {
+			continue
+		}
+
+		switch {
+		case fields[0] == "" && len(fields) > 1 && fields[1] == "1":
+			ex, em, ok := gen5Label(label)
+			if !ok {
+				return nil, fmt.Errorf("line %d: cannot find wavelengths of read %q", i+1, label)
+			}
+			script++
+			i = parseGen5Grid(data, lines, i, ex, em, script)
+		case strings.ToLower(fields[0]) == "time" && len(fields) > 1:
+			ex, em, ok := gen5Label(label)
+			if !ok {
+				return nil, fmt.Errorf("line %d: cannot find wavelengths of read %q", i+1, label)
+			}
+			script++
+			i = parseGen5Kinetic(data, lines, i, ex, em, script)
+		case !strings.Contains(strings.TrimSpace(lines[i]), "\t"):
+			// labels such as 485,528 contain commas so are taken whole
+			label = strings.TrimSpace(lines[i])
+		}
+	}
+
+	if len(data.Readings) == 0 {
+		return nil, fmt.Errorf("no readings found in Gen5 export")
+	}
+	return data, nil
+}
+
+// parseGen5Grid parses an endpoint read from its header line and returns the
+// index of the last line of the read. Rows may end with the read label.
+func parseGen5Grid(data *dataset.ExportData, lines []string, start int, ex, em, script int) int {
+	header := splitFields(lines[start])
+
+	i := start + 1
+	for ; i < len(lines); i++ {
+		fields := splitFields(lines[i])
+		if len(fields) == 0 || !isRowLetter(fields[0]) {
+			break
+		}
+		for j := 1; j < len(fields) && j < len(header); j++ {
+			if _, err := strconv.Atoi(header[j]); err != nil {
+				continue
+			}
+			v, ok := parseReading(fields[j])
+			if !ok {
+				continue
+			}
+			data.Add(fields[0]+header[j], dataset.PRMeasurement{
+				EWavelength: ex,
+				RWavelength: em,
+				Reading:     v,
+				Script:      script,
+			})
+		}
+	}
+	return i - 1
+}
+
+// parseGen5Kinetic parses a kinetic read from its header line, i.e., "Time",
+// the temperature and then the wells, and returns the index of the last line
+// of the read
+func parseGen5Kinetic(data *dataset.ExportData, lines []string, start int, ex, em, script int) int {
+	header := splitFields(lines[start])
+	tempCol := -1
+	for j, h := range header[1:] {
+		if strings.HasPrefix(h, "T") && !isWellName(h) {
+			tempCol = j + 1
+			break
+		}
+	}
+
+	i := start + 1
+	for ; i < len(lines); i++ {
+		fields := splitFields(lines[i])
+		if blank(fields) {
+			break
+		}
+		t, err := parseClock(fields[0])
+		if err != nil {
+			// Gen5 ends kinetic reads with summary rows
+			break
+		}
+		var temp float64
+		if tempCol >= 0 && tempCol < len(fields) {
+			temp, _ = parseReading(fields[tempCol])
+		}
+		for j := 1; j < len(fields) && j < len(header); j++ {
+			if !isWellName(header[j]) {
+				continue
+			}
+			v, ok := parseReading(fields[j])
+			if !ok {
+				continue
+			}
+			data.Add(header[j], dataset.PRMeasurement{
+				EWavelength: ex,
+				RWavelength: em,
+				Reading:     v,
+				Timestamp:   t,
+				Temp:        temp,
+				Script:      script,
+			})
+		}
+	}
+	return i - 1
+}
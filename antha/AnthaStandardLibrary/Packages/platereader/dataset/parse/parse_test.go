@@ -0,0 +1,215 @@
+package parse
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader/dataset"
+)
+
+func tsv(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\r\n"))
+}
+
+var tecanEndpoint = tsv(
+	"Application: Tecan i-control",
+	"Device: infinite 200Pro",
+	"",
+	"Mode\t\t\t\tAbsorbance",
+	"Measurement Wavelength\t\t\t\t600\tnm",
+	"",
+	"<>\t1\t2\t3",
+	"A\t0.100\t0.200\tOVER",
+	"B\t0,300\t0,400\t0,500",
+	"",
+	"Mode\t\t\t\tFluorescence Top Reading",
+	"Excitation Wavelength\t\t\t\t485\tnm",
+	"Emission Wavelength\t\t\t\t528\tnm",
+	"Gain\t\t\t\t60\tManual",
+	"<>\t1\t2\t3",
+	"A\t100\t200\t300",
+)
+
+var tecanKinetic = tsv(
+	"Application: SparkControl Magellan",
+	"Mode\tAbsorbance",
+	"Measurement wavelength\t600",
+	"Cycle Nr.\t1\t2\t3",
+	"Time [s]\t0\t60\t120",
+	"Temp. [°C]\t30\t30.1\t30.2",
+	"A1\t0.1\t0.2\t0.3",
+	"A2\t0.2\t0.4\t0.6",
+	"",
+	"Mode\tLuminescence",
+	"Cycle Nr.\tTime [s]\tTemp. [°C]\tA1\tA2",
+	"1\t0\t30\t1000\t2000",
+	"2\t30\t30\t1100\t2100",
+)
+
+var gen5Export = tsv(
+	"Software Version\t3.08.01",
+	"Reader Type:\tSynergy H1",
+	"",
+	"Results",
+	"600",
+	"",
+	"\t1\t2\t3",
+	"A\t0.101\t0.102\t0.103\t600",
+	"B\t0.201\t0.202\t0.203\t600",
+	"",
+	"Read 2:485,528",
+	"",
+	"Time\tT° Read 2:485,528\tA1\tA2",
+	"0:00:00\t37.0\t1000\t2000",
+	"0:10:00\t37.1\t1500\t2500",
+	"0:20:00\t37.0\t2000\t3000",
+	"",
+	"Max V [485,528]\t50\t50",
+)
+
+var softMaxExport = tsv(
+	"##BLOCKS= 2",
+	"Plate:\tPlate1\t1.3\tPlateFormat\tEndpoint\tAbsorbance\tRaw\tFALSE\t1\t\t\t\t\t\t2\t450 600\t1\t3\t96\t1\t8\tNone",
+	"\tTemperature(°C)\t1\t2\t3\t\t1\t2\t3\t",
+	"\t25.0\t0.11\t0.12\t0.13\t\t0.61\t0.62\t0.63\t",
+	"\t\t0.21\t0.22\t0.23\t\t0.71\t0.72\t0.73\t",
+	"",
+	"~End",
+	"Plate:\tPlate2\t1.3\tTimeFormat\tKinetic\tFluorescence\tRaw\tFALSE\t3\t\t\t\t\t\t1\t528\t1\t2\t96\t485\t\tNone",
+	"Time\tTemperature(°C)\tA1\tA2",
+	"00:00:00\t25.0\t10\t20",
+	"00:05:00\t25.1\t15\t25",
+	"00:10:00\t25.2\t20\t30",
+	"~End",
+	"Original Filename: test",
+)
+
+func TestDetectFormat(t *testing.T) {
+	for _, tc := range []struct {
+		contents []byte
+		format   string
+	}{
+		{tecanEndpoint, Tecan},
+		{tecanKinetic, Tecan},
+		{gen5Export, Gen5},
+		{softMaxExport, SoftMax},
+		{[]byte("PK\x03\x04..."), Mars},
+		{[]byte("<?xml version=\"1.0\"?>"), SpectraMax},
+	} {
+		format, err := DetectFormat(tc.contents)
+		if err != nil {
+			t.Errorf("%s: %s", tc.format, err)
+		} else if format != tc.format {
+			t.Errorf("expecting format %s found %s", tc.format, format)
+		}
+	}
+
+	if _, err := DetectFormat([]byte("hello")); err == nil {
+		t.Error("expecting error for unknown format")
+	}
+}
+
+func expectReading(t *testing.T, what string, e, f float64, err error) {
+	if err != nil {
+		t.Errorf("%s: %s", what, err)
+	} else if d := e - f; d > 1.0e-9 || d < -1.0e-9 {
+		t.Errorf("%s: expecting %g found %g", what, e, f)
+	}
+}
+
+func expectTimeCourse(t *testing.T, what string, data dataset.TimeCourseData, well string, ex, em int, times []time.Duration, values []float64) {
+	xs, ys, err := data.TimeCourse(well, ex, em, 0)
+	if err != nil {
+		t.Errorf("%s: %s", what, err)
+		return
+	}
+	if len(xs) != len(times) || len(ys) != len(values) {
+		t.Errorf("%s: expecting %d readings found %d", what, len(times), len(xs))
+		return
+	}
+	for i := range xs {
+		if xs[i] != times[i] || ys[i] != values[i] {
+			t.Errorf("%s: expecting %v at %v found %v at %v", what, values[i], times[i], ys[i], xs[i])
+		}
+	}
+}
+
+func TestParseTecanText(t *testing.T) {
+	data, err := ParseTecanText(tecanEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	abs, err := data.Absorbance("B02", 600)
+	expectReading(t, "absorbance", 0.4, abs.Reading, err)
+	if _, err := data.Absorbance("A3", 600); err == nil {
+		t.Error("expecting no absorbance reading for overflowed well")
+	}
+	fl, err := data.Fluorescence("A3", 485, 528)
+	expectReading(t, "fluorescence", 300, fl, err)
+	if e, f := 60, data.Readings["A3"][0].Gain; e != f {
+		t.Errorf("expecting gain %d found %d", e, f)
+	}
+
+	data, err = ParseTecanText(tecanKinetic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectTimeCourse(t, "absorbance", data, "A2", 600, 600, []time.Duration{0, time.Minute, 2 * time.Minute}, []float64{0.2, 0.4, 0.6})
+	expectTimeCourse(t, "luminescence", data, "A1", 0, 0, []time.Duration{0, 30 * time.Second}, []float64{1000, 1100})
+	if e, f := 30.2, data.Readings["A1"][2].Temp; e != f {
+		t.Errorf("expecting temperature %g found %g", e, f)
+	}
+}
+
+func TestParseGen5Text(t *testing.T) {
+	data, err := ParseGen5Text(gen5Export)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := []string{"A1", "A2", "A3", "B1", "B2", "B3"}, data.Wells(); strings.Join(e, ",") != strings.Join(f, ",") {
+		t.Errorf("expecting wells %v found %v", e, f)
+	}
+
+	abs, err := data.Absorbance("B3", 600)
+	expectReading(t, "absorbance", 0.203, abs.Reading, err)
+	expectTimeCourse(t, "fluorescence", data, "A2", 485, 528, []time.Duration{0, 10 * time.Minute, 20 * time.Minute}, []float64{2000, 2500, 3000})
+
+	avg, err := data.ReadingsAsAverage("A1", platereader.TIME, "10m")
+	expectReading(t, "average", 1500, avg, err)
+}
+
+func TestParseSoftMaxText(t *testing.T) {
+	data, err := ParseSoftMaxText(softMaxExport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	abs, err := data.Absorbance("B2", 450)
+	expectReading(t, "absorbance at 450", 0.22, abs.Reading, err)
+	abs, err = data.Absorbance("B2", 600)
+	expectReading(t, "absorbance at 600", 0.72, abs.Reading, err)
+	expectTimeCourse(t, "fluorescence", data, "A2", 485, 528, []time.Duration{0, 5 * time.Minute, 10 * time.Minute}, []float64{20, 25, 30})
+
+	avg, err := data.ReadingsAsAverage("A1", platereader.EXWAVELENGTH, 485)
+	expectReading(t, "average", 15, avg, err)
+}
+
+func TestParsePlateReaderData(t *testing.T) {
+	data, err := ParsePlateReaderData(gen5Export)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := data.(dataset.FluorescenceTimeCourseData); !ok {
+		t.Errorf("expecting fluorescence time course data found %T", data)
+	}
+	if _, ok := data.(dataset.PlateReaderData); !ok {
+		t.Errorf("expecting plate reader data found %T", data)
+	}
+
+	if _, err := ParsePlateReaderData(tsv("##BLOCKS= 0")); err == nil {
+		t.Error("expecting error for export with no readings")
+	}
+}
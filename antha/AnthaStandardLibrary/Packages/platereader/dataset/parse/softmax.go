@@ -0,0 +1,296 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader/dataset"
+)
+
+// SoftMax is the format of text exports from Molecular Devices SoftMax Pro
+const SoftMax = "SoftMax"
+
+// softMaxPlate is the header of a plate block of a SoftMax Pro export
+type softMaxPlate struct {
+	format     string // PlateFormat or TimeFormat
+	readType   string // Endpoint, Kinetic or Spectrum
+	mode       string // Absorbance, Fluorescence or Luminescence
+	emission   []int
+	excitation []int
+	firstCol   int
+}
+
+func parseInts(s string) ([]int, bool) {
+	var ret []int
+	for _, f := range strings.Fields(s) {
+		i, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, false
+		}
+		ret = append(ret, i)
+	}
+	return ret, len(ret) != 0
+}
+
+// parseSoftMaxPlate parses the "Plate:" line of a block. The position of the
+// wavelengths varies between export versions, so they are found as the
+// number of wavelengths followed by a list of that many; the first column and
+// excitation wavelengths are found relative to them.
+func parseSoftMaxPlate(fields []string) (softMaxPlate, error) {
+	if len(fields) < 6 {
+		return softMaxPlate{}, fmt.Errorf("too few fields in plate header")
+	}
+	p := softMaxPlate{
+		format:   fields[3],
+		readType: fields[4],
+		mode:     fields[5],
+		firstCol: 1,
+	}
+
+	for k := 6; k+1 < len(fields); k++ {
+		n, err := strconv.Atoi(fields[k])
+		if err != nil || n < 1 {
+			continue
+		}
+		ws, ok := parseInts(fields[k+1])
+		if !ok || len(ws) != n || ws[0] < 200 {
+			continue
+		}
+		p.emission = ws
+		if k+2 < len(fields) {
+			if c, err := strconv.Atoi(fields[k+2]); err == nil && c > 0 {
+				p.firstCol = c
+			}
+		}
+		if k+5 < len(fields) {
+			p.excitation, _ = parseInts(fields[k+5])
+		}
+		break
+	}
+
+	if p.mode != "Luminescence" && len(p.emission) == 0 && p.readType != "Spectrum" {
+		return p, fmt.Errorf("cannot find wavelengths in plate header")
+	}
+	return p, nil
+}
+
+// wavelengths returns the wavelengths of the ith wavelength read. For spectra
+// the reading wavelength is given as scanned.
+func (p softMaxPlate) wavelengths(i int, scanned int) (ex, em int) {
+	em = scanned
+	if em == 0 && i < len(p.emission) {
+		em = p.emission[i]
+	}
+	switch p.mode {
+	case "Luminescence":
+		if p.readType == "Spectrum" {
+			return 0, em
+		}
+		return 0, 0
+	case "Fluorescence":
+		if i < len(p.excitation) {
+			return p.excitation[i], em
+		}
+		if len(p.excitation) != 0 {
+			return p.excitation[0], em
+		}
+		return 0, em
+	default:
+		return em, em
+	}
+}
+
+// ParseSoftMaxText parses the text export of Molecular Devices SoftMax Pro.
+// Plate blocks exported in either plate or time format are supported for
+// endpoint, kinetic and spectrum reads; other blocks are ignored.
+func ParseSoftMaxText(contents []byte) (*dataset.ExportData, error) {
+	text, err := exportText(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	data := dataset.NewExportData(SoftMax)
+	script := 0
+	lines := textLines(text)
+
+	for i := 0; i < len(lines); i++ {
+		fields := splitFields(lines[i])
+		if len(fields) == 0 || fields[0] != "Plate:" {
+			continue
+		}
+
+		plate, err := parseSoftMaxPlate(fields)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", i+1, err)
+		}
+
+		end := i + 1
+		for end < len(lines) && !strings.HasPrefix(lines[end], "~End") {
+			end++
+		}
+
+		script++
+		switch plate.format {
+		case "PlateFormat":
+			err = parseSoftMaxPlateFormat(data, lines[i+1:end], plate, script)
+		case "TimeFormat":
+			err = parseSoftMaxTimeFormat(data, lines[i+1:end], plate, script)
+		default:
+			err = fmt.Errorf("unknown export format %q", plate.format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", i+1, err)
+		}
+		i = end
+	}
+
+	if len(data.Readings) == 0 {
+		return nil, fmt.Errorf("no readings found in SoftMax export")
+	}
+	return data, nil
+}
+
+// parseSoftMaxReadKey parses the first field of a read, which is the time of
+// kinetic reads and the wavelength of spectra
+func parseSoftMaxReadKey(plate softMaxPlate, key string) (t time.Duration, scanned int, err error) {
+	switch plate.readType {
+	case "Kinetic":
+		t, err = parseClock(key)
+	case "Spectrum":
+		f, ok := parseReading(key)
+		if !ok {
+			err = fmt.Errorf("cannot parse wavelength %q", key)
+		}
+		scanned = int(f)
+	}
+	return
+}
+
+// parseSoftMaxPlateFormat parses plate format data, which has a header line
+// with the column numbers and then a plate grid per read. Reads at several
+// wavelengths are side by side separated by an empty column.
+func parseSoftMaxPlateFormat(data *dataset.ExportData, lines []string, plate softMaxPlate, script int) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	header := splitFields(lines[0])
+	ncols := 0
+	for j := 2; j < len(header); j++ {
+		h := header[j]
+		if _, err := strconv.Atoi(h); err != nil {
+			break
+		}
+		ncols++
+	}
+	if ncols == 0 {
+		return fmt.Errorf("no columns in plate format header")
+	}
+
+	var t time.Duration
+	var scanned int
+	var temp float64
+	row := 0
+	for _, line := range lines[1:] {
+		fields := splitFields(line)
+		if blank(fields) {
+			row = 0
+			continue
+		}
+		if fields[0] != "" {
+			var err error
+			if t, scanned, err = parseSoftMaxReadKey(plate, fields[0]); err != nil {
+				return err
+			}
+			row = 0
+		}
+		if len(fields) > 1 && fields[1] != "" {
+			temp, _ = parseReading(fields[1])
+		}
+
+		rowName := string(rune('A' + row))
+		for w := 0; ; w++ {
+			offset := 2 + w*(ncols+1)
+			if offset >= len(fields) {
+				break
+			}
+			ex, em := plate.wavelengths(w, scanned)
+			for c := 0; c < ncols && offset+c < len(fields); c++ {
+				v, ok := parseReading(fields[offset+c])
+				if !ok {
+					continue
+				}
+				data.Add(rowName+strconv.Itoa(plate.firstCol+c), dataset.PRMeasurement{
+					EWavelength: ex,
+					RWavelength: em,
+					Reading:     v,
+					Timestamp:   t,
+					Temp:        temp,
+					Script:      script,
+				})
+			}
+		}
+		row++
+	}
+	return nil
+}
+
+// parseSoftMaxTimeFormat parses time format data, which has a header line
+// with the wells and then a line per read. Reads at several wavelengths repeat
+// the wells.
+func parseSoftMaxTimeFormat(data *dataset.ExportData, lines []string, plate softMaxPlate, script int) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	header := splitFields(lines[0])
+	group := make([]int, len(header))
+	seen := make(map[string]int)
+	for j, h := range header {
+		if isWellName(h) {
+			group[j] = seen[h]
+			seen[h]++
+		}
+	}
+
+	for _, line := range lines[1:] {
+		fields := splitFields(line)
+		if blank(fields) {
+			continue
+		}
+		var t time.Duration
+		var scanned int
+		if fields[0] != "" {
+			var err error
+			if t, scanned, err = parseSoftMaxReadKey(plate, fields[0]); err != nil {
+				return err
+			}
+		}
+		var temp float64
+		if len(fields) > 1 {
+			temp, _ = parseReading(fields[1])
+		}
+
+		for j := 2; j < len(fields) && j < len(header); j++ {
+			if !isWellName(header[j]) {
+				continue
+			}
+			v, ok := parseReading(fields[j])
+			if !ok {
+				continue
+			}
+			ex, em := plate.wavelengths(group[j], scanned)
+			data.Add(header[j], dataset.PRMeasurement{
+				EWavelength: ex,
+				RWavelength: em,
+				Reading:     v,
+				Timestamp:   t,
+				Temp:        temp,
+				Script:      script,
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader/dataset"
+)
+
+// Tecan is the format of text exports from Tecan readers
+const Tecan = "Tecan"
+
+// tecanSettings are the measurement settings in force for a block of readings
+type tecanSettings struct {
+	mode string
+	ex   int
+	em   int
+	gain int
+}
+
+func (s tecanSettings) wavelengths() (ex, em int) {
+	switch s.mode {
+	case "luminescence":
+		return 0, 0
+	case "absorbance":
+		return s.ex, s.ex
+	default:
+		return s.ex, s.em
+	}
+}
+
+func (s *tecanSettings) set(key string, fields []string) {
+	var value string
+	for _, f := range fields[1:] {
+		if f != "" {
+			value = f
+			break
+		}
+	}
+
+	wavelength := func() int {
+		w, _, _ := parseWavelengths(value)
+		return w
+	}
+
+	switch key {
+	case "mode":
+		lv := strings.ToLower(value)
+		for _, mode := range []string{"absorbance", "fluorescence", "luminescence"} {
+			if strings.Contains(lv, mode) {
+				s.mode = mode
+			}
+		}
+	case "measurement wavelength", "wavelength":
+		s.mode = "absorbance"
+		s.ex = wavelength()
+	case "excitation wavelength":
+		s.ex = wavelength()
+	case "emission wavelength":
+		s.em = wavelength()
+	case "gain":
+		if g, err := strconv.Atoi(value); err == nil {
+			s.gain = g
+		}
+	}
+}
+
+// ParseTecanText parses the text or CSV export of a Tecan reader running
+// i-control, SparkControl or Magellan. Both endpoint reads, exported as plate
+// grids, and kinetic reads, exported with a row or column per well, are
+// supported.
+func ParseTecanText(contents []byte) (*dataset.ExportData, error) {
+	text, err := exportText(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	data := dataset.NewExportData(Tecan)
+	var settings tecanSettings
+	script := 0
+	lines := textLines(text)
+
+	for i := 0; i < len(lines); i++ {
+		fields := splitFields(lines[i])
+		if blank(fields) {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSuffix(fields[0], ":"))
+
+		switch {
+		case key == "<>":
+			script++
+			i = parseTecanGrid(data, lines, i, settings, script)
+		case strings.HasPrefix(key, "cycle nr"):
+			script++
+			if i, err = parseTecanKinetic(data, lines, i, settings, script); err != nil {
+				return nil, err
+			}
+		case len(fields) > 1:
+			settings.set(key, fields)
+		}
+	}
+
+	if len(data.Readings) == 0 {
+		return nil, fmt.Errorf("no readings found in Tecan export")
+	}
+	return data, nil
+}
+
+// parseTecanGrid parses an endpoint read starting from its header line and
+// returns the index of the last line of the read
+func parseTecanGrid(data *dataset.ExportData, lines []string, start int, settings tecanSettings, script int) int {
+	header := splitFields(lines[start])
+	ex, em := settings.wavelengths()
+
+	i := start + 1
+	for ; i < len(lines); i++ {
+		fields := splitFields(lines[i])
+		if len(fields) == 0 || !isRowLetter(fields[0]) {
+			break
+		}
+		for j := 1; j < len(fields) && j < len(header); j++ {
+			v, ok := parseReading(fields[j])
+			if !ok {
+				continue
+			}
+			data.Add(fields[0]+header[j], dataset.PRMeasurement{
+				EWavelength: ex,
+				RWavelength: em,
+				Reading:     v,
+				Gain:        settings.gain,
+				Script:      script,
+			})
+		}
+	}
+	return i - 1
+}
+
+func parseSeconds(s string) (time.Duration, bool) {
+	secs, ok := parseReading(s)
+	return time.Duration(secs * float64(time.Second)), ok
+}
+
+// parseTecanKinetic parses a kinetic read starting from its "Cycle Nr." line
+// and returns the index of the last line of the read. Reads may be exported
+// with a row per well and a column per cycle or the other way round.
+func parseTecanKinetic(data *dataset.ExportData, lines []string, start int, settings tecanSettings, script int) (int, error) {
+	header := splitFields(lines[start])
+	ex, em := settings.wavelengths()
+
+	measurement := func(v float64, t time.Duration, temp float64) dataset.PRMeasurement {
+		return dataset.PRMeasurement{
+			EWavelength: ex,
+			RWavelength: em,
+			Reading:     v,
+			Timestamp:   t,
+			Temp:        temp,
+			Gain:        settings.gain,
+			Script:      script,
+		}
+	}
+
+	// a column per well
+	if len(header) > 1 && !isNumber(header[1]) {
+		timeCol, tempCol := -1, -1
+		for j, h := range header {
+			lh := strings.ToLower(h)
+			switch {
+			case strings.HasPrefix(lh, "time"):
+				timeCol = j
+			case strings.HasPrefix(lh, "temp"):
+				tempCol = j
+			}
+		}
+		if timeCol < 0 {
+			return start, fmt.Errorf("line %d: kinetic read has no time column", start+1)
+		}
+
+		i := start + 1
+		for ; i < len(lines); i++ {
+			fields := splitFields(lines[i])
+			if len(fields) <= timeCol || !isNumber(fields[0]) {
+				break
+			}
+			t, ok := parseSeconds(fields[timeCol])
+			if !ok {
+				return i, fmt.Errorf("line %d: cannot parse time %q", i+1, fields[timeCol])
+			}
+			var temp float64
+			if tempCol >= 0 && tempCol < len(fields) {
+				temp, _ = parseReading(fields[tempCol])
+			}
+			for j := 0; j < len(fields) && j < len(header); j++ {
+				if !isWellName(header[j]) {
+					continue
+				}
+				if v, ok := parseReading(fields[j]); ok {
+					data.Add(header[j], measurement(v, t, temp))
+				}
+			}
+		}
+		return i - 1, nil
+	}
+
+	// a row per well
+	var times []time.Duration
+	var temps []float64
+	i := start + 1
+	for ; i < len(lines); i++ {
+		fields := splitFields(lines[i])
+		if blank(fields) {
+			break
+		}
+		key := strings.ToLower(fields[0])
+		switch {
+		case strings.HasPrefix(key, "time"):
+			for _, f := range fields[1:] {
+				t, ok := parseSeconds(f)
+				if !ok {
+					break
+				}
+				times = append(times, t)
+			}
+		case strings.HasPrefix(key, "temp"):
+			for _, f := range fields[1:] {
+				temp, ok := parseReading(f)
+				if !ok {
+					break
+				}
+				temps = append(temps, temp)
+			}
+		case isWellName(fields[0]):
+			for j, f := range fields[1:] {
+				if j >= len(times) {
+					break
+				}
+				v, ok := parseReading(f)
+				if !ok {
+					continue
+				}
+				var temp float64
+				if j < len(temps) {
+					temp = temps[j]
+				}
+				data.Add(fields[0], measurement(v, times[j], temp))
+			}
+		default:
+			return i - 1, nil
+		}
+	}
+	return i - 1, nil
+}
+
+func isNumber(s string) bool {
+	_, ok := parseReading(s)
+	return ok
+}
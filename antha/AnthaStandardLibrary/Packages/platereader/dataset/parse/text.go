@@ -0,0 +1,143 @@
+package parse
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var utf16LEBOM = []byte{0xff, 0xfe}
+
+// exportText returns the contents of a text export as UTF-8, decoding UTF-16
+// if the contents start with a byte order mark
+func exportText(contents []byte) (string, error) {
+	if bytes.HasPrefix(contents, utf16LEBOM) {
+		s, err := decodeUTF16(contents[len(utf16LEBOM):])
+		if err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+	return string(bytes.TrimPrefix(contents, []byte("\xef\xbb\xbf"))), nil
+}
+
+// textLines splits a text export into lines without line endings
+func textLines(s string) []string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, "\r")
+	}
+	return lines
+}
+
+// splitFields splits a line of a text export on tabs or, failing that, on
+// semicolons or commas. Fields are trimmed of white space.
+func splitFields(line string) []string {
+	sep := ","
+	if strings.Contains(line, "\t") {
+		sep = "\t"
+	} else if strings.Contains(line, ";") {
+		sep = ";"
+	}
+	fields := strings.Split(line, sep)
+	for i, f := range fields {
+		fields[i] = strings.TrimSpace(f)
+	}
+	return fields
+}
+
+// blank returns true if a line has no non-empty fields
+func blank(fields []string) bool {
+	for _, f := range fields {
+		if f != "" {
+			return false
+		}
+	}
+	return true
+}
+
+var rowLetterRe = regexp.MustCompile(`^[A-P]$`)
+
+// isRowLetter returns true for the row names of plates of up to 384 wells
+func isRowLetter(s string) bool {
+	return rowLetterRe.MatchString(s)
+}
+
+var wellNameRe = regexp.MustCompile(`^[A-P]0?[0-9]{1,2}$`)
+
+// isWellName returns true for well names such as A1 or A01
+func isWellName(s string) bool {
+	return wellNameRe.MatchString(s)
+}
+
+// parseReading parses a reading which may use a decimal comma. Readings
+// which are out of range, e.g., OVER, are not parsed.
+func parseReading(s string) (float64, bool) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	if strings.Count(s, ",") == 1 && !strings.Contains(s, ".") {
+		if f, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64); err == nil {
+			return f, true
+		}
+	}
+	return 0.0, false
+}
+
+var wavelengthRe = regexp.MustCompile(`(?:^|[^0-9.])([0-9]{3,4})(?:\.0+)?(?:\s*nm)?(?:/[0-9]+)?`)
+
+// parseWavelengths finds the wavelengths in a read label such as "600",
+// "485,528", "GFP:485/20,528/20" or "Ex 485 nm Em 528 nm". A single
+// wavelength is an absorbance read, returned as equal excitation and emission
+// wavelengths.
+func parseWavelengths(label string) (ex, em int, ok bool) {
+	var ws []int
+	for _, m := range wavelengthRe.FindAllStringSubmatch(label, -1) {
+		w, err := strconv.Atoi(m[1])
+		if err != nil || w < 200 || w > 1000 {
+			continue
+		}
+		ws = append(ws, w)
+	}
+	switch len(ws) {
+	case 0:
+		return 0, 0, false
+	case 1:
+		return ws[0], ws[0], true
+	default:
+		return ws[0], ws[1], true
+	}
+}
+
+// parseClock parses times such as 1:02:03, 02:03, 0:00:01.5 or a number of
+// seconds
+func parseClock(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty time")
+	}
+	if !strings.Contains(s, ":") {
+		secs, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "s")), 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse time %q", s)
+		}
+		return time.Duration(secs * float64(time.Second)), nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("cannot parse time %q", s)
+	}
+	var secs float64
+	for _, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse time %q", s)
+		}
+		secs = secs*60 + v
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
@@ -0,0 +1,382 @@
+// antha/AnthaStandardLibrary/Packages/platereader/standardcurve.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package platereader
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/fit"
+	anthaplot "github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/plot"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+)
+
+// CurveModel is a model of the response of an assay to concentration
+type CurveModel int
+
+const (
+	// LinearCurve is response = a + b conc
+	LinearCurve CurveModel = iota
+	// FourPL is the four parameter logistic,
+	// response = d + (a - d) / (1 + (conc / c)^b)
+	FourPL
+	// FivePL is the five parameter logistic, which adds asymmetry g,
+	// response = d + (a - d) / (1 + (conc / c)^b)^g
+	FivePL
+)
+
+func (m CurveModel) String() string {
+	switch m {
+	case LinearCurve:
+		return "linear"
+	case FourPL:
+		return "4PL"
+	case FivePL:
+		return "5PL"
+	default:
+		return fmt.Sprintf("CurveModel(%d)", int(m))
+	}
+}
+
+// Weighting of standards when fitting a standard curve
+type Weighting int
+
+const (
+	// Unweighted gives each standard equal weight
+	Unweighted Weighting = iota
+	// InverseResponse weights each standard by 1 / response
+	InverseResponse
+	// InverseResponseSquared weights each standard by 1 / response^2, which
+	// suits assays whose error is proportional to the response
+	InverseResponseSquared
+)
+
+func (w Weighting) weight(response float64) float64 {
+	r := math.Max(math.Abs(response), 1.0e-9)
+	switch w {
+	case InverseResponse:
+		return 1.0 / r
+	case InverseResponseSquared:
+		return 1.0 / (r * r)
+	default:
+		return 1.0
+	}
+}
+
+// A Standard is a reading of a well of known concentration
+type Standard struct {
+	Well          string
+	Concentration wunit.Concentration
+	Response      float64
+}
+
+// NewStandards returns the standards for wells of known concentrations using
+// response to read each well, e.g., the Absorbance method of plate reader
+// data. Standards are returned in order of concentration.
+func NewStandards(concentrations map[string]wunit.Concentration, response func(well string) (float64, error)) ([]Standard, error) {
+	var ret []Standard
+	for well, conc := range concentrations {
+		r, err := response(well)
+		if err != nil {
+			return nil, fmt.Errorf("standard %s: %s", well, err)
+		}
+		ret = append(ret, Standard{Well: well, Concentration: conc, Response: r})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i].Concentration.SIValue(), ret[j].Concentration.SIValue()
+		if a != b {
+			return a < b
+		}
+		return ret[i].Well < ret[j].Well
+	})
+	return ret, nil
+}
+
+// A StandardCurve is a model fitted to standards. Concentrations are in the
+// unit of the first standard.
+type StandardCurve struct {
+	Model     CurveModel
+	Weighting Weighting
+	Standards []Standard
+	Fit       *fit.Result
+
+	unit   wunit.PrefixedUnit
+	conc   []float64
+	lowest float64
+	top    float64
+}
+
+func fourPL(x float64, p []float64) float64 {
+	return p[3] + (p[0]-p[3])/(1+math.Pow(x/p[2], p[1]))
+}
+
+func fivePL(x float64, p []float64) float64 {
+	return p[3] + (p[0]-p[3])/math.Pow(1+math.Pow(x/p[2], p[1]), p[4])
+}
+
+func (m CurveModel) model() fit.Model {
+	switch m {
+	case FourPL:
+		return fourPL
+	case FivePL:
+		return fivePL
+	default:
+		return fit.LinearModel
+	}
+}
+
+// inverse returns the concentration giving a response
+func (m CurveModel) inverse(y float64, p []float64) float64 {
+	switch m {
+	case FourPL:
+		return p[2] * math.Pow((p[0]-p[3])/(y-p[3])-1, 1/p[1])
+	case FivePL:
+		return p[2] * math.Pow(math.Pow((p[0]-p[3])/(y-p[3]), 1/p[4])-1, 1/p[1])
+	default:
+		return (y - p[0]) / p[1]
+	}
+}
+
+// FitStandardCurve fits a model to standards. At least one more standard than
+// the number of parameters of the model is needed to estimate uncertainty.
+func FitStandardCurve(standards []Standard, model CurveModel, weighting Weighting) (*StandardCurve, error) {
+	if len(standards) == 0 {
+		return nil, fmt.Errorf("no standards")
+	}
+
+	c := &StandardCurve{
+		Model:     model,
+		Weighting: weighting,
+		Standards: standards,
+		unit:      standards[0].Concentration.Unit(),
+	}
+
+	var resp, weights []float64
+	for _, s := range standards {
+		if s.Concentration.Unit().BaseSISymbol() != c.unit.BaseSISymbol() {
+			return nil, fmt.Errorf("standard %s has concentration %s which cannot be converted to %s", s.Well, s.Concentration, c.unit.PrefixedSymbol())
+		}
+		c.conc = append(c.conc, s.Concentration.ConvertTo(c.unit))
+		resp = append(resp, s.Response)
+		weights = append(weights, weighting.weight(s.Response))
+	}
+	c.lowest, c.top = c.conc[0], c.conc[0]
+	for _, x := range c.conc {
+		c.lowest = math.Min(c.lowest, x)
+		c.top = math.Max(c.top, x)
+	}
+
+	opts := fit.Options{Weights: weights}
+	var err error
+	switch model {
+	case LinearCurve:
+		c.Fit, err = fit.Linear(c.conc, resp, opts)
+	case FourPL, FivePL:
+		c.Fit, err = c.fitLogistic(resp, opts)
+	default:
+		err = fmt.Errorf("unknown curve model %v", model)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot fit %v standard curve: %s", model, err)
+	}
+	return c, nil
+}
+
+// fitLogistic fits a 4PL curve starting from the responses at the lowest and
+// highest concentrations and the median concentration, then a 5PL curve
+// starting from the 4PL fit if needed
+func (c *StandardCurve) fitLogistic(resp []float64, opts fit.Options) (*fit.Result, error) {
+	var lowResp, highResp float64
+	var positive []float64
+	for i, x := range c.conc {
+		if x == c.lowest {
+			lowResp = resp[i]
+		}
+		if x == c.top {
+			highResp = resp[i]
+		}
+		if x > 0 {
+			positive = append(positive, x)
+		}
+	}
+	if len(positive) == 0 {
+		return nil, fmt.Errorf("no standards with positive concentration")
+	}
+	sort.Float64s(positive)
+
+	tiny := 1.0e-9 * c.top
+	initial := []float64{lowResp, 1, positive[len(positive)/2], highResp}
+	opts.Lower = []float64{math.Inf(-1), 0.05, tiny, math.Inf(-1)}
+	opts.Upper = []float64{math.Inf(1), 20, math.Inf(1), math.Inf(1)}
+
+	res, err := fit.LeastSquares(fourPL, c.conc, resp, initial, opts)
+	if err != nil || c.Model == FourPL {
+		return res, err
+	}
+
+	opts.Lower = append(opts.Lower, 0.05)
+	opts.Upper = append(opts.Upper, 20)
+	return fit.LeastSquares(fivePL, c.conc, resp, append(res.Values(), 1), opts)
+}
+
+// Unit returns the unit of concentration of the curve
+func (c *StandardCurve) Unit() string {
+	return c.unit.PrefixedSymbol()
+}
+
+// Response returns the response predicted by the curve at a concentration
+func (c *StandardCurve) Response(conc wunit.Concentration) float64 {
+	return c.Fit.Predict(conc.ConvertTo(c.unit))
+}
+
+// Range describes where a sample lies relative to the standards
+type Range int
+
+const (
+	InRange Range = iota
+	// BelowRange samples have concentrations below the lowest standard
+	BelowRange
+	// AboveRange samples have concentrations above the highest standard
+	AboveRange
+)
+
+func (r Range) String() string {
+	switch r {
+	case BelowRange:
+		return "below range"
+	case AboveRange:
+		return "above range"
+	default:
+		return "in range"
+	}
+}
+
+// A Quantification is a concentration interpolated from a standard curve with
+// its standard error and confidence interval
+type Quantification struct {
+	Concentration wunit.Concentration
+	StdErr        wunit.Concentration
+	Lower         wunit.Concentration
+	Upper         wunit.Concentration
+	// Range is set if the concentration is extrapolated
+	Range Range
+}
+
+// Concentration returns the concentration of a sample from the mean of the
+// responses of its replicates. The uncertainty combines that of the curve
+// with the scatter of the standards about it. Samples outside the standards
+// are flagged by Range; an error is returned if the response is beyond the
+// asymptotes of the curve.
+func (c *StandardCurve) Concentration(responses ...float64) (Quantification, error) {
+	if len(responses) == 0 {
+		return Quantification{}, fmt.Errorf("no responses to quantify")
+	}
+	var y float64
+	for _, r := range responses {
+		y += r
+	}
+	y /= float64(len(responses))
+
+	params := c.Fit.Values()
+	x := c.Model.inverse(y, params)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return Quantification{}, fmt.Errorf("response %g is outside the range of the %v curve", y, c.Model)
+	}
+
+	// delta method: derivatives of the concentration with respect to the
+	// parameters and the response
+	grad := make([]float64, len(params))
+	q := make([]float64, len(params))
+	for i := range params {
+		h := 1.0e-6 * math.Max(math.Abs(params[i]), 1.0e-3)
+		copy(q, params)
+		q[i] += h
+		up := c.Model.inverse(y, q)
+		q[i] -= 2 * h
+		grad[i] = (up - c.Model.inverse(y, q)) / (2 * h)
+	}
+	hy := 1.0e-6 * math.Max(math.Abs(y), 1.0e-3)
+	dxdy := (c.Model.inverse(y+hy, params) - c.Model.inverse(y-hy, params)) / (2 * hy)
+
+	var variance float64
+	for i := range grad {
+		for j := range grad {
+			variance += grad[i] * c.Fit.Covariance[i][j] * grad[j]
+		}
+	}
+	if c.Fit.DF > 0 {
+		// residual variance at this response given the weighting
+		s2 := c.Fit.RSS / float64(c.Fit.DF) / c.Weighting.weight(y)
+		variance += dxdy * dxdy * s2 / float64(len(responses))
+	}
+	se := math.Sqrt(variance)
+	t := fit.StudentTQuantile(0.975, c.Fit.DF)
+	if c.Fit.DF <= 0 {
+		t = math.NaN()
+	}
+
+	unit := c.unit.PrefixedSymbol()
+	ret := Quantification{
+		Concentration: wunit.NewConcentration(x, unit),
+		StdErr:        wunit.NewConcentration(se, unit),
+		Lower:         wunit.NewConcentration(x-t*se, unit),
+		Upper:         wunit.NewConcentration(x+t*se, unit),
+	}
+	switch {
+	case x < c.lowest:
+		ret.Range = BelowRange
+	case x > c.top:
+		ret.Range = AboveRange
+	}
+	return ret, nil
+}
+
+// Plot returns a plot of the standards and the fitted curve
+func (c *StandardCurve) Plot() (*plot.Plot, error) {
+	var resp []float64
+	for _, s := range c.Standards {
+		resp = append(resp, s.Response)
+	}
+	plt, err := anthaplot.Plot(c.conc, [][]float64{resp})
+	if err != nil {
+		return nil, err
+	}
+	plt.Add(plotter.NewFunction(c.Fit.Predict))
+	plt.Title.Text = fmt.Sprintf("%v standard curve (R^2 = %.4f)", c.Model, c.Fit.RSquared)
+	anthaplot.AddAxisTitles(plt, fmt.Sprintf("Concentration (%s)", c.Unit()), "Response")
+	return plt, nil
+}
+
+// PlotFile exports a plot of the standard curve to a file, by default a png.
+// Height and length are given as strings such as "10cm".
+func (c *StandardCurve) PlotFile(height, length, filename string) (wtype.File, error) {
+	plt, err := c.Plot()
+	if err != nil {
+		return wtype.File{}, err
+	}
+	return anthaplot.Export(plt, height, length, filename)
+}
@@ -0,0 +1,127 @@
+package platereader
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func makeStandards(concs []float64, unit string, response func(float64) float64) []Standard {
+	var ret []Standard
+	for i, x := range concs {
+		// a little deterministic scatter
+		r := response(x) * (1 + 0.005*math.Sin(float64(3*i)))
+		ret = append(ret, Standard{
+			Well:          fmt.Sprintf("A%d", i+1),
+			Concentration: wunit.NewConcentration(x, unit),
+			Response:      r,
+		})
+	}
+	return ret
+}
+
+func TestLinearStandardCurve(t *testing.T) {
+	standards := makeStandards([]float64{0, 0.25, 0.5, 1, 1.5, 2}, "mg/ml", func(x float64) float64 {
+		return 0.05 + 0.6*x
+	})
+	c, err := FitStandardCurve(standards, LinearCurve, Unweighted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := c.Concentration(0.65, 0.66, 0.64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 1.0, q.Concentration.RawValue(); math.Abs(e-f) > 0.02 {
+		t.Errorf("expecting concentration %g found %g", e, f)
+	}
+	if e, f := c.Unit(), q.Concentration.Unit().PrefixedSymbol(); e != f {
+		t.Errorf("expecting unit %s found %s", e, f)
+	}
+	if !(q.Lower.RawValue() < q.Concentration.RawValue() && q.Concentration.RawValue() < q.Upper.RawValue()) {
+		t.Errorf("expecting concentration within its confidence interval found %v (%v, %v)", q.Concentration, q.Lower, q.Upper)
+	}
+	if q.Range != InRange {
+		t.Errorf("expecting sample in range found %v", q.Range)
+	}
+
+	if q, err := c.Concentration(2.0); err != nil {
+		t.Error(err)
+	} else if q.Range != AboveRange {
+		t.Errorf("expecting sample above range found %v", q.Range)
+	}
+
+	if _, err := FitStandardCurve(append(standards, Standard{Concentration: wunit.NewConcentration(1, "mM")}), LinearCurve, Unweighted); err == nil {
+		t.Error("expecting error for standards with incompatible units")
+	}
+}
+
+func TestLogisticStandardCurve(t *testing.T) {
+	concs := []float64{0, 1, 3, 10, 30, 100, 300, 1000}
+	for _, model := range []CurveModel{FourPL, FivePL} {
+		params := []float64{0.1, 1.2, 40, 2.5}
+		truth := func(x float64) float64 { return fourPL(x, params) }
+		if model == FivePL {
+			params = append(params, 0.6)
+			truth = func(x float64) float64 { return fivePL(x, params) }
+		}
+
+		c, err := FitStandardCurve(makeStandards(concs, "ng/ml", truth), model, InverseResponseSquared)
+		if err != nil {
+			t.Fatalf("%v: %s", model, err)
+		}
+		if c.Fit.RSquared < 0.99 {
+			t.Errorf("%v: expecting good fit found R^2 %g", model, c.Fit.RSquared)
+		}
+
+		q, err := c.Concentration(truth(50))
+		if err != nil {
+			t.Fatalf("%v: %s", model, err)
+		}
+		if f := q.Concentration.RawValue(); math.Abs(f-50) > 5 {
+			t.Errorf("%v: expecting concentration 50 found %g", model, f)
+		}
+		if q.StdErr.RawValue() <= 0 {
+			t.Errorf("%v: expecting positive standard error found %v", model, q.StdErr)
+		}
+
+		if _, err := c.Concentration(10); err == nil {
+			t.Errorf("%v: expecting error for response beyond upper asymptote", model)
+		}
+	}
+}
+
+func TestNewStandards(t *testing.T) {
+	concs := map[string]wunit.Concentration{
+		"B1": wunit.NewConcentration(2, "mg/ml"),
+		"A1": wunit.NewConcentration(1, "mg/ml"),
+	}
+	readings := map[string]float64{"A1": 0.5, "B1": 1.0}
+	standards, err := NewStandards(concs, func(well string) (float64, error) {
+		r, ok := readings[well]
+		if !ok {
+			return 0, fmt.Errorf("no reading")
+		}
+		return r, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := "A1", standards[0].Well; e != f {
+		t.Errorf("expecting standards in order of concentration found %s first", f)
+	}
+
+	concs["C1"] = wunit.NewConcentration(3, "mg/ml")
+	if _, err := NewStandards(concs, func(well string) (float64, error) {
+		r, ok := readings[well]
+		if !ok {
+			return 0, fmt.Errorf("no reading")
+		}
+		return r, nil
+	}); err == nil {
+		t.Error("expecting error for standard with no reading")
+	}
+}
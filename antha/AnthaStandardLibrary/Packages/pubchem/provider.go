@@ -0,0 +1,72 @@
+// antha/AnthaStandardLibrary/Packages/pubchem/provider.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package pubchem
+
+import (
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/remote"
+)
+
+// A Provider looks up the PUG REST service of PubChem. Paths are those of
+// the service, i.e., <input specification>/<operation
+// specification>/<output specification>.
+type Provider interface {
+	Lookup(path string) ([]byte, error)
+}
+
+// DefaultProvider is the provider used to look up molecules. By default
+// responses from PubChem are cached in the antha directory.
+var DefaultProvider Provider = Cached(PUG{URL: pugprepend}, remote.NewCache("pubchem"))
+
+// PUG looks up the PUG REST service at a URL
+type PUG struct {
+	URL string
+}
+
+// Lookup requests a path of the service
+func (p PUG) Lookup(path string) ([]byte, error) {
+	return remote.HTTPGet(p.URL + "/" + path)
+}
+
+type cached struct {
+	provider Provider
+	cache    *remote.Cache
+}
+
+// Cached returns a provider which caches the responses of another
+func Cached(p Provider, cache *remote.Cache) Provider {
+	return cached{provider: p, cache: cache}
+}
+
+func (c cached) Lookup(path string) ([]byte, error) {
+	return c.cache.Get(path, func() ([]byte, error) {
+		return c.provider.Lookup(path)
+	})
+}
+
+// Fixtures are responses keyed by path, for use in tests
+type Fixtures remote.Fixtures
+
+// Lookup returns the response to a path
+func (f Fixtures) Lookup(path string) ([]byte, error) {
+	return remote.Fixtures(f).Get(path)
+}
@@ -27,8 +27,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"strings"
 
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
@@ -190,28 +188,15 @@ var pugprepend string = "http://pubchem.ncbi.nlm.nih.gov/rest/pug"
 func pugLookup(inputspec string, operationspec string, outputspec string, operation_options string) ([]byte, error) {
 
 	array := make([]string, 0)
-	array = append(array, pugprepend, inputspec, operationspec, outputspec)
+	array = append(array, inputspec, operationspec, outputspec)
 	if operation_options != "" {
 		array = append(array, operation_options)
 	}
-	Urlstring := strings.Join(array, "/")
 	/*<input specification>/<operation specification>/[<output specification>][?<operation_options>]
 
 	http://pubchem.ncbi.nlm.nih.gov/rest/pug/compound/name/glucose/property/MolecularFormula,MolecularWeight/JSON
 	*/
-	res, err := http.Get(Urlstring)
-	if err != nil {
-		return nil, err
-	}
-	if res != nil {
-		defer res.Body.Close()
-	}
-
-	output, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	return output, nil
+	return DefaultProvider.Lookup(strings.Join(array, "/"))
 }
 
 func Compoundproperties(name string) (string, error) {
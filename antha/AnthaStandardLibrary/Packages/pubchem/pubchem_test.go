@@ -0,0 +1,59 @@
+// antha/AnthaStandardLibrary/Packages/pubchem/pubchem_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package pubchem
+
+import (
+	"testing"
+)
+
+func TestMakeMolecule(t *testing.T) {
+	defer func(p Provider) {
+		DefaultProvider = p
+	}(DefaultProvider)
+
+	DefaultProvider = Fixtures{
+		"compound/name/glucose/property/MolecularFormula,MolecularWeight/JSON": []byte(`{
+  "PropertyTable": {
+    "Properties": [
+      {
+        "CID": 5793,
+        "MolecularFormula": "C6H12O6",
+        "MolecularWeight": 180.16
+      }
+    ]
+  }
+}`),
+	}
+
+	molecule, err := MakeMolecule("glucose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if molecule.CID != 5793 || molecule.MolecularFormula != "C6H12O6" || molecule.MolecularWeight != 180.16 {
+		t.Errorf("unexpected molecule %s", molecule.ToString())
+	}
+
+	if _, err := MakeMolecule("unobtainium"); err == nil {
+		t.Errorf("expected error looking up molecule without fixture")
+	}
+}
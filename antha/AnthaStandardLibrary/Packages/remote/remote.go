@@ -0,0 +1,195 @@
+// antha/AnthaStandardLibrary/Packages/remote/remote.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package remote caches the responses of remote data services, such as
+// entrez, igem and pubchem, on disk so that lookups are reproducible and can
+// be made offline. Each service has its own provider interface which can be
+// wrapped in a Cache or replaced by fixtures in tests.
+package remote
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/AnthaPath"
+)
+
+// Offline disables requests to all remote services so that only cached
+// responses are served. It is set if the ANTHA_OFFLINE environment variable
+// is not empty.
+var Offline = os.Getenv("ANTHA_OFFLINE") != ""
+
+// NotCachedError is returned for queries which cannot be answered offline
+type NotCachedError struct {
+	Key string
+}
+
+func (err NotCachedError) Error() string {
+	return fmt.Sprintf("no cached response to %s and remote lookups are disabled", err.Key)
+}
+
+// A Cache stores the responses of a remote service on disk, keyed by query.
+// A cache directory with Offline set can be used as a fixture of tests.
+type Cache struct {
+	// Dir is the directory of the cached responses
+	Dir string
+	// Offline serves only cached responses
+	Offline bool
+	// Retries is the number of times requests which fail with a Temporary
+	// error are repeated, waiting Delay longer each time
+	Retries int
+	Delay   time.Duration
+}
+
+// NewCache returns a cache of the responses of a service in the antha
+// directory
+func NewCache(service string) *Cache {
+	return &Cache{
+		Dir:     filepath.Join(anthapath.Path(), "cache", service),
+		Retries: 3,
+		Delay:   time.Second,
+	}
+}
+
+// Get returns the cached response to a query or, if there is none, fetches
+// and caches it. Responses are not fetched offline.
+func (c *Cache) Get(key string, fetch func() ([]byte, error)) ([]byte, error) {
+	data, err := ioutil.ReadFile(c.file(key))
+	if err == nil {
+		return data, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if c.Offline || Offline {
+		return nil, NotCachedError{Key: key}
+	}
+
+	for i := 0; i <= c.Retries; i++ {
+		time.Sleep(time.Duration(i) * c.Delay)
+		if data, err = fetch(); err == nil || !Temporary(err) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Put(key, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Put caches the response to a query
+func (c *Cache) Put(key string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, 0777); err != nil {
+		return err
+	}
+
+	// responses are written whole so that interrupted requests are not
+	// cached
+	tmp, err := ioutil.TempFile(c.Dir, "tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.file(key))
+}
+
+// file returns the name of the file of the response to a query
+func (c *Cache) file(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:]))
+}
+
+// Fixtures are responses to queries, keyed as in a cache, for use in tests
+type Fixtures map[string][]byte
+
+// Get returns the response to a query
+func (f Fixtures) Get(key string) ([]byte, error) {
+	data, ok := f[key]
+	if !ok {
+		return nil, NotCachedError{Key: key}
+	}
+	return data, nil
+}
+
+// StatusError is returned by HTTPGet for responses other than 200 OK
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (err StatusError) Error() string {
+	return fmt.Sprintf("GET %s: %s", err.URL, err.Status)
+}
+
+// Temporary returns whether a request which failed with an error might
+// succeed if repeated, i.e., whether the error is a temporary failure or
+// timeout of the network or an error of the server rather than of the
+// request, such as a malformed url or a page which is not found.
+func Temporary(err error) bool {
+	switch err := err.(type) {
+	case StatusError:
+		return err.StatusCode >= http.StatusInternalServerError
+	case net.Error:
+		return err.Temporary() || err.Timeout()
+	default:
+		return false
+	}
+}
+
+// HTTPGet returns the body of the response to a GET request of a url. Error
+// responses are returned as a StatusError so that they are not cached.
+func HTTPGet(url string) ([]byte, error) {
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, StatusError{URL: url, StatusCode: res.StatusCode, Status: res.Status}
+	}
+	return data, nil
+}
@@ -0,0 +1,153 @@
+// antha/AnthaStandardLibrary/Packages/remote/remote_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package remote
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := &Cache{Dir: dir, Retries: 2}
+	calls := 0
+	fetch := func() ([]byte, error) {
+		calls++
+		if calls < 3 {
+			return nil, StatusError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}
+		}
+		return []byte("response"), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		data, err := cache.Get("query", fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "response" {
+			t.Errorf("expected response, got %q", data)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("expected 2 retries and then cached response, got %d calls", calls)
+	}
+
+	offline := &Cache{Dir: dir, Offline: true}
+	if data, err := offline.Get("query", fetch); err != nil || string(data) != "response" {
+		t.Errorf("expected cached response offline, got %q: %v", data, err)
+	}
+	if _, err := offline.Get("other query", fetch); err == nil {
+		t.Errorf("expected error for uncached query offline")
+	} else if _, ok := err.(NotCachedError); !ok {
+		t.Errorf("expected NotCachedError, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected no requests offline, got %d calls", calls)
+	}
+
+	failing := &Cache{Dir: dir, Retries: 2}
+	failures := 0
+	if _, err := failing.Get("failing query", func() ([]byte, error) {
+		failures++
+		return nil, fmt.Errorf("invalid query")
+	}); err == nil {
+		t.Errorf("expected error of failed request")
+	}
+	if failures != 1 {
+		t.Errorf("expected request which is not temporarily failing not to be repeated, got %d calls", failures)
+	}
+	if _, err := offline.Get("failing query", fetch); err == nil {
+		t.Errorf("expected failed request not to be cached")
+	}
+}
+
+func TestHTTPGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/found":
+			fmt.Fprint(w, "response")
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	if data, err := HTTPGet(server.URL + "/found"); err != nil || string(data) != "response" {
+		t.Errorf("expected response, got %q: %v", data, err)
+	}
+	if _, err := HTTPGet(server.URL + "/missing"); err == nil || Temporary(err) {
+		t.Errorf("expected page which is not found not to be temporary, got %v", err)
+	}
+	if _, err := HTTPGet(server.URL + "/unavailable"); err == nil || !Temporary(err) {
+		t.Errorf("expected unavailable server to be temporary, got %v", err)
+	}
+
+	server.Close()
+	if _, err := HTTPGet(server.URL + "/found"); err == nil {
+		t.Errorf("expected error of closed server")
+	}
+
+	for _, url := range []string{"http://%zz", "gopher://example.com"} {
+		if _, err := HTTPGet(url); err == nil || Temporary(err) {
+			t.Errorf("expected malformed url %q not to be temporary, got %v", url, err)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return false }
+
+func TestTemporary(t *testing.T) {
+	if !Temporary(timeoutError{}) {
+		t.Errorf("expected timeout to be temporary")
+	}
+	if !Temporary(StatusError{StatusCode: http.StatusBadGateway}) {
+		t.Errorf("expected server error to be temporary")
+	}
+	if Temporary(StatusError{StatusCode: http.StatusBadRequest}) {
+		t.Errorf("expected bad request not to be temporary")
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	fixtures := Fixtures{"query": []byte("response")}
+	if data, err := fixtures.Get("query"); err != nil || string(data) != "response" {
+		t.Errorf("expected response, got %q: %v", data, err)
+	}
+	if _, err := fixtures.Get("other query"); err == nil {
+		t.Errorf("expected error for missing fixture")
+	}
+}
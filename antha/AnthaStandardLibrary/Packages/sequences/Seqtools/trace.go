@@ -0,0 +1,310 @@
+// antha/AnthaStandardLibrary/Packages/sequences/Seqtools/trace.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package seqtools
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// Trace is a Sanger sequencing read: the base calls, the Phred quality of
+// each call and the chromatogram from which they were called.
+type Trace struct {
+	Name string
+	// Sequence is the base calls of the read.
+	Sequence string
+	// Qualities are the Phred quality values of each base call.
+	Qualities []int
+	// Peaks are the positions in the channels of the peak of each base call.
+	Peaks []int
+	// Channels are the intensities of the A, C, G and T channels of the chromatogram.
+	Channels map[string][]int
+}
+
+// DNASequence returns the base calls of the trace as a linear DNA sequence.
+func (t Trace) DNASequence() wtype.DNASequence {
+	return wtype.MakeLinearDNASequence(t.Name, t.Sequence)
+}
+
+// Trim returns the highest quality region of the trace using Mott's
+// algorithm, which keeps the region with the largest sum of the error limit
+// less the error probability of each base call.
+// Base calls with an error probability greater than the limit, e.g. 0.05 for
+// Phred quality 13, reduce the sum.
+// Traces without quality values are returned unchanged.
+func (t Trace) Trim(errorLimit float64) Trace {
+	if len(t.Qualities) != len(t.Sequence) || len(t.Sequence) == 0 {
+		return t
+	}
+
+	var score, best float64
+	var start, bestStart, bestEnd int
+	for i, q := range t.Qualities {
+		score += errorLimit - math.Pow(10, -float64(q)/10)
+		if score <= 0 {
+			score = 0
+			start = i + 1
+			continue
+		}
+		if score > best {
+			best, bestStart, bestEnd = score, start, i+1
+		}
+	}
+
+	trimmed := t
+	trimmed.Sequence = t.Sequence[bestStart:bestEnd]
+	trimmed.Qualities = t.Qualities[bestStart:bestEnd]
+	if len(t.Peaks) == len(t.Sequence) {
+		trimmed.Peaks = t.Peaks[bestStart:bestEnd]
+	}
+	return trimmed
+}
+
+// ParseTrace parses a trace file in AB1 or SCF format. The format is found
+// from the file extension or, failing that, the contents of the file.
+// Traces with no sample name are named after the file.
+func ParseTrace(file wtype.File) (trace Trace, err error) {
+	data, err := file.ReadAll()
+	if err != nil {
+		return
+	}
+
+	ext := strings.ToLower(filepath.Ext(file.Name))
+	switch {
+	case ext == ".ab1" || ext == ".abi" || ext == ".ab" || bytes.HasPrefix(data, []byte("ABIF")):
+		trace, err = ParseAB1(data)
+	case ext == ".scf" || bytes.HasPrefix(data, []byte(".scf")):
+		trace, err = ParseSCF(data)
+	default:
+		err = fmt.Errorf("%s is not a trace file in AB1 or SCF format", file.Name)
+	}
+
+	if err == nil && trace.Name == "" {
+		trace.Name = strings.TrimSuffix(filepath.Base(file.Name), filepath.Ext(file.Name))
+	}
+	return
+}
+
+// abifEntry is an entry of the directory of an AB1 file
+type abifEntry struct {
+	elementSize int
+	elements    int
+	data        []byte
+}
+
+// ints returns the data of the entry as a list of integers of the element size
+func (e abifEntry) ints() []int {
+	ints := make([]int, 0, e.elements)
+	for i := 0; i < e.elements; i++ {
+		element := e.data[i*e.elementSize : (i+1)*e.elementSize]
+		switch e.elementSize {
+		case 1:
+			ints = append(ints, int(element[0]))
+		case 2:
+			ints = append(ints, int(int16(binary.BigEndian.Uint16(element))))
+		case 4:
+			ints = append(ints, int(int32(binary.BigEndian.Uint32(element))))
+		}
+	}
+	return ints
+}
+
+// ParseAB1 parses the contents of a trace file in the ABIF format of Applied
+// Biosystems sequencers. The base calls made by the basecaller are used in
+// preference to any which have been edited.
+func ParseAB1(data []byte) (trace Trace, err error) {
+	const entrySize = 28
+
+	if len(data) < 6+entrySize || string(data[:4]) != "ABIF" {
+		return trace, fmt.Errorf("not an AB1 file")
+	}
+
+	// the root entry gives the location of the directory
+	root := data[6 : 6+entrySize]
+	count := int(int32(binary.BigEndian.Uint32(root[12:16])))
+	offset := int(int32(binary.BigEndian.Uint32(root[20:24])))
+	if count < 0 || offset < 0 || offset+count*entrySize > len(data) {
+		return trace, fmt.Errorf("AB1 directory is not within the file")
+	}
+
+	entries := make(map[string]abifEntry, count)
+	for i := 0; i < count; i++ {
+		e := data[offset+i*entrySize : offset+(i+1)*entrySize]
+		size := int(int32(binary.BigEndian.Uint32(e[16:20])))
+		entry := abifEntry{
+			elementSize: int(int16(binary.BigEndian.Uint16(e[10:12]))),
+			elements:    int(int32(binary.BigEndian.Uint32(e[12:16]))),
+		}
+		// the element size and number of elements are trusted by ints
+		if size < 0 || entry.elementSize < 1 || entry.elements < 0 || entry.elements > size/entry.elementSize {
+			return trace, fmt.Errorf("AB1 entry %s of %d bytes cannot hold %d elements of %d bytes", e[:4], size, entry.elements, entry.elementSize)
+		}
+		if size <= 4 {
+			// small data are held in the offset field
+			entry.data = e[20 : 20+size]
+		} else {
+			start := int(int32(binary.BigEndian.Uint32(e[20:24])))
+			if start < 0 || start+size > len(data) {
+				return trace, fmt.Errorf("data of AB1 entry %s is not within the file", e[:4])
+			}
+			entry.data = data[start : start+size]
+		}
+		entries[fmt.Sprintf("%s%d", e[:4], int32(binary.BigEndian.Uint32(e[4:8])))] = entry
+	}
+
+	calls, found := entries["PBAS2"]
+	if !found {
+		if calls, found = entries["PBAS1"]; !found {
+			return trace, fmt.Errorf("no base calls in AB1 file")
+		}
+	}
+	trace.Sequence = strings.ToUpper(string(calls.data))
+
+	if e, found := entries["PCON2"]; found {
+		trace.Qualities = e.ints()
+	} else if e, found := entries["PCON1"]; found {
+		trace.Qualities = e.ints()
+	}
+	if e, found := entries["PLOC2"]; found {
+		trace.Peaks = e.ints()
+	} else if e, found := entries["PLOC1"]; found {
+		trace.Peaks = e.ints()
+	}
+
+	if e, found := entries["SMPL1"]; found && len(e.data) > 0 {
+		// a Pascal string
+		length := int(e.data[0])
+		if length < len(e.data) {
+			trace.Name = string(e.data[1 : 1+length])
+		}
+	}
+
+	if order, found := entries["FWO_1"]; found {
+		trace.Channels = make(map[string][]int)
+		for i, base := range strings.ToUpper(string(order.data)) {
+			if channel, found := entries[fmt.Sprintf("DATA%d", 9+i)]; found {
+				trace.Channels[string(base)] = channel.ints()
+			}
+		}
+	}
+
+	return trace, nil
+}
+
+// ParseSCF parses the contents of a trace file in the Standard Chromatogram
+// Format, versions 2 and 3. The quality of each base call is the probability
+// given for the base called.
+func ParseSCF(data []byte) (trace Trace, err error) {
+	const headerSize = 128
+
+	if len(data) < headerSize || string(data[:4]) != ".scf" {
+		return trace, fmt.Errorf("not an SCF file")
+	}
+
+	field := func(i int) int {
+		return int(binary.BigEndian.Uint32(data[4*i : 4*i+4]))
+	}
+	samples, samplesOffset := field(1), field(2)
+	bases, basesOffset := field(3), field(6)
+	version := string(data[36:40])
+	sampleSize := field(10)
+	if sampleSize != 1 && sampleSize != 2 {
+		return trace, fmt.Errorf("SCF sample size of %d bytes not supported", sampleSize)
+	}
+
+	if samplesOffset+4*samples*sampleSize > len(data) || basesOffset+12*bases > len(data) {
+		return trace, fmt.Errorf("SCF data are not within the file")
+	}
+
+	sample := func(i int) int {
+		if sampleSize == 1 {
+			return int(data[samplesOffset+i])
+		}
+		return int(binary.BigEndian.Uint16(data[samplesOffset+2*i:]))
+	}
+
+	order := []string{"A", "C", "G", "T"}
+	trace.Channels = make(map[string][]int, len(order))
+	sequence := make([]byte, bases)
+	trace.Qualities = make([]int, bases)
+	trace.Peaks = make([]int, bases)
+
+	if version >= "3" {
+		// channels follow one another and are stored as second differences
+		mask := 1<<uint(8*sampleSize) - 1
+		for c, base := range order {
+			channel := make([]int, samples)
+			for i := range channel {
+				channel[i] = sample(c*samples + i)
+			}
+			for n := 0; n < 2; n++ {
+				previous := 0
+				for i := range channel {
+					channel[i] = (channel[i] + previous) & mask
+					previous = channel[i]
+				}
+			}
+			trace.Channels[base] = channel
+		}
+
+		probabilities := basesOffset + 4*bases
+		for i := 0; i < bases; i++ {
+			trace.Peaks[i] = int(binary.BigEndian.Uint32(data[basesOffset+4*i:]))
+			sequence[i] = data[probabilities+4*bases+i]
+			for c, base := range order {
+				if strings.EqualFold(base, string(sequence[i])) {
+					trace.Qualities[i] = int(data[probabilities+c*bases+i])
+				}
+			}
+		}
+	} else {
+		// samples of the channels are interleaved and each base takes 12 bytes
+		for c, base := range order {
+			channel := make([]int, samples)
+			for i := range channel {
+				channel[i] = sample(4*i + c)
+			}
+			trace.Channels[base] = channel
+		}
+
+		for i := 0; i < bases; i++ {
+			call := data[basesOffset+12*i : basesOffset+12*(i+1)]
+			trace.Peaks[i] = int(binary.BigEndian.Uint32(call))
+			sequence[i] = call[8]
+			for c, base := range order {
+				if strings.EqualFold(base, string(sequence[i])) {
+					trace.Qualities[i] = int(call[4+c])
+				}
+			}
+		}
+	}
+
+	trace.Sequence = strings.ToUpper(string(sequence))
+	return trace, nil
+}
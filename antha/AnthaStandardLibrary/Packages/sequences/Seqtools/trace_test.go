@@ -0,0 +1,269 @@
+package seqtools
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+type abifTag struct {
+	name        string
+	number      int
+	elementSize int
+	data        []byte
+}
+
+// makeAB1 makes the contents of an AB1 file with the tags given
+func makeAB1(tags []abifTag) []byte {
+	var data, directory bytes.Buffer
+	const header = 128
+	for _, tag := range tags {
+		entry := make([]byte, 28)
+		copy(entry, tag.name)
+		binary.BigEndian.PutUint32(entry[4:], uint32(tag.number))
+		binary.BigEndian.PutUint16(entry[10:], uint16(tag.elementSize))
+		binary.BigEndian.PutUint32(entry[12:], uint32(len(tag.data)/tag.elementSize))
+		binary.BigEndian.PutUint32(entry[16:], uint32(len(tag.data)))
+		if len(tag.data) <= 4 {
+			copy(entry[20:], tag.data)
+		} else {
+			binary.BigEndian.PutUint32(entry[20:], uint32(header+data.Len()))
+			data.Write(tag.data)
+		}
+		directory.Write(entry)
+	}
+
+	contents := make([]byte, header)
+	copy(contents, "ABIF")
+	binary.BigEndian.PutUint16(contents[4:], 101)
+	copy(contents[6:], "tdir")
+	binary.BigEndian.PutUint32(contents[18:], uint32(len(tags)))
+	binary.BigEndian.PutUint32(contents[26:], uint32(header+data.Len()))
+	contents = append(contents, data.Bytes()...)
+	return append(contents, directory.Bytes()...)
+}
+
+func shorts(values ...int) []byte {
+	b := make([]byte, 2*len(values))
+	for i, v := range values {
+		binary.BigEndian.PutUint16(b[2*i:], uint16(v))
+	}
+	return b
+}
+
+// makeSCF makes the contents of an SCF file of version 2 or 3 with samples
+// of two bytes
+func makeSCF(version string, calls string, qualities []int, peaks []int, channels map[string][]int) []byte {
+	order := []string{"A", "C", "G", "T"}
+	samples := len(channels["A"])
+
+	var sampleData []byte
+	if version == "3.00" {
+		for _, base := range order {
+			// second differences
+			values := append([]int(nil), channels[base]...)
+			for n := 0; n < 2; n++ {
+				previous := 0
+				for i, v := range values {
+					values[i] = (v - previous) & 0xffff
+					previous = v
+				}
+			}
+			sampleData = append(sampleData, shorts(values...)...)
+		}
+	} else {
+		for i := 0; i < samples; i++ {
+			for _, base := range order {
+				sampleData = append(sampleData, shorts(channels[base][i])...)
+			}
+		}
+	}
+
+	probabilities := func(i int) []byte {
+		p := make([]byte, 4)
+		for c, base := range order {
+			if base == string(calls[i]) {
+				p[c] = byte(qualities[i])
+			}
+		}
+		return p
+	}
+
+	var baseData []byte
+	if version == "3.00" {
+		for _, p := range peaks {
+			baseData = append(baseData, 0, 0, 0, 0)
+			binary.BigEndian.PutUint32(baseData[len(baseData)-4:], uint32(p))
+		}
+		for c := range order {
+			for i := range calls {
+				baseData = append(baseData, probabilities(i)[c])
+			}
+		}
+		baseData = append(baseData, calls...)
+		baseData = append(baseData, make([]byte, 3*len(calls))...)
+	} else {
+		for i := range calls {
+			call := make([]byte, 12)
+			binary.BigEndian.PutUint32(call, uint32(peaks[i]))
+			copy(call[4:], probabilities(i))
+			call[8] = calls[i]
+			baseData = append(baseData, call...)
+		}
+	}
+
+	header := make([]byte, 128)
+	copy(header, ".scf")
+	binary.BigEndian.PutUint32(header[4:], uint32(samples))
+	binary.BigEndian.PutUint32(header[8:], 128)
+	binary.BigEndian.PutUint32(header[12:], uint32(len(calls)))
+	binary.BigEndian.PutUint32(header[24:], uint32(128+len(sampleData)))
+	copy(header[36:], version)
+	binary.BigEndian.PutUint32(header[40:], 2)
+
+	return append(append(header, sampleData...), baseData...)
+}
+
+var (
+	testCalls     = "ACGTNACGTA"
+	testQualities = []int{10, 20, 30, 40, 2, 40, 40, 30, 20, 10}
+	testPeaks     = []int{2, 5, 8, 11, 14, 17, 20, 23, 26, 29}
+	testChannels  = map[string][]int{
+		"A": {0, 100, 900, 60000, 20, 5, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24},
+		"C": {5, 4, 3, 2, 1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25},
+		"G": {0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 65535},
+		"T": {7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+	}
+)
+
+func TestParseAB1(t *testing.T) {
+	qualities := make([]byte, len(testQualities))
+	for i, q := range testQualities {
+		qualities[i] = byte(q)
+	}
+	tags := []abifTag{
+		{name: "PBAS", number: 1, elementSize: 1, data: []byte("ACGTTACGTA")},
+		{name: "PBAS", number: 2, elementSize: 1, data: []byte(testCalls)},
+		{name: "PCON", number: 2, elementSize: 1, data: qualities},
+		{name: "PLOC", number: 2, elementSize: 2, data: shorts(testPeaks...)},
+		{name: "SMPL", number: 1, elementSize: 1, data: append([]byte{6}, "clone1"...)},
+		{name: "FWO_", number: 1, elementSize: 1, data: []byte("GATC")},
+		{name: "DATA", number: 9, elementSize: 2, data: shorts(testChannels["G"]...)},
+		{name: "DATA", number: 10, elementSize: 2, data: shorts(1, 2, 3)},
+		{name: "DATA", number: 11, elementSize: 2, data: shorts(testChannels["T"]...)},
+		{name: "DATA", number: 12, elementSize: 2, data: shorts(testChannels["C"]...)},
+	}
+
+	trace, err := ParseTrace(wtype.File{Name: "read.ab1"})
+	if err == nil {
+		t.Error("expected error parsing empty file")
+	}
+
+	var file wtype.File
+	file.Name = "read.ab1"
+	if err := file.WriteAll(makeAB1(tags)); err != nil {
+		t.Fatal(err)
+	}
+	trace, err = ParseTrace(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if trace.Name != "clone1" || trace.Sequence != testCalls {
+		t.Errorf("expected clone1 %s, got %s %s", testCalls, trace.Name, trace.Sequence)
+	}
+	if !reflect.DeepEqual(trace.Qualities, testQualities) || !reflect.DeepEqual(trace.Peaks, testPeaks) {
+		t.Errorf("expected qualities %v and peaks %v, got %v and %v", testQualities, testPeaks, trace.Qualities, trace.Peaks)
+	}
+	// the G channel includes a value which is negative as a signed short
+	expectedG := append([]int(nil), testChannels["G"]...)
+	expectedG[len(expectedG)-1] = -1
+	if !reflect.DeepEqual(trace.Channels["G"], expectedG) || !reflect.DeepEqual(trace.Channels["A"], []int{1, 2, 3}) || !reflect.DeepEqual(trace.Channels["C"], testChannels["C"]) {
+		t.Errorf("unexpected channels %v", trace.Channels)
+	}
+}
+
+func TestParseAB1Corrupt(t *testing.T) {
+	tags := []abifTag{
+		{name: "PBAS", number: 2, elementSize: 1, data: []byte(testCalls)},
+		{name: "PLOC", number: 2, elementSize: 2, data: shorts(testPeaks...)},
+	}
+	// fields of the entry of the peaks in the directory at the end of the file
+	for _, field := range []struct {
+		name   string
+		offset int
+		value  []byte
+	}{
+		{"negative element size", 10, []byte{0xff, 0xff}},
+		{"zero element size", 10, []byte{0, 0}},
+		{"negative number of elements", 12, []byte{0xff, 0xff, 0xff, 0xff}},
+		{"more elements than data", 12, []byte{0, 0, 0x10, 0}},
+		{"negative data size", 16, []byte{0xff, 0xff, 0xff, 0xff}},
+	} {
+		data := makeAB1(tags)
+		copy(data[len(data)-28+field.offset:], field.value)
+		if _, err := ParseAB1(data); err == nil {
+			t.Errorf("%s: expected error", field.name)
+		}
+	}
+}
+
+func TestParseSCF(t *testing.T) {
+	for _, version := range []string{"3.00", "2.00"} {
+		var file wtype.File
+		file.Name = "clone2_F.scf"
+		if err := file.WriteAll(makeSCF(version, testCalls, testQualities, testPeaks, testChannels)); err != nil {
+			t.Fatal(err)
+		}
+
+		trace, err := ParseTrace(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if trace.Name != "clone2_F" || trace.Sequence != testCalls {
+			t.Errorf("version %s: expected clone2_F %s, got %s %s", version, testCalls, trace.Name, trace.Sequence)
+		}
+		// the quality of N is not given by the probabilities of the bases
+		expected := append([]int(nil), testQualities...)
+		expected[4] = 0
+		if !reflect.DeepEqual(trace.Qualities, expected) || !reflect.DeepEqual(trace.Peaks, testPeaks) {
+			t.Errorf("version %s: expected qualities %v and peaks %v, got %v and %v", version, expected, testPeaks, trace.Qualities, trace.Peaks)
+		}
+		if !reflect.DeepEqual(trace.Channels, testChannels) {
+			t.Errorf("version %s: expected channels %v, got %v", version, testChannels, trace.Channels)
+		}
+	}
+
+	if _, err := ParseSCF([]byte(".scf")); err == nil {
+		t.Error("expected error parsing truncated SCF file")
+	}
+}
+
+func TestTrim(t *testing.T) {
+	trace := Trace{
+		Name:      "read",
+		Sequence:  "NNACGTACGTAN",
+		Qualities: []int{2, 5, 30, 30, 30, 8, 30, 30, 30, 30, 10, 3},
+		Peaks:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+	}
+
+	trimmed := trace.Trim(0.05)
+	if trimmed.Sequence != "ACGTACGT" || !reflect.DeepEqual(trimmed.Peaks, []int{3, 4, 5, 6, 7, 8, 9, 10}) {
+		t.Errorf("expected ACGTACGT from 3 to 10, got %s %v", trimmed.Sequence, trimmed.Peaks)
+	}
+
+	// a single poor call is trimmed with a strict limit
+	trimmed = trace.Trim(0.002)
+	if trimmed.Sequence != "ACGT" {
+		t.Errorf("expected ACGT, got %s", trimmed.Sequence)
+	}
+
+	unscored := Trace{Sequence: "NNACGT"}
+	if unscored.Trim(0.05).Sequence != "NNACGT" {
+		t.Error("expected trace without qualities to be unchanged")
+	}
+}
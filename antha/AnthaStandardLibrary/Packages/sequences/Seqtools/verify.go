@@ -0,0 +1,513 @@
+// antha/AnthaStandardLibrary/Packages/sequences/Seqtools/verify.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package seqtools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/align"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// Kinds of difference between a read and the expected sequence
+const (
+	Mismatch  = "mismatch"
+	Insertion = "insertion"
+	Deletion  = "deletion"
+)
+
+// Difference is a difference between a read and the expected sequence.
+type Difference struct {
+	Read string
+	Kind string
+	// Position is the human friendly position in the expected sequence of
+	// the difference or, for insertions, of the base after which the
+	// insertion is found.
+	Position int
+	// Expected are the bases of the expected sequence, none for insertions.
+	Expected string
+	// Found are the bases of the read, none for deletions.
+	Found string
+	// Quality is the lowest Phred quality of the base calls of the difference
+	// or, for deletions, of the base calls either side of it.
+	Quality int
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case Insertion:
+		return fmt.Sprintf("insertion of %s after %d in %s (Q%d)", d.Found, d.Position, d.Read, d.Quality)
+	case Deletion:
+		return fmt.Sprintf("deletion of %s at %d in %s (Q%d)", d.Expected, d.Position, d.Read, d.Quality)
+	}
+	return fmt.Sprintf("%s at %d: expected %s, found %s in %s (Q%d)", d.Kind, d.Position, d.Expected, d.Found, d.Read, d.Quality)
+}
+
+// ReadAlignment is the alignment of a quality trimmed read to the expected sequence.
+type ReadAlignment struct {
+	Read    string
+	Reverse bool
+	// Start and End are the human friendly positions of the expected
+	// sequence to which the read aligns. The end is before the start if the
+	// read spans the origin of a plasmid.
+	Start, End int
+	// Length is the length of the read after trimming.
+	Length      int
+	Identity    float64
+	Differences []Difference
+	Alignment   align.Result
+}
+
+// FeatureCoverage summarises the verification of a feature of the expected sequence.
+type FeatureCoverage struct {
+	Feature string
+	Length  int
+	// Covered is the number of bases of the feature with a base call, or a
+	// deletion, of at least the minimum quality.
+	Covered     int
+	Differences int
+}
+
+// Coverage returns the fraction of the feature which is covered.
+func (c FeatureCoverage) Coverage() float64 {
+	if c.Length == 0 {
+		return 0
+	}
+	return float64(c.Covered) / float64(c.Length)
+}
+
+// Verification is the result of the verification of a clone by Sanger sequencing.
+type Verification struct {
+	Clone    string
+	Expected string
+	Reads    []ReadAlignment
+	// Differences are those with base calls of at least the minimum quality
+	// which are not contradicted by a higher quality base call of another read.
+	Differences []Difference
+	Coverage    []FeatureCoverage
+	Pass        bool
+	// Reasons give why the clone failed verification.
+	Reasons []string
+}
+
+func (v Verification) String() string {
+	verdict := "PASS"
+	if !v.Pass {
+		verdict = "FAIL"
+	}
+	lines := []string{fmt.Sprintf("%s (%s): %s", v.Clone, v.Expected, verdict)}
+	for _, r := range v.Reasons {
+		lines = append(lines, "  "+r)
+	}
+	for _, c := range v.Coverage {
+		lines = append(lines, fmt.Sprintf("  %s: %.1f%% covered, %d differences", c.Feature, 100*c.Coverage(), c.Differences))
+	}
+	for _, d := range v.Differences {
+		lines = append(lines, "  "+d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// VerificationOptions set how reads are trimmed and which features of the
+// expected sequence a clone must match to pass.
+type VerificationOptions struct {
+	// ErrorLimit is the error probability used to trim reads.
+	ErrorLimit float64
+	// MinQuality is the Phred quality below which base calls are disregarded.
+	MinQuality int
+	// MinCoverage is the fraction of each required feature which must be
+	// covered by base calls of at least the minimum quality.
+	MinCoverage float64
+	// Features are the names of the features which must be covered and match.
+	// If none are given all features of the expected sequence are required,
+	// or the whole sequence if it has no features.
+	Features []string
+}
+
+// DefaultVerificationOptions require every feature to be fully covered by base
+// calls of at least Phred quality 20 with no differences.
+var DefaultVerificationOptions = VerificationOptions{
+	ErrorLimit:  0.05,
+	MinQuality:  20,
+	MinCoverage: 1,
+}
+
+// evidence collects the base calls of all reads at each position of the expected sequence
+type evidence struct {
+	// best quality of a base call matching the expected sequence, -1 if none
+	support []int
+	// whether there is a base call of at least the minimum quality
+	covered []bool
+}
+
+func newEvidence(length int) evidence {
+	e := evidence{
+		support: make([]int, length),
+		covered: make([]bool, length),
+	}
+	for i := range e.support {
+		e.support[i] = -1
+	}
+	return e
+}
+
+// alignRead trims a read and aligns it to the expected sequence, recording
+// the differences found and adding the base calls to the evidence.
+func alignRead(expected wtype.DNASequence, trace Trace, opt VerificationOptions, ev evidence) (ra ReadAlignment, err error) {
+	trimmed := trace.Trim(opt.ErrorLimit)
+	calls := strings.ToUpper(trimmed.Sequence)
+
+	quality := func(i int) int {
+		if len(trimmed.Qualities) != len(calls) {
+			// without quality values all base calls are trusted
+			return opt.MinQuality
+		}
+		return trimmed.Qualities[i]
+	}
+
+	// the aligner does not accept Ns so they are left out; the index gives
+	// the position in the trimmed read of each base aligned
+	var bases []byte
+	var index []int
+	for i := 0; i < len(calls); i++ {
+		if calls[i] != 'N' && calls[i] != '-' {
+			bases = append(bases, calls[i])
+			index = append(index, i)
+		}
+	}
+	if len(bases) == 0 {
+		return ra, fmt.Errorf("read %s has no base calls after trimming", trace.Name)
+	}
+
+	read := wtype.MakeLinearDNASequence(trace.Name, string(bases))
+	result, err := align.DNA(expected, read, align.Fitted)
+	if len(result.Alignment.QueryResult) == 0 {
+		if err == nil {
+			err = fmt.Errorf("no alignment found")
+		}
+		return ra, fmt.Errorf("aligning read %s to %s: %s", trace.Name, expected.Nm, err)
+	}
+
+	templateResult, queryResult := result.Alignment.TemplateResult, result.Alignment.QueryResult
+
+	ra = ReadAlignment{
+		Read:      trace.Name,
+		Length:    len(calls),
+		Identity:  result.Identity(),
+		Alignment: result,
+	}
+	switch strings.ToUpper(strings.Replace(queryResult, "-", "", -1)) {
+	case string(bases):
+	case wtype.RevComp(string(bases)):
+		// reverse reads are aligned as their reverse complement
+		ra.Reverse = true
+	default:
+		return ra, fmt.Errorf("aligning read %s to %s: read is not fully aligned", trace.Name, expected.Nm)
+	}
+
+	// readIndex returns the position in the trimmed read of the kth base
+	// of the read as aligned
+	readIndex := func(k int) int {
+		if ra.Reverse {
+			return index[len(index)-1-k]
+		}
+		return index[k]
+	}
+
+	// overhanging ends of the alignment are disregarded
+	first, last := -1, -1
+	for c := range templateResult {
+		if templateResult[c] != '-' && queryResult[c] != '-' {
+			if first < 0 {
+				first = c
+			}
+			last = c
+		}
+	}
+	if first < 0 {
+		return ra, fmt.Errorf("aligning read %s to %s: no bases aligned", trace.Name, expected.Nm)
+	}
+
+	k := len(strings.Replace(queryResult[:first], "-", "", -1))
+	positions := result.Alignment.TemplatePositions
+	position := positions[first]
+	if ra.Reverse {
+		position = positions[len(positions)-1-first]
+	}
+	next := func(p int) int {
+		if p == len(expected.Seq) {
+			return 1
+		}
+		return p + 1
+	}
+
+	ra.Start = position
+	previous := position
+	var open *Difference
+	for c := first; c <= last; c++ {
+		t, q := templateResult[c], queryResult[c]
+		switch {
+		case t != '-' && q != '-':
+			open = nil
+			call := quality(readIndex(k))
+			if strings.EqualFold(string(t), string(q)) {
+				if call > ev.support[position-1] {
+					ev.support[position-1] = call
+				}
+			} else {
+				ra.Differences = append(ra.Differences, Difference{
+					Read:     trace.Name,
+					Kind:     Mismatch,
+					Position: position,
+					Expected: strings.ToUpper(string(t)),
+					Found:    strings.ToUpper(string(q)),
+					Quality:  call,
+				})
+			}
+			if call >= opt.MinQuality {
+				ev.covered[position-1] = true
+			}
+			k++
+			previous = position
+			position = next(position)
+		case t == '-':
+			call := quality(readIndex(k))
+			if open == nil || open.Kind != Insertion {
+				ra.Differences = append(ra.Differences, Difference{
+					Read:     trace.Name,
+					Kind:     Insertion,
+					Position: previous,
+					Quality:  call,
+				})
+				open = &ra.Differences[len(ra.Differences)-1]
+			}
+			open.Found += strings.ToUpper(string(q))
+			if call < open.Quality {
+				open.Quality = call
+			}
+			k++
+		default:
+			// the quality of a deletion is that of the base calls either
+			// side of it and of any Ns left out between them
+			low, high := readIndex(k-1), readIndex(k)
+			if low > high {
+				low, high = high, low
+			}
+			call := quality(low)
+			for i := low + 1; i <= high; i++ {
+				if quality(i) < call {
+					call = quality(i)
+				}
+			}
+			if open == nil || open.Kind != Deletion {
+				ra.Differences = append(ra.Differences, Difference{
+					Read:     trace.Name,
+					Kind:     Deletion,
+					Position: position,
+					Quality:  call,
+				})
+				open = &ra.Differences[len(ra.Differences)-1]
+			}
+			open.Expected += strings.ToUpper(string(t))
+			if call >= opt.MinQuality {
+				ev.covered[position-1] = true
+			}
+			position = next(position)
+		}
+	}
+	ra.End = previous
+
+	return ra, nil
+}
+
+// region is a named set of positions of the expected sequence which must be verified
+type region struct {
+	name      string
+	positions []int
+}
+
+// featurePositions returns the human friendly positions of a feature.
+// Features of plasmids which are not reverse and whose start is after their
+// end span the origin.
+func featurePositions(feature wtype.Feature, length int, plasmid bool) (positions []int) {
+	ranges := feature.Ranges
+	if len(ranges) == 0 {
+		ranges = []wtype.FeatureRange{{
+			StartPosition: feature.StartPosition,
+			EndPosition:   feature.EndPosition,
+			Reverse:       feature.Reverse,
+		}}
+	}
+	for _, r := range ranges {
+		start, end := r.StartPosition, r.EndPosition
+		if start > end && (r.Reverse || !plasmid) {
+			start, end = end, start
+		}
+		for p := start; ; p++ {
+			if p > length {
+				p = 1
+			}
+			if p >= 1 {
+				positions = append(positions, p)
+			}
+			if p == end || len(positions) > length {
+				break
+			}
+		}
+	}
+	return
+}
+
+// requiredRegions returns the regions of the expected sequence which must be verified
+func requiredRegions(expected wtype.DNASequence, names []string) (regions []region, err error) {
+	features := expected.Features
+	if len(names) > 0 {
+		features = nil
+		for _, name := range names {
+			found := false
+			for _, f := range expected.Features {
+				if strings.EqualFold(f.Name, name) {
+					features = append(features, f)
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("feature %s not found in %s; found these features: %v", name, expected.Nm, expected.FeatureNames())
+			}
+		}
+	}
+
+	for _, f := range features {
+		regions = append(regions, region{name: f.Name, positions: featurePositions(f, len(expected.Seq), expected.Plasmid)})
+	}
+	if len(regions) == 0 {
+		whole := region{name: expected.Nm}
+		for p := 1; p <= len(expected.Seq); p++ {
+			whole.positions = append(whole.positions, p)
+		}
+		regions = append(regions, whole)
+	}
+	return
+}
+
+// Verify verifies a clone by aligning its Sanger sequencing reads to the
+// expected sequence. Reads are quality trimmed and may be forward or reverse.
+// The clone passes if each required feature is covered by base calls of at
+// least the minimum quality and there are no differences within it.
+// Low quality differences, or those contradicted by a higher quality base
+// call of another read, are disregarded.
+func Verify(clone string, expected wtype.DNASequence, traces []Trace, opt VerificationOptions) (v Verification, err error) {
+	v = Verification{Clone: clone, Expected: expected.Nm}
+	if len(traces) == 0 {
+		return v, fmt.Errorf("no reads of clone %s", clone)
+	}
+
+	regions, err := requiredRegions(expected, opt.Features)
+	if err != nil {
+		return v, err
+	}
+
+	ev := newEvidence(len(expected.Seq))
+	for _, trace := range traces {
+		ra, err := alignRead(expected, trace, opt, ev)
+		if err != nil {
+			return v, err
+		}
+		v.Reads = append(v.Reads, ra)
+	}
+
+	// the same difference is usually found by more than one read
+	confirmed := make(map[string]int)
+	for _, ra := range v.Reads {
+		for _, d := range ra.Differences {
+			if d.Quality < opt.MinQuality {
+				continue
+			}
+			if d.Kind != Insertion && ev.support[d.Position-1] > d.Quality {
+				continue
+			}
+			key := fmt.Sprint(d.Kind, d.Position, d.Expected, d.Found)
+			if i, found := confirmed[key]; found {
+				if d.Quality > v.Differences[i].Quality {
+					v.Differences[i] = d
+				}
+				continue
+			}
+			confirmed[key] = len(v.Differences)
+			v.Differences = append(v.Differences, d)
+		}
+	}
+	sort.SliceStable(v.Differences, func(i, j int) bool {
+		return v.Differences[i].Position < v.Differences[j].Position
+	})
+
+	for _, r := range regions {
+		c := FeatureCoverage{Feature: r.name, Length: len(r.positions)}
+		in := make(map[int]bool, len(r.positions))
+		for _, p := range r.positions {
+			in[p] = true
+			if ev.covered[p-1] {
+				c.Covered++
+			}
+		}
+		for _, d := range v.Differences {
+			if in[d.Position] {
+				c.Differences++
+			}
+		}
+		v.Coverage = append(v.Coverage, c)
+
+		if c.Coverage() < opt.MinCoverage {
+			v.Reasons = append(v.Reasons, fmt.Sprintf("%s is only %.1f%% covered", c.Feature, 100*c.Coverage()))
+		}
+		if c.Differences > 0 {
+			v.Reasons = append(v.Reasons, fmt.Sprintf("%s has %d differences", c.Feature, c.Differences))
+		}
+	}
+	v.Pass = len(v.Reasons) == 0
+
+	return v, nil
+}
+
+// VerifyClones verifies each of a set of clones, given by name with their
+// reads, against the same expected sequence. Verifications are returned in
+// order of clone name.
+func VerifyClones(expected wtype.DNASequence, clones map[string][]Trace, opt VerificationOptions) ([]Verification, error) {
+	var names []string
+	for name := range clones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var verifications []Verification
+	for _, name := range names {
+		v, err := Verify(name, expected, clones[name], opt)
+		if err != nil {
+			return verifications, err
+		}
+		verifications = append(verifications, v)
+	}
+	return verifications, nil
+}
@@ -0,0 +1,144 @@
+package seqtools
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/seqtest"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// makeExpected makes a 300 bp sequence with a feature from 51 to 250
+func makeExpected() wtype.DNASequence {
+	seq := []byte(seqtest.RandomDNA(rand.New(rand.NewSource(1)), 300))
+	// bases around which indels are made
+	copy(seq[116:], "ACGTA")
+	copy(seq[148:], "ACGT")
+	copy(seq[198:], "TGCA")
+
+	expected := wtype.MakeLinearDNASequence("construct", string(seq))
+	expected.Features = []wtype.Feature{
+		{Name: "insert", Class: wtype.CDS, StartPosition: 51, EndPosition: 250},
+	}
+	return expected
+}
+
+// makeRead makes a read of the expected sequence from start to end, in human
+// friendly positions, with poor quality calls at either end
+func makeRead(name, seq string, reverse bool) Trace {
+	if reverse {
+		seq = wtype.RevComp(seq)
+	}
+	trace := Trace{Name: name, Sequence: "NAC" + seq + "GTN"}
+	for range trace.Sequence {
+		trace.Qualities = append(trace.Qualities, 40)
+	}
+	for _, i := range []int{0, 1, 2, len(trace.Sequence) - 3, len(trace.Sequence) - 2, len(trace.Sequence) - 1} {
+		trace.Qualities[i] = 3
+	}
+	return trace
+}
+
+func TestVerify(t *testing.T) {
+	expected := makeExpected()
+	seq := expected.Seq
+
+	// a read with a poor quality N where 120 is expected
+	withN := makeRead("fwd", seq[20:119]+"N"+seq[120:280], false)
+	withN.Qualities[3+99] = 5
+
+	v, err := Verify("clone1", expected, []Trace{withN, makeRead("rev", seq[30:290], true)}, DefaultVerificationOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Pass || len(v.Differences) != 0 {
+		t.Errorf("expected clone to pass, got\n%s", v)
+	}
+	if v.Reads[0].Reverse || v.Reads[0].Start != 21 || v.Reads[0].End != 280 || v.Reads[0].Length != 260 {
+		t.Errorf("expected forward read from 21 to 280 of length 260, got %+v", v.Reads[0])
+	}
+	if !v.Reads[1].Reverse || v.Reads[1].Start != 31 || v.Reads[1].End != 290 {
+		t.Errorf("expected reverse read from 31 to 290, got reverse %t from %d to %d", v.Reads[1].Reverse, v.Reads[1].Start, v.Reads[1].End)
+	}
+	if !reflect.DeepEqual(v.Coverage, []FeatureCoverage{{Feature: "insert", Length: 200, Covered: 200}}) {
+		t.Errorf("expected insert to be covered, got %+v", v.Coverage)
+	}
+	// the N is only a poor quality deletion in the forward read
+	if d := v.Reads[0].Differences; len(d) != 1 || d[0].Kind != Deletion || d[0].Position != 120 || d[0].Quality != 5 {
+		t.Errorf("expected poor quality deletion at 120, got %v", d)
+	}
+
+	// a mismatch at 100, a deletion at 150 and an insertion after 200
+	mutated := []byte(seq)
+	mutated[99] = wtype.Comp(seq[99:100])[0]
+	mutant := string(mutated[20:149]) + string(mutated[150:200]) + "A" + string(mutated[200:280])
+
+	v, err = Verify("clone2", expected, []Trace{makeRead("mutant", mutant, true)}, DefaultVerificationOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Pass {
+		t.Errorf("expected clone to fail, got\n%s", v)
+	}
+	expectedDifferences := []Difference{
+		{Read: "mutant", Kind: Mismatch, Position: 100, Expected: seq[99:100], Found: string(mutated[99]), Quality: 40},
+		{Read: "mutant", Kind: Deletion, Position: 150, Expected: "C", Quality: 40},
+		{Read: "mutant", Kind: Insertion, Position: 200, Found: "A", Quality: 40},
+	}
+	if !reflect.DeepEqual(v.Differences, expectedDifferences) {
+		t.Errorf("expected differences %v, got %v", expectedDifferences, v.Differences)
+	}
+	if len(v.Coverage) != 1 || v.Coverage[0].Differences != 3 || v.Coverage[0].Covered != 200 {
+		t.Errorf("expected insert to be covered with 3 differences, got %+v", v.Coverage)
+	}
+
+	// a short read does not cover the feature, but verifying only the start of the
+	// sequence it passes
+	short := []Trace{makeRead("short", seq[:150], false)}
+	v, err = Verify("clone3", expected, short, DefaultVerificationOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Pass || len(v.Coverage) != 1 || v.Coverage[0].Covered != 100 {
+		t.Errorf("expected insert to be half covered, got\n%s", v)
+	}
+
+	start := expected
+	start.Features = append(start.Features, wtype.Feature{Name: "start", StartPosition: 1, EndPosition: 100})
+	opt := DefaultVerificationOptions
+	opt.Features = []string{"start"}
+	if v, err := Verify("clone3", start, short, opt); err != nil || !v.Pass {
+		t.Errorf("expected start to be verified, got %v\n%s", err, v)
+	}
+
+	opt.Features = []string{"promoter"}
+	if _, err := Verify("clone3", start, short, opt); err == nil {
+		t.Error("expected error verifying missing feature")
+	}
+}
+
+func TestVerifyPlasmid(t *testing.T) {
+	expected := makeExpected()
+	expected.Plasmid = true
+	expected.Features = []wtype.Feature{{Name: "origin", StartPosition: 281, EndPosition: 20}}
+	seq := expected.Seq
+
+	clones := map[string][]Trace{
+		"b": {makeRead("b", seq[250:]+seq[:50], false)},
+		"a": {makeRead("a", seq[270:]+seq[:10], false)},
+	}
+	verifications, err := VerifyClones(expected, clones, DefaultVerificationOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(verifications) != 2 || verifications[0].Clone != "a" || verifications[1].Clone != "b" {
+		t.Fatalf("expected verifications of a and b, got %v", verifications)
+	}
+	if a := verifications[0]; a.Pass || a.Coverage[0].Covered != 30 || a.Coverage[0].Length != 40 {
+		t.Errorf("expected a to cover 30 of 40 bases, got\n%s", a)
+	}
+	if b := verifications[1]; !b.Pass || b.Reads[0].Start != 251 || b.Reads[0].End != 50 {
+		t.Errorf("expected b to pass from 251 to 50, got\n%s\n%+v", b, b.Reads[0])
+	}
+}
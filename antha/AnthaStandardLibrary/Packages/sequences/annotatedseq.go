@@ -91,7 +91,15 @@ func ORFs2Features(orfs []ORF) (features []wtype.Feature) {
 		if strings.ToUpper(orf.Direction) == strings.ToUpper("REVERSE") {
 			reverse = true
 		}
-		feature := wtype.Feature{"orf" + strconv.Itoa(i), "orf", reverse, orf.StartPosition, orf.EndPosition, orf.DNASeq, orf.ProtSeq}
+		feature := wtype.Feature{
+			Name:          "orf" + strconv.Itoa(i),
+			Class:         "orf",
+			Reverse:       reverse,
+			StartPosition: orf.StartPosition,
+			EndPosition:   orf.EndPosition,
+			DNASeq:        orf.DNASeq,
+			Protseq:       orf.ProtSeq,
+		}
 		features = append(features, feature)
 	}
 	return
@@ -0,0 +1,118 @@
+// antha/AnthaStandardLibrary/Packages/sequences/blast/local/database.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package local performs blast style searches of local sequence collections,
+// for use where the NCBI blast service cannot be reached. Hits are returned in
+// the same form as those of the blast package so they can be summarised with
+// blast.HitSummary, blast.FindBestHit and blast.AllExactMatches.
+package local
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/kmer"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/parse"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// DefaultWordSize is the length of the k-mers used to seed alignments, as
+// used by blastn
+const DefaultWordSize = 11
+
+// A Database is an indexed collection of DNA sequences to search
+type Database struct {
+	WordSize  int
+	Sequences []wtype.DNASequence
+
+	texts   []string
+	indexes []*kmer.Index
+	length  int
+}
+
+// NewDatabase indexes sequences for searching with k-mers of length
+// wordSize. The k-mers of plasmids are indexed across the origin.
+func NewDatabase(seqs []wtype.DNASequence, wordSize int) (*Database, error) {
+	if wordSize < 1 {
+		return nil, fmt.Errorf("invalid word size %d", wordSize)
+	}
+
+	db := &Database{WordSize: wordSize}
+	for _, seq := range seqs {
+		if err := db.Add(seq); err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// NewDatabaseFromFiles indexes the sequences of FASTA, GenBank, SBOL or GDX
+// files
+func NewDatabaseFromFiles(files []wtype.File, wordSize int) (*Database, error) {
+	var seqs []wtype.DNASequence
+	for _, file := range files {
+		fileSeqs, err := parse.DNAFileToDNASequence(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %s", file.Name, err)
+		}
+		seqs = append(seqs, fileSeqs...)
+	}
+	return NewDatabase(seqs, wordSize)
+}
+
+// Add indexes another sequence
+func (db *Database) Add(seq wtype.DNASequence) error {
+	if len(seq.Seq) == 0 {
+		return fmt.Errorf("sequence %s is empty", seq.Nm)
+	}
+
+	text := strings.ToUpper(seq.Seq)
+	indexed := text
+	if seq.Plasmid {
+		indexed += text[:min(db.WordSize-1, len(text))]
+	}
+
+	db.Sequences = append(db.Sequences, seq)
+	db.texts = append(db.texts, text)
+	db.indexes = append(db.indexes, kmer.NewIndex(indexed, db.WordSize))
+	db.length += len(text)
+	return nil
+}
+
+// Len returns the total length of the sequences in the database
+func (db *Database) Len() int {
+	return db.length
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,387 @@
+// antha/AnthaStandardLibrary/Packages/sequences/blast/local/search.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package local
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/align"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/biogo/ncbi/blast"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// Hits are scored as megablast scores them by default, i.e., with a reward of
+// 1, a penalty of 2 and linear gap costs; lambda and kappa are the
+// Karlin-Altschul parameters of these scores.
+const (
+	matchScore    = 1.0
+	mismatchScore = -2.0
+	gapScore      = -2.5
+	lambda        = 1.28
+	kappa         = 0.46
+)
+
+// SearchOptions control which hits a search returns
+type SearchOptions struct {
+	// MinSeeds is the number of k-mers of the query which must be found in a
+	// sequence, on the same strand and on neighbouring diagonals, before that
+	// part of the sequence is aligned
+	MinSeeds int
+	// MinIdentity is the smallest fraction of an alignment which must match
+	MinIdentity float64
+	// MaxEValue is the largest expect value of the hits returned; 0 for no
+	// limit
+	MaxEValue float64
+	// MaxHits is the largest number of sequences returned; 0 for no limit
+	MaxHits int
+}
+
+// DefaultSearchOptions are the search options used by MegaBlastN
+var DefaultSearchOptions = SearchOptions{
+	MinSeeds:  2,
+	MaxEValue: 10,
+	MaxHits:   50,
+}
+
+// MegaBlastN searches the database for a DNA sequence with the default
+// search options, as blast.MegaBlastN searches the NCBI nucleotide database
+func (db *Database) MegaBlastN(query string) ([]blast.Hit, error) {
+	return db.Search(wtype.MakeLinearDNASequence("Query", query), DefaultSearchOptions)
+}
+
+// Search finds the sequences of the database which match the query. Matches
+// are seeded by k-mers shared with either strand of the query and extended by
+// local alignment with align.DNA. Hits are ordered by the bit score of their
+// best high scoring pair and their pairs by bit score too. As with blast, the
+// positions of pairs count from 1 and those of pairs on the minus strand of a
+// sequence run backwards, i.e., HitFrom is greater than HitTo.
+func (db *Database) Search(query wtype.DNASequence, opt SearchOptions) ([]blast.Hit, error) {
+	q := strings.ToUpper(query.Seq)
+	if len(q) < db.WordSize {
+		return nil, fmt.Errorf("query %s is shorter than the word size %d", query.Nm, db.WordSize)
+	}
+	rev := wtype.RevComp(q)
+	if len(rev) != len(q) {
+		return nil, fmt.Errorf("query %s contains characters which are not nucleotides", query.Nm)
+	}
+
+	minSeeds := min(opt.MinSeeds, len(q)-db.WordSize+1)
+
+	var hits []blast.Hit
+	for i, seq := range db.Sequences {
+		var hsps []blast.Hsp
+		seen := make(map[[4]int]bool)
+		for strand, s := range []string{q, rev} {
+			for _, c := range db.clusters(i, s, minSeeds) {
+				hsp, ok, err := db.extend(i, query.Nm, s, strand == 1, c)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+				key := [4]int{hsp.QueryFrom, hsp.QueryTo, hsp.HitFrom, hsp.HitTo}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				if float64(*hsp.HspIdentity)/float64(*hsp.AlignLen) < opt.MinIdentity {
+					continue
+				}
+				if opt.MaxEValue > 0 && hsp.EValue > opt.MaxEValue {
+					continue
+				}
+				hsps = append(hsps, hsp)
+			}
+		}
+		if len(hsps) == 0 {
+			continue
+		}
+
+		sort.SliceStable(hsps, func(a, b int) bool {
+			return hsps[a].BitScore > hsps[b].BitScore
+		})
+		for j := range hsps {
+			hsps[j].N = j + 1
+		}
+		hits = append(hits, blast.Hit{
+			Id:        "lcl|" + seq.Nm,
+			Def:       seq.Nm,
+			Accession: seq.Nm,
+			Len:       len(db.texts[i]),
+			Hsps:      hsps,
+		})
+	}
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		return hits[a].Hsps[0].BitScore > hits[b].Hsps[0].BitScore
+	})
+	if opt.MaxHits > 0 && len(hits) > opt.MaxHits {
+		hits = hits[:opt.MaxHits]
+	}
+	for i := range hits {
+		hits[i].N = i + 1
+	}
+	return hits, nil
+}
+
+// a cluster is a group of seeds on neighbouring diagonals. The diagonal of a
+// seed is its position in the sequence less its position in the query.
+type cluster struct {
+	minDiagonal, maxDiagonal int
+	queryStart, queryEnd     int
+	seeds                    int
+}
+
+type seed struct {
+	diagonal, query int
+}
+
+// clusters returns the clusters of at least minSeeds seeds of a strand of the
+// query in the ith sequence
+func (db *Database) clusters(i int, s string, minSeeds int) []cluster {
+	k := db.WordSize
+	n := len(db.texts[i])
+
+	var seeds []seed
+	for q := 0; q+k <= len(s); q++ {
+		w := s[q : q+k]
+		if strings.IndexFunc(w, ambiguous) >= 0 {
+			continue
+		}
+		for _, p := range db.indexes[i].Lookup(w) {
+			d := p - q
+			if db.Sequences[i].Plasmid {
+				d = (d%n + n) % n
+			}
+			seeds = append(seeds, seed{diagonal: d, query: q})
+		}
+	}
+	sort.Slice(seeds, func(a, b int) bool {
+		if seeds[a].diagonal != seeds[b].diagonal {
+			return seeds[a].diagonal < seeds[b].diagonal
+		}
+		return seeds[a].query < seeds[b].query
+	})
+
+	var clusters []cluster
+	for j, sd := range seeds {
+		if j == 0 || sd.diagonal-seeds[j-1].diagonal > k {
+			clusters = append(clusters, cluster{
+				minDiagonal: sd.diagonal,
+				queryStart:  sd.query,
+				queryEnd:    sd.query + k,
+			})
+		}
+		c := &clusters[len(clusters)-1]
+		c.maxDiagonal = sd.diagonal
+		c.queryStart = min(c.queryStart, sd.query)
+		c.queryEnd = max(c.queryEnd, sd.query+k)
+		c.seeds++
+	}
+
+	var ret []cluster
+	for _, c := range clusters {
+		if c.seeds >= minSeeds {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// extend aligns the part of a strand of the query around a cluster of seeds
+// with the ith sequence and returns the high scoring pair found, if any
+func (db *Database) extend(i int, name, s string, reverse bool, c cluster) (hsp blast.Hsp, ok bool, err error) {
+	k := db.WordSize
+	text := db.texts[i]
+	n := len(text)
+
+	// the alignment may extend some way beyond the seeds and indels may move
+	// it off their diagonals
+	qa := max(0, c.queryStart-4*k)
+	qb := min(len(s), c.queryEnd+4*k)
+	ta := qa + c.minDiagonal - k
+	tb := qb + c.maxDiagonal + k
+	if db.Sequences[i].Plasmid {
+		tb = min(tb, ta+n)
+	} else {
+		ta, tb = max(0, ta), min(n, tb)
+	}
+	if tb <= ta {
+		return
+	}
+
+	// position returns the position in the sequence of the jth base of the
+	// window aligned
+	position := func(j int) int {
+		return ((ta+j)%n + n) % n
+	}
+	window := make([]byte, tb-ta)
+	for j := range window {
+		window[j] = text[position(j)]
+	}
+
+	part := s[qa:qb]
+	result, err := align.DNA(
+		wtype.MakeLinearDNASequence(db.Sequences[i].Nm, mask(string(window))),
+		wtype.MakeLinearDNASequence(name, mask(part)),
+		align.SW1,
+	)
+	if len(result.Alignment.QueryResult) == 0 {
+		return hsp, false, err
+	}
+	// otherwise err only warns of a query longer than the template, which
+	// does not matter to a local alignment
+	err = nil
+
+	var querySeq, subjectSeq []byte
+	qFirst, tFirst := -1, -1
+	var qLast, tLast int
+	for _, raw := range result.Alignment.Raw {
+		t, q := raw.TemplateAlignment, raw.QueryAlignment
+		switch {
+		case t.Length == 0:
+			querySeq = append(querySeq, part[q.Start:q.End]...)
+			subjectSeq = append(subjectSeq, strings.Repeat("-", q.Length)...)
+		case q.Length == 0:
+			querySeq = append(querySeq, strings.Repeat("-", t.Length)...)
+			subjectSeq = append(subjectSeq, window[t.Start:t.End]...)
+		default:
+			querySeq = append(querySeq, part[q.Start:q.End]...)
+			subjectSeq = append(subjectSeq, window[t.Start:t.End]...)
+		}
+		if q.Length != 0 {
+			if qFirst < 0 {
+				qFirst = q.Start
+			}
+			qLast = q.End
+		}
+		if t.Length != 0 {
+			if tFirst < 0 {
+				tFirst = t.Start
+			}
+			tLast = t.End
+		}
+	}
+	if qFirst < 0 || tFirst < 0 {
+		return
+	}
+	// align.DNA also tries the reverse complement of the query; alignments
+	// of the other strand are found from its own seeds
+	if mask(string(querySeq)) != strings.ToUpper(result.Alignment.QueryResult) {
+		return
+	}
+
+	var identity, mismatches, gaps int
+	midline := make([]byte, len(querySeq))
+	for j := range querySeq {
+		switch {
+		case querySeq[j] == '-' || subjectSeq[j] == '-':
+			gaps++
+			midline[j] = ' '
+		case querySeq[j] == subjectSeq[j] && !ambiguous(rune(querySeq[j])):
+			identity++
+			midline[j] = '|'
+		default:
+			mismatches++
+			midline[j] = ' '
+		}
+	}
+	score := float64(identity)*matchScore + float64(mismatches)*mismatchScore + float64(gaps)*gapScore
+	if score <= 0 {
+		return
+	}
+	bitScore := (lambda*score - math.Log(kappa)) / math.Ln2
+
+	hsp = blast.Hsp{
+		BitScore:      bitScore,
+		Score:         score,
+		EValue:        float64(len(s)) * float64(db.length) * math.Pow(2, -bitScore),
+		QueryFrom:     qa + qFirst + 1,
+		QueryTo:       qa + qLast,
+		HitFrom:       position(tFirst) + 1,
+		HitTo:         position(tLast-1) + 1,
+		QueryFrame:    intPtr(1),
+		HitFrame:      intPtr(1),
+		HspIdentity:   intPtr(identity),
+		HspPositive:   intPtr(identity),
+		HspGaps:       intPtr(gaps),
+		AlignLen:      intPtr(len(midline)),
+		QuerySeq:      querySeq,
+		SubjectSeq:    subjectSeq,
+		FormatMidline: midline,
+	}
+	if reverse {
+		// pairs are given on the plus strand of the query
+		hsp.QueryFrom, hsp.QueryTo = len(s)-hsp.QueryTo+1, len(s)-hsp.QueryFrom+1
+		hsp.HitFrom, hsp.HitTo = hsp.HitTo, hsp.HitFrom
+		hsp.HitFrame = intPtr(-1)
+		hsp.QuerySeq = revCompGapped(querySeq)
+		hsp.SubjectSeq = revCompGapped(subjectSeq)
+		reverseBytes(hsp.FormatMidline)
+	}
+	return hsp, true, nil
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// ambiguous returns whether a base is other than A, C, G or T
+func ambiguous(r rune) bool {
+	return !strings.ContainsRune("ACGT", r)
+}
+
+// mask replaces ambiguous bases with gaps, which the aligner scores as a
+// mismatch with any base
+func mask(s string) string {
+	return strings.Map(func(r rune) rune {
+		if ambiguous(r) {
+			return '-'
+		}
+		return r
+	}, s)
+}
+
+// revCompGapped returns the reverse complement of an aligned sequence,
+// keeping its gaps
+func revCompGapped(s []byte) []byte {
+	ret := make([]byte, len(s))
+	for i, b := range s {
+		c := wtype.Comp(string(b))
+		if c == "" {
+			c = string(b)
+		}
+		ret[len(s)-1-i] = c[0]
+	}
+	return ret
+}
+
+func reverseBytes(s []byte) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,178 @@
+// antha/AnthaStandardLibrary/Packages/sequences/blast/local/search_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package local
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/biogo/ncbi/blast"
+	seqblast "github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/blast"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/seqtest"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// makeDatabase makes a database of common plasmid features, the last of
+// which, the pBR322 origin, is searched as a plasmid
+func makeDatabase(t *testing.T) *Database {
+	var seqs []wtype.DNASequence
+	for _, name := range []string{"ampicillin_resistance_gene_ORF", "tetracycline_resistance_ORF", "lacI_ORF"} {
+		seqs = append(seqs, seqtest.Feature(t, name))
+	}
+	ori := seqtest.Feature(t, "pBR322_replication_origin")
+	seqs = append(seqs, wtype.MakePlasmidDNASequence(ori.Nm, ori.Seq))
+
+	db, err := NewDatabase(seqs, DefaultWordSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func ungapped(s []byte) string {
+	return strings.Replace(string(s), "-", "", -1)
+}
+
+func TestSearch(t *testing.T) {
+	db := makeDatabase(t)
+	subject := db.Sequences[1].Seq
+
+	// an ambiguous base at position 100 of the query
+	query := []byte(subject[500:800])
+	query[100] = 'N'
+
+	hits, err := db.Search(wtype.MakeLinearDNASequence("query", string(query)), DefaultSearchOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("expected 1 hit, got %d", len(hits))
+	}
+
+	hit := hits[0]
+	if hit.Accession != "tetracycline_resistance_ORF" || hit.Len != len(subject) || hit.N != 1 {
+		t.Errorf("unexpected hit %s of length %d", hit.Accession, hit.Len)
+	}
+	hsp := hit.Hsps[0]
+	if hsp.QueryFrom != 1 || hsp.QueryTo != 300 || hsp.HitFrom != 501 || hsp.HitTo != 800 {
+		t.Errorf("expected query 1..300 to hit 501..800, got %d..%d to %d..%d", hsp.QueryFrom, hsp.QueryTo, hsp.HitFrom, hsp.HitTo)
+	}
+	if *hsp.HspIdentity != 299 || *hsp.AlignLen != 300 || *hsp.HspGaps != 0 || *hsp.HitFrame != 1 {
+		t.Errorf("expected 299 of 300 identical without gaps, got %d of %d with %d gaps", *hsp.HspIdentity, *hsp.AlignLen, *hsp.HspGaps)
+	}
+	if hsp.Score != 297 || hsp.EValue > 1e-50 {
+		t.Errorf("unexpected score %g and expect value %g", hsp.Score, hsp.EValue)
+	}
+	if hsp.FormatMidline[100] != ' ' || strings.Count(string(hsp.FormatMidline), "|") != 299 {
+		t.Errorf("unexpected midline %s", hsp.FormatMidline)
+	}
+
+	if _, identity, _, _, err := seqblast.FindBestHit(hits); err != nil || identity < 99 {
+		t.Errorf("expected best hit with identity of over 99%%, got %g: %v", identity, err)
+	}
+}
+
+func TestSearchReverse(t *testing.T) {
+	db := makeDatabase(t)
+	subject := db.Sequences[2].Seq
+
+	// a deletion of position 850 of the sequence
+	part := subject[800:850] + subject[851:1000]
+	query := wtype.RevComp(part)
+
+	hits, err := db.MegaBlastN(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].Accession != "lacI_ORF" {
+		t.Fatalf("expected a hit of lacI_ORF, got %v", hits)
+	}
+
+	hsp := hits[0].Hsps[0]
+	if hsp.QueryFrom != 1 || hsp.QueryTo != 199 || hsp.HitFrom != 1000 || hsp.HitTo != 801 {
+		t.Errorf("expected query 1..199 to hit 1000..801, got %d..%d to %d..%d", hsp.QueryFrom, hsp.QueryTo, hsp.HitFrom, hsp.HitTo)
+	}
+	if *hsp.HitFrame != -1 || *hsp.HspGaps != 1 || *hsp.HspIdentity != 199 || *hsp.AlignLen != 200 {
+		t.Errorf("expected minus strand with 1 gap, got frame %d with %d gaps", *hsp.HitFrame, *hsp.HspGaps)
+	}
+	if ungapped(hsp.QuerySeq) != query {
+		t.Errorf("expected query sequence on its plus strand, got %s", hsp.QuerySeq)
+	}
+	if ungapped(hsp.SubjectSeq) != wtype.RevComp(subject[800:1000]) {
+		t.Errorf("expected minus strand of sequence, got %s", hsp.SubjectSeq)
+	}
+}
+
+func TestSearchPlasmid(t *testing.T) {
+	db := makeDatabase(t)
+	subject := db.Sequences[3].Seq
+
+	query := subject[len(subject)-100:] + subject[:100]
+	hits, err := db.MegaBlastN(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].Accession != "pBR322_replication_origin" {
+		t.Fatalf("expected a hit of pBR322_replication_origin, got %v", hits)
+	}
+
+	hsp := hits[0].Hsps[0]
+	if hsp.QueryFrom != 1 || hsp.QueryTo != 200 || hsp.HitFrom != len(subject)-99 || hsp.HitTo != 100 || *hsp.HitFrame != 1 {
+		t.Errorf("expected query 1..200 to hit %d..100 across the origin, got %d..%d to %d..%d", len(subject)-99, hsp.QueryFrom, hsp.QueryTo, hsp.HitFrom, hsp.HitTo)
+	}
+
+	exact, _, err := seqblast.AllExactMatches(hits)
+	if err != nil || len(exact) != 1 {
+		t.Errorf("expected an exact match, got %d: %v", len(exact), err)
+	}
+}
+
+func TestSearchOptions(t *testing.T) {
+	db := makeDatabase(t)
+	subject := db.Sequences[0].Seq
+
+	// one base in twenty differs
+	query := []byte(subject[:200])
+	for i := 10; i < len(query); i += 20 {
+		query[i] = wtype.Comp(string(query[i]))[0]
+	}
+
+	search := func(opt SearchOptions) []blast.Hit {
+		hits, err := db.Search(wtype.MakeLinearDNASequence("query", string(query)), opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hits
+	}
+
+	if hits := search(SearchOptions{MinSeeds: 1}); len(hits) == 0 {
+		t.Errorf("expected a hit with 19 matching bases in 20")
+	}
+	if hits := search(SearchOptions{MinSeeds: 1, MinIdentity: 0.96}); len(hits) != 0 {
+		t.Errorf("expected no hits with identity of 96%%, got %d", len(hits))
+	}
+
+	if _, err := db.MegaBlastN("ACGT"); err == nil {
+		t.Errorf("expected error searching with a query shorter than the word size")
+	}
+}
@@ -0,0 +1,549 @@
+// antha/AnthaStandardLibrary/Packages/sequences/codonopt/codonopt.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package codonopt chooses codons to encode a protein in an expression host
+// while avoiding sequence features which hinder cloning and synthesis
+package codonopt
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/enzymes/lookup"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/kmer"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// Names of the constraints in a Report
+const (
+	RestrictionSites = "restriction sites"
+	AvoidedSequences = "avoided sequences"
+	Homopolymers     = "homopolymers"
+	Repeats          = "repeats"
+	GCContent        = "GC content"
+	Synthesis        = "synthesis"
+)
+
+// Constraints on the DNA sequence chosen by Optimise. Zero values turn a
+// constraint off.
+type Constraints struct {
+	// AvoidEnzymes are names of restriction enzymes, from the rebase data,
+	// whose sites must not occur on either strand
+	AvoidEnzymes []string
+	// AvoidSequences must not occur on either strand; IUPAC codes may be used
+	AvoidSequences []string
+	// MaxHomopolymer is the longest run of a single base allowed
+	MaxHomopolymer int
+	// RepeatLength is the length of the shortest direct repeat not allowed
+	RepeatLength int
+	// GCWindow is the size of the windows whose GC content must be between
+	// MinGC and MaxGC
+	GCWindow     int
+	MinGC, MaxGC float64
+	// MinCodonFrequency is the lowest frequency, for its amino acid, of a
+	// codon which may be used
+	MinCodonFrequency float64
+	// Synthesis is a manufacturer in sequences.SynthesisStandards whose
+	// rules on homopolymers and GC content must be followed
+	Synthesis string
+}
+
+// DefaultConstraints avoid rare codons, long homopolymers and repeats and
+// extremes of GC content
+var DefaultConstraints = Constraints{
+	MaxHomopolymer:    8,
+	RepeatLength:      20,
+	GCWindow:          50,
+	MinGC:             0.3,
+	MaxGC:             0.7,
+	MinCodonFrequency: 0.1,
+}
+
+// ConstraintResult reports whether a constraint was met
+type ConstraintResult struct {
+	Constraint string
+	Satisfied  bool
+	Violations []string
+}
+
+// Report describes a sequence chosen by Optimise
+type Report struct {
+	Constraints []ConstraintResult
+	// CAI is the codon adaptation index of the sequence for the host
+	CAI       float64
+	GCContent float64
+	// SynthesisStatus is the outcome of sequences.ValidateSynthesis, if a
+	// manufacturer is given, which includes rules on length and order size
+	// which codon choice cannot meet
+	SynthesisStatus string
+}
+
+// Satisfied returns true if all constraints were met
+func (r Report) Satisfied() bool {
+	for _, c := range r.Constraints {
+		if !c.Satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Report) String() string {
+	lines := []string{fmt.Sprintf("CAI %.3f, GC content %.1f%%", r.CAI, 100*r.GCContent)}
+	for _, c := range r.Constraints {
+		status := "met"
+		if !c.Satisfied {
+			status = fmt.Sprintf("not met: %s", strings.Join(c.Violations, "; "))
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", c.Constraint, status))
+	}
+	if r.SynthesisStatus != "" {
+		lines = append(lines, r.SynthesisStatus)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// a violation of a constraint between start and end, counting from 0.
+// Severity measures how far the sequence is from meeting the constraint.
+type violation struct {
+	start, end  int
+	severity    float64
+	description string
+}
+
+type check struct {
+	constraint string
+	find       func(seq string) []violation
+}
+
+// siteCheck finds occurrences of sites, expanded from IUPAC codes, on either
+// strand
+func siteCheck(constraint string, names, sites []string) check {
+	patterns := make(map[int]map[string]string)
+	for i, site := range sites {
+		site = strings.ToUpper(strings.TrimSpace(site))
+		if site == "" {
+			continue
+		}
+		for _, s := range sequences.Wobble(site) {
+			for _, p := range []string{s, sequences.RevComp(s)} {
+				if patterns[len(p)] == nil {
+					patterns[len(p)] = make(map[string]string)
+				}
+				patterns[len(p)][p] = names[i]
+			}
+		}
+	}
+
+	return check{
+		constraint: constraint,
+		find: func(seq string) []violation {
+			var vs []violation
+			for i := range seq {
+				for n, ps := range patterns {
+					if i+n > len(seq) {
+						continue
+					}
+					if name, found := ps[seq[i:i+n]]; found {
+						vs = append(vs, violation{
+							start:       i,
+							end:         i + n,
+							severity:    1,
+							description: fmt.Sprintf("%s at %d", name, i+1),
+						})
+					}
+				}
+			}
+			return vs
+		},
+	}
+}
+
+func homopolymerCheck(constraint string, max int) check {
+	return check{
+		constraint: constraint,
+		find: func(seq string) []violation {
+			var vs []violation
+			for i := 0; i < len(seq); {
+				j := i
+				for j < len(seq) && seq[j] == seq[i] {
+					j++
+				}
+				if j-i > max {
+					vs = append(vs, violation{
+						start:       i,
+						end:         j,
+						severity:    float64(j - i - max),
+						description: fmt.Sprintf("%d %c at %d", j-i, seq[i], i+1),
+					})
+				}
+				i = j
+			}
+			return vs
+		},
+	}
+}
+
+func repeatCheck(constraint string, k int) check {
+	return check{
+		constraint: constraint,
+		find: func(seq string) []violation {
+			var vs []violation
+			for _, ps := range kmer.NewIndex(seq, k).Positions {
+				for _, p := range ps[1:] {
+					vs = append(vs, violation{
+						start:       p,
+						end:         p + k,
+						severity:    1,
+						description: fmt.Sprintf("repeat of %d at %d", ps[0]+1, p+1),
+					})
+				}
+			}
+			return vs
+		},
+	}
+}
+
+// gcCheck finds windows whose GC content is out of range. Overlapping
+// windows are merged into a single violation.
+func gcCheck(constraint string, window int, min, max float64) check {
+	return check{
+		constraint: constraint,
+		find: func(seq string) []violation {
+			w := window
+			if w <= 0 || w > len(seq) {
+				w = len(seq)
+			}
+			gc := make([]int, len(seq)+1)
+			for i := range seq {
+				gc[i+1] = gc[i]
+				if seq[i] == 'G' || seq[i] == 'C' {
+					gc[i+1]++
+				}
+			}
+
+			var vs []violation
+			for i := 0; i+w <= len(seq); i++ {
+				n := float64(gc[i+w] - gc[i])
+				excess := math.Max(n-max*float64(w), min*float64(w)-n)
+				if excess <= 0 {
+					continue
+				}
+				if last := len(vs) - 1; last >= 0 && vs[last].end >= i {
+					vs[last].end = i + w
+					vs[last].severity += excess
+					continue
+				}
+				vs = append(vs, violation{start: i, end: i + w, severity: excess})
+			}
+			for i := range vs {
+				vs[i].description = fmt.Sprintf("GC content of %d-%d outside %.0f-%.0f%% in %d bp windows", vs[i].start+1, vs[i].end, 100*min, 100*max, w)
+			}
+			return vs
+		},
+	}
+}
+
+func checks(c Constraints) ([]check, error) {
+	var cs []check
+
+	if len(c.AvoidEnzymes) != 0 {
+		var sites []string
+		for _, name := range c.AvoidEnzymes {
+			enzyme, err := lookup.RestrictionEnzyme(name)
+			if err != nil {
+				return nil, err
+			}
+			sites = append(sites, enzyme.RecognitionSequence)
+		}
+		cs = append(cs, siteCheck(RestrictionSites, c.AvoidEnzymes, sites))
+	}
+	if len(c.AvoidSequences) != 0 {
+		cs = append(cs, siteCheck(AvoidedSequences, c.AvoidSequences, c.AvoidSequences))
+	}
+	if c.MaxHomopolymer > 0 {
+		cs = append(cs, homopolymerCheck(Homopolymers, c.MaxHomopolymer))
+	}
+	if c.RepeatLength > 0 {
+		cs = append(cs, repeatCheck(Repeats, c.RepeatLength))
+	}
+	if c.MinGC > 0 || (c.MaxGC > 0 && c.MaxGC < 1) {
+		max := c.MaxGC
+		if max <= 0 {
+			max = 1
+		}
+		cs = append(cs, gcCheck(GCContent, c.GCWindow, c.MinGC, max))
+	}
+
+	if c.Synthesis != "" {
+		standards, found := sequences.SynthesisStandards[c.Synthesis]
+		if !found {
+			return nil, fmt.Errorf("unknown synthesis manufacturer %q", c.Synthesis)
+		}
+		// the rules of sequences.ValidateSynthesis
+		if repeatMax, ok := standards["RepeatMax"].(int); ok {
+			cs = append(cs, homopolymerCheck(Synthesis, repeatMax-1))
+		}
+		cs = append(cs, gcCheck(Synthesis, 0, sequences.MinSynthesisGC, sequences.MaxSynthesisGC),
+			gcCheck(Synthesis, sequences.SynthesisGCWindow, sequences.MinLocalSynthesisGC, sequences.MaxLocalSynthesisGC))
+	}
+	return cs, nil
+}
+
+// a codon option for an amino acid, with its cost relative to the most
+// frequent codon for the amino acid
+type option struct {
+	codon string
+	cost  float64
+}
+
+// options returns the codons which may be used for each amino acid, most
+// frequent first
+func options(table sequences.FrequencyTable, aa string, minFrequency float64) ([]option, error) {
+	set, found := table.CodonByAA[aa]
+	if !found || len(set) == 0 {
+		return nil, fmt.Errorf("no codons for %s in codon usage table %s", aa, table.TaxID)
+	}
+
+	var max float64
+	for _, f := range set {
+		max = math.Max(max, f)
+	}
+	if max <= 0 {
+		return nil, fmt.Errorf("no codons used for %s in codon usage table %s", aa, table.TaxID)
+	}
+
+	var opts []option
+	for _, codon := range set.Codons() {
+		f := set[codon]
+		if f <= 0 || (f < minFrequency && f < max) {
+			continue
+		}
+		opts = append(opts, option{codon: strings.ToUpper(codon), cost: -math.Log(f / max)})
+	}
+	sort.SliceStable(opts, func(i, j int) bool {
+		return opts[i].cost < opts[j].cost
+	})
+	return opts, nil
+}
+
+// maxIterations is the largest number of changes Optimise makes
+const maxIterations = 2000
+
+// maxCandidates is the largest number of codons considered for changing to
+// fix a violation
+const maxCandidates = 30
+
+// Optimise chooses codons to encode a protein using the most frequent codons
+// of a host which meet the constraints. Codons are first chosen by frequency
+// alone and then, while constraints are not met, changed one at a time near
+// violations to the codon which most reduces them. The sequence which comes
+// closest to meeting the constraints is returned with a report of each
+// constraint.
+func Optimise(protein wtype.ProteinSequence, table sequences.FrequencyTable, c Constraints) (wtype.DNASequence, Report, error) {
+	var report Report
+
+	aas := strings.ToUpper(protein.Seq)
+	if len(aas) == 0 {
+		return wtype.DNASequence{}, report, fmt.Errorf("no protein sequence to optimise for %s", protein.Nm)
+	}
+	if err := wtype.ValidAA(aas); err != nil {
+		return wtype.DNASequence{}, report, err
+	}
+
+	opts := make([][]option, len(aas))
+	for i := range aas {
+		var err error
+		if opts[i], err = options(table, aas[i:i+1], c.MinCodonFrequency); err != nil {
+			return wtype.DNASequence{}, report, err
+		}
+	}
+
+	cs, err := checks(c)
+	if err != nil {
+		return wtype.DNASequence{}, report, err
+	}
+
+	choice := make([]int, len(aas))
+	seq := make([]byte, 0, 3*len(aas))
+	for i := range aas {
+		seq = append(seq, opts[i][0].codon...)
+	}
+
+	violations := func(seq string) (vs []violation, score float64) {
+		for _, ch := range cs {
+			for _, v := range ch.find(seq) {
+				vs = append(vs, v)
+				score += v.severity
+			}
+		}
+		sortViolations(vs)
+		return
+	}
+	cost := func() (total float64) {
+		for i, o := range choice {
+			total += opts[i][o].cost
+		}
+		return
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	vs, score := violations(string(seq))
+	best, bestScore, bestCost := string(seq), score, cost()
+
+	for iter := 0; iter < maxIterations && len(vs) != 0; iter++ {
+		v := vs[rnd.Intn(len(vs))]
+
+		// codons overlapping the violation which have alternatives
+		var candidates []int
+		for i := v.start / 3; i <= (v.end-1)/3 && i < len(aas); i++ {
+			if len(opts[i]) > 1 {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) == 0 {
+			// this violation cannot be fixed; carry on with the others
+			var rest []violation
+			for _, other := range vs {
+				if other != v {
+					rest = append(rest, other)
+				}
+			}
+			vs = rest
+			continue
+		}
+		if len(candidates) > maxCandidates {
+			sample := make([]int, maxCandidates)
+			for k, j := range rnd.Perm(len(candidates))[:maxCandidates] {
+				sample[k] = candidates[j]
+			}
+			candidates = sample
+		}
+
+		bestI, bestO := -1, -1
+		var bestChangeScore, bestChangeCost float64
+		for _, i := range candidates {
+			old := choice[i]
+			for o := range opts[i] {
+				if o == old {
+					continue
+				}
+				copy(seq[3*i:], opts[i][o].codon)
+				_, s := violations(string(seq))
+				d := opts[i][o].cost - opts[i][old].cost
+				if bestI < 0 || s < bestChangeScore || (s == bestChangeScore && d < bestChangeCost) {
+					bestI, bestO, bestChangeScore, bestChangeCost = i, o, s, d
+				}
+			}
+			copy(seq[3*i:], opts[i][old].codon)
+		}
+
+		if bestChangeScore >= score {
+			// no single change helps, so make a random one to move on
+			bestI = candidates[rnd.Intn(len(candidates))]
+			bestO = rnd.Intn(len(opts[bestI]))
+		}
+		choice[bestI] = bestO
+		copy(seq[3*bestI:], opts[bestI][bestO].codon)
+
+		vs, score = violations(string(seq))
+		if total := cost(); score < bestScore || (score == bestScore && total < bestCost) {
+			best, bestScore, bestCost = string(seq), score, total
+		}
+	}
+
+	dna := wtype.MakeLinearDNASequence(protein.Nm, best)
+	report = makeReport(best, aas, table, cs)
+	if c.Synthesis != "" {
+		// checked as if cloned into the manufacturer's standard vector
+		var vector string
+		if vectors, ok := sequences.SynthesisStandards[c.Synthesis]["Vector"].([]string); ok && len(vectors) != 0 {
+			vector = vectors[0]
+		}
+		report.SynthesisStatus, _ = sequences.ValidateSynthesis([]wtype.DNASequence{dna}, vector, c.Synthesis)
+	}
+	return dna, report, nil
+}
+
+// sortViolations puts violations in order along the sequence so that the
+// choice between them doesn't depend on map iteration order
+func sortViolations(vs []violation) {
+	sort.Slice(vs, func(i, j int) bool {
+		if vs[i].start != vs[j].start {
+			return vs[i].start < vs[j].start
+		}
+		if vs[i].end != vs[j].end {
+			return vs[i].end < vs[j].end
+		}
+		return vs[i].description < vs[j].description
+	})
+}
+
+// makeReport reports the violations of each constraint by a sequence and its
+// codon adaptation index, the geometric mean of the frequency of each codon
+// relative to the most frequent codon for its amino acid
+func makeReport(seq, aas string, table sequences.FrequencyTable, cs []check) Report {
+	var r Report
+
+	index := make(map[string]int)
+	for _, ch := range cs {
+		i, found := index[ch.constraint]
+		if !found {
+			i = len(r.Constraints)
+			index[ch.constraint] = i
+			r.Constraints = append(r.Constraints, ConstraintResult{Constraint: ch.constraint, Satisfied: true})
+		}
+		vs := ch.find(seq)
+		sortViolations(vs)
+		for _, v := range vs {
+			r.Constraints[i].Satisfied = false
+			r.Constraints[i].Violations = append(r.Constraints[i].Violations, v.description)
+		}
+	}
+
+	r.GCContent = sequences.GCcontent(seq)
+
+	var logw float64
+	var n int
+	for i := range aas {
+		set := table.CodonByAA[aas[i:i+1]]
+		if len(set) < 2 {
+			continue
+		}
+		var max float64
+		for _, f := range set {
+			max = math.Max(max, f)
+		}
+		if f := set[seq[3*i:3*i+3]]; f > 0 {
+			logw += math.Log(f / max)
+			n++
+		}
+	}
+	if n != 0 {
+		r.CAI = math.Exp(logw / float64(n))
+	}
+	return r
+}
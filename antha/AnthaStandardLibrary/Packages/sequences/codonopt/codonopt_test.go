@@ -0,0 +1,136 @@
+package codonopt
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+func randomProtein(r *rand.Rand, n int) string {
+	aas := []byte("ACDEFGHIKLMNPQRSTVWY")
+	s := []byte{'M'}
+	for len(s) < n {
+		s = append(s, aas[r.Intn(len(aas))])
+	}
+	return string(s)
+}
+
+func translate(dna wtype.DNASequence) string {
+	var aas []string
+	for i := 0; i+3 <= len(dna.Seq); i += 3 {
+		aas = append(aas, sequences.Codontable[dna.Seq[i:i+3]])
+	}
+	return strings.Join(aas, "")
+}
+
+func TestOptimise(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// EcoRI sites, GAATTC, are made by EF and polyA by KKK
+	aas := randomProtein(r, 200) + "EFEFKKKKKKKK" + randomProtein(r, 100)[1:] + "*"
+	protein := wtype.ProteinSequence{Nm: "test", Seq: aas}
+
+	c := DefaultConstraints
+	c.AvoidEnzymes = []string{"EcoRI", "BsaI"}
+
+	for _, host := range []string{"E.Coli", "yeast", "CHO", "HEK293"} {
+		table, err := sequences.HostCodonTable(host)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dna, report, err := Optimise(protein, table, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := translate(dna); got != aas {
+			t.Errorf("%s: sequence does not encode the protein", host)
+		}
+		for _, site := range []string{"GAATTC", "GGTCTC", "GAGACC", "AAAAAAAAA", "TTTTTTTTT"} {
+			if strings.Contains(dna.Seq, site) {
+				t.Errorf("%s: sequence contains %s", host, site)
+			}
+		}
+		if !report.Satisfied() {
+			t.Errorf("%s: constraints not met:\n%s", host, report)
+		}
+		if report.CAI < 0.6 || report.CAI > 1 {
+			t.Errorf("%s: unexpected CAI %f", host, report.CAI)
+		}
+	}
+}
+
+func TestOptimiseAvoid(t *testing.T) {
+	// CTG is the most frequent codon for L in E. coli
+	aas := "M" + strings.Repeat("L", 20)
+	protein := wtype.ProteinSequence{Nm: "test", Seq: aas}
+
+	dna, report, err := Optimise(protein, sequences.EColiTable, Constraints{AvoidSequences: []string{"CTGCTG"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := translate(dna); got != aas {
+		t.Errorf("sequence does not encode the protein")
+	}
+	if strings.Contains(dna.Seq, "CTGCTG") || strings.Contains(dna.Seq, "CAGCAG") {
+		t.Errorf("sequence %s contains CTGCTG", dna.Seq)
+	}
+	if !report.Satisfied() || report.CAI >= 1 {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
+
+func TestOptimiseSynthesis(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	protein := wtype.ProteinSequence{Nm: "test", Seq: randomProtein(r, 300)}
+
+	c := DefaultConstraints
+	c.Synthesis = "EuroFins"
+
+	_, report, err := Optimise(protein, sequences.EColiTable, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Satisfied() {
+		t.Errorf("constraints not met:\n%s", report)
+	}
+	if report.GCContent < 0.40 || report.GCContent > 0.65 {
+		t.Errorf("GC content %f outside synthesis limits", report.GCContent)
+	}
+	if report.SynthesisStatus == "" {
+		t.Errorf("no synthesis status reported")
+	}
+}
+
+func TestOptimiseUnmet(t *testing.T) {
+	// only ATG encodes M
+	protein := wtype.ProteinSequence{Nm: "test", Seq: "MMM"}
+	c := Constraints{AvoidSequences: []string{"ATGATG"}}
+
+	dna, report, err := Optimise(protein, sequences.EColiTable, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dna.Seq != "ATGATGATG" {
+		t.Errorf("expected ATGATGATG, got %s", dna.Seq)
+	}
+	if report.Satisfied() || len(report.Constraints) != 1 || len(report.Constraints[0].Violations) != 2 {
+		t.Errorf("expected two violations of %s, got %v", AvoidedSequences, report.Constraints)
+	}
+}
+
+func TestOptimiseErrors(t *testing.T) {
+	protein := wtype.ProteinSequence{Nm: "test", Seq: "MKV"}
+
+	if _, _, err := Optimise(protein, sequences.EColiTable, Constraints{AvoidEnzymes: []string{"NotAnEnzyme"}}); err == nil {
+		t.Errorf("expected error for unknown enzyme")
+	}
+	if _, _, err := Optimise(protein, sequences.EColiTable, Constraints{Synthesis: "NotAManufacturer"}); err == nil {
+		t.Errorf("expected error for unknown manufacturer")
+	}
+	if _, _, err := Optimise(wtype.ProteinSequence{Nm: "bad", Seq: "MK1"}, sequences.EColiTable, DefaultConstraints); err == nil {
+		t.Errorf("expected error for invalid protein")
+	}
+}
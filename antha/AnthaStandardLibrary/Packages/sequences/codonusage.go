@@ -0,0 +1,409 @@
+// antha/AnthaStandardLibrary/Packages/sequences/codonusage.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package sequences
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// Codon usage tables of common expression hosts, from the Codon Usage
+// Database (http://www.kazusa.or.jp/codon/). Frequencies are the fraction of
+// codons for each amino acid.
+var (
+	// YeastTable is a frequency table for Saccharomyces cerevisiae.
+	YeastTable = FrequencyTable{
+		TaxID: "S.Cerevisiae",
+		CodonByAA: map[string]wtype.CodonSet{
+			"F": wtype.CodonSet{
+				"TTT": 0.59,
+				"TTC": 0.41,
+			},
+			"L": wtype.CodonSet{
+				"TTA": 0.28,
+				"TTG": 0.29,
+				"CTT": 0.13,
+				"CTC": 0.06,
+				"CTA": 0.14,
+				"CTG": 0.1,
+			},
+			"Y": wtype.CodonSet{
+				"TAT": 0.56,
+				"TAC": 0.44,
+			},
+			"*": wtype.CodonSet{
+				"TAA": 0.47,
+				"TAG": 0.23,
+				"TGA": 0.3,
+			},
+			"H": wtype.CodonSet{
+				"CAT": 0.64,
+				"CAC": 0.36,
+			},
+			"Q": wtype.CodonSet{
+				"CAA": 0.69,
+				"CAG": 0.31,
+			},
+			"I": wtype.CodonSet{
+				"ATT": 0.46,
+				"ATC": 0.26,
+				"ATA": 0.28,
+			},
+			"M": wtype.CodonSet{
+				"ATG": 1.0,
+			},
+			"N": wtype.CodonSet{
+				"AAT": 0.59,
+				"AAC": 0.41,
+			},
+			"K": wtype.CodonSet{
+				"AAA": 0.58,
+				"AAG": 0.42,
+			},
+			"V": wtype.CodonSet{
+				"GTT": 0.39,
+				"GTC": 0.21,
+				"GTA": 0.21,
+				"GTG": 0.19,
+			},
+			"D": wtype.CodonSet{
+				"GAT": 0.65,
+				"GAC": 0.35,
+			},
+			"E": wtype.CodonSet{
+				"GAA": 0.7,
+				"GAG": 0.3,
+			},
+			"S": wtype.CodonSet{
+				"TCT": 0.26,
+				"TCC": 0.16,
+				"TCA": 0.21,
+				"TCG": 0.1,
+				"AGT": 0.16,
+				"AGC": 0.11,
+			},
+			"C": wtype.CodonSet{
+				"TGT": 0.63,
+				"TGC": 0.37,
+			},
+			"W": wtype.CodonSet{
+				"TGG": 1.0,
+			},
+			"P": wtype.CodonSet{
+				"CCT": 0.31,
+				"CCC": 0.15,
+				"CCA": 0.42,
+				"CCG": 0.12,
+			},
+			"R": wtype.CodonSet{
+				"CGT": 0.14,
+				"CGC": 0.06,
+				"CGA": 0.07,
+				"CGG": 0.04,
+				"AGA": 0.48,
+				"AGG": 0.21,
+			},
+			"T": wtype.CodonSet{
+				"ACT": 0.35,
+				"ACC": 0.22,
+				"ACA": 0.3,
+				"ACG": 0.13,
+			},
+			"A": wtype.CodonSet{
+				"GCT": 0.38,
+				"GCC": 0.22,
+				"GCA": 0.29,
+				"GCG": 0.11,
+			},
+			"G": wtype.CodonSet{
+				"GGT": 0.47,
+				"GGC": 0.19,
+				"GGA": 0.22,
+				"GGG": 0.12,
+			},
+		},
+		AAByCodon: Codontable,
+	}
+
+	// CHOTable is a frequency table for Chinese hamster ovary cells (Cricetulus griseus).
+	CHOTable = FrequencyTable{
+		TaxID: "CHO",
+		CodonByAA: map[string]wtype.CodonSet{
+			"F": wtype.CodonSet{
+				"TTT": 0.47,
+				"TTC": 0.53,
+			},
+			"L": wtype.CodonSet{
+				"TTA": 0.06,
+				"TTG": 0.14,
+				"CTT": 0.13,
+				"CTC": 0.19,
+				"CTA": 0.08,
+				"CTG": 0.4,
+			},
+			"Y": wtype.CodonSet{
+				"TAT": 0.44,
+				"TAC": 0.56,
+			},
+			"*": wtype.CodonSet{
+				"TAA": 0.26,
+				"TAG": 0.22,
+				"TGA": 0.52,
+			},
+			"H": wtype.CodonSet{
+				"CAT": 0.44,
+				"CAC": 0.56,
+			},
+			"Q": wtype.CodonSet{
+				"CAA": 0.24,
+				"CAG": 0.76,
+			},
+			"I": wtype.CodonSet{
+				"ATT": 0.35,
+				"ATC": 0.51,
+				"ATA": 0.14,
+			},
+			"M": wtype.CodonSet{
+				"ATG": 1.0,
+			},
+			"N": wtype.CodonSet{
+				"AAT": 0.45,
+				"AAC": 0.55,
+			},
+			"K": wtype.CodonSet{
+				"AAA": 0.39,
+				"AAG": 0.61,
+			},
+			"V": wtype.CodonSet{
+				"GTT": 0.18,
+				"GTC": 0.24,
+				"GTA": 0.12,
+				"GTG": 0.46,
+			},
+			"D": wtype.CodonSet{
+				"GAT": 0.47,
+				"GAC": 0.53,
+			},
+			"E": wtype.CodonSet{
+				"GAA": 0.41,
+				"GAG": 0.59,
+			},
+			"S": wtype.CodonSet{
+				"TCT": 0.22,
+				"TCC": 0.22,
+				"TCA": 0.14,
+				"TCG": 0.05,
+				"AGT": 0.15,
+				"AGC": 0.22,
+			},
+			"C": wtype.CodonSet{
+				"TGT": 0.47,
+				"TGC": 0.53,
+			},
+			"W": wtype.CodonSet{
+				"TGG": 1.0,
+			},
+			"P": wtype.CodonSet{
+				"CCT": 0.31,
+				"CCC": 0.32,
+				"CCA": 0.29,
+				"CCG": 0.08,
+			},
+			"R": wtype.CodonSet{
+				"CGT": 0.11,
+				"CGC": 0.18,
+				"CGA": 0.14,
+				"CGG": 0.19,
+				"AGA": 0.19,
+				"AGG": 0.19,
+			},
+			"T": wtype.CodonSet{
+				"ACT": 0.26,
+				"ACC": 0.37,
+				"ACA": 0.29,
+				"ACG": 0.08,
+			},
+			"A": wtype.CodonSet{
+				"GCT": 0.32,
+				"GCC": 0.37,
+				"GCA": 0.23,
+				"GCG": 0.08,
+			},
+			"G": wtype.CodonSet{
+				"GGT": 0.2,
+				"GGC": 0.34,
+				"GGA": 0.26,
+				"GGG": 0.2,
+			},
+		},
+		AAByCodon: Codontable,
+	}
+
+	// HEKTable is a frequency table for human cells, such as HEK293.
+	HEKTable = FrequencyTable{
+		TaxID: "HEK293",
+		CodonByAA: map[string]wtype.CodonSet{
+			"F": wtype.CodonSet{
+				"TTT": 0.45,
+				"TTC": 0.55,
+			},
+			"L": wtype.CodonSet{
+				"TTA": 0.07,
+				"TTG": 0.13,
+				"CTT": 0.13,
+				"CTC": 0.2,
+				"CTA": 0.07,
+				"CTG": 0.4,
+			},
+			"Y": wtype.CodonSet{
+				"TAT": 0.43,
+				"TAC": 0.57,
+			},
+			"*": wtype.CodonSet{
+				"TAA": 0.28,
+				"TAG": 0.2,
+				"TGA": 0.52,
+			},
+			"H": wtype.CodonSet{
+				"CAT": 0.41,
+				"CAC": 0.59,
+			},
+			"Q": wtype.CodonSet{
+				"CAA": 0.25,
+				"CAG": 0.75,
+			},
+			"I": wtype.CodonSet{
+				"ATT": 0.36,
+				"ATC": 0.48,
+				"ATA": 0.16,
+			},
+			"M": wtype.CodonSet{
+				"ATG": 1.0,
+			},
+			"N": wtype.CodonSet{
+				"AAT": 0.46,
+				"AAC": 0.54,
+			},
+			"K": wtype.CodonSet{
+				"AAA": 0.42,
+				"AAG": 0.58,
+			},
+			"V": wtype.CodonSet{
+				"GTT": 0.18,
+				"GTC": 0.24,
+				"GTA": 0.11,
+				"GTG": 0.47,
+			},
+			"D": wtype.CodonSet{
+				"GAT": 0.46,
+				"GAC": 0.54,
+			},
+			"E": wtype.CodonSet{
+				"GAA": 0.42,
+				"GAG": 0.58,
+			},
+			"S": wtype.CodonSet{
+				"TCT": 0.18,
+				"TCC": 0.22,
+				"TCA": 0.15,
+				"TCG": 0.06,
+				"AGT": 0.15,
+				"AGC": 0.24,
+			},
+			"C": wtype.CodonSet{
+				"TGT": 0.45,
+				"TGC": 0.55,
+			},
+			"W": wtype.CodonSet{
+				"TGG": 1.0,
+			},
+			"P": wtype.CodonSet{
+				"CCT": 0.28,
+				"CCC": 0.33,
+				"CCA": 0.27,
+				"CCG": 0.12,
+			},
+			"R": wtype.CodonSet{
+				"CGT": 0.08,
+				"CGC": 0.19,
+				"CGA": 0.11,
+				"CGG": 0.21,
+				"AGA": 0.21,
+				"AGG": 0.2,
+			},
+			"T": wtype.CodonSet{
+				"ACT": 0.24,
+				"ACC": 0.36,
+				"ACA": 0.28,
+				"ACG": 0.12,
+			},
+			"A": wtype.CodonSet{
+				"GCT": 0.26,
+				"GCC": 0.4,
+				"GCA": 0.23,
+				"GCG": 0.11,
+			},
+			"G": wtype.CodonSet{
+				"GGT": 0.16,
+				"GGC": 0.34,
+				"GGA": 0.25,
+				"GGG": 0.25,
+			},
+		},
+		AAByCodon: Codontable,
+	}
+)
+
+// hostCodonTables are the codon usage tables of expression hosts, with
+// aliases, keyed by lower case name without punctuation
+var hostCodonTables = map[string]FrequencyTable{
+	"ecoli":                   EColiTable,
+	"escherichiacoli":         EColiTable,
+	"yeast":                   YeastTable,
+	"scerevisiae":             YeastTable,
+	"saccharomycescerevisiae": YeastTable,
+	"cho":                     CHOTable,
+	"hek":                     HEKTable,
+	"hek293":                  HEKTable,
+	"human":                   HEKTable,
+	"homosapiens":             HEKTable,
+}
+
+// HostCodonTable returns the codon usage table of an expression host; one of
+// E.Coli, yeast, CHO or HEK293. Case, spaces and punctuation are ignored.
+func HostCodonTable(host string) (FrequencyTable, error) {
+	key := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(host))
+
+	table, found := hostCodonTables[key]
+	if !found {
+		return FrequencyTable{}, fmt.Errorf("no codon usage table for host %q; valid hosts are E.Coli, yeast, CHO and HEK293", host)
+	}
+	return table, nil
+}
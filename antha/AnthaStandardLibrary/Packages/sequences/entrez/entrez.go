@@ -53,11 +53,18 @@ func appendError(err error, err2 error) (newErr error) {
 	return fmt.Errorf("Errors: " + err.Error() + ": " + err2.Error())
 }
 
-// This queries the selected database saving the record to bytes
+// RetrieveRecords returns up to Max records of a database matching a query,
+// from the DefaultProvider, as the contents of a file in the format ReturnType.
 // Database options are nucleotide, Protein, Gene. For full list see http://www.ncbi.nlm.nih.gov/books/NBK25497/table/chapter2.T._entrez_unique_identifiers_ui/?report=objectonly
-// Return type includes but must match the database type. See http://www.ncbi.nlm.nih.gov/books/NBK25499/table/chapter4.T._valid_values_of__retmode_and/?report=objectonly
+// Return type, e.g. "gb" or "fasta", must match the database type. See http://www.ncbi.nlm.nih.gov/books/NBK25499/table/chapter4.T._valid_values_of__retmode_and/?report=objectonly
 // Query can be any string but it is recommended to use GI number if one specific record is required.
 func RetrieveRecords(query string, database string, Max int, ReturnType string) (contentsinbytes []byte, err error) {
+	return DefaultProvider.RetrieveRecords(query, database, Max, ReturnType)
+}
+
+// RetrieveRecords retrieves records from the NCBI entrez service, trying each
+// request up to retries times
+func (NCBI) RetrieveRecords(query string, database string, Max int, ReturnType string) (contentsinbytes []byte, err error) {
 	// query database
 
 	h := biogo.History{}
@@ -49,3 +49,33 @@ func TestRetrieveRecords(t *testing.T) {
 		}
 	}
 }
+
+func TestRetrieveSequence(t *testing.T) {
+	defer func(p Provider) {
+		DefaultProvider = p
+	}(DefaultProvider)
+
+	DefaultProvider = Fixtures{
+		Key("X00001", "nucleotide", 1, "gb"): []byte(`LOCUS       X00001                    20 bp    DNA     linear   SYN 01-JAN-2017
+DEFINITION  test sequence.
+FEATURES             Location/Qualifiers
+     gene            1..10
+                     /gene="abc"
+ORIGIN
+        1 acgtacgtac gtacgtacgt
+//
+`),
+	}
+
+	seq, err := RetrieveVector("X00001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq.Seq != "ACGTACGTACGTACGTACGT" || len(seq.Features) != 1 || seq.Features[0].Name != "abc" {
+		t.Errorf("unexpected sequence %s with features %v", seq.Seq, seq.Features)
+	}
+
+	if _, err := RetrieveVector("X00002"); err == nil {
+		t.Errorf("expected error retrieving sequence without fixture")
+	}
+}
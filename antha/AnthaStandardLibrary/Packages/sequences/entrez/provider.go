@@ -0,0 +1,80 @@
+// antha/AnthaStandardLibrary/Packages/sequences/entrez/provider.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package entrez
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/remote"
+)
+
+// A Provider retrieves records from NCBI databases
+type Provider interface {
+	RetrieveRecords(query string, database string, Max int, ReturnType string) ([]byte, error)
+}
+
+// DefaultProvider is the provider used to retrieve records. By default
+// records from NCBI are cached in the antha directory.
+var DefaultProvider Provider = Cached(NCBI{}, newCache())
+
+// newCache returns the cache of records from NCBI. NCBI repeats failed
+// requests itself so the cache does not.
+func newCache() *remote.Cache {
+	cache := remote.NewCache("entrez")
+	cache.Retries = 0
+	return cache
+}
+
+// NCBI retrieves records from the NCBI entrez service
+type NCBI struct{}
+
+// Key returns the key of a query in caches and fixtures, i.e.,
+// <database>/<return type>/<max>/<query>
+func Key(query string, database string, Max int, ReturnType string) string {
+	return strings.Join([]string{database, ReturnType, strconv.Itoa(Max), query}, "/")
+}
+
+type cached struct {
+	provider Provider
+	cache    *remote.Cache
+}
+
+// Cached returns a provider which caches the records retrieved by another
+func Cached(p Provider, cache *remote.Cache) Provider {
+	return cached{provider: p, cache: cache}
+}
+
+func (c cached) RetrieveRecords(query string, database string, Max int, ReturnType string) ([]byte, error) {
+	return c.cache.Get(Key(query, database, Max, ReturnType), func() ([]byte, error) {
+		return c.provider.RetrieveRecords(query, database, Max, ReturnType)
+	})
+}
+
+// Fixtures are records keyed as by Key, for use in tests
+type Fixtures remote.Fixtures
+
+// RetrieveRecords returns the records of a query
+func (f Fixtures) RetrieveRecords(query string, database string, Max int, ReturnType string) ([]byte, error) {
+	return remote.Fixtures(f).Get(Key(query, database, Max, ReturnType))
+}
@@ -0,0 +1,48 @@
+// index.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package kmer
+
+// An Index holds the positions of each k-mer in a sequence, for finding
+// seeds of alignments quickly. Each lookup is a map access, so an Index
+// pays off over sequences.FindAll, which scans the whole sequence per
+// query, when many words are looked up in the same sequence.
+type Index struct {
+	K         int
+	Positions map[string][]int
+}
+
+// NewIndex returns an index of the k-mers of a sequence. Positions count
+// from 0.
+func NewIndex(s string, k int) *Index {
+	idx := &Index{K: k, Positions: make(map[string][]int)}
+	for i := 0; i+k <= len(s); i++ {
+		w := s[i : i+k]
+		idx.Positions[w] = append(idx.Positions[w], i)
+	}
+	return idx
+}
+
+// Lookup returns the positions of a k-mer in the indexed sequence
+func (idx *Index) Lookup(w string) []int {
+	return idx.Positions[w]
+}
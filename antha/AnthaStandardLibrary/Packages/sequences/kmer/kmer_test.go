@@ -36,3 +36,17 @@ func Test1(t *testing.T) {
 		t.Errorf("Expected first hit to be sequence 'There', instead got '", sr[0].Name, "'")
 	}
 }
+
+func TestIndex(t *testing.T) {
+	idx := NewIndex("ACGTACGTTT", 4)
+
+	if ps := idx.Lookup("ACGT"); len(ps) != 2 || ps[0] != 0 || ps[1] != 4 {
+		t.Errorf("expected ACGT at 0 and 4, got %v", ps)
+	}
+	if ps := idx.Lookup("CGTT"); len(ps) != 1 || ps[0] != 5 {
+		t.Errorf("expected CGTT at 5, got %v", ps)
+	}
+	if ps := idx.Lookup("GGGG"); len(ps) != 0 {
+		t.Errorf("expected no GGGG, got %v", ps)
+	}
+}
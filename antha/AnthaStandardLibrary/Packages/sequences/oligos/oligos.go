@@ -25,6 +25,7 @@ package oligos
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -40,6 +41,8 @@ type Primer struct {
 	GCContent   float64
 	Reverse     bool
 	MeltingTemp wunit.Temperature
+	Hairpin     SecondaryStructure
+	SelfDimer   SecondaryStructure
 }
 
 // checks for overlap between sequences (not including mismatches)
@@ -162,71 +165,131 @@ func DNAregion(sequence wtype.DNASequence, startposition int, endposition int) (
 
 }
 
-// Takes defined region and makes an oligosequence between a defined minimum and maximum length
-// with a melting temperature between a defined minimum and maximum and a maximum GC content ( between 0 and 1).
-// function finds oligo by starting at position 0 and making sequence of the minimum length, calculating parameters
-// and if they do not match then adds one basepair to end of sequence until the maximum length is reached.
-// if still unsuccessful, the function begins again at position 1 and cycles through until a matching oligo sequence is found.
-// overlapthresholdwithseqstoavoid allows maximum permissable partial overlap to be specified by the user, if set to -1 any overlap is tolerated
-func FWDOligoSeq(seq wtype.DNASequence, maxGCcontent float64, minlength int, maxlength int, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int) (oligoseq Primer, err error) {
+// PrimerOptions are the conditions under which candidate primers are scored
+// by FWDOligoSeq, REVOligoSeq and DesignPrimerstoFlankRegion. Candidate
+// primers forming hairpins or dimers with a lower ΔG (kcal/mol) than the
+// thresholds are rejected. Structures at the 3' end of a primer can be
+// extended by polymerases so have stricter thresholds.
+type PrimerOptions struct {
+	Conditions                 Conditions
+	HairpinThreshold           float64
+	ThreePrimeHairpinThreshold float64
+	DimerThreshold             float64
+	ThreePrimeDimerThreshold   float64
+}
 
-	if maxlength > len(seq.Sequence()) {
-		return oligoseq, fmt.Errorf("Sequence %s %s too small to design primer for or max length of primer %d too long", seq.Nm, seq.Seq, maxlength)
+// DefaultPrimerOptions returns the default conditions and thresholds, which
+// are also used by the functions designing primers that take no options
+func DefaultPrimerOptions() PrimerOptions {
+	return PrimerOptions{
+		Conditions:                 DefaultConditions(),
+		HairpinThreshold:           -3.0,
+		ThreePrimeHairpinThreshold: -2.0,
+		DimerThreshold:             -6.0,
+		ThreePrimeDimerThreshold:   -5.0,
 	}
+}
 
-	region := strings.ToUpper(seq.Sequence())
+// tooStable returns true if a structure is more stable than the thresholds
+func tooStable(s SecondaryStructure, threshold, threePrimeThreshold float64) bool {
+	if s.ThreePrime {
+		return s.DeltaG < threePrimeThreshold
+	}
+	return s.DeltaG < threshold
+}
 
-	for start := 0; start < maxlength; start++ {
+func (o PrimerOptions) hairpinTooStable(s SecondaryStructure) bool {
+	return tooStable(s, o.HairpinThreshold, o.ThreePrimeHairpinThreshold)
+}
 
-		for end := minlength + start; end <= start+maxlength; end++ {
+func (o PrimerOptions) dimerTooStable(s SecondaryStructure) bool {
+	return tooStable(s, o.DimerThreshold, o.ThreePrimeDimerThreshold)
+}
 
-			var overlapthresholdfail bool
+// scorePrimer returns a primer for an oligo binding a template if it meets
+// the design criteria, or otherwise the first criterion it fails
+func scorePrimer(template wtype.DNASequence, oligo string, maxGCcontent float64, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int, opts PrimerOptions) (Primer, error) {
 
-			tempoligoseq := region[start:end]
+	gc := sequences.GCcontent(oligo)
+	if gc > maxGCcontent {
+		return Primer{}, fmt.Errorf("gc content %f above maximum %f", gc, maxGCcontent)
+	}
+
+	if search.InStrings(seqstoavoid, oligo) {
+		return Primer{}, fmt.Errorf("in sequences to avoid")
+	}
+	if overlapthresholdwithseqstoavoid > 0 {
+		for _, seq := range seqstoavoid {
+			if _, overlap, _ := OverlapCheck(oligo, seq); overlap > overlapthresholdwithseqstoavoid {
+				return Primer{}, fmt.Errorf("overlaps %s by %d bases", seq, overlap)
+			}
+		}
+	}
 
-			ssoligo := wtype.MakeSingleStrandedDNASequence("oligo", tempoligoseq)
+	ssoligo := wtype.MakeSingleStrandedDNASequence("oligo", oligo)
 
-			temppercentage := sequences.GCcontent(tempoligoseq)
+	meltingtemp, err := MeltingTemp(ssoligo, opts.Conditions)
+	if err != nil {
+		return Primer{}, err
+	}
+	if minmeltingtemp.SIValue() >= meltingtemp.SIValue() || maxmeltingtemp.SIValue() <= meltingtemp.SIValue() {
+		return Primer{}, fmt.Errorf("melting temp %s out of range", meltingtemp.ToString())
+	}
 
-			meltingtemp := BasicMeltingTemp(ssoligo)
+	if bindingsites := CheckNonSpecificBinding(template, ssoligo); bindingsites != 1 {
+		return Primer{}, fmt.Errorf("%d binding sites", bindingsites)
+	}
 
-			bindingsites := CheckNonSpecificBinding(seq, ssoligo)
+	hairpin, err := Hairpin(oligo, opts.Conditions)
+	if err != nil {
+		return Primer{}, err
+	}
+	if opts.hairpinTooStable(hairpin) {
+		return Primer{}, fmt.Errorf("hairpin %s", hairpin)
+	}
 
-			if len(seqstoavoid) > 0 && overlapthresholdwithseqstoavoid > 0 {
-				for _, seq := range seqstoavoid {
-					_, overlap, _ := OverlapCheck(tempoligoseq, seq)
+	dimer, err := SelfDimer(oligo, opts.Conditions)
+	if err != nil {
+		return Primer{}, err
+	}
+	if opts.dimerTooStable(dimer) {
+		return Primer{}, fmt.Errorf("self dimer %s", dimer)
+	}
 
-					if overlap > overlapthresholdwithseqstoavoid {
-						overlapthresholdfail = true
-					}
-				}
-			}
+	return Primer{
+		DNASequence: wtype.MakeSingleStrandedDNASequence("Primer", oligo),
+		Length:      len(oligo),
+		GCContent:   gc,
+		MeltingTemp: meltingtemp,
+		Hairpin:     hairpin,
+		SelfDimer:   dimer,
+	}, nil
+}
 
-			if temppercentage <= maxGCcontent && minmeltingtemp.SIValue() < meltingtemp.SIValue() && maxmeltingtemp.SIValue() > meltingtemp.SIValue() && bindingsites == 1 && search.InStrings(seqstoavoid, tempoligoseq) == false && overlapthresholdfail == false {
-				oligoseq.DNASequence = wtype.MakeSingleStrandedDNASequence("Primer", tempoligoseq)
-				oligoseq.GCContent = temppercentage
-				oligoseq.Length = len(tempoligoseq)
-				oligoseq.MeltingTemp = meltingtemp
-				err = nil
-				return
+// Takes defined region and makes an oligosequence between a defined minimum and maximum length
+// with a melting temperature between a defined minimum and maximum and a maximum GC content ( between 0 and 1).
+// function finds oligo by starting at position 0 and making sequence of the minimum length, calculating parameters
+// and if they do not match then adds one basepair to end of sequence until the maximum length is reached.
+// if still unsuccessful, the function begins again at position 1 and cycles through until a matching oligo sequence is found.
+// overlapthresholdwithseqstoavoid allows maximum permissable partial overlap to be specified by the user, if set to -1 any overlap is tolerated
+//
+// Melting temperatures are calculated by MeltingTemp under the conditions of
+// opts and oligos forming hairpins or self dimers more stable than its
+// thresholds are rejected.
+func FWDOligoSeq(seq wtype.DNASequence, maxGCcontent float64, minlength int, maxlength int, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int, opts PrimerOptions) (oligoseq Primer, err error) {
 
-			} else {
-				err = fmt.Errorf("No primers found matching criteria: "+" last bindingsites = "+strconv.Itoa(bindingsites)+" last gc percentage= ", temppercentage, "last melting temp:", meltingtemp.ToString())
-			}
-		}
+	if maxlength > len(seq.Sequence()) {
+		return oligoseq, fmt.Errorf("Sequence %s %s too small to design primer for or max length of primer %d too long", seq.Nm, seq.Seq, maxlength)
 	}
 
-	//}else {
-	//	// fmt.Println("no oligos")
-	//	}
+	region := strings.ToUpper(seq.Sequence())
 
-	return
+	return firstPrimer(seq, region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, seqstoavoid, overlapthresholdwithseqstoavoid, opts)
 }
 
-func REVOligoSeq(seq wtype.DNASequence, maxGCcontent float64, minlength int, maxlength int, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int) (oligoseq Primer, err error) {
-
-	//var start int
-	//var end int
+// REVOligoSeq designs a primer as FWDOligoSeq does but binding the end of
+// the sequence
+func REVOligoSeq(seq wtype.DNASequence, maxGCcontent float64, minlength int, maxlength int, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int, opts PrimerOptions) (oligoseq Primer, err error) {
 
 	// get the reverse complement of the region
 
@@ -234,46 +297,30 @@ func REVOligoSeq(seq wtype.DNASequence, maxGCcontent float64, minlength int, max
 		return oligoseq, fmt.Errorf("Sequence %s %s too small to design primer for or max length of primer %d too long", seq.Nm, seq.Seq, maxlength)
 	}
 
-	region := seq.Sequence()
+	region := strings.ToUpper(seq.Sequence())
 	revregion := sequences.RevComp(region)
 
-	for start := 0; start < maxlength; start++ {
-
-		for end := minlength + start; end <= start+maxlength; end++ {
-
-			var overlapthresholdfail bool
-
-			tempoligoseq := revregion[start:end]
+	return firstPrimer(seq, revregion, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, seqstoavoid, overlapthresholdwithseqstoavoid, opts)
+}
 
-			ssoligo := wtype.MakeSingleStrandedDNASequence("oligo", tempoligoseq)
+// firstPrimer returns the first oligo from the start of a region which meets
+// the design criteria
+func firstPrimer(seq wtype.DNASequence, region string, maxGCcontent float64, minlength int, maxlength int, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int, opts PrimerOptions) (oligoseq Primer, err error) {
 
-			temppercentage := sequences.GCcontent(tempoligoseq)
+	err = fmt.Errorf("No primers found matching criteria")
 
-			meltingtemp := BasicMeltingTemp(ssoligo)
+	for start := 0; start < maxlength; start++ {
 
-			bindingsites := CheckNonSpecificBinding(seq, ssoligo)
+		for end := minlength + start; end <= start+maxlength && end <= len(region); end++ {
 
-			if len(seqstoavoid) > 0 && overlapthresholdwithseqstoavoid > 0 {
-				for _, seq := range seqstoavoid {
-					_, overlap, _ := OverlapCheck(tempoligoseq, seq)
+			tempoligoseq := region[start:end]
 
-					if overlap > overlapthresholdwithseqstoavoid {
-						overlapthresholdfail = true
-					}
-				}
+			primer, failed := scorePrimer(seq, tempoligoseq, maxGCcontent, minmeltingtemp, maxmeltingtemp, seqstoavoid, overlapthresholdwithseqstoavoid, opts)
+			if failed == nil {
+				return primer, nil
 			}
 
-			if temppercentage <= maxGCcontent && minmeltingtemp.SIValue() < meltingtemp.SIValue() && maxmeltingtemp.SIValue() > meltingtemp.SIValue() && bindingsites == 1 && search.InStrings(seqstoavoid, tempoligoseq) == false && overlapthresholdfail == false {
-				oligoseq.DNASequence = wtype.MakeSingleStrandedDNASequence("Primer", tempoligoseq)
-				oligoseq.GCContent = temppercentage
-				oligoseq.Length = len(tempoligoseq)
-				oligoseq.MeltingTemp = meltingtemp
-				err = nil
-				return
-
-			} else {
-				err = fmt.Errorf("No primers found matching criteria: "+" last bindingsites = "+strconv.Itoa(bindingsites)+" last gc percentage= ", temppercentage, "last melting temp:", meltingtemp.ToString())
-			}
+			err = fmt.Errorf("No primers found matching criteria: last oligo %s: %s", tempoligoseq, failed)
 		}
 	}
 
@@ -295,7 +342,7 @@ func DesignFWDPRimerstoCoverFullSequence(seq wtype.DNASequence, sequenceinterval
 
 		region := DNAregion(seq, i, len(seq.Sequence()))
 
-		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid)
+		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid, DefaultPrimerOptions())
 
 		if err != nil {
 			panic(err.Error() + " for " + region.Nm)
@@ -332,7 +379,7 @@ func DesignFWDPRimerstoCoverRegion(seq wtype.DNASequence, regionstart, regionend
 
 		region := DNAregion(seq, i, len(seq.Sequence()))
 
-		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid)
+		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid, DefaultPrimerOptions())
 
 		if err != nil {
 			panic(err.Error() + " for " + region.Nm)
@@ -347,44 +394,84 @@ func DesignFWDPRimerstoCoverRegion(seq wtype.DNASequence, regionstart, regionend
 	return
 }
 
-/*
-func DesignPrimerstoFlankRegion(seq wtype.DNASequence, regionstart, regionend int, maxGCcontent float64, minlength int, maxlength int, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int) (primers [2]Primer) {
-
-	primers = make([]Primer, 2)
+// DesignPrimerstoFlankRegion designs a forward and reverse primer to amplify
+// the region of a sequence between regionstart and regionend, counting from
+// 1. Primers bind within 100 bp of the region and meet the criteria of
+// FWDOligoSeq. Of the pairs which do not form stable dimers with each other,
+// the pair with the closest melting temperatures is chosen, preferring
+// primers close to the region.
+func DesignPrimerstoFlankRegion(seq wtype.DNASequence, regionstart, regionend int, maxGCcontent float64, minlength int, maxlength int, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int, opts PrimerOptions) (primers [2]Primer, err error) {
+
+	const flank = 100
+	// candidates per primer considered when pairing
+	const maxCandidates = 50
+
+	dna := strings.ToUpper(seq.Sequence())
+	if regionstart < 1 || regionend > len(dna) || regionstart > regionend {
+		return primers, fmt.Errorf("region %d:%d not within sequence %s of length %d", regionstart, regionend, seq.Nm, len(dna))
+	}
 
-	avoidthese := make([]string, 0)
+	type candidate struct {
+		primer   Primer
+		distance int
+	}
 
-	if len(seqstoavoid) != 0 {
-		for _, seq := range seqstoavoid {
-			avoidthese = append(avoidthese, seq)
+	score := func(oligo string, distance int, cs []candidate) []candidate {
+		primer, failed := scorePrimer(seq, oligo, maxGCcontent, minmeltingtemp, maxmeltingtemp, seqstoavoid, overlapthresholdwithseqstoavoid, opts)
+		if failed != nil {
+			return cs
 		}
+		return append(cs, candidate{primer: primer, distance: distance})
 	}
 
-	if regionstart-100 > 0 {
-		regionstart = regionstart - 100
-	} else {
-		regionstart = 0
+	// forward primers end before the region and reverse primers start after
+	// it; candidates are found closest to the region first
+	var fwds, revs []candidate
+	for end := regionstart - 1; end >= minlength && end >= regionstart-1-flank && len(fwds) < maxCandidates; end-- {
+		for l := minlength; l <= maxlength && l <= end; l++ {
+			fwds = score(dna[end-l:end], regionstart-1-end, fwds)
+		}
 	}
-
-	for i := regionstart; i < regionend; i = i + sequenceinterval {
-
-		region := DNAregion(seq, i, len(seq.Sequence()))
-
-		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid)
-
-		if err != nil {
-			panic(err.Error() + " for " + region.Nm)
+	for start := regionend; start+minlength <= len(dna) && start <= regionend+flank && len(revs) < maxCandidates; start++ {
+		for l := minlength; l <= maxlength && start+l <= len(dna); l++ {
+			revs = score(sequences.RevComp(dna[start:start+l]), start-regionend, revs)
 		}
+	}
 
-		primer.Nm = "primer_" + seq.Nm + "_" + strconv.Itoa(i) + ":" + strconv.Itoa(i-1+sequenceinterval)
+	if len(fwds) == 0 {
+		return primers, fmt.Errorf("no forward primers found matching criteria upstream of %d in %s", regionstart, seq.Nm)
+	}
+	if len(revs) == 0 {
+		return primers, fmt.Errorf("no reverse primers found matching criteria downstream of %d in %s", regionend, seq.Nm)
+	}
 
-		primers = append(primers, primer)
+	bestPenalty := math.Inf(1)
+	for _, fwd := range fwds {
+		for _, rev := range revs {
+			dimer, err := HeteroDimer(fwd.primer.Sequence(), rev.primer.Sequence(), opts.Conditions)
+			if err != nil {
+				return primers, err
+			}
+			if opts.dimerTooStable(dimer) {
+				continue
+			}
+			penalty := math.Abs(fwd.primer.MeltingTemp.SIValue()-rev.primer.MeltingTemp.SIValue()) + 0.02*float64(fwd.distance+rev.distance)
+			if penalty < bestPenalty {
+				bestPenalty = penalty
+				primers = [2]Primer{fwd.primer, rev.primer}
+			}
+		}
+	}
 
-		avoidthese = append(avoidthese, primer.Sequence())
+	if math.IsInf(bestPenalty, 1) {
+		return primers, fmt.Errorf("all primer pairs flanking %d:%d in %s form stable dimers", regionstart, regionend, seq.Nm)
 	}
-	return
+
+	primers[0].Nm = "primer_" + seq.Nm + "_" + strconv.Itoa(regionstart) + ":" + strconv.Itoa(regionend) + "_fwd"
+	primers[1].Nm = "primer_" + seq.Nm + "_" + strconv.Itoa(regionstart) + ":" + strconv.Itoa(regionend) + "_rev"
+	primers[1].Reverse = true
+	return primers, nil
 }
-*/
 
 func DesignFWDPRimerstoCoverSequence(seq wtype.DNASequence, targetseq string, sequenceinterval int, maxGCcontent float64, minlength int, maxlength int, minmeltingtemp wunit.Temperature, maxmeltingtemp wunit.Temperature, seqstoavoid []string, overlapthresholdwithseqstoavoid int) (primers []Primer) {
 
@@ -429,7 +516,7 @@ func DesignFWDPRimerstoCoverSequence(seq wtype.DNASequence, targetseq string, se
 
 		region := DNAregion(seq, i, len(seq.Sequence()))
 
-		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid)
+		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid, DefaultPrimerOptions())
 
 		if err != nil {
 			panic(err.Error() + " for " + region.Nm)
@@ -521,7 +608,7 @@ func DesignFWDPRimerstoCoverFeature(seq wtype.DNASequence, targetfeaturename str
 
 		region := DNAregion(seq, i, len(seq.Sequence()))
 
-		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid)
+		primer, err := FWDOligoSeq(region, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, avoidthese, overlapthresholdwithseqstoavoid, DefaultPrimerOptions())
 
 		if err != nil {
 			panic(err.Error() + " for " + region.Nm)
@@ -547,7 +634,7 @@ func MakeOutwardFacingPrimers(sequence wtype.DNASequence, maxGCcontent float64,
 
 	endstartingpoint := wtype.MakeLinearDNASequence("endprimer", sequence.Sequence()[len(sequence.Sequence())-100:len(sequence.Sequence())-1])
 
-	oligoforpartsafter, _ = FWDOligoSeq(endstartingpoint, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, seqstoavoid, overlapthresholdwithseqstoavoid)
+	oligoforpartsafter, _ = FWDOligoSeq(endstartingpoint, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, seqstoavoid, overlapthresholdwithseqstoavoid, DefaultPrimerOptions())
 
 	// now reverse
 	reversesequence := wtype.RevComp(sequence.Sequence())
@@ -556,7 +643,7 @@ func MakeOutwardFacingPrimers(sequence wtype.DNASequence, maxGCcontent float64,
 
 	endstartingpoint = wtype.MakeLinearDNASequence("endprimer", reversesequence[len(reversesequence)-100:len(reversesequence)-1])
 
-	oligoforpartsbefore, _ = FWDOligoSeq(endstartingpoint, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, seqstoavoid, overlapthresholdwithseqstoavoid)
+	oligoforpartsbefore, _ = FWDOligoSeq(endstartingpoint, maxGCcontent, minlength, maxlength, minmeltingtemp, maxmeltingtemp, seqstoavoid, overlapthresholdwithseqstoavoid, DefaultPrimerOptions())
 
 	oligoforpartsbefore.Reverse = true
 
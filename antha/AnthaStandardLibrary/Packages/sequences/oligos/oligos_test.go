@@ -98,7 +98,7 @@ func TestBasicMeltingTemp(t *testing.T) {
 
 func TestFWDOligoSeq(t *testing.T) {
 	for _, oligo := range oligotests {
-		oligoseq, err := FWDOligoSeq(oligo.sequence, oligo.maxGCcontent, oligo.minlength, oligo.maxlength, oligo.mintemp, oligo.maxtemp, oligo.seqstoavoid, oligo.overlapthreshold)
+		oligoseq, err := FWDOligoSeq(oligo.sequence, oligo.maxGCcontent, oligo.minlength, oligo.maxlength, oligo.mintemp, oligo.maxtemp, oligo.seqstoavoid, oligo.overlapthreshold, DefaultPrimerOptions())
 		if oligoseq.Sequence() != oligo.outputoligoseq {
 			t.Error(
 				"For", oligo.sequence, "\n",
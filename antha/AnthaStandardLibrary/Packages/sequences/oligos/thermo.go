@@ -0,0 +1,436 @@
+// Part of the Antha language
+// Copyright (C) 2015 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package oligos
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// gasConstant in cal/(K mol)
+const gasConstant = 1.9872
+
+// Conditions of hybridisation used to calculate melting temperatures and the
+// stability of secondary structures
+type Conditions struct {
+	// OligoConc is the concentration of the oligo; the template is assumed
+	// to be at a much lower concentration
+	OligoConc wunit.Concentration
+	// Monovalent is the concentration of monovalent cations, i.e., Na+ or K+
+	Monovalent wunit.Concentration
+	// Magnesium is the concentration of Mg2+
+	Magnesium wunit.Concentration
+	// DNTPs is the total concentration of dNTPs, which bind Mg2+
+	DNTPs wunit.Concentration
+	// Temperature at which the stability of secondary structures is
+	// calculated; 37 ℃ if not given
+	Temperature wunit.Temperature
+}
+
+// DefaultConditions returns the standard conditions of 50 nM oligo and 50 mM
+// Na+ assumed by BasicMeltingTemp
+func DefaultConditions() Conditions {
+	return Conditions{
+		OligoConc:  wunit.NewConcentration(50, "nM"),
+		Monovalent: wunit.NewConcentration(50, "mM"),
+	}
+}
+
+// PCRConditions returns typical conditions of a PCR with 1.5 mM Mg2+ and 0.2
+// mM of each dNTP
+func PCRConditions() Conditions {
+	return Conditions{
+		OligoConc:  wunit.NewConcentration(250, "nM"),
+		Monovalent: wunit.NewConcentration(50, "mM"),
+		Magnesium:  wunit.NewConcentration(1.5, "mM"),
+		DNTPs:      wunit.NewConcentration(0.8, "mM"),
+	}
+}
+
+// kelvin returns the temperature at which stabilities are calculated in K
+func (c Conditions) kelvin() float64 {
+	if c.Temperature.IsNil() {
+		return 310.15
+	}
+	return c.Temperature.SIValue() + 273.15
+}
+
+// freeMagnesium returns the molar concentration of Mg2+ not bound to dNTPs,
+// using an association constant of 3e4 per M (Owczarzy et al. 2008)
+func (c Conditions) freeMagnesium() float64 {
+	const ka = 3.0e4
+	mg, dntp := c.Magnesium.SIValue(), c.DNTPs.SIValue()
+	if mg <= 0 {
+		return 0
+	}
+	if dntp <= 0 {
+		return mg
+	}
+	b := ka*dntp - ka*mg + 1
+	return (-b + math.Sqrt(b*b+4*ka*mg)) / (2 * ka)
+}
+
+// sodiumEquivalent returns the molar concentration of Na+ with the same
+// effect on stability as the cations present (von Ahsen et al. 2001)
+func (c Conditions) sodiumEquivalent() float64 {
+	na := c.Monovalent.SIValue() + 120*math.Sqrt(c.freeMagnesium())
+	if na <= 0 {
+		// avoid ln(0) in the absence of any cations
+		return 1.0e-3
+	}
+	return na
+}
+
+// nnParam is the change in enthalpy (kcal/mol) and entropy (cal/(K mol)) of a
+// nearest neighbour pair or other contribution to duplex stability
+type nnParam struct {
+	dH, dS float64
+}
+
+func (p nnParam) add(q nnParam) nnParam {
+	return nnParam{dH: p.dH + q.dH, dS: p.dS + q.dS}
+}
+
+// deltaG returns the change in free energy (kcal/mol) at a temperature in K
+func (p nnParam) deltaG(t float64) float64 {
+	return p.dH - t*p.dS/1000
+}
+
+// unifiedNN are the unified nearest neighbour parameters at 1 M NaCl of
+// SantaLucia (1998) Proc Natl Acad Sci USA 95:1460-1465, indexed by the
+// dinucleotide of the top strand
+var unifiedNN = map[string]nnParam{
+	"AA": {-7.9, -22.2}, "TT": {-7.9, -22.2},
+	"AT": {-7.2, -20.4},
+	"TA": {-7.2, -21.3},
+	"CA": {-8.5, -22.7}, "TG": {-8.5, -22.7},
+	"GT": {-8.4, -22.4}, "AC": {-8.4, -22.4},
+	"CT": {-7.8, -21.0}, "AG": {-7.8, -21.0},
+	"GA": {-8.2, -22.2}, "TC": {-8.2, -22.2},
+	"CG": {-10.6, -27.2},
+	"GC": {-9.8, -24.4},
+	"GG": {-8.0, -19.9}, "CC": {-8.0, -19.9},
+}
+
+var (
+	// initiation with a terminal G·C or A·T pair, applied to each end
+	initGC = nnParam{0.1, -2.8}
+	initAT = nnParam{2.3, 4.1}
+	// symmetry correction for self complementary duplexes
+	symmetry = nnParam{0, -1.4}
+)
+
+func initiation(b byte) nnParam {
+	if b == 'A' || b == 'T' {
+		return initAT
+	}
+	return initGC
+}
+
+// stack returns the sum of the nearest neighbour parameters of a perfectly
+// paired stretch of sequence
+func stack(seq string) nnParam {
+	var p nnParam
+	for i := 0; i+1 < len(seq); i++ {
+		p = p.add(unifiedNN[seq[i:i+2]])
+	}
+	return p
+}
+
+func checkBases(seq string) error {
+	for i := 0; i < len(seq); i++ {
+		switch seq[i] {
+		case 'A', 'C', 'G', 'T':
+		default:
+			return fmt.Errorf("cannot calculate thermodynamics of sequence with base %q at position %d", seq[i], i+1)
+		}
+	}
+	return nil
+}
+
+func complement(b byte) byte {
+	switch b {
+	case 'A':
+		return 'T'
+	case 'T':
+		return 'A'
+	case 'C':
+		return 'G'
+	case 'G':
+		return 'C'
+	}
+	return 0
+}
+
+func pairs(a, b byte) bool {
+	return complement(a) == b
+}
+
+func selfComplementary(seq string) bool {
+	for i := 0; i < len(seq); i++ {
+		if !pairs(seq[i], seq[len(seq)-1-i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func gcFraction(seq string) float64 {
+	return float64(strings.Count(seq, "G")+strings.Count(seq, "C")) / float64(len(seq))
+}
+
+// saltCorrection returns the melting temperature in K of a duplex which
+// melts at tm K in 1 M Na+, following Owczarzy et al. (2004) Biochemistry
+// 43:3537-3554 for monovalent cations and Owczarzy et al. (2008)
+// Biochemistry 47:5336-5353 where Mg2+ is significant
+func saltCorrection(tm float64, seq string, c Conditions) float64 {
+	mon := c.Monovalent.SIValue()
+	mg := c.freeMagnesium()
+	fgc := gcFraction(seq)
+	n := float64(len(seq))
+
+	monovalent := func() float64 {
+		ln := math.Log(mon)
+		return 1/tm + (4.29*fgc-3.95)*1.0e-5*ln + 9.40e-6*ln*ln
+	}
+
+	if mg <= 0 {
+		if mon <= 0 {
+			return tm
+		}
+		return 1 / monovalent()
+	}
+
+	a, b, cc, d, e, f, g := 3.92e-5, -9.11e-6, 6.26e-5, 1.42e-5, -4.82e-4, 5.25e-4, 8.31e-5
+	if mon > 0 {
+		r := math.Sqrt(mg) / mon
+		if r < 0.22 {
+			return 1 / monovalent()
+		}
+		if r < 6.0 {
+			lm := math.Log(mon)
+			a = 3.92e-5 * (0.843 - 0.352*math.Sqrt(mon)*lm)
+			d = 1.42e-5 * (1.279 - 4.03e-3*lm - 8.03e-3*lm*lm)
+			g = 8.31e-5 * (0.486 - 0.258*lm + 5.25e-3*lm*lm*lm)
+		}
+	}
+	lmg := math.Log(mg)
+	inv := 1/tm + a + b*lmg + fgc*(cc+d*lmg) + (e+f*lmg+g*lmg*lmg)/(2*(n-1))
+	return 1 / inv
+}
+
+/*
+MeltingTemp calculates the melting temperature of an oligo binding its
+perfect complement using the unified nearest neighbour parameters of
+SantaLucia (1998) Proc Natl Acad Sci USA 95:1460-1465.
+
+The melting temperature is corrected for the concentrations of oligo,
+monovalent cations, Mg2+ and dNTPs given in the conditions. Mg2+ bound by
+dNTPs is not available to stabilise the duplex.
+*/
+func MeltingTemp(oligo wtype.DNASequence, c Conditions) (wunit.Temperature, error) {
+	seq := strings.ToUpper(oligo.Sequence())
+	if len(seq) < 2 {
+		return wunit.Temperature{}, fmt.Errorf("oligo %s too short to calculate melting temperature", oligo.Nm)
+	}
+	if err := checkBases(seq); err != nil {
+		return wunit.Temperature{}, err
+	}
+
+	p := stack(seq).add(initiation(seq[0])).add(initiation(seq[len(seq)-1]))
+
+	ct := c.OligoConc.SIValue()
+	if ct <= 0 {
+		return wunit.Temperature{}, fmt.Errorf("oligo concentration must be positive")
+	}
+	x := 4.0
+	if selfComplementary(seq) {
+		p = p.add(symmetry)
+		x = 1.0
+	}
+
+	tm := 1000 * p.dH / (p.dS + gasConstant*math.Log(ct/x))
+	tm = saltCorrection(tm, seq, c)
+
+	return wunit.NewTemperature(tm-273.15, "℃"), nil
+}
+
+// A SecondaryStructure is the most stable structure of a kind found in one
+// or two oligos. Only perfectly paired stems are considered; structures
+// stabilised by mismatches or bulges are not found.
+type SecondaryStructure struct {
+	// DeltaG is the change in free energy on forming the structure in
+	// kcal/mol; structures with lower values are more stable. Zero if no
+	// structure is found.
+	DeltaG float64
+	// Stem is the sequence of the paired bases of the first oligo
+	Stem string
+	// Positions of the paired bases, counting from 0 from the 5' end of each
+	// oligo. For hairpins, the second position is in the same oligo.
+	Start1, End1 int
+	Start2, End2 int
+	// ThreePrime is true if the 3' end of either oligo is paired. Such
+	// structures can be extended by polymerases.
+	ThreePrime bool
+}
+
+// Found returns true if a structure was found
+func (s SecondaryStructure) Found() bool {
+	return s.DeltaG < 0 || len(s.Stem) != 0
+}
+
+// String returns a description of the structure
+func (s SecondaryStructure) String() string {
+	if !s.Found() {
+		return "no structure"
+	}
+	end := ""
+	if s.ThreePrime {
+		end = " at 3' end"
+	}
+	return fmt.Sprintf("%s (%d-%d/%d-%d)%s, ΔG %.2f kcal/mol", s.Stem, s.Start1+1, s.End1+1, s.Start2+1, s.End2+1, end, s.DeltaG)
+}
+
+// saltEntropy returns the correction to the entropy of a duplex of n pairs
+// for the cations present (SantaLucia 1998)
+func saltEntropy(n int, c Conditions) float64 {
+	return 0.368 * float64(n-1) * math.Log(c.sodiumEquivalent())
+}
+
+// hairpinLoop returns the free energy of hairpin loops of n bases at 37 ℃
+// (SantaLucia and Hicks (2004) Annu Rev Biophys Biomol Struct 33:415-440).
+// Loops are entropic, so the free energy scales with temperature.
+func hairpinLoop(n int) nnParam {
+	loops := []float64{0, 0, 0, 3.5, 3.5, 3.3, 4.0, 4.2, 4.3, 4.5}
+	var g float64
+	if n < len(loops) {
+		g = loops[n]
+	} else {
+		g = loops[9] + 2.44*gasConstant*310.15/1000*math.Log(float64(n)/9)
+	}
+	return nnParam{dH: 0, dS: -1000 * g / 310.15}
+}
+
+// terminalAT is the penalty for a stem ending in an A·T pair
+var terminalAT = nnParam{2.2, 6.9}
+
+// Hairpin returns the most stable hairpin of an oligo with a loop of at
+// least 3 bases
+func Hairpin(oligo string, c Conditions) (SecondaryStructure, error) {
+	seq := strings.ToUpper(oligo)
+	if err := checkBases(seq); err != nil {
+		return SecondaryStructure{}, err
+	}
+	t := c.kelvin()
+
+	var best SecondaryStructure
+	for i := 0; i < len(seq); i++ {
+		for j := len(seq) - 1; j-i >= 4; j-- {
+			if !pairs(seq[i], seq[j]) {
+				continue
+			}
+			// extend the stem inwards from the closing pair (i, j)
+			for l := 1; i+l < j-l && pairs(seq[i+l], seq[j-l]); l++ {
+				loop := (j - l) - (i + l) - 1
+				if loop < 3 {
+					break
+				}
+				stem := seq[i : i+l+1]
+				p := stack(stem).add(hairpinLoop(loop))
+				if seq[i] == 'A' || seq[i] == 'T' {
+					p = p.add(terminalAT)
+				}
+				p.dS += saltEntropy(len(stem), c)
+				if g := p.deltaG(t); g < best.DeltaG {
+					best = SecondaryStructure{
+						DeltaG:     g,
+						Stem:       stem,
+						Start1:     i,
+						End1:       i + l,
+						Start2:     j - l,
+						End2:       j,
+						ThreePrime: j == len(seq)-1,
+					}
+				}
+			}
+		}
+	}
+	return best, nil
+}
+
+// HeteroDimer returns the most stable duplex formed between two oligos
+func HeteroDimer(oligo1, oligo2 string, c Conditions) (SecondaryStructure, error) {
+	s1, s2 := strings.ToUpper(oligo1), strings.ToUpper(oligo2)
+	if err := checkBases(s1); err != nil {
+		return SecondaryStructure{}, err
+	}
+	if err := checkBases(s2); err != nil {
+		return SecondaryStructure{}, err
+	}
+	t := c.kelvin()
+
+	var best SecondaryStructure
+	// s1[i] pairs with s2[j] and s1[i+k] with s2[j-k]
+	for i := 0; i < len(s1); i++ {
+		for j := 0; j < len(s2); j++ {
+			if !pairs(s1[i], s2[j]) || (i > 0 && j+1 < len(s2) && pairs(s1[i-1], s2[j+1])) {
+				// only start from the 5' end of each stretch of pairs
+				continue
+			}
+			n := 0
+			for i+n < len(s1) && j-n >= 0 && pairs(s1[i+n], s2[j-n]) {
+				n++
+			}
+			// the most stable part of the stretch
+			for a := 0; a < n; a++ {
+				for b := a + 1; b < n; b++ {
+					stem := s1[i+a : i+b+1]
+					p := stack(stem).add(initiation(stem[0])).add(initiation(stem[len(stem)-1]))
+					p.dS += saltEntropy(len(stem), c)
+					if g := p.deltaG(t); g < best.DeltaG {
+						best = SecondaryStructure{
+							DeltaG:     g,
+							Stem:       stem,
+							Start1:     i + a,
+							End1:       i + b,
+							Start2:     j - b,
+							End2:       j - a,
+							ThreePrime: i+b == len(s1)-1 || j-a == len(s2)-1,
+						}
+					}
+				}
+			}
+		}
+	}
+	return best, nil
+}
+
+// SelfDimer returns the most stable duplex formed between two copies of an
+// oligo
+func SelfDimer(oligo string, c Conditions) (SecondaryStructure, error) {
+	return HeteroDimer(oligo, oligo, c)
+}
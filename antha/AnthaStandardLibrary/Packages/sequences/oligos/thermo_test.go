@@ -0,0 +1,162 @@
+package oligos
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func TestMeltingTemp(t *testing.T) {
+	oligo := wtype.MakeSingleStrandedDNASequence("oligo", "CAGTCAGTCAGTCAGTCAGT")
+
+	tests := []struct {
+		conditions Conditions
+		expected   float64
+	}{
+		// ΔH -153.9 kcal/mol and ΔS -418.0 cal/(K mol) from the unified
+		// parameters with no salt correction in 1 M Na+
+		{Conditions{OligoConc: wunit.NewConcentration(50, "nM"), Monovalent: wunit.NewConcentration(1, "M")}, 65.716},
+		{DefaultConditions(), 50.532},
+	}
+
+	for _, test := range tests {
+		tm, err := MeltingTemp(oligo, test.conditions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(tm.SIValue()-test.expected) > 0.01 {
+			t.Errorf("expected melting temp %.3f, got %s", test.expected, tm.ToString())
+		}
+	}
+}
+
+func TestMeltingTempConditions(t *testing.T) {
+	oligo := wtype.MakeSingleStrandedDNASequence("oligo", "ATGAGCAAAGGAGAAGAACTTTTCA")
+
+	tm := func(c Conditions) float64 {
+		v, err := MeltingTemp(oligo, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v.SIValue()
+	}
+
+	base := tm(DefaultConditions())
+
+	moreOligo := DefaultConditions()
+	moreOligo.OligoConc = wunit.NewConcentration(1, "uM")
+	if tm(moreOligo) <= base {
+		t.Error("expected melting temp to increase with oligo concentration")
+	}
+
+	lessSalt := DefaultConditions()
+	lessSalt.Monovalent = wunit.NewConcentration(10, "mM")
+	if tm(lessSalt) >= base {
+		t.Error("expected melting temp to decrease with Na+ concentration")
+	}
+
+	magnesium := DefaultConditions()
+	magnesium.Magnesium = wunit.NewConcentration(1.5, "mM")
+	if tm(magnesium) <= base {
+		t.Error("expected melting temp to increase with Mg2+")
+	}
+
+	dntps := magnesium
+	dntps.DNTPs = wunit.NewConcentration(0.8, "mM")
+	if tm(dntps) >= tm(magnesium) {
+		t.Error("expected dNTPs to decrease melting temp in Mg2+")
+	}
+
+	if _, err := MeltingTemp(wtype.MakeSingleStrandedDNASequence("oligo", "ATGNNC"), DefaultConditions()); err == nil {
+		t.Error("expected error for ambiguous bases")
+	}
+}
+
+func TestHairpin(t *testing.T) {
+	hp, err := Hairpin("GCGCGCAAAAGCGCGC", DefaultConditions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hp.Stem != "GCGCGC" || hp.Start1 != 0 || hp.End1 != 5 || hp.Start2 != 10 || hp.End2 != 15 {
+		t.Errorf("expected stem GCGCGC at 1-6/11-16, got %s", hp)
+	}
+	if !hp.ThreePrime {
+		t.Error("expected hairpin at 3' end")
+	}
+	if hp.DeltaG > -5 {
+		t.Errorf("expected stable hairpin, got ΔG %f", hp.DeltaG)
+	}
+
+	hp, err = Hairpin("AAAAAAAAAAAAAAAA", DefaultConditions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hp.Found() {
+		t.Errorf("expected no hairpin, got %s", hp)
+	}
+}
+
+func TestDimers(t *testing.T) {
+	oligo1 := "ACGTTTTTGGCCGCAG"
+	oligo2 := sequences.RevComp("GGCCGCAG") + "TTTTTT"
+
+	d, err := HeteroDimer(oligo1, oligo2, DefaultConditions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Stem != "GGCCGCAG" || d.End1 != len(oligo1)-1 || d.Start2 != 0 || d.End2 != 7 {
+		t.Errorf("expected dimer GGCCGCAG at 3' end of first oligo, got %s", d)
+	}
+	if !d.ThreePrime {
+		t.Error("expected dimer at 3' end")
+	}
+
+	weak, err := SelfDimer("AAAAAAAAAAAA", DefaultConditions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weak.Found() {
+		t.Errorf("expected no self dimer, got %s", weak)
+	}
+
+	strong, err := SelfDimer("GCGCGCGCAT", DefaultConditions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !DefaultPrimerOptions().dimerTooStable(strong) {
+		t.Errorf("expected stable self dimer, got %s", strong)
+	}
+}
+
+func TestDesignPrimerstoFlankRegion(t *testing.T) {
+	seq := oligotests[0].sequence
+	dna := strings.ToUpper(seq.Sequence())
+
+	primers, err := DesignPrimerstoFlankRegion(seq, 300, 400, 0.6, 18, 30, wunit.NewTemperature(45, "C"), wunit.NewTemperature(65, "C"), nil, 0, DefaultPrimerOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fwd := strings.Index(dna, primers[0].Sequence())
+	if fwd < 0 || fwd+primers[0].Length > 299 {
+		t.Errorf("expected forward primer upstream of region, got %s at %d", primers[0].Sequence(), fwd+1)
+	}
+	rev := strings.Index(dna, sequences.RevComp(primers[1].Sequence()))
+	if rev < 400 {
+		t.Errorf("expected reverse primer downstream of region, got %s at %d", primers[1].Sequence(), rev+1)
+	}
+	if !primers[1].Reverse {
+		t.Error("expected second primer to be reverse")
+	}
+	if d := math.Abs(primers[0].MeltingTemp.SIValue() - primers[1].MeltingTemp.SIValue()); d > 3 {
+		t.Errorf("expected primers with similar melting temps, got %s and %s", primers[0].MeltingTemp.ToString(), primers[1].MeltingTemp.ToString())
+	}
+
+	if _, err := DesignPrimerstoFlankRegion(seq, 400, 300, 0.6, 18, 30, wunit.NewTemperature(45, "C"), wunit.NewTemperature(65, "C"), nil, 0, DefaultPrimerOptions()); err == nil {
+		t.Error("expected error for invalid region")
+	}
+}
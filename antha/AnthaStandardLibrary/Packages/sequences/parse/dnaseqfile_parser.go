@@ -30,10 +30,11 @@ import (
 	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/parse/fasta"
 	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/parse/gdx"
 	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/parse/genbank"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/parse/sbol"
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 )
 
-// Creates a DNASequence from a sequence file of format: .gdx .fasta .gb
+// Creates a DNASequence from a sequence file of format: .gdx .fasta .gb .sbol
 func DNAFileToDNASequence(sequenceFile wtype.File) (sequences []wtype.DNASequence, err error) {
 
 	sequences = make([]wtype.DNASequence, 0)
@@ -54,6 +55,11 @@ func DNAFileToDNASequence(sequenceFile wtype.File) (sequences []wtype.DNASequenc
 	case filepath.Ext(fn) == ".gb" || filepath.Ext(fn) == ".gbk":
 		seq, err = genbank.GenbankToFeaturelessDNASequence(sequenceFile)
 		sequences = append(sequences, seq)
+	case filepath.Ext(fn) == ".sbol":
+		seqs, err = sbol.SBOLToDNASequences(sequenceFile)
+		for _, seq := range seqs {
+			sequences = append(sequences, seq)
+		}
 	default:
 		err = fmt.Errorf("non valid sequence file format: %s", filepath.Ext(fn))
 	}
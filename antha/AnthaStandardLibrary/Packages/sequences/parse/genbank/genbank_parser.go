@@ -106,28 +106,50 @@ func GenbankToAnnotatedSeq(file wtype.File) (annotated wtype.DNASequence, err er
 	return
 }
 
-// parses contents of a genbank file into a DNASEquence making features from annotations
+// parses contents of a genbank file into a DNASEquence making features from annotations.
+// The source feature, which describes the whole sequence rather than a region
+// of it, is not included; GenbankContentsToSource returns it.
 func GenbankContentsToAnnotatedSeq(contentsinbytes []byte) (annotated wtype.DNASequence, err error) {
-	line := ""
-	genbanklines := make([]string, 0)
+	genbanklines, err := genbankLines(contentsinbytes)
+	if err != nil {
+		return
+	}
 
-	data := bytes.NewBuffer(contentsinbytes)
+	annotated, err = handleGenbank(genbanklines)
 
-	scanner := bufio.NewScanner(data)
-	for scanner.Scan() {
-		line = fmt.Sprintln(scanner.Text())
-		genbanklines = append(genbanklines, line)
-	}
+	return
+}
 
-	if err = scanner.Err(); err != nil {
+// GenbankContentsToSource returns the source feature of the contents of a
+// genbank file, with qualifiers such as the organism and molecule type of the
+// sequence. found is false if there is no source feature.
+func GenbankContentsToSource(contentsinbytes []byte) (source wtype.Feature, found bool, err error) {
+	genbanklines, err := genbankLines(contentsinbytes)
+	if err != nil {
 		return
 	}
 
-	annotated, err = handleGenbank(genbanklines)
-
+	seq := handleSequence(genbanklines)
+	for _, entry := range featureTable(genbanklines) {
+		if entry.key == sourceKey {
+			source, err = entry.feature(seq, "DNA")
+			return source, err == nil, err
+		}
+	}
 	return
 }
 
+func genbankLines(contentsinbytes []byte) ([]string, error) {
+	genbanklines := make([]string, 0)
+
+	scanner := bufio.NewScanner(bytes.NewBuffer(contentsinbytes))
+	for scanner.Scan() {
+		genbanklines = append(genbanklines, fmt.Sprintln(scanner.Text()))
+	}
+
+	return genbanklines, scanner.Err()
+}
+
 func handleGenbank(lines []string) (annotatedseq wtype.DNASequence, err error) {
 	if lines[0][0:5] == `LOCUS` {
 		name, _, _, circular, _, err := locusLine(lines[0])
@@ -142,10 +164,9 @@ func handleGenbank(lines []string) (annotatedseq wtype.DNASequence, err error) {
 		if err != nil {
 			return annotatedseq, err
 		}
-		annotatedseq, err = sequences.MakeAnnotatedSeq(name, seq, circular, features)
-		if err != nil {
-			return annotatedseq, err
-		}
+		annotatedseq = wtype.MakeLinearDNASequence(name, seq)
+		annotatedseq.Plasmid = circular
+		annotatedseq.Features = features
 	} else {
 		err = fmt.Errorf("no LOCUS found on first line")
 	}
@@ -195,263 +216,248 @@ func locusLine(line string) (name string, seqlength int, seqtype string, circula
 
 	return
 }
-func cleanup(line string) (cleanarray []string) {
-	fields := strings.Split(line, " ")
-
-	for _, s := range fields {
 
-		if s != "" && s != " " {
-			cleanarray = append(cleanarray, s)
-		}
+// columns of the feature table at which feature keys and, on continuation
+// lines, locations and qualifiers start
+const (
+	featureKeyColumn = 5
+	qualifierColumn  = 21
+)
 
-	}
+// key of the feature which spans the whole sequence and describes where it
+// comes from
+const sourceKey = "source"
 
-	return
+// qualifiers whose values are wrapped without spaces in the feature table
+var unspacedQualifiers = map[string]bool{
+	"translation": true,
 }
 
-func featureline1(line string) (reverse bool, class string, startposition int, endposition int, err error) {
-
-	newarray := cleanup(line)
-
-	class = newarray[0]
-
-	for _, s := range newarray {
-		if s[0] == '<' {
-			s = s[1:]
-		}
-		if s[0] == '>' {
-			s = s[1:]
-		}
-		var warning error
-		if strings.Contains(s, `join`) {
-			warning = fmt.Errorf("double position of feature!!", s, "adding as one feature only for now")
-			s = strings.Replace(s, "Join(", "", -1)
-			s = strings.Replace(s, ")", "", -1)
-			joinhandler := strings.Split(s, `,`)
-			split := strings.Split(joinhandler[0], "..")
-			startposition, err = strconv.Atoi(split[0])
-			if err != nil {
-				return
-			}
-			split = strings.Split(joinhandler[1], "..")
-			endposition, err = strconv.Atoi(strings.TrimRight(split[1], "\n"))
-			if err != nil {
-				return
-			}
-		} else {
-			if strings.Contains(s, `complement`) {
-				reverse = true
-				s = strings.TrimLeft(s, `(complement)`)
-				s = strings.TrimRight(s, ")")
-				if s[0] == '<' {
-					s = s[1:]
-				}
-				if s[0] == '>' {
-					s = s[1:]
-				}
-			}
-			index := strings.Index(s, "..")
-			if index != -1 {
-
-				startposition, err = strconv.Atoi(s[0:index])
-				if err != nil {
-				}
-				ss := strings.SplitAfter(s, "..")
-				if strings.Contains(ss[1], ")") {
-					ss[1] = strings.Replace(ss[1], ")", "", -1)
-				}
-				if strings.Contains(ss[1], "bp") {
-					ss[1] = strings.Replace(ss[1], "bp", "", -1)
-				}
-				if ss[1][0] == '>' {
-					ss[1] = ss[1][1:]
-				} else if ss[1][0] == '<' {
-					ss[1] = ss[1][1:]
-				}
-				endposition, err = strconv.Atoi(strings.TrimRight(ss[1], "\n"))
-				if err != nil {
-					return
-				}
+// genbankFeature is an entry of the feature table as it is written
+type genbankFeature struct {
+	key        string
+	location   string
+	qualifiers []wtype.Qualifier
+}
 
-			}
+// featureTable reads the entries of the feature table which follows the
+// FEATURES line and ends at the next line which is not indented.
+// Qualifier values are unquoted.
+func featureTable(lines []string) (entries []genbankFeature) {
+	intable := false
+	open := false // whether the value of the last qualifier has an unclosed quote
 
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r\n")
+		if !intable {
+			intable = strings.HasPrefix(line, "FEATURES")
+			continue
 		}
-		if err == nil {
-			err = warning
+		if strings.TrimSpace(line) == "" {
+			continue
 		}
-	}
-
-	return
-}
-func featureline2(line string) (description string, found bool) {
-
-	fields := strings.Split(line, " ")
-
-	for i, field := range fields {
-		if strings.Contains(field, `"`) {
-			tempfields := make([]string, i)
-			tempfield := strings.Join(fields[i:len(fields)], " ")
-			tempfields = append(tempfields, tempfield)
-			fields = tempfields
+		if line[0] != ' ' {
 			break
 		}
-	}
 
-	newarray := make([]string, 0)
-	for _, s := range fields {
-		if s != "" && s != " " {
-			newarray = append(newarray, s)
+		content := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if indent < qualifierColumn || len(entries) == 0 {
+			fields := strings.Fields(content)
+			entries = append(entries, genbankFeature{
+				key:      fields[0],
+				location: strings.Join(fields[1:], ""),
+			})
+			open = false
+			continue
 		}
-	}
-	for _, line := range newarray {
-
-		if strings.Contains(line, `/gene`) {
 
-			parts := strings.SplitAfterN(line, `="`, 2)
+		entry := &entries[len(entries)-1]
+		switch {
+		case open || (!strings.HasPrefix(content, "/") && len(entry.qualifiers) > 0):
+			q := &entry.qualifiers[len(entry.qualifiers)-1]
+			if unspacedQualifiers[q.Key] {
+				q.Value += content
+			} else {
+				q.Value += " " + content
+			}
+		case strings.HasPrefix(content, "/"):
+			parts := strings.SplitN(content[1:], "=", 2)
+			q := wtype.Qualifier{Key: parts[0]}
 			if len(parts) == 2 {
-				parts[1] = strings.Replace(parts[1], `"`, "", -1)
-				description = strings.TrimSpace(parts[1])
-				found = true
-				return
+				q.Value = parts[1]
 			}
-
+			entry.qualifiers = append(entry.qualifiers, q)
+		default:
+			entry.location += content
 		}
 
-		if strings.Contains(line, `/label`) {
-			if strings.Contains(line, `="`) {
-				parts := strings.SplitAfterN(line, `="`, 2)
-				if len(parts) == 2 {
-					parts[1] = strings.Replace(parts[1], `"`, "", -1)
-					description = strings.TrimSpace(parts[1])
-					found = true
-					return
-				}
-			} else {
-				parts := strings.SplitAfterN(line, "=", 2)
-				if len(parts) == 2 {
-					description = strings.TrimSpace(parts[1])
-					found = true
-					return
-				}
-
-			}
-
+		if len(entry.qualifiers) > 0 {
+			open = strings.Count(entry.qualifiers[len(entry.qualifiers)-1].Value, `"`)%2 == 1
 		}
+	}
 
-		if strings.Contains(line, `/product`) {
-
-			parts := strings.SplitAfterN(line, `="`, 2)
-			if len(parts) == 2 {
-				parts[1] = strings.Replace(parts[1], `"`, "", -1)
-				description = strings.TrimSpace(parts[1])
-				found = true
-				return
+	for i := range entries {
+		for j, q := range entries[i].qualifiers {
+			if strings.HasPrefix(q.Value, `"`) {
+				value := strings.TrimSuffix(strings.TrimPrefix(q.Value, `"`), `"`)
+				entries[i].qualifiers[j].Value = strings.Replace(value, `""`, `"`, -1)
 			}
-
 		}
 	}
-
 	return
 }
 
-func handleFeature(lines []string) (description string, reverse bool, class string, startposition int, endposition int, err error) {
-
-	if len(lines) > 0 {
-		reverse, class, startposition, endposition, err := featureline1(lines[0])
-
-		if err != nil {
-			fmt.Errorf("Error with Featureline1 func %s", lines[0])
-			return description, reverse, class, startposition, endposition, err
+// featureName returns the name of a feature from the first of its gene, label
+// or product qualifiers or, if it has none of these, its key
+func featureName(key string, qualifiers []wtype.Qualifier) string {
+	for _, q := range qualifiers {
+		if q.Key == "gene" || q.Key == "label" || q.Key == "product" {
+			return strings.TrimSpace(q.Value)
 		}
+	}
+	return key
+}
 
-		for i := 1; i < len(lines); i++ {
-			description, found := featureline2(lines[i])
-			if found {
-				return description, reverse, class, startposition, endposition, err
+// splitLocations splits a list of locations, e.g. the contents of a join, at
+// the commas which are not within the brackets of a location
+func splitLocations(list string) (locations []string) {
+	depth := 0
+	start := 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				locations = append(locations, list[start:i])
+				start = i + 1
 			}
-
 		}
-
 	}
-	return
+	return append(locations, list[start:])
 }
-func detectFeature(lines []string) (detected bool, startlineindex int, endlineindex int) {
 
-	for i := 0; i < len(lines); i++ {
+// parseLocation parses a feature location, e.g. complement(join(1..10,20..30)),
+// into its ranges in the order in which they are joined.
+// Fuzzy positions, e.g. <1..>200, are read as exact positions.
+func parseLocation(location string) (ranges []wtype.FeatureRange, err error) {
+	location = strings.TrimSpace(location)
 
-		if string(lines[i][0]) != " " {
-			return
+	for _, compound := range []string{"complement(", "join(", "order("} {
+		if !strings.HasPrefix(location, compound) || !strings.HasSuffix(location, ")") {
+			continue
 		}
+		inner := location[len(compound) : len(location)-1]
 
-		if string(lines[i][7]) != " " {
-			startlineindex = i
+		if compound == "complement(" {
+			complemented, err := parseLocation(inner)
+			if err != nil {
+				return nil, err
+			}
+			for i := len(complemented) - 1; i >= 0; i-- {
+				r := complemented[i]
+				ranges = append(ranges, wtype.FeatureRange{
+					StartPosition: r.EndPosition,
+					EndPosition:   r.StartPosition,
+					Reverse:       !r.Reverse,
+				})
+			}
+			return ranges, nil
 		}
 
-		_, found := featureline2(lines[i])
-		if found {
-			endlineindex = i + 1
+		for _, l := range splitLocations(inner) {
+			joined, err := parseLocation(l)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, joined...)
 		}
+		return ranges, nil
+	}
 
-		if startlineindex != -1 && endlineindex != 0 {
-			detected = true
-			return
-		}
+	if strings.Contains(location, ":") {
+		return nil, fmt.Errorf("location %s refers to another sequence", location)
 	}
 
-	return
-}
-func handleFeatures(lines []string, seq string, seqtype string) (features []wtype.Feature, err error) {
-	featurespresent := false
-	for _, line := range lines {
-		if strings.Contains(line, "FEATURES") {
-			featurespresent = true
+	positions := strings.NewReplacer("<", "", ">", "").Replace(location)
+	parts := strings.SplitN(positions, "..", 2)
+	if len(parts) == 1 {
+		// a site between two bases, e.g. 12^13
+		parts = strings.SplitN(positions, "^", 2)
+	}
+
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid location %s", location)
+	}
+	end := start
+	if len(parts) == 2 {
+		if end, err = strconv.Atoi(parts[1]); err != nil {
+			return nil, fmt.Errorf("invalid location %s", location)
 		}
 	}
-	if featurespresent != true {
-		return
+
+	return []wtype.FeatureRange{{StartPosition: start, EndPosition: end}}, nil
+}
+
+// feature makes a feature of the sequence from an entry of the feature table.
+// The sequence of a feature with a compound location is the sequence of its
+// ranges joined in order.
+func (entry genbankFeature) feature(seq string, seqtype string) (feature wtype.Feature, err error) {
+	ranges, err := parseLocation(entry.location)
+	if err != nil {
+		return feature, fmt.Errorf("%s feature: %s", entry.key, err)
 	}
-	features = make([]wtype.Feature, 0)
-	var feature wtype.Feature
 
-	for i := 0; i < len(lines); i++ {
-		if lines[i][0:8] == "FEATURES" {
-			lines = lines[i+1 : len(lines)]
-			break
+	var featureseq string
+	reverse := true
+	for _, r := range ranges {
+		low, high := r.StartPosition, r.EndPosition
+		if r.Reverse {
+			low, high = high, low
 		}
+		if low < 1 || high > len(seq) || low > high {
+			return feature, fmt.Errorf("%s feature: location %s is not within the sequence of length %d", entry.key, entry.location, len(seq))
+		}
+		part := seq[low-1 : high]
+		if r.Reverse {
+			part = wtype.RevComp(part)
+		}
+		featureseq += part
+		reverse = reverse && r.Reverse
 	}
 
-	linesatstart := lines
-	for i := 0; i < len(linesatstart); i++ {
+	start, end := ranges[0].StartPosition, ranges[len(ranges)-1].EndPosition
 
-		detected, start, end := detectFeature(lines)
-
-		if detected {
-			description, reverse, class, startposition, endposition, err := handleFeature(lines[start:end])
-			if err != nil {
-				return features, err
-			}
-			rev := ""
-			if reverse {
-				rev = "Reverse"
-			}
+	feature = sequences.MakeFeature(featureName(entry.key, entry.qualifiers), featureseq, start, end, seqtype, entry.key, "")
+	feature.Reverse = reverse
+	if len(ranges) > 1 {
+		feature.Ranges = ranges
+	}
+	feature.Qualifiers = entry.qualifiers
 
-			// Warning! this needs to change to handle cases where start and position assignment has failed rather than just ignoring the problem
-			if startposition != 0 && endposition != 0 {
-				feature = sequences.MakeFeature(description, seq[startposition-1:endposition], startposition, endposition, seqtype, class, rev)
-			}
+	return feature, nil
+}
 
-			features = append(features, feature)
-			lines = lines[end:len(lines)]
-			if start > end {
-				return features, fmt.Errorf("Start position cannot be greater than end position in feature")
-			}
+// handleFeatures makes features of the sequence from every entry of the
+// feature table other than the source, keeping their qualifiers and compound
+// locations
+func handleFeatures(lines []string, seq string, seqtype string) (features []wtype.Feature, err error) {
+	for _, entry := range featureTable(lines) {
+		if entry.key == sourceKey {
+			continue
 		}
-
+		feature, err := entry.feature(seq, seqtype)
+		if err != nil {
+			return features, err
+		}
+		features = append(features, feature)
 	}
-	//features = search.DuplicateFeatures(features)
-
 	return
-
 }
 
 var (
@@ -0,0 +1,234 @@
+// antha/AnthaStandardLibrary/Packages/sequences/parse/genbank/genbank_writer.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package genbank
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// maximum length of lines in a genbank file and number of bases on each line
+// of the sequence
+const (
+	lineWidth     = 79
+	basesPerLine  = 60
+	basesPerBlock = 10
+)
+
+// qualifiers whose values are written without quotes
+var unquotedQualifiers = map[string]bool{
+	"codon_start":      true,
+	"transl_table":     true,
+	"transl_except":    true,
+	"number":           true,
+	"estimated_length": true,
+	"citation":         true,
+	"rpt_type":         true,
+	"rpt_unit_range":   true,
+	"direction":        true,
+	"anticodon":        true,
+}
+
+// AnnotatedSeqToGenbank returns the contents of a genbank file of the sequence
+// with all of its features and their qualifiers.
+// Plasmids are written with circular topology.
+// The locus name is the name of the sequence with any spaces replaced by underscores.
+func AnnotatedSeqToGenbank(seq wtype.DNASequence) ([]byte, error) {
+	var buf bytes.Buffer
+
+	name := strings.Join(strings.Fields(seq.Nm), "_")
+	if name == "" {
+		return nil, fmt.Errorf("cannot write genbank file of a sequence with no name")
+	}
+	topology := "linear"
+	if seq.Plasmid {
+		topology = "circular"
+	}
+	date := strings.ToUpper(time.Now().Format("02-Jan-2006"))
+
+	fmt.Fprintf(&buf, "LOCUS       %-16s %11d bp    DNA     %-8s SYN %s\n", name, len(seq.Seq), topology, date)
+	fmt.Fprintf(&buf, "DEFINITION  %s.\n", seq.Nm)
+
+	buf.WriteString("FEATURES             Location/Qualifiers\n")
+	for _, feature := range seq.Features {
+		location, err := featureLocation(feature, len(seq.Seq), seq.Plasmid)
+		if err != nil {
+			return nil, err
+		}
+		writeFeature(&buf, feature, location)
+	}
+
+	buf.WriteString("ORIGIN\n")
+	bases := strings.ToLower(seq.Seq)
+	for i := 0; i < len(bases); i += basesPerLine {
+		buf.WriteString(fmt.Sprintf("%9d", i+1))
+		for j := i; j < i+basesPerLine && j < len(bases); j += basesPerBlock {
+			end := j + basesPerBlock
+			if end > len(bases) {
+				end = len(bases)
+			}
+			buf.WriteString(" " + bases[j:end])
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("//\n")
+
+	return buf.Bytes(), nil
+}
+
+// featureRanges returns the ranges of a feature with a compound location or
+// else the single range of the feature. Ranges of features which wrap the
+// origin of plasmids are split at the origin.
+func featureRanges(feature wtype.Feature, plasmid bool, length int) []wtype.FeatureRange {
+	if len(feature.Ranges) > 0 {
+		return feature.Ranges
+	}
+	if plasmid && !feature.Reverse && feature.StartPosition > feature.EndPosition {
+		return []wtype.FeatureRange{
+			{StartPosition: feature.StartPosition, EndPosition: length},
+			{StartPosition: 1, EndPosition: feature.EndPosition},
+		}
+	}
+	return []wtype.FeatureRange{{
+		StartPosition: feature.StartPosition,
+		EndPosition:   feature.EndPosition,
+		Reverse:       feature.Reverse,
+	}}
+}
+
+// featureLocation returns the location of a feature in genbank format, e.g.
+// complement(join(1..10,20..30)). Positions of reverse features may be given
+// in either order.
+func featureLocation(feature wtype.Feature, length int, plasmid bool) (string, error) {
+	ranges := featureRanges(feature, plasmid, length)
+
+	reverse := true
+	for _, r := range ranges {
+		reverse = reverse && r.Reverse
+	}
+
+	var locations []string
+	for _, r := range ranges {
+		low, high := r.StartPosition, r.EndPosition
+		if low > high {
+			low, high = high, low
+		}
+		if low < 1 || high > length {
+			return "", fmt.Errorf("feature %s: position %d..%d is not within the sequence of length %d", feature.Name, low, high, length)
+		}
+
+		location := strconv.Itoa(low)
+		if high != low {
+			location += ".." + strconv.Itoa(high)
+		}
+		if r.Reverse && !reverse {
+			location = "complement(" + location + ")"
+		}
+
+		if reverse {
+			// complemented ranges are listed in the order of the forward strand
+			locations = append([]string{location}, locations...)
+		} else {
+			locations = append(locations, location)
+		}
+	}
+
+	location := strings.Join(locations, ",")
+	if len(locations) > 1 {
+		location = "join(" + location + ")"
+	}
+	if reverse {
+		location = "complement(" + location + ")"
+	}
+	return location, nil
+}
+
+// writeFeature writes a feature of the feature table. A label qualifier is
+// added to features whose name cannot be found from their qualifiers.
+func writeFeature(buf *bytes.Buffer, feature wtype.Feature, location string) {
+	key := feature.Class
+	if key == "" {
+		key = wtype.MISC_FEATURE
+	}
+
+	qualifiers := feature.Qualifiers
+	if featureName(key, qualifiers) != feature.Name {
+		qualifiers = append([]wtype.Qualifier{{Key: "label", Value: feature.Name}}, qualifiers...)
+	}
+
+	fmt.Fprintf(buf, "%s%-*s", strings.Repeat(" ", featureKeyColumn), qualifierColumn-featureKeyColumn-1, key)
+	for i, line := range wrap(location, lineWidth-qualifierColumn, false) {
+		if i > 0 {
+			buf.WriteString(strings.Repeat(" ", qualifierColumn))
+		} else {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(line + "\n")
+	}
+
+	for _, q := range qualifiers {
+		text := "/" + q.Key
+		if q.Value != "" {
+			if unquotedQualifiers[q.Key] && !strings.ContainsAny(q.Value, ` "`) {
+				text += "=" + q.Value
+			} else {
+				text += `="` + strings.Replace(q.Value, `"`, `""`, -1) + `"`
+			}
+		}
+		for _, line := range wrap(text, lineWidth-qualifierColumn, !unspacedQualifiers[q.Key]) {
+			buf.WriteString(strings.Repeat(" ", qualifierColumn) + line + "\n")
+		}
+	}
+}
+
+// wrap splits text into lines of at most width characters. Text which is
+// spaced is split at spaces, which are dropped, unless a single word is longer
+// than the width; other text, such as locations and translations, is split anywhere.
+func wrap(text string, width int, spaced bool) (lines []string) {
+	for len(text) > width {
+		split := width
+		if spaced {
+			if i := strings.LastIndex(text[:width+1], " "); i > 0 {
+				split = i
+			} else if i := strings.Index(text, " "); i > 0 {
+				split = i
+			} else {
+				break
+			}
+		} else if len(text) == width+1 {
+			// rather than leave a closing quote on a line of its own
+			split = width - 1
+		}
+		lines = append(lines, text[:split])
+		text = text[split:]
+		if spaced {
+			text = strings.TrimPrefix(text, " ")
+		}
+	}
+	return append(lines, text)
+}
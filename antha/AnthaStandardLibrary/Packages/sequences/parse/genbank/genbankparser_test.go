@@ -322,7 +322,7 @@ var tests []genbanktest = []genbanktest{
 			"repA":         [2]int{3971, 4921},
 		},*/
 		fileContents:         []byte(testfileContents),
-		expectedfeaturenames: []string{"Ampicillin (860 - 672)", "Ampicillin (860 - 672)", "AmpR_promoter", "CMV_immearly_promoter", "5_LTR", "CAG_enhancer", "CMV_fwd_primer", "psi_plus_pack", "gag", "ORF frame 1", "MSCV_primer", "hUbC_promoter", "ORF frame 3", "ORF frame 2"},
+		expectedfeaturenames: []string{"Ampicillin (860 - 672)", "AmpR_promoter", "CMV_immearly_promoter", "5_LTR", "CAG_enhancer", "CMV_fwd_primer", "psi_plus_pack", "gag", "ORF frame 1", "MSCV_primer", "hUbC_promoter", "ORF frame 3", "ORF frame 2"},
 		featurePositionMap: map[string][2]int{
 			"Ampicillin (860 - 672)": [2]int{200, 12},
 			"AmpR_promoter":          [2]int{270, 242},
@@ -399,3 +399,23 @@ func TestGenbanktoAnnotatedSeq(t *testing.T) {
 	}
 
 }
+
+func TestGenbankContentsToSource(t *testing.T) {
+	source, found, err := GenbankContentsToSource([]byte(testfileContents))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("source feature not found")
+	}
+	if source.Start() != 1 || source.End() != 11976 {
+		t.Errorf("expected source at 1..11976, got %d..%d", source.Start(), source.End())
+	}
+	if organism, _ := source.Qualifier("organism"); organism != "pRubiC-T2A-Cas9" {
+		t.Errorf("expected organism pRubiC-T2A-Cas9, got %q", organism)
+	}
+
+	if _, found, err := GenbankContentsToSource([]byte(compoundContents)); err != nil || found {
+		t.Errorf("expected no source feature, got found %t and error %v", found, err)
+	}
+}
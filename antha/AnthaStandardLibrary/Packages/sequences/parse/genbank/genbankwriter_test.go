@@ -0,0 +1,176 @@
+package genbank
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+var compoundContents = `LOCUS       test_plasmid              40 bp    DNA     circular SYN 01-JAN-2017
+DEFINITION  test plasmid.
+FEATURES             Location/Qualifiers
+     CDS             join(1..6,11..16)
+                     /gene="spliced"
+                     /codon_start=1
+                     /note="a ""quoted"" note which is long enough to be wrapped
+                     over two lines"
+     misc_feature    complement(join(21..24,31..34))
+                     /label=reverse
+                     /pseudo
+     misc_feature    join(37..40,1..3)
+                     /label="origin"
+ORIGIN
+        1 atggcaaaaa tttaacccgg gttttaaacc cgggtttaaa
+//
+`
+
+func TestGenbankRoundTrip(t *testing.T) {
+	for _, contents := range [][]byte{[]byte(testfileContents), []byte(compoundContents)} {
+		seq, err := GenbankContentsToAnnotatedSeq(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		written, err := AnnotatedSeqToGenbank(seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reread, err := GenbankContentsToAnnotatedSeq(written)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if reread.Nm != seq.Nm || reread.Seq != seq.Seq || reread.Plasmid != seq.Plasmid {
+			t.Errorf("%s: expected %s of length %d, plasmid %t; got %s of length %d, plasmid %t",
+				seq.Nm, seq.Nm, len(seq.Seq), seq.Plasmid, reread.Nm, len(reread.Seq), reread.Plasmid)
+		}
+		if !reflect.DeepEqual(reread.Features, seq.Features) {
+			t.Errorf("%s: expected features %+v, got %+v", seq.Nm, seq.Features, reread.Features)
+		}
+
+		rewritten, err := AnnotatedSeqToGenbank(reread)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(rewritten) != string(written) {
+			t.Errorf("%s: expected the same file when written again, got\n%s\nand\n%s", seq.Nm, written, rewritten)
+		}
+	}
+}
+
+func TestCompoundLocations(t *testing.T) {
+	seq, err := GenbankContentsToAnnotatedSeq([]byte(compoundContents))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !seq.Plasmid {
+		t.Error("expected circular topology")
+	}
+
+	expected := []struct {
+		name       string
+		dna        string
+		start, end int
+		reverse    bool
+		ranges     []wtype.FeatureRange
+		qualifiers []wtype.Qualifier
+	}{
+		{
+			name: "spliced", dna: "ATGGCATTTAAC", start: 1, end: 16,
+			ranges: []wtype.FeatureRange{{StartPosition: 1, EndPosition: 6}, {StartPosition: 11, EndPosition: 16}},
+			qualifiers: []wtype.Qualifier{
+				{Key: "gene", Value: "spliced"},
+				{Key: "codon_start", Value: "1"},
+				{Key: "note", Value: `a "quoted" note which is long enough to be wrapped over two lines`},
+			},
+		},
+		{
+			name: "reverse", dna: "CCCGAAAC", start: 34, end: 21, reverse: true,
+			ranges: []wtype.FeatureRange{
+				{StartPosition: 34, EndPosition: 31, Reverse: true},
+				{StartPosition: 24, EndPosition: 21, Reverse: true},
+			},
+			qualifiers: []wtype.Qualifier{{Key: "label", Value: "reverse"}, {Key: "pseudo"}},
+		},
+		{
+			name: "origin", dna: "TAAAATG", start: 37, end: 3,
+			ranges:     []wtype.FeatureRange{{StartPosition: 37, EndPosition: 40}, {StartPosition: 1, EndPosition: 3}},
+			qualifiers: []wtype.Qualifier{{Key: "label", Value: "origin"}},
+		},
+	}
+
+	if len(seq.Features) != len(expected) {
+		t.Fatalf("expected %d features, got %d", len(expected), len(seq.Features))
+	}
+
+	for i, e := range expected {
+		f := seq.Features[i]
+		if f.Name != e.name || strings.ToUpper(f.DNASeq) != e.dna || f.Start() != e.start || f.End() != e.end || f.Reverse != e.reverse {
+			t.Errorf("expected %s %s %d..%d reverse %t, got %s %s %d..%d reverse %t",
+				e.name, e.dna, e.start, e.end, e.reverse, f.Name, f.DNASeq, f.Start(), f.End(), f.Reverse)
+		}
+		if !reflect.DeepEqual(f.Ranges, e.ranges) {
+			t.Errorf("%s: expected ranges %v, got %v", e.name, e.ranges, f.Ranges)
+		}
+		if !reflect.DeepEqual(f.Qualifiers, e.qualifiers) {
+			t.Errorf("%s: expected qualifiers %q, got %q", e.name, e.qualifiers, f.Qualifiers)
+		}
+	}
+
+	written, err := AnnotatedSeqToGenbank(seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, location := range []string{" join(1..6,11..16)\n", " complement(join(21..24,31..34))\n", " join(37..40,1..3)\n"} {
+		if !strings.Contains(string(written), location) {
+			t.Errorf("expected location%s in\n%s", location, written)
+		}
+	}
+}
+
+func TestAnnotatedSeqToGenbank(t *testing.T) {
+	seq := wtype.MakePlasmidDNASequence("my plasmid", "ATGGCAAAAATTTAACCCGGGTTTTAAACCCGGGTTTAAA")
+	seq.Features = []wtype.Feature{
+		{Name: "pTac", Class: wtype.PROMOTER, StartPosition: 5, EndPosition: 14},
+		{Name: "reverse", Class: wtype.CDS, Reverse: true, StartPosition: 10, EndPosition: 20},
+		{Name: "origin", StartPosition: 35, EndPosition: 5},
+	}
+
+	written, err := AnnotatedSeqToGenbank(seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(written), "LOCUS       my_plasmid") || !strings.Contains(strings.SplitN(string(written), "\n", 2)[0], " circular ") {
+		t.Errorf("expected circular locus my_plasmid, got\n%s", written)
+	}
+
+	reread, err := GenbankContentsToAnnotatedSeq(written)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reread.FeatureNames(), []string{"pTac", "reverse", "origin"}) {
+		t.Errorf("expected features pTac, reverse and origin, got %v", reread.FeatureNames())
+	}
+
+	positions := [][2]int{{5, 14}, {20, 10}, {35, 5}}
+	for i, f := range reread.Features {
+		if f.Start() != positions[i][0] || f.End() != positions[i][1] {
+			t.Errorf("%s: expected %d..%d, got %d..%d", f.Name, positions[i][0], positions[i][1], f.Start(), f.End())
+		}
+		if label, _ := f.Qualifier("label"); label != f.Name {
+			t.Errorf("%s: expected label qualifier, got %q", f.Name, f.Qualifiers)
+		}
+	}
+	if reread.Features[1].Class != wtype.CDS || reread.Features[2].Class != wtype.MISC_FEATURE {
+		t.Errorf("expected classes CDS and misc_feature, got %s and %s", reread.Features[1].Class, reread.Features[2].Class)
+	}
+
+	seq.Features = append(seq.Features, wtype.Feature{Name: "outside", StartPosition: 30, EndPosition: 50})
+	if _, err := AnnotatedSeqToGenbank(seq); err == nil {
+		t.Error("expected error writing feature outside of the sequence")
+	}
+}
@@ -0,0 +1,131 @@
+package sbol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/enzymes"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// seedLength is the length of the exact matches from which parts are located
+// in products
+const seedLength = 20
+
+// match is a region of a part found in a product, counting from 0
+type match struct {
+	partStart, productStart, length int
+	reverse                         bool
+}
+
+// longestMatch returns the longest region of a part which occurs in a
+// product, in either orientation
+func longestMatch(product, part string, circular bool) (best match, found bool) {
+	n := len(product)
+	at := func(i int) (byte, bool) {
+		if circular {
+			return product[((i%n)+n)%n], true
+		}
+		if i < 0 || i >= n {
+			return 0, false
+		}
+		return product[i], true
+	}
+
+	searched := product
+	if circular {
+		searched += product
+	}
+
+	for _, reverse := range []bool{false, true} {
+		p := part
+		if reverse {
+			p = wtype.RevComp(part)
+		}
+		seed := seedLength
+		if len(p) < seed {
+			seed = len(p)
+		}
+		for s := 0; s+seed <= len(p); s += seed {
+			word := p[s : s+seed]
+			for from := 0; ; {
+				i := strings.Index(searched[from:], word)
+				if i < 0 || from+i >= n {
+					break
+				}
+				pos := from + i
+				from = pos + 1
+
+				left := 0
+				for s-left-1 >= 0 && left+seed < n {
+					b, ok := at(pos - left - 1)
+					if !ok || b != p[s-left-1] {
+						break
+					}
+					left++
+				}
+				right := 0
+				for s+seed+right < len(p) && left+seed+right < n {
+					b, ok := at(pos + seed + right)
+					if !ok || b != p[s+seed+right] {
+						break
+					}
+					right++
+				}
+
+				if length := left + seed + right; !found || length > best.length {
+					start := pos - left
+					if circular {
+						start = ((start % n) + n) % n
+					}
+					best = match{partStart: s - left, productStart: start, length: length, reverse: reverse}
+					found = true
+				}
+			}
+		}
+	}
+	return
+}
+
+// LocateParts returns a composite design of a product made from parts by
+// finding where each part occurs in the product. Parts need only occur in
+// part, as when their ends are removed by restriction digestion, and the
+// longest region of each which occurs is used. Products of homology based
+// assemblies, such as those of enzymes.SimulateHomologyAssembly, and of
+// type IIs assemblies can be described this way.
+func LocateParts(product wtype.DNASequence, parts ...wtype.DNASequence) (Composite, error) {
+	composite := Composite{Product: product}
+	seq := strings.ToUpper(product.Seq)
+	n := len(seq)
+
+	for _, part := range parts {
+		m, found := longestMatch(seq, strings.ToUpper(part.Seq), product.Plasmid)
+		if !found || part.Seq == "" {
+			return composite, fmt.Errorf("part %s not found in %s", part.Nm, product.Nm)
+		}
+		end := m.productStart + m.length
+		if product.Plasmid && end > n {
+			end -= n
+		}
+		composite.Parts = append(composite.Parts, SubComponent{
+			Part:    part,
+			Start:   m.productStart + 1,
+			End:     end,
+			Reverse: m.reverse,
+		})
+	}
+	return composite, nil
+}
+
+// AssemblyComposite simulates a type IIs assembly and returns a composite
+// design of its product, made up of the vector and parts
+func AssemblyComposite(params enzymes.Assemblyparameters) (Composite, error) {
+	_, _, _, products, err := enzymes.Assemblysimulator(params)
+	if err != nil {
+		return Composite{}, err
+	}
+	if len(products) == 0 {
+		return Composite{}, fmt.Errorf("no product of assembly %s", params.Constructname)
+	}
+	return LocateParts(products[0], append([]wtype.DNASequence{params.Vector}, params.Partsinorder...)...)
+}
@@ -0,0 +1,446 @@
+// antha/AnthaStandardLibrary/Packages/sequences/parse/sbol/sbol.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package sbol reads and writes DNA sequences and designs in the Synthetic
+// Biology Open Language (http://sbolstandard.org), versions 2 and 3, as
+// RDF/XML.
+package sbol
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// Version is a version of SBOL
+type Version int
+
+// Supported versions of SBOL
+const (
+	SBOL2 Version = 2
+	SBOL3 Version = 3
+)
+
+// Namespaces of SBOL documents
+const (
+	sbol2NS = "http://sbols.org/v2#"
+	sbol3NS = "http://sbols.org/v3#"
+)
+
+// Terms used to describe DNA components
+const (
+	biopaxDNA        = "http://www.biopax.org/release/biopax-level3.owl#DnaRegion"
+	sboDNA           = "https://identifiers.org/SBO:0000251"
+	soCircular       = "SO:0000988"
+	soLinear         = "SO:0000987"
+	soEngineered     = "SO:0000804"
+	soInline         = "SO:0001030"
+	soReverse        = "SO:0001031"
+	sbol2Inline      = "http://sbols.org/v2#inline"
+	sbol2Reverse     = "http://sbols.org/v2#reverseComplement"
+	sbol2Public      = "http://sbols.org/v2#public"
+	sbol2Encoding    = "http://www.chem.qmul.ac.uk/iubmb/misc/naseq.html"
+	sbol3Encoding    = "https://identifiers.org/edam:format_1207"
+	sbol2SOPrefix    = "http://identifiers.org/so/"
+	sbol3SOPrefix    = "https://identifiers.org/"
+	defaultNamespace = "http://antha-lang.org"
+)
+
+// soTerms are the Sequence Ontology terms of feature classes, as used in
+// GenBank files. Where classes are synonyms the first is used when reading.
+var soTerms = []struct {
+	class, term string
+}{
+	{wtype.CDS, "SO:0000316"},
+	{wtype.ORF, "SO:0000236"},
+	{wtype.GENE, "SO:0000704"},
+	{wtype.PROMOTER, "SO:0000167"},
+	{wtype.TRNA, "SO:0000253"},
+	{wtype.RRNA, "SO:0000252"},
+	{wtype.NCRNA, "SO:0000655"},
+	{wtype.REGULATORY, "SO:0005836"},
+	{wtype.REPEAT_REGION, "SO:0000657"},
+	{wtype.MISC_FEATURE, "SO:0000001"},
+	{"terminator", "SO:0000141"},
+	{"RBS", "SO:0000139"},
+	{"ribosome_entry_site", "SO:0000139"},
+	{"rep_origin", "SO:0000296"},
+	{"origin_of_replication", "SO:0000296"},
+	{"primer_bind", "SO:0005850"},
+	{"polyA_signal", "SO:0000551"},
+	{"protein_bind", "SO:0000410"},
+	{"sig_peptide", "SO:0000418"},
+	{"operator", "SO:0000057"},
+	{"insulator", "SO:0000627"},
+	{"5'UTR", "SO:0000204"},
+	{"3'UTR", "SO:0000205"},
+	{"restriction_site", "SO:0001687"},
+	{"assembly_scar", "SO:0001953"},
+	{"engineered_region", soEngineered},
+	{"sequence_feature", "SO:0000110"},
+}
+
+// classTerm returns the Sequence Ontology term of a feature class
+func classTerm(class string) string {
+	for _, t := range soTerms {
+		if strings.EqualFold(t.class, class) {
+			return t.term
+		}
+	}
+	return "SO:0000110"
+}
+
+// featureClass returns the feature class of a Sequence Ontology term
+func featureClass(role string) (string, bool) {
+	term := soTerm(role)
+	for _, t := range soTerms {
+		if t.term == term {
+			return t.class, true
+		}
+	}
+	return "", false
+}
+
+// soTerm returns the Sequence Ontology term of a URI, e.g., SO:0000316 from
+// http://identifiers.org/so/SO:0000316
+func soTerm(uri string) string {
+	i := strings.LastIndex(uri, "SO:")
+	if i < 0 {
+		return ""
+	}
+	return uri[i:]
+}
+
+// A SubComponent is a part within a composite design
+type SubComponent struct {
+	Part wtype.DNASequence
+	// Start and End are the position of the part in the composite, in human
+	// friendly format. Parts which span the origin of a plasmid start after
+	// they end.
+	Start, End int
+	Reverse    bool
+}
+
+// A Composite is a design made up of parts, such as the product of an
+// assembly
+type Composite struct {
+	Product wtype.DNASequence
+	Parts   []SubComponent
+}
+
+// A Document is the DNA sequences and composite designs in an SBOL file.
+// The products and parts of composites are included in Sequences when read.
+type Document struct {
+	Sequences  []wtype.DNASequence
+	Composites []Composite
+}
+
+// DetectVersion returns the version of an SBOL document
+func DetectVersion(data []byte) (Version, error) {
+	switch {
+	case bytes.Contains(data, []byte(sbol3NS)):
+		return SBOL3, nil
+	case bytes.Contains(data, []byte(sbol2NS)):
+		return SBOL2, nil
+	}
+	return 0, fmt.Errorf("not an SBOL 2 or 3 RDF/XML document")
+}
+
+// SBOLToDNASequences parses the DNA components of an SBOL file into DNA
+// sequences with features
+func SBOLToDNASequences(sequenceFile wtype.File) ([]wtype.DNASequence, error) {
+	data, err := sequenceFile.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return SBOLContentsToDNASequences(data)
+}
+
+// SBOLContentsToDNASequences parses the DNA components of the contents of an
+// SBOL file into DNA sequences with features
+func SBOLContentsToDNASequences(data []byte) ([]wtype.DNASequence, error) {
+	doc, err := Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Sequences, nil
+}
+
+// rdfResource is a reference to another object
+type rdfResource struct {
+	Resource string `xml:"resource,attr"`
+}
+
+// rdfRange is a Range location. Elements are matched by local name, so the
+// same types read SBOL 2 and 3.
+type rdfRange struct {
+	Start       int         `xml:"start"`
+	End         int         `xml:"end"`
+	Orientation rdfResource `xml:"orientation"`
+}
+
+type rdfFeature struct {
+	About       string        `xml:"about,attr"`
+	DisplayID   string        `xml:"displayId"`
+	Title       string        `xml:"title"`
+	Name        string        `xml:"name"`
+	Roles       []rdfResource `xml:"role"`
+	Orientation rdfResource   `xml:"orientation"`
+	Ranges2     []rdfRange    `xml:"location>Range"`
+	Ranges3     []rdfRange    `xml:"hasLocation>Range"`
+	Component   rdfResource   `xml:"component"`
+	Definition  rdfResource   `xml:"definition"`
+	InstanceOf  rdfResource   `xml:"instanceOf"`
+}
+
+type rdfComponent struct {
+	About         string        `xml:"about,attr"`
+	DisplayID     string        `xml:"displayId"`
+	Title         string        `xml:"title"`
+	Name          string        `xml:"name"`
+	Types         []rdfResource `xml:"type"`
+	Sequence      []rdfResource `xml:"sequence"`
+	HasSequence   []rdfResource `xml:"hasSequence"`
+	Components    []rdfFeature  `xml:"component>Component"`
+	Annotations   []rdfFeature  `xml:"sequenceAnnotation>SequenceAnnotation"`
+	Features      []rdfFeature  `xml:"hasFeature>SequenceFeature"`
+	SubComponents []rdfFeature  `xml:"hasFeature>SubComponent"`
+}
+
+type rdfSequence struct {
+	About    string `xml:"about,attr"`
+	Elements string `xml:"elements"`
+}
+
+type rdfDocument struct {
+	XMLName              xml.Name       `xml:"RDF"`
+	ComponentDefinitions []rdfComponent `xml:"ComponentDefinition"`
+	Components           []rdfComponent `xml:"Component"`
+	Sequences            []rdfSequence  `xml:"Sequence"`
+}
+
+func (c rdfComponent) name() string {
+	for _, n := range []string{c.Title, c.Name, c.DisplayID} {
+		if n != "" {
+			return n
+		}
+	}
+	return c.About
+}
+
+func (f rdfFeature) name() string {
+	for _, n := range []string{f.Title, f.Name, f.DisplayID} {
+		if n != "" {
+			return n
+		}
+	}
+	return f.About
+}
+
+func hasType(c rdfComponent, suffix string) bool {
+	for _, t := range c.Types {
+		if strings.HasSuffix(t.Resource, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDNA returns true for components which are DNA, or whose type is not
+// given
+func (c rdfComponent) isDNA() bool {
+	return len(c.Types) == 0 || hasType(c, "#DnaRegion") || hasType(c, "SBO:0000251")
+}
+
+func isReverse(orientation rdfResource) bool {
+	return strings.HasSuffix(orientation.Resource, "reverseComplement") || strings.HasSuffix(orientation.Resource, soReverse)
+}
+
+// location returns the position of a feature from its ranges. Features of
+// plasmids may be split into two ranges either side of the origin.
+func (f rdfFeature) location(length int) (start, end int, reverse bool, ok bool) {
+	ranges := append(append([]rdfRange(nil), f.Ranges2...), f.Ranges3...)
+	if len(ranges) == 0 {
+		return 0, 0, false, false
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	reverse = isReverse(f.Orientation) || isReverse(ranges[0].Orientation)
+	first, last := ranges[0], ranges[len(ranges)-1]
+	if len(ranges) == 2 && first.Start == 1 && last.End == length && last.Start > first.End+1 {
+		// wraps round the origin
+		return last.Start, first.End, reverse, true
+	}
+	start, end = first.Start, first.End
+	for _, r := range ranges[1:] {
+		if r.End > end {
+			end = r.End
+		}
+	}
+	return start, end, reverse, true
+}
+
+// Unmarshal parses an SBOL 2 or 3 document in RDF/XML
+func Unmarshal(data []byte) (Document, error) {
+	var doc Document
+
+	version, err := DetectVersion(data)
+	if err != nil {
+		return doc, err
+	}
+
+	var rdf rdfDocument
+	if err := xml.Unmarshal(data, &rdf); err != nil {
+		return doc, err
+	}
+
+	elements := make(map[string]string)
+	for _, s := range rdf.Sequences {
+		elements[s.About] = strings.ToUpper(strings.Join(strings.Fields(s.Elements), ""))
+	}
+
+	components := rdf.ComponentDefinitions
+	if version == SBOL3 {
+		components = rdf.Components
+	}
+
+	byURI := make(map[string]wtype.DNASequence)
+	for _, c := range components {
+		if !c.isDNA() {
+			continue
+		}
+		refs := append(c.Sequence, c.HasSequence...)
+		var seq string
+		for _, r := range refs {
+			if s, found := elements[r.Resource]; found {
+				seq = s
+				break
+			}
+		}
+
+		var dna wtype.DNASequence
+		if hasType(c, soCircular) {
+			dna = wtype.MakePlasmidDNASequence(c.name(), seq)
+		} else {
+			dna = wtype.MakeLinearDNASequence(c.name(), seq)
+		}
+
+		for _, f := range append(c.Annotations, c.Features...) {
+			if f.Component.Resource != "" {
+				// the location of a sub component
+				continue
+			}
+			start, end, reverse, ok := f.location(len(seq))
+			if !ok {
+				continue
+			}
+			class := wtype.MISC_FEATURE
+			for _, r := range f.Roles {
+				if cl, found := featureClass(r.Resource); found {
+					class = cl
+					break
+				}
+			}
+			feature := wtype.Feature{
+				Name:          f.name(),
+				Class:         class,
+				Reverse:       reverse,
+				StartPosition: start,
+				EndPosition:   end,
+			}
+			feature.DNASeq = featureSequence(dna, feature)
+			dna.Features = append(dna.Features, feature)
+		}
+
+		byURI[c.About] = dna
+		doc.Sequences = append(doc.Sequences, dna)
+	}
+
+	for _, c := range components {
+		product, found := byURI[c.About]
+		if !found || len(c.Components)+len(c.SubComponents) == 0 {
+			continue
+		}
+		composite := Composite{Product: product}
+
+		// SBOL 2 locates components with sequence annotations
+		located := make(map[string]rdfFeature)
+		for _, a := range c.Annotations {
+			if a.Component.Resource != "" {
+				located[a.Component.Resource] = a
+			}
+		}
+
+		for _, sub := range append(c.Components, c.SubComponents...) {
+			definition := sub.Definition.Resource
+			if definition == "" {
+				definition = sub.InstanceOf.Resource
+			}
+			part, found := byURI[definition]
+			if !found {
+				return doc, fmt.Errorf("no DNA component %s for sub component %s of %s", definition, sub.About, c.About)
+			}
+			loc := sub
+			if a, found := located[sub.About]; found {
+				loc = a
+			}
+			start, end, reverse, ok := loc.location(len(product.Seq))
+			if !ok {
+				return doc, fmt.Errorf("no location for sub component %s of %s", sub.About, c.About)
+			}
+			composite.Parts = append(composite.Parts, SubComponent{
+				Part:    part,
+				Start:   start,
+				End:     end,
+				Reverse: reverse || isReverse(sub.Orientation),
+			})
+		}
+		doc.Composites = append(doc.Composites, composite)
+	}
+
+	return doc, nil
+}
+
+// featureSequence returns the sequence of a feature, in the direction of the
+// feature
+func featureSequence(seq wtype.DNASequence, f wtype.Feature) string {
+	s, e := f.StartPosition, f.EndPosition
+	if s < 1 || e > len(seq.Seq) || s > len(seq.Seq) || e < 1 {
+		return ""
+	}
+	var region string
+	if s <= e {
+		region = seq.Seq[s-1 : e]
+	} else if seq.Plasmid {
+		region = seq.Seq[s-1:] + seq.Seq[:e]
+	}
+	if f.Reverse {
+		return wtype.RevComp(region)
+	}
+	return region
+}
@@ -0,0 +1,262 @@
+package sbol
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/enzymes"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/seqtest"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+type location struct {
+	Name, Class string
+	Reverse     bool
+	Start, End  int
+	Seq         string
+}
+
+func locations(fs []wtype.Feature) []location {
+	var ls []location
+	for _, f := range fs {
+		ls = append(ls, location{f.Name, f.Class, f.Reverse, f.StartPosition, f.EndPosition, f.DNASeq})
+	}
+	return ls
+}
+
+// testPlasmid is a plasmid of common features: the ampicillin resistance
+// gene in reverse, lacI under the lac promoter and the pBR322 origin, which
+// spans the end of the sequence
+func testPlasmid(t *testing.T) wtype.DNASequence {
+	ori := seqtest.Feature(t, "pBR322_replication_origin").Seq
+	amp := seqtest.Feature(t, "ampicillin_resistance_gene_ORF").Seq
+	promoter := seqtest.Feature(t, "lac_promoter").Seq
+	lacI := seqtest.Feature(t, "lacI_ORF").Seq
+
+	split := 300
+	seq := ori[split:] + wtype.RevComp(amp) + promoter + lacI + ori[:split]
+	ampStart := len(ori) - split + 1
+	promoterStart := ampStart + len(amp)
+	lacIStart := promoterStart + len(promoter)
+
+	plasmid := wtype.MakePlasmidDNASequence("pTest <1>", seq)
+	plasmid.Features = []wtype.Feature{
+		{Name: "bla & co", Class: wtype.CDS, Reverse: true, StartPosition: ampStart, EndPosition: ampStart + len(amp) - 1, DNASeq: amp},
+		{Name: "lac", Class: wtype.PROMOTER, StartPosition: promoterStart, EndPosition: lacIStart - 1, DNASeq: promoter},
+		{Name: "lacI", Class: wtype.CDS, StartPosition: lacIStart, EndPosition: lacIStart + len(lacI) - 1, DNASeq: lacI},
+		{Name: "pBR322 ori", Class: "rep_origin", StartPosition: len(seq) - split + 1, EndPosition: len(ori) - split, DNASeq: ori},
+	}
+	return plasmid
+}
+
+func TestRoundTrip(t *testing.T) {
+	plasmid := testPlasmid(t)
+	linear := wtype.MakeLinearDNASequence("oligo", "ACGTACGTAC")
+
+	for _, version := range []Version{SBOL2, SBOL3} {
+		data, err := Marshal(Document{Sequences: []wtype.DNASequence{plasmid, linear}}, version, "http://example.com/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, err := DetectVersion(data); err != nil || v != version {
+			t.Errorf("SBOL%d: detected version %d, %v", version, v, err)
+		}
+
+		seqs, err := SBOLContentsToDNASequences(data)
+		if err != nil {
+			t.Fatalf("SBOL%d: %s", version, err)
+		}
+		if len(seqs) != 2 {
+			t.Fatalf("SBOL%d: expected 2 sequences, got %d", version, len(seqs))
+		}
+		got := seqs[0]
+		if got.Nm != plasmid.Nm || got.Seq != plasmid.Seq || !got.Plasmid {
+			t.Errorf("SBOL%d: sequence %s not read back", version, plasmid.Nm)
+		}
+		if e, g := locations(plasmid.Features), locations(got.Features); !reflect.DeepEqual(e, g) {
+			t.Errorf("SBOL%d: expected features %v, got %v", version, e, g)
+		}
+		if seqs[1].Nm != linear.Nm || seqs[1].Seq != linear.Seq || seqs[1].Plasmid {
+			t.Errorf("SBOL%d: sequence %s not read back", version, linear.Nm)
+		}
+	}
+}
+
+// an SBOL 2 document as written by other tools
+var sbol2Document = `<?xml version="1.0" ?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:prov="http://www.w3.org/ns/prov#" xmlns:sbol="http://sbols.org/v2#">
+  <sbol:ComponentDefinition rdf:about="http://example.com/gfp_cassette/1">
+    <sbol:persistentIdentity rdf:resource="http://example.com/gfp_cassette"/>
+    <sbol:displayId>gfp_cassette</sbol:displayId>
+    <sbol:version>1</sbol:version>
+    <sbol:type rdf:resource="http://www.biopax.org/release/biopax-level3.owl#DnaRegion"/>
+    <sbol:role rdf:resource="http://identifiers.org/so/SO:0000804"/>
+    <sbol:sequence rdf:resource="http://example.com/gfp_cassette_seq/1"/>
+    <sbol:sequenceAnnotation>
+      <sbol:SequenceAnnotation rdf:about="http://example.com/gfp_cassette/rbs/1">
+        <sbol:displayId>rbs</sbol:displayId>
+        <sbol:location>
+          <sbol:Range rdf:about="http://example.com/gfp_cassette/rbs/range/1">
+            <sbol:displayId>range</sbol:displayId>
+            <sbol:start>3</sbol:start>
+            <sbol:end>8</sbol:end>
+            <sbol:orientation rdf:resource="http://sbols.org/v2#inline"/>
+          </sbol:Range>
+        </sbol:location>
+        <sbol:role rdf:resource="http://identifiers.org/so/SO:0000139"/>
+      </sbol:SequenceAnnotation>
+    </sbol:sequenceAnnotation>
+    <sbol:sequenceAnnotation>
+      <sbol:SequenceAnnotation rdf:about="http://example.com/gfp_cassette/term/1">
+        <sbol:displayId>term</sbol:displayId>
+        <dcterms:title>B0015</dcterms:title>
+        <sbol:location>
+          <sbol:Range rdf:about="http://example.com/gfp_cassette/term/range/1">
+            <sbol:start>15</sbol:start>
+            <sbol:end>20</sbol:end>
+            <sbol:orientation rdf:resource="http://sbols.org/v2#reverseComplement"/>
+          </sbol:Range>
+        </sbol:location>
+        <sbol:role rdf:resource="http://identifiers.org/so/SO:0000141"/>
+      </sbol:SequenceAnnotation>
+    </sbol:sequenceAnnotation>
+  </sbol:ComponentDefinition>
+  <sbol:ComponentDefinition rdf:about="http://example.com/gfp/1">
+    <sbol:displayId>gfp</sbol:displayId>
+    <sbol:type rdf:resource="http://www.biopax.org/release/biopax-level3.owl#Protein"/>
+  </sbol:ComponentDefinition>
+  <sbol:Sequence rdf:about="http://example.com/gfp_cassette_seq/1">
+    <sbol:displayId>gfp_cassette_seq</sbol:displayId>
+    <sbol:elements>aaaggagg
+      aaaaaattttttt</sbol:elements>
+    <sbol:encoding rdf:resource="http://www.chem.qmul.ac.uk/iubmb/misc/naseq.html"/>
+  </sbol:Sequence>
+</rdf:RDF>
+`
+
+func TestUnmarshalSBOL2(t *testing.T) {
+	seqs, err := SBOLContentsToDNASequences([]byte(sbol2Document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seqs) != 1 {
+		t.Fatalf("expected 1 DNA sequence, got %d", len(seqs))
+	}
+	seq := seqs[0]
+	if seq.Nm != "gfp_cassette" || seq.Seq != "AAAGGAGGAAAAAATTTTTTT" || seq.Plasmid {
+		t.Errorf("unexpected sequence %s %s", seq.Nm, seq.Seq)
+	}
+	expected := []location{
+		{"rbs", "RBS", false, 3, 8, "AGGAGG"},
+		{"B0015", "terminator", true, 15, 20, "AAAAAA"},
+	}
+	if got := locations(seq.Features); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected features %v, got %v", expected, got)
+	}
+
+	if _, err := Unmarshal([]byte("<rdf:RDF/>")); err == nil {
+		t.Errorf("expected error for document which is not SBOL")
+	}
+}
+
+func TestComposite(t *testing.T) {
+	a := seqtest.Feature(t, "ampicillin_resistance_gene_ORF").Seq
+	b := seqtest.Feature(t, "lacI_ORF").Seq
+	c := seqtest.Feature(t, "pBR322_replication_origin").Seq
+	product := wtype.MakePlasmidDNASequence("construct", b[200:]+wtype.RevComp(c)+a+b[:200])
+
+	// parts with ends which are not in the product
+	other := map[byte]string{'A': "C", 'C': "G", 'G': "T", 'T': "A"}
+	rc := wtype.RevComp(c)
+	parts := []wtype.DNASequence{
+		wtype.MakeLinearDNASequence("a", "GGTCTC"+other[rc[len(rc)-1]]+a+other[b[0]]+"GAGACC"),
+		wtype.MakeLinearDNASequence("b", b),
+		wtype.MakeLinearDNASequence("c", c),
+	}
+
+	composite, err := LocateParts(product, parts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cStart := len(b) - 200 + 1
+	aStart := cStart + len(c)
+	expected := []SubComponent{
+		{Part: parts[0], Start: aStart, End: aStart + len(a) - 1},
+		{Part: parts[1], Start: aStart + len(a), End: len(b) - 200},
+		{Part: parts[2], Start: cStart, End: aStart - 1, Reverse: true},
+	}
+	if !reflect.DeepEqual(expected, composite.Parts) {
+		for _, p := range composite.Parts {
+			t.Logf("%s %d..%d %v", p.Part.Nm, p.Start, p.End, p.Reverse)
+		}
+		t.Fatalf("parts not located")
+	}
+
+	for _, version := range []Version{SBOL2, SBOL3} {
+		data, err := Marshal(Document{Composites: []Composite{composite}}, version, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := Unmarshal(data)
+		if err != nil {
+			t.Fatalf("SBOL%d: %s", version, err)
+		}
+		if len(doc.Sequences) != 4 || len(doc.Composites) != 1 {
+			t.Fatalf("SBOL%d: expected 4 sequences and 1 composite, got %d and %d", version, len(doc.Sequences), len(doc.Composites))
+		}
+		got := doc.Composites[0]
+		if got.Product.Seq != product.Seq || !got.Product.Plasmid {
+			t.Errorf("SBOL%d: composite product not read back", version)
+		}
+		for i, p := range got.Parts {
+			e := expected[i]
+			if p.Part.Nm != e.Part.Nm || p.Part.Seq != e.Part.Seq || p.Start != e.Start || p.End != e.End || p.Reverse != e.Reverse {
+				t.Errorf("SBOL%d: expected part %s at %d..%d, got %s at %d..%d", version, e.Part.Nm, e.Start, e.End, p.Part.Nm, p.Start, p.End)
+			}
+		}
+	}
+}
+
+func TestHomologyAssemblyComposite(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	overlap := seqtest.RandomDNA(r, 30)
+	closing := seqtest.RandomDNA(r, 30)
+	x := closing + seqtest.Feature(t, "ampicillin_resistance_gene_ORF").Seq + overlap
+	y := overlap + seqtest.Feature(t, "lacI_ORF").Seq + closing
+
+	fragments := []wtype.DNASequence{
+		wtype.MakeLinearDNASequence("x", x),
+		wtype.MakeLinearDNASequence("y", y),
+	}
+	result, err := enzymes.SimulateHomologyAssembly("xy", fragments, true, enzymes.Gibson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Products) != 1 {
+		t.Fatalf("expected one product, got %d", len(result.Products))
+	}
+
+	composite, err := LocateParts(result.Products[0], fragments...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range composite.Parts {
+		length := p.End - p.Start + 1
+		if p.Start > p.End {
+			length += len(result.Products[0].Seq)
+		}
+		if length != len(fragments[i].Seq) || p.Reverse {
+			t.Errorf("part %s located at %d..%d", p.Part.Nm, p.Start, p.End)
+		}
+	}
+
+	data, err := Marshal(Document{Composites: []Composite{composite}}, SBOL3, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "sbol:SubComponent") {
+		t.Errorf("no sub components written")
+	}
+}
@@ -0,0 +1,308 @@
+package sbol
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// entry is a DNA component to be written
+type entry struct {
+	seq   wtype.DNASequence
+	id    string
+	uri   string
+	parts []SubComponent
+}
+
+// writer writes the RDF/XML of an SBOL document
+type writer struct {
+	version   Version
+	namespace string
+	buf       bytes.Buffer
+	ids       map[string]bool
+	entries   []*entry
+	byKey     map[string]*entry
+}
+
+func sequenceKey(seq wtype.DNASequence) string {
+	return seq.Nm + "\x00" + strings.ToUpper(seq.Seq) + "\x00" + strconv.FormatBool(seq.Plasmid)
+}
+
+// displayID returns a unique SBOL display id for a name, which may only
+// contain letters, digits and underscores and must not start with a digit
+func (w *writer) displayID(name string) string {
+	id := []byte(name)
+	for i, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			id[i] = '_'
+		}
+	}
+	if len(id) == 0 || id[0] >= '0' && id[0] <= '9' {
+		id = append([]byte("_"), id...)
+	}
+
+	unique := string(id)
+	for n := 2; w.ids[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", id, n)
+	}
+	w.ids[unique] = true
+	return unique
+}
+
+// add returns the entry of a sequence, adding it if it is new
+func (w *writer) add(seq wtype.DNASequence) *entry {
+	key := sequenceKey(seq)
+	if e, found := w.byKey[key]; found {
+		return e
+	}
+	id := w.displayID(seq.Nm)
+	e := &entry{seq: seq, id: id, uri: w.namespace + "/" + id}
+	w.byKey[key] = e
+	w.entries = append(w.entries, e)
+	return e
+}
+
+func (w *writer) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&w.buf, format, args...)
+}
+
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// ranges returns the ranges of a feature. Features which wrap round the
+// origin of a plasmid are split in two as SBOL ranges must have start before
+// end.
+func ranges(start, end, length int, wraps bool) [][2]int {
+	if start <= end {
+		return [][2]int{{start, end}}
+	}
+	if wraps {
+		return [][2]int{{start, length}, {1, end}}
+	}
+	return [][2]int{{end, start}}
+}
+
+// featureWraps returns true if a feature of a plasmid whose start is after
+// its end wraps round the origin rather than being written in reverse
+func featureWraps(seq wtype.DNASequence, f wtype.Feature) bool {
+	if !seq.Plasmid || f.StartPosition <= f.EndPosition {
+		return false
+	}
+	wrapped := len(seq.Seq) - f.StartPosition + 1 + f.EndPosition
+	if n := len(f.DNASeq); n != 0 {
+		return n == wrapped
+	}
+	return !f.Reverse
+}
+
+func (w *writer) orientation(reverse bool) string {
+	switch {
+	case w.version == SBOL2 && reverse:
+		return sbol2Reverse
+	case w.version == SBOL2:
+		return sbol2Inline
+	case reverse:
+		return sbol3SOPrefix + soReverse
+	default:
+		return sbol3SOPrefix + soInline
+	}
+}
+
+func (w *writer) so(term string) string {
+	if w.version == SBOL2 {
+		return sbol2SOPrefix + term
+	}
+	return sbol3SOPrefix + term
+}
+
+func (w *writer) location(parent string, rs [][2]int, reverse bool, sequence string) {
+	tag := "location"
+	if w.version == SBOL3 {
+		tag = "hasLocation"
+	}
+	for i, r := range rs {
+		id := fmt.Sprintf("range%d", i+1)
+		w.printf("        <sbol:%s>\n", tag)
+		w.printf("          <sbol:Range rdf:about=\"%s/%s\">\n", escape(parent), id)
+		w.printf("            <sbol:displayId>%s</sbol:displayId>\n", id)
+		if w.version == SBOL3 {
+			w.printf("            <sbol:hasSequence rdf:resource=\"%s\"/>\n", escape(sequence))
+		}
+		w.printf("            <sbol:start>%d</sbol:start>\n", r[0])
+		w.printf("            <sbol:end>%d</sbol:end>\n", r[1])
+		w.printf("            <sbol:orientation rdf:resource=\"%s\"/>\n", w.orientation(reverse))
+		w.printf("          </sbol:Range>\n")
+		w.printf("        </sbol:%s>\n", tag)
+	}
+}
+
+func (w *writer) component(e *entry) {
+	seqURI := e.uri + "_sequence"
+	element, name, sequence := "ComponentDefinition", "dcterms:title", "sequence"
+	dnaType, topology := biopaxDNA, w.so(soLinear)
+	if w.version == SBOL3 {
+		element, name, sequence = "Component", "sbol:name", "hasSequence"
+		dnaType = sboDNA
+	}
+	if e.seq.Plasmid {
+		topology = w.so(soCircular)
+	}
+
+	w.printf("  <sbol:%s rdf:about=\"%s\">\n", element, escape(e.uri))
+	if w.version == SBOL2 {
+		w.printf("    <sbol:persistentIdentity rdf:resource=\"%s\"/>\n", escape(e.uri))
+	} else {
+		w.printf("    <sbol:hasNamespace rdf:resource=\"%s\"/>\n", escape(w.namespace))
+	}
+	w.printf("    <sbol:displayId>%s</sbol:displayId>\n", e.id)
+	w.printf("    <%s>%s</%s>\n", name, escape(e.seq.Nm), name)
+	w.printf("    <sbol:type rdf:resource=\"%s\"/>\n", dnaType)
+	w.printf("    <sbol:type rdf:resource=\"%s\"/>\n", topology)
+	w.printf("    <sbol:role rdf:resource=\"%s\"/>\n", w.so(soEngineered))
+	w.printf("    <sbol:%s rdf:resource=\"%s\"/>\n", sequence, escape(seqURI))
+
+	n := 0
+	for _, f := range e.seq.Features {
+		n++
+		rs := ranges(f.StartPosition, f.EndPosition, len(e.seq.Seq), featureWraps(e.seq, f))
+		w.feature(e, n, f.Name, classTerm(f.Class), rs, f.Reverse, "", seqURI)
+	}
+
+	for i, p := range e.parts {
+		part := w.byKey[sequenceKey(p.Part)]
+		rs := ranges(p.Start, p.End, len(e.seq.Seq), e.seq.Plasmid)
+		if w.version == SBOL3 {
+			w.subComponent3(e, i+1, part, rs, p.Reverse, seqURI)
+			continue
+		}
+		// SBOL 2 components are located by sequence annotations
+		uri := fmt.Sprintf("%s/component%d", e.uri, i+1)
+		w.printf("    <sbol:component>\n")
+		w.printf("      <sbol:Component rdf:about=\"%s\">\n", escape(uri))
+		w.printf("        <sbol:persistentIdentity rdf:resource=\"%s\"/>\n", escape(uri))
+		w.printf("        <sbol:displayId>component%d</sbol:displayId>\n", i+1)
+		w.printf("        <sbol:access rdf:resource=\"%s\"/>\n", sbol2Public)
+		w.printf("        <sbol:definition rdf:resource=\"%s\"/>\n", escape(part.uri))
+		w.printf("      </sbol:Component>\n")
+		w.printf("    </sbol:component>\n")
+		n++
+		w.feature(e, n, part.seq.Nm, "", rs, p.Reverse, uri, seqURI)
+	}
+
+	w.printf("  </sbol:%s>\n", element)
+
+	w.printf("  <sbol:Sequence rdf:about=\"%s\">\n", escape(seqURI))
+	if w.version == SBOL2 {
+		w.printf("    <sbol:persistentIdentity rdf:resource=\"%s\"/>\n", escape(seqURI))
+	} else {
+		w.printf("    <sbol:hasNamespace rdf:resource=\"%s\"/>\n", escape(w.namespace))
+	}
+	w.printf("    <sbol:displayId>%s_sequence</sbol:displayId>\n", e.id)
+	w.printf("    <sbol:elements>%s</sbol:elements>\n", escape(strings.ToLower(e.seq.Seq)))
+	if w.version == SBOL2 {
+		w.printf("    <sbol:encoding rdf:resource=\"%s\"/>\n", sbol2Encoding)
+	} else {
+		w.printf("    <sbol:encoding rdf:resource=\"%s\"/>\n", sbol3Encoding)
+	}
+	w.printf("  </sbol:Sequence>\n")
+}
+
+// feature writes a sequence annotation, for SBOL 2, or sequence feature, for
+// SBOL 3. SBOL 2 annotations may locate a component rather than have a role.
+func (w *writer) feature(e *entry, n int, name, term string, rs [][2]int, reverse bool, component, sequence string) {
+	container, element, id, title := "sequenceAnnotation", "SequenceAnnotation", fmt.Sprintf("annotation%d", n), "dcterms:title"
+	if w.version == SBOL3 {
+		container, element, id, title = "hasFeature", "SequenceFeature", fmt.Sprintf("SequenceFeature%d", n), "sbol:name"
+	}
+	uri := e.uri + "/" + id
+
+	w.printf("    <sbol:%s>\n", container)
+	w.printf("      <sbol:%s rdf:about=\"%s\">\n", element, escape(uri))
+	if w.version == SBOL2 {
+		w.printf("        <sbol:persistentIdentity rdf:resource=\"%s\"/>\n", escape(uri))
+	}
+	w.printf("        <sbol:displayId>%s</sbol:displayId>\n", id)
+	if name != "" {
+		w.printf("        <%s>%s</%s>\n", title, escape(name), title)
+	}
+	if term != "" {
+		w.printf("        <sbol:role rdf:resource=\"%s\"/>\n", w.so(term))
+	}
+	if component != "" {
+		w.printf("        <sbol:component rdf:resource=\"%s\"/>\n", escape(component))
+	}
+	if w.version == SBOL3 {
+		w.printf("        <sbol:orientation rdf:resource=\"%s\"/>\n", w.orientation(reverse))
+	}
+	w.location(uri, rs, reverse, sequence)
+	w.printf("      </sbol:%s>\n", element)
+	w.printf("    </sbol:%s>\n", container)
+}
+
+func (w *writer) subComponent3(e *entry, n int, part *entry, rs [][2]int, reverse bool, sequence string) {
+	id := fmt.Sprintf("SubComponent%d", n)
+	uri := e.uri + "/" + id
+	w.printf("    <sbol:hasFeature>\n")
+	w.printf("      <sbol:SubComponent rdf:about=\"%s\">\n", escape(uri))
+	w.printf("        <sbol:displayId>%s</sbol:displayId>\n", id)
+	w.printf("        <sbol:instanceOf rdf:resource=\"%s\"/>\n", escape(part.uri))
+	w.printf("        <sbol:orientation rdf:resource=\"%s\"/>\n", w.orientation(reverse))
+	w.location(uri, rs, reverse, sequence)
+	w.printf("      </sbol:SubComponent>\n")
+	w.printf("    </sbol:hasFeature>\n")
+}
+
+// Marshal writes DNA sequences and composite designs as an SBOL document in
+// RDF/XML. Objects are identified by URIs in the namespace given, which
+// defaults to http://antha-lang.org. Feature classes are written as Sequence
+// Ontology roles and composite designs as components with sub components for
+// each of their parts.
+func Marshal(doc Document, version Version, namespace string) ([]byte, error) {
+	if version != SBOL2 && version != SBOL3 {
+		return nil, fmt.Errorf("cannot write SBOL version %d", version)
+	}
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	w := &writer{
+		version:   version,
+		namespace: strings.TrimSuffix(namespace, "/"),
+		ids:       make(map[string]bool),
+		byKey:     make(map[string]*entry),
+	}
+
+	for _, seq := range doc.Sequences {
+		w.add(seq)
+	}
+	for _, c := range doc.Composites {
+		e := w.add(c.Product)
+		for _, p := range c.Parts {
+			if p.Start < 1 || p.End < 1 || p.Start > len(c.Product.Seq) || p.End > len(c.Product.Seq) {
+				return nil, fmt.Errorf("part %s is outside composite %s", p.Part.Nm, c.Product.Nm)
+			}
+			w.add(p.Part)
+		}
+		e.parts = append(e.parts, c.Parts...)
+	}
+
+	w.printf("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	if version == SBOL2 {
+		w.printf("<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:dcterms=\"http://purl.org/dc/terms/\" xmlns:prov=\"http://www.w3.org/ns/prov#\" xmlns:sbol=\"%s\">\n", sbol2NS)
+	} else {
+		w.printf("<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\" xmlns:sbol=\"%s\">\n", sbol3NS)
+	}
+	for _, e := range w.entries {
+		w.component(e)
+	}
+	w.printf("</rdf:RDF>\n")
+
+	return w.buf.Bytes(), nil
+}
@@ -90,6 +90,12 @@ func MakePlasmapperFeatures() (featuremap FeatureMap, err error) {
 	return
 }
 
+// CommonFeatures returns the sequences of the common plasmid features from
+// which MakePlasmapperFeatures selects origins and selection markers.
+func CommonFeatures() ([]wtype.DNASequence, error) {
+	return fasta.FastaContentstoDNASequences(plasmapperfile)
+}
+
 // ValidPlasmid evaluates whether a test sequence is circular, contains any origins of replications and selection markers.
 // The features are evaluated for exact matches against a restricted list of common features defined as the variable commonfeatures.
 func ValidPlasmid(sequence wtype.DNASequence) (plasmid bool, oris []string, selectionmarkers []string, err error) {
@@ -96,3 +96,32 @@ func TestRevTranslate(t *testing.T) {
 	}
 
 }
+
+func TestHostCodonTable(t *testing.T) {
+	for _, host := range []string{"E.Coli", "yeast", "S. cerevisiae", "CHO", "HEK293", "human"} {
+		table, err := HostCodonTable(host)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		for aa, codons := range table.CodonByAA {
+			var total float64
+			for codon, f := range codons {
+				if Codontable[codon] != aa {
+					t.Errorf("%s: %s does not encode %s", host, codon, aa)
+				}
+				total += f
+			}
+			if total < 0.98 || total > 1.02 {
+				t.Errorf("%s: frequencies of codons for %s sum to %f", host, aa, total)
+			}
+		}
+		if len(table.CodonByAA) != 21 {
+			t.Errorf("%s: expected codons for 21 amino acids, got %d", host, len(table.CodonByAA))
+		}
+	}
+
+	if _, err := HostCodonTable("mouse"); err == nil {
+		t.Errorf("expected error for unknown host")
+	}
+}
@@ -0,0 +1,60 @@
+// seqtest.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package seqtest provides DNA sequences for use in tests.
+package seqtest
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/sequences/plasmid"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// RandomDNA returns n bases chosen uniformly at random from r.
+func RandomDNA(r *rand.Rand, n int) string {
+	bases := "ACGT"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = bases[r.Intn(len(bases))]
+	}
+	return string(b)
+}
+
+// Feature returns the common plasmid feature with the given name, e.g.
+// "lacI_ORF" or "pBR322_replication_origin", as a linear sequence. The name
+// is the part of the plasmapper header before the feature type.
+func Feature(t testing.TB, name string) wtype.DNASequence {
+	features, err := plasmid.CommonFeatures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range features {
+		if i := strings.Index(f.Nm, "["); i >= 0 && f.Nm[:i] == name {
+			return wtype.MakeLinearDNASequence(name, strings.ToUpper(f.Seq))
+		}
+	}
+	t.Fatalf("no common plasmid feature named %q", name)
+	return wtype.DNASequence{}
+}
@@ -9,6 +9,16 @@ import (
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 )
 
+// GC content limits for synthesis, over the whole of a part and within
+// windows of SynthesisGCWindow bases
+const (
+	MinSynthesisGC      = 0.40
+	MaxSynthesisGC      = 0.65
+	MinLocalSynthesisGC = 0.25
+	MaxLocalSynthesisGC = 0.80
+	SynthesisGCWindow   = 100
+)
+
 // This simulates the sequence assembly reaction to validate if parts will synthesise with intended manufacturer.
 // Does not validate construct assembly so should be used in conjunction with enzymes.Assemblysimulator()
 func ValidateSynthesis(parts []wtype.DNASequence, vector string, manufacturer string) (string, bool) {
@@ -56,8 +66,8 @@ func ValidateSynthesis(parts []wtype.DNASequence, vector string, manufacturer st
 	}
 
 	for _, part := range parts {
-		GCC := GCcontent(part.Seq)              // global gc
-		gc := localGCContent(part.Seq, 100, 50) // local gc
+		GCC := GCcontent(part.Seq)                                             // global gc
+		gc := localGCContent(part.Seq, SynthesisGCWindow, SynthesisGCWindow/2) // local gc
 
 		// check lengths of seq, repeat content and global gc content of each part
 		if len(part.Seq) < c {
@@ -70,7 +80,7 @@ func ValidateSynthesis(parts []wtype.DNASequence, vector string, manufacturer st
 			strings.Contains(strings.ToUpper(part.Seq), strings.Repeat("C", a)) || strings.Contains(strings.ToUpper(part.Seq), strings.Repeat("A", a)) == true {
 			status = status + ". " + fmt.Sprint("Warning:", part.Nm, "is highly repetetive and unsuitable for synthesis")
 			bad = true
-		} else if GCC > 0.65 || GCC < 0.40 {
+		} else if GCC > MaxSynthesisGC || GCC < MinSynthesisGC {
 			status = status + ". " + fmt.Sprint("Warning: GC content of", part.Nm, "is very high or low and may be difficult to synthesise")
 			bad = true
 		} else {
@@ -80,7 +90,7 @@ func ValidateSynthesis(parts []wtype.DNASequence, vector string, manufacturer st
 
 		// check local gc content of each part in 100bp sliding window
 		for _, v := range gc {
-			if v < 0.25 || v > 0.80 {
+			if v < MinLocalSynthesisGC || v > MaxLocalSynthesisGC {
 				status = fmt.Sprint("Warning: Local GC content very high or low in", part.Nm)
 				bad = true
 			}
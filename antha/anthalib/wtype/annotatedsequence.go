@@ -72,6 +72,39 @@ type Feature struct {
 	EndPosition   int    `json:"end_position"`   // in human friendly format
 	DNASeq        string `json:"dna_seq"`
 	Protseq       string `json:"prot_seq"`
+	// Ranges are the contiguous ranges of a feature with a compound location,
+	// e.g. a spliced CDS, in the order in which they are joined.
+	// They are empty for features with a single range.
+	Ranges []FeatureRange `json:"ranges,omitempty"`
+	// Qualifiers further describe the feature, e.g. /gene="lacZ" in genbank format,
+	// in the order in which they were given.
+	Qualifiers []Qualifier `json:"qualifiers,omitempty"`
+}
+
+// FeatureRange is a contiguous range of a feature with a compound location.
+// Positions are in human friendly format and directional as for the Feature,
+// i.e. the start position of a reverse range is greater than its end position.
+type FeatureRange struct {
+	StartPosition int  `json:"start_position"`
+	EndPosition   int  `json:"end_position"`
+	Reverse       bool `json:"reverse"`
+}
+
+// Qualifier is a key value pair describing a feature.
+// Qualifiers with no value, e.g. /pseudo in genbank format, have an empty value.
+type Qualifier struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Qualifier returns the value of the first qualifier of the feature with the key specified.
+func (f Feature) Qualifier(key string) (value string, found bool) {
+	for _, q := range f.Qualifiers {
+		if q.Key == key {
+			return q.Value, true
+		}
+	}
+	return "", false
 }
 
 // DNASequence returns the linear DNA sequence of the feature.
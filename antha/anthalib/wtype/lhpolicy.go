@@ -26,6 +26,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"github.com/antha-lang/antha/antha/anthalib/wutil"
@@ -113,11 +114,16 @@ func (plhp *LHPolicy) UnmarshalJSON(data []byte) error {
 				return fmt.Errorf("Wrong type for %s: should be %s got %s", k, alhpi.Type.Name(), reflect.TypeOf(v))
 			}
 			lhp[k] = tv
-		case "wunit.Volume":
-			tv, ok := v.(wunit.Volume)
+		case "Volume":
+			// volumes are serialized as strings, e.g., "0.5 ul"
+			s, ok := v.(string)
 			if !ok {
 				return fmt.Errorf("Wrong type for %s: should be %s got %s", k, alhpi.Type.Name(), reflect.TypeOf(v))
 			}
+			var tv wunit.Volume
+			if err := json.Unmarshal([]byte(strconv.Quote(s)), &tv); err != nil {
+				return fmt.Errorf("Wrong value for %s: %s", k, err)
+			}
 			lhp[k] = tv
 		}
 	}
@@ -423,18 +429,45 @@ func (lhpr LHPolicyRuleSet) GetEquivalentRuleTo(rule LHPolicyRule) string {
 	return ""
 }
 
-func (lhpr *LHPolicyRuleSet) MergeWith(other *LHPolicyRuleSet) {
+// MergeWith adds the rules of other to the rule set. Where a rule has the
+// same conditions as an existing one their policies are merged, with values
+// in other taking precedence. An error is returned, and the rule set left
+// unchanged, if a rule of other has the name of an existing rule but
+// different conditions.
+func (lhpr *LHPolicyRuleSet) MergeWith(other *LHPolicyRuleSet) error {
+	if other == nil {
+		return nil
+	}
+
+	equivalent := make(map[string]string, len(other.Rules))
 	for k, rule := range other.Rules {
 		name := lhpr.GetEquivalentRuleTo(rule)
+		if name == "" {
+			if _, seen := lhpr.Rules[rule.Name]; seen {
+				return fmt.Errorf("cannot merge rule %q: a rule with that name but different conditions already exists", rule.Name)
+			}
+		}
+		equivalent[k] = name
+	}
 
-		if name != "" {
+	for k, rule := range other.Rules {
+		if name := equivalent[k]; name != "" {
 			// merge the two policies
 			pol := other.Policies[k]
-			p2 := lhpr.Policies[k]
+			p2 := DupLHPolicy(lhpr.Policies[name])
 			p2.MergeWith(pol)
-			lhpr.Policies[k] = p2
+			lhpr.Policies[name] = p2
+		} else {
+			lhpr.AddRule(rule, DupLHPolicy(other.Policies[k]))
 		}
 	}
+	if lhpr.Options == nil && len(other.Options) != 0 {
+		lhpr.Options = make(map[string]interface{})
+	}
+	for k, v := range other.Options {
+		lhpr.Options[k] = v
+	}
+	return nil
 }
 
 type SortableRules []LHPolicyRule
@@ -1,8 +1,11 @@
 package wtype
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
 )
 
 func TestComponentPolicy(t *testing.T) {
@@ -95,3 +98,111 @@ func TestPolicyOption(t *testing.T) {
 		t.Errorf("Trying to set a boolean value to an int should fail but did not")
 	}
 }
+
+func TestPolicyRuleSetFile(t *testing.T) {
+	rs := NewLHPolicyRuleSet()
+	if err := rs.SetOption("USE_LLF", true); err != nil {
+		t.Fatal(err)
+	}
+	rs.AddRule(MakeTestCondition(), MakeTestPolicy())
+
+	glycerol := NewLHPolicyRule("glycerol")
+	if err := glycerol.AddCategoryConditionOn("LIQUIDCLASS", "glycerol"); err != nil {
+		t.Fatal(err)
+	}
+	rs.AddRule(glycerol, LHPolicy{"ASPSPEED": 1.5, "POST_MIX": 3})
+	rs.Policies["default"] = LHPolicy{"DSPSPEED": 3.0}
+
+	bs, err := MarshalLHPolicyRuleSetYAML(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r2, err := UnmarshalLHPolicyRuleSet(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rs.IsEqualTo(r2) || !r2.IsEqualTo(rs) {
+		t.Errorf("rule set coming out not same as went in:\n%s", bs)
+	}
+	if e, f := true, r2.Options["USE_LLF"]; e != f {
+		t.Errorf("expecting option %v found %v", e, f)
+	}
+	if e, f := 3.0, r2.Policies["default"]["DSPSPEED"]; e != f {
+		t.Errorf("expecting unruled policy to be kept: %v != %v", e, f)
+	}
+
+	vs := NewLHPolicyRuleSet()
+	vs.AddRule(MakeTestCondition(), LHPolicy{"EXTRA_ASP_VOLUME": wunit.NewVolume(0.5, "ul")})
+	if bs, err := MarshalLHPolicyRuleSet(vs); err != nil {
+		t.Error(err)
+	} else if v2, err := UnmarshalLHPolicyRuleSet(bs); err != nil {
+		t.Error(err)
+	} else if v, ok := v2.Policies["verylowvolumerule"]["EXTRA_ASP_VOLUME"].(wunit.Volume); !ok || v.ConvertToString("ul") != 0.5 {
+		t.Errorf("expecting volume of 0.5 ul found %v", v2.Policies["verylowvolumerule"]["EXTRA_ASP_VOLUME"])
+	}
+
+	// rule sets serialized directly are accepted too
+	legacy, err := json.Marshal(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r3, err := UnmarshalLHPolicyRuleSet(legacy); err != nil {
+		t.Error(err)
+	} else if !rs.IsEqualTo(r3) {
+		t.Error("legacy rule set coming out not same as went in")
+	}
+
+	for _, bad := range []string{
+		"version: 2\nrules: []\n",
+		"version: 1\nrules:\n- name: x\n  conditions:\n  - variable: NOTAVARIABLE\n    category: y\n",
+		"version: 1\nrules:\n- name: x\n  conditions:\n  - variable: VOLUME\n    lower: 1\n",
+		"version: 1\nrules:\n- name: x\n  policy:\n    NOTANITEM: 1\n",
+	} {
+		if _, err := UnmarshalLHPolicyRuleSet([]byte(bad)); err == nil {
+			t.Errorf("expecting error reading %q", bad)
+		}
+	}
+}
+
+func TestPolicyRuleSetMerge(t *testing.T) {
+	rs := NewLHPolicyRuleSet()
+	rs.AddRule(MakeTestCondition(), LHPolicy{"ASPSPEED": 1.0, "DSPSPEED": 1.0})
+
+	other := NewLHPolicyRuleSet()
+	same := MakeTestCondition()
+	same.Name = "renamed"
+	other.AddRule(same, LHPolicy{"ASPSPEED": 2.0})
+	glycerol := NewLHPolicyRule("glycerol")
+	if err := glycerol.AddCategoryConditionOn("LIQUIDCLASS", "glycerol"); err != nil {
+		t.Fatal(err)
+	}
+	other.AddRule(glycerol, LHPolicy{"POST_MIX": 3})
+
+	if err := rs.MergeWith(other); err != nil {
+		t.Fatal(err)
+	}
+
+	if e, f := 2, len(rs.Rules); e != f {
+		t.Fatalf("expecting %d rules found %d", e, f)
+	}
+	if e, f := (LHPolicy{"ASPSPEED": 2.0, "DSPSPEED": 1.0}), rs.Policies["verylowvolumerule"]; !e.IsEqualTo(f) || !f.IsEqualTo(e) {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if _, ok := rs.Rules["glycerol"]; !ok {
+		t.Error("expecting new rule to be added")
+	}
+
+	clash := NewLHPolicyRuleSet()
+	ethanol := NewLHPolicyRule("glycerol")
+	if err := ethanol.AddCategoryConditionOn("LIQUIDCLASS", "ethanol"); err != nil {
+		t.Fatal(err)
+	}
+	clash.AddRule(ethanol, LHPolicy{"POST_MIX": 5})
+	if err := rs.MergeWith(clash); err == nil {
+		t.Error("expecting error merging rule with the name of an existing rule but different conditions")
+	}
+	if e, f := 3, rs.Policies["glycerol"]["POST_MIX"]; e != f {
+		t.Errorf("expecting existing rule to be kept with POST_MIX %v found %v", e, f)
+	}
+}
@@ -0,0 +1,208 @@
+package wtype
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ghodss/yaml"
+)
+
+// LHPolicyFileVersion is the version of the rule set file format written by
+// MarshalLHPolicyRuleSet
+const LHPolicyFileVersion = 1
+
+// lhPolicyFile is the stable, versioned file format for LHPolicyRuleSets.
+// For example, in YAML:
+//
+//	version: 1
+//	options:
+//	  USE_LLF: true
+//	rules:
+//	- name: smallglycerol
+//	  conditions:
+//	  - variable: LIQUIDCLASS
+//	    category: glycerol
+//	  - variable: VOLUME
+//	    lower: 0
+//	    upper: 20
+//	  policy:
+//	    ASPSPEED: 1.5
+//
+// Rules are applied in order of increasing priority; if priority is omitted it
+// is the number of conditions. Policies which no rule refers to are kept
+// under policies.
+type lhPolicyFile struct {
+	Version  int                    `json:"version"`
+	Options  map[string]interface{} `json:"options,omitempty"`
+	Rules    []lhPolicyFileRule     `json:"rules"`
+	Policies map[string]LHPolicy    `json:"policies,omitempty"`
+}
+
+type lhPolicyFileRule struct {
+	Name       string                  `json:"name"`
+	Priority   *int                    `json:"priority,omitempty"`
+	Conditions []lhPolicyFileCondition `json:"conditions,omitempty"`
+	Policy     LHPolicy                `json:"policy"`
+}
+
+type lhPolicyFileCondition struct {
+	Variable string   `json:"variable"`
+	Category string   `json:"category,omitempty"`
+	Lower    *float64 `json:"lower,omitempty"`
+	Upper    *float64 `json:"upper,omitempty"`
+}
+
+func toPolicyFileCondition(vc LHVariableCondition) (lhPolicyFileCondition, error) {
+	c := lhPolicyFileCondition{Variable: vc.TestVariable}
+	switch t := vc.Condition.(type) {
+	case LHCategoryCondition:
+		c.Category = t.Category
+	case LHNumericCondition:
+		lower, upper := t.Lower, t.Upper
+		c.Lower = &lower
+		c.Upper = &upper
+	default:
+		return c, fmt.Errorf("cannot serialize condition on %s of type %T", vc.TestVariable, vc.Condition)
+	}
+	return c, nil
+}
+
+// MarshalLHPolicyRuleSet returns a rule set in the versioned file format as
+// indented JSON. Rules are sorted by name so that output is stable.
+func MarshalLHPolicyRuleSet(lhpr *LHPolicyRuleSet) ([]byte, error) {
+	f := lhPolicyFile{
+		Version: LHPolicyFileVersion,
+		Options: lhpr.Options,
+	}
+
+	var names []string
+	for name := range lhpr.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rule := lhpr.Rules[name]
+		priority := rule.Priority
+		fr := lhPolicyFileRule{
+			Name:     name,
+			Priority: &priority,
+			Policy:   lhpr.Policies[name],
+		}
+		for _, vc := range rule.Conditions {
+			c, err := toPolicyFileCondition(vc)
+			if err != nil {
+				return nil, err
+			}
+			fr.Conditions = append(fr.Conditions, c)
+		}
+		f.Rules = append(f.Rules, fr)
+	}
+
+	for name, pol := range lhpr.Policies {
+		if _, ok := lhpr.Rules[name]; ok {
+			continue
+		}
+		if f.Policies == nil {
+			f.Policies = make(map[string]LHPolicy)
+		}
+		f.Policies[name] = pol
+	}
+
+	return json.MarshalIndent(f, "", "  ")
+}
+
+// MarshalLHPolicyRuleSetYAML returns a rule set in the versioned file format
+// as YAML
+func MarshalLHPolicyRuleSetYAML(lhpr *LHPolicyRuleSet) ([]byte, error) {
+	bs, err := MarshalLHPolicyRuleSet(lhpr)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(bs)
+}
+
+// UnmarshalLHPolicyRuleSet reads a rule set in the versioned file format from
+// JSON or YAML. Policy items, options and condition variables are checked
+// against those known. Rule sets serialized directly from an
+// LHPolicyRuleSet, which have no version, are also accepted.
+func UnmarshalLHPolicyRuleSet(data []byte) (*LHPolicyRuleSet, error) {
+	bs, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(bs, &probe); err != nil {
+		return nil, err
+	}
+	if _, ok := probe["version"]; !ok {
+		if _, legacy := probe["Rules"]; legacy {
+			lhpr := NewLHPolicyRuleSet()
+			if err := json.Unmarshal(bs, lhpr); err != nil {
+				return nil, err
+			}
+			return lhpr, nil
+		}
+		return nil, fmt.Errorf("policy rule set has no version")
+	}
+
+	var f lhPolicyFile
+	if err := json.Unmarshal(bs, &f); err != nil {
+		return nil, err
+	}
+	if f.Version != LHPolicyFileVersion {
+		return nil, fmt.Errorf("unsupported policy rule set version %d: expecting %d", f.Version, LHPolicyFileVersion)
+	}
+
+	lhpr := NewLHPolicyRuleSet()
+	for name, value := range f.Options {
+		if err := lhpr.SetOption(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fr := range f.Rules {
+		if fr.Name == "" {
+			return nil, fmt.Errorf("policy rule with no name")
+		}
+		if _, seen := lhpr.Rules[fr.Name]; seen {
+			return nil, fmt.Errorf("policy rule %s defined more than once", fr.Name)
+		}
+
+		rule := NewLHPolicyRule(fr.Name)
+		for _, c := range fr.Conditions {
+			var err error
+			switch {
+			case c.Category != "" && c.Lower == nil && c.Upper == nil:
+				err = rule.AddCategoryConditionOn(c.Variable, c.Category)
+			case c.Category == "" && c.Lower != nil && c.Upper != nil:
+				err = rule.AddNumericConditionOn(c.Variable, *c.Lower, *c.Upper)
+			default:
+				err = fmt.Errorf("condition on %s needs either a category or both lower and upper", c.Variable)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("policy rule %s: %s", fr.Name, err)
+			}
+		}
+		if fr.Priority != nil {
+			rule.Priority = *fr.Priority
+		}
+
+		pol := fr.Policy
+		if pol == nil {
+			pol = NewLHPolicy()
+		}
+		lhpr.AddRule(rule, pol)
+	}
+
+	for name, pol := range f.Policies {
+		if _, ok := lhpr.Policies[name]; ok {
+			return nil, fmt.Errorf("policy %s defined more than once", name)
+		}
+		lhpr.Policies[name] = pol
+	}
+
+	return lhpr, nil
+}
@@ -0,0 +1,156 @@
+// wunit/compoundunit.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wunit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// compoundUnits are the units that may appear, optionally with an SI
+// prefix, in a compound unit, together with their value in SI base units
+var compoundUnits = map[string]Quantity{
+	"g":     {Value: 1e-3, Dim: MassDimension},
+	"l":     {Value: 1e-3, Dim: VolumeDimension},
+	"L":     {Value: 1e-3, Dim: VolumeDimension},
+	"m":     {Value: 1, Dim: LengthDimension},
+	"s":     {Value: 1, Dim: TimeDimension},
+	"min":   {Value: 60, Dim: TimeDimension},
+	"h":     {Value: 3600, Dim: TimeDimension},
+	"day":   {Value: 86400, Dim: TimeDimension},
+	"days":  {Value: 86400, Dim: TimeDimension},
+	"mol":   {Value: 1, Dim: AmountDimension},
+	"Mol":   {Value: 1, Dim: AmountDimension},
+	"M":     {Value: 1e3, Dim: MolarConcentration},
+	"U":     {Value: 1e-6 / 60, Dim: MolarActivityDimension},
+	"cell":  {Value: 1, Dim: CountDimension},
+	"cells": {Value: 1, Dim: CountDimension},
+	"K":     {Value: 1, Dim: TemperatureDimension},
+	"A":     {Value: 1, Dim: CurrentDimension},
+	"N":     {Value: 1, Dim: ForceDimension},
+	"J":     {Value: 1, Dim: EnergyDimension},
+	"W":     {Value: 1, Dim: EnergyDimension.Divide(TimeDimension)},
+	"V":     {Value: 1, Dim: EnergyDimension.Divide(TimeDimension).Divide(CurrentDimension)},
+	"Pa":    {Value: 1, Dim: PressureDimension},
+	"Hz":    {Value: 1, Dim: RateDimension},
+	"rpm":   {Value: 1.0 / 60, Dim: RateDimension},
+	"X":     {Value: 1, Dim: Dimensionless},
+	"x":     {Value: 1, Dim: Dimensionless},
+	"%":     {Value: 0.01, Dim: Dimensionless},
+}
+
+// compoundPrefixes are the SI prefixes allowed in compound units. Hecto is
+// omitted so that h is always hours.
+const compoundPrefixes = "yzafpnumcdkMGTPEZY"
+
+// ParseUnit parses a compound unit such as "mg/mL", "µL/s", "nmol/µL",
+// "cells/mL" or "kg*m^2/s^2" and returns the quantity of one of that unit.
+// A compound unit is a sequence of terms separated by "/" or "*", which
+// associate to the left; each term is an optionally prefixed unit, or 1, with
+// an optional integer exponent, e.g., "m^-2". A leading "/" or "1/" gives a
+// reciprocal unit and the empty unit is dimensionless.
+//
+// Note that in compound units M is moles per litre (molar); amounts of
+// substance are written mol or Mol.
+func ParseUnit(unit string) (Quantity, error) {
+	in := strings.TrimSpace(unit)
+	in = strings.Replace(in, "µ", "u", -1) // MICRO SIGN
+	in = strings.Replace(in, "μ", "u", -1) // GREEK SMALL LETTER MU
+
+	ret := Quantity{Value: 1}
+	if len(in) == 0 {
+		return ret, nil
+	}
+
+	divide := false
+	if in[0] == '/' {
+		divide = true
+		in = in[1:]
+	}
+
+	for {
+		end := strings.IndexAny(in, "/*")
+		if end < 0 {
+			end = len(in)
+		}
+		term, err := parseUnitTerm(in[:end])
+		if err != nil {
+			return Quantity{}, fmt.Errorf("cannot parse %s: %s", unit, err)
+		}
+		if divide {
+			ret = ret.Divide(term)
+		} else {
+			ret = ret.Multiply(term)
+		}
+
+		if end == len(in) {
+			return ret, nil
+		}
+		divide = in[end] == '/'
+		in = in[end+1:]
+	}
+}
+
+// parseUnitTerm parses a single, possibly prefixed, unit with an optional
+// integer exponent
+func parseUnitTerm(term string) (Quantity, error) {
+	exp := 1
+	if idx := strings.Index(term, "^"); idx >= 0 {
+		n, err := strconv.Atoi(term[idx+1:])
+		if err != nil {
+			return Quantity{}, fmt.Errorf("bad exponent in %q", term)
+		}
+		exp = n
+		term = term[:idx]
+	}
+
+	switch {
+	case len(term) == 0:
+		return Quantity{}, fmt.Errorf("missing unit")
+	case term == "1":
+		return Quantity{Value: 1}, nil
+	}
+
+	if u, ok := compoundUnits[term]; ok {
+		return u.Pow(exp), nil
+	}
+
+	// The prefix is applied before the exponent so that mm^2 is (mm)^2
+	var prefix float64
+	var rest string
+	switch {
+	case strings.HasPrefix(term, "da"):
+		prefix, rest = 10, term[2:]
+	case strings.IndexByte(compoundPrefixes, term[0]) >= 0:
+		prefix, rest = SIPrefixBySymbol(term[:1]).Value, term[1:]
+	default:
+		return Quantity{}, fmt.Errorf("unknown unit %q", term)
+	}
+
+	u, ok := compoundUnits[rest]
+	if !ok {
+		return Quantity{}, fmt.Errorf("unknown unit %q", term)
+	}
+	return Quantity{Value: u.Value * prefix, Dim: u.Dim}.Pow(exp), nil
+}
@@ -93,6 +93,8 @@ func VolumeForTargetMass(targetmass Mass, stockConc Concentration) (v Volume, er
 		err = fmt.Errorf("Convert ", targetmass.ToString(), " to g and ", stockConc.ToString(), " to g/l")
 	}
 
+	v.SetRelativeUncertainty(productRelativeUncertainty(targetmass, stockConc))
+
 	return
 }
 
@@ -100,6 +102,7 @@ func VolumeForTargetMass(targetmass Mass, stockConc Concentration) (v Volume, er
 // returns an error if the concentration units are incompatible (M/l and g/L) or if the target concentration is higher than the stock concentration
 // If the stock concentration is zero a volume of 0ul will be returned with an error.
 // if the target concetnration or total volume are set to zero a volume of 0ul will be returned with no error.
+// The uncertainty of the returned volume combines the relative uncertainties of the concentrations and total volume.
 func VolumeForTargetConcentration(targetConc Concentration, stockConc Concentration, totalVol Volume) (v Volume, err error) {
 
 	if stockConc.RawValue() == 0.0 {
@@ -117,6 +120,7 @@ func VolumeForTargetConcentration(targetConc Concentration, stockConc Concentrat
 	}
 
 	v = MultiplyVolume(totalVol, factor)
+	v.SetRelativeUncertainty(productRelativeUncertainty(targetConc, stockConc, totalVol))
 
 	if v.GreaterThan(totalVol) {
 		err = fmt.Errorf(fmt.Sprint("Target concentration, ", targetConc.ToString(), " is higher than stock concentration ", stockConc.ToString(), " so volume calculated ", v.ToString(), " is larger than total volume ", totalVol.ToString()))
@@ -153,6 +157,7 @@ func MassForTargetConcentration(targetconc Concentration, totalvol Volume) (m Ma
 	}
 
 	m = NewMass(float64((targetconc.RawValue()*multiplier)*(totalvol.SIValue()/litre.SIValue())), unit)
+	m.SetRelativeUncertainty(productRelativeUncertainty(targetconc, totalvol))
 
 	return
 }
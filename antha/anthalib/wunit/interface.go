@@ -32,7 +32,20 @@ import (
 )
 
 // units mapped by string
-var unitMap map[string]GenericUnit
+var unitMap = makeUnitMap()
+
+// makeUnitMap returns the initial unit library together with ml/min, the
+// unit of FlowRate
+func makeUnitMap() map[string]GenericUnit {
+	units := Make_units()
+	units["ml/min"] = GenericUnit{
+		StrName:             "millilitres per minute",
+		StrSymbol:           "ml/min",
+		FltConversionfactor: 1,
+		StrBaseUnit:         "ml/min",
+	}
+	return units
+}
 
 // deserialize JSON prefix library
 func GetPrefixLib(fn string) (*(map[string]SIPrefix), error) {
@@ -111,10 +124,6 @@ func ParsePrefixedUnit(unit string) *GenericPrefixedUnit {
 
 // look up unit by symbol
 func UnitBySymbol(sym string) GenericUnit {
-	if unitMap == nil {
-		unitMap = Make_units()
-	}
-
 	return unitMap[sym]
 }
 
@@ -0,0 +1,468 @@
+// wunit/quantity.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wunit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Dimension is the physical dimension of a quantity expressed as exponents
+// of base quantities, e.g., a mass concentration has Mass 1 and Length -3.
+// Counted things (cells, colonies) are a base quantity of their own so that
+// cells/ml is not confused with a rate.
+type Dimension struct {
+	Mass        int
+	Length      int
+	Time        int
+	Amount      int
+	Temperature int
+	Current     int
+	Count       int
+}
+
+// Commonly used dimensions
+var (
+	Dimensionless          = Dimension{}
+	MassDimension          = Dimension{Mass: 1}
+	LengthDimension        = Dimension{Length: 1}
+	AreaDimension          = Dimension{Length: 2}
+	VolumeDimension        = Dimension{Length: 3}
+	TimeDimension          = Dimension{Time: 1}
+	AmountDimension        = Dimension{Amount: 1}
+	MassConcentration      = Dimension{Mass: 1, Length: -3}
+	MolarConcentration     = Dimension{Amount: 1, Length: -3}
+	ActivityConcentration  = Dimension{Amount: 1, Length: -3, Time: -1}
+	CountConcentration     = Dimension{Count: 1, Length: -3}
+	FlowRateDimension      = Dimension{Length: 3, Time: -1}
+	RateDimension          = Dimension{Time: -1}
+	VelocityDimension      = Dimension{Length: 1, Time: -1}
+	TemperatureDimension   = Dimension{Temperature: 1}
+	CurrentDimension       = Dimension{Current: 1}
+	CountDimension         = Dimension{Count: 1}
+	EnergyDimension        = Dimension{Mass: 1, Length: 2, Time: -2}
+	ForceDimension         = Dimension{Mass: 1, Length: 1, Time: -2}
+	PressureDimension      = Dimension{Mass: 1, Length: -1, Time: -2}
+	MolarActivityDimension = Dimension{Amount: 1, Time: -1}
+)
+
+// Multiply returns the dimension of the product of quantities with
+// dimensions a and b
+func (a Dimension) Multiply(b Dimension) Dimension {
+	return Dimension{
+		Mass:        a.Mass + b.Mass,
+		Length:      a.Length + b.Length,
+		Time:        a.Time + b.Time,
+		Amount:      a.Amount + b.Amount,
+		Temperature: a.Temperature + b.Temperature,
+		Current:     a.Current + b.Current,
+		Count:       a.Count + b.Count,
+	}
+}
+
+// Divide returns the dimension of the quotient of quantities with dimensions
+// a and b
+func (a Dimension) Divide(b Dimension) Dimension {
+	return a.Multiply(b.Pow(-1))
+}
+
+// Pow returns the dimension of a quantity raised to the power n
+func (a Dimension) Pow(n int) Dimension {
+	return Dimension{
+		Mass:        a.Mass * n,
+		Length:      a.Length * n,
+		Time:        a.Time * n,
+		Amount:      a.Amount * n,
+		Temperature: a.Temperature * n,
+		Current:     a.Current * n,
+		Count:       a.Count * n,
+	}
+}
+
+// String returns the SI base units of a dimension, e.g., "kg/m^3"
+func (a Dimension) String() string {
+	var num, den []string
+	add := func(symbol string, exp int) {
+		switch {
+		case exp == 1:
+			num = append(num, symbol)
+		case exp > 1:
+			num = append(num, fmt.Sprintf("%s^%d", symbol, exp))
+		case exp == -1:
+			den = append(den, symbol)
+		case exp < -1:
+			den = append(den, fmt.Sprintf("%s^%d", symbol, -exp))
+		}
+	}
+	add("kg", a.Mass)
+	add("m", a.Length)
+	add("mol", a.Amount)
+	add("K", a.Temperature)
+	add("A", a.Current)
+	add("cells", a.Count)
+	add("s", a.Time)
+
+	s := strings.Join(num, "*")
+	for _, d := range den {
+		s += "/" + d
+	}
+	return s
+}
+
+// A DimensionError is returned when quantities of incompatible dimensions
+// are combined or converted
+type DimensionError struct {
+	Have Dimension
+	Want Dimension
+}
+
+func (a *DimensionError) Error() string {
+	have, want := a.Have.String(), a.Want.String()
+	if have == "" {
+		have = "dimensionless"
+	}
+	if want == "" {
+		want = "dimensionless"
+	}
+	return fmt.Sprintf("incompatible dimensions: have %s want %s", have, want)
+}
+
+// Quantity is a value with a dimension. Unlike the typed measurements
+// (Volume, Concentration, etc.), quantities can be multiplied and divided
+// freely; the dimension of the result is tracked automatically so that,
+// for example, a mass divided by a volume is a mass concentration. Values
+// are stored in SI base units (kg, m, s, mol, K, A and cells).
+type Quantity struct {
+	Value float64
+	Dim   Dimension
+}
+
+// NewQuantity makes a quantity from a value and a unit, which may be a
+// compound unit such as "mg/mL" or "nmol/µL"
+func NewQuantity(v float64, unit string) (Quantity, error) {
+	u, err := ParseUnit(unit)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return u.MultiplyBy(v), nil
+}
+
+// ParseQuantity parses a value and unit such as "10 mg/mL" or "2.5µL/s"
+func ParseQuantity(s string) (Quantity, error) {
+	s = strings.TrimSpace(s)
+	value, unit := SplitValueAndUnit(s)
+	if unit == s {
+		return Quantity{}, fmt.Errorf("no value in quantity %q", s)
+	}
+	return NewQuantity(value, unit)
+}
+
+// QuantityOf converts a typed measurement into a quantity
+func QuantityOf(m Measurement) (Quantity, error) {
+	if m == nil {
+		return Quantity{}, fmt.Errorf("no measurement")
+	}
+	pu := m.Unit()
+	if pu == nil {
+		return Quantity{}, fmt.Errorf("measurement has no unit")
+	}
+	sym := pu.RawSymbol()
+	if s, ok := measurementSymbols[sym]; ok {
+		sym = s
+	}
+	u, err := ParseUnit(sym)
+	if err != nil {
+		return Quantity{}, err
+	}
+	if p := pu.Prefix().Value; p != 0 {
+		u = u.MultiplyBy(p)
+	}
+	return u.MultiplyBy(m.RawValue()), nil
+}
+
+// measurementSymbols maps unit symbols of typed measurements to compound
+// units where the two differ; typed measurements use M for moles whereas
+// compound units use M for moles per litre.
+var measurementSymbols = map[string]string{
+	"M":   "mol",
+	"M/l": "mol/l",
+	"M/L": "mol/L",
+}
+
+// MultiplyMeasurements returns the product of two typed measurements
+func MultiplyMeasurements(a, b Measurement) (Quantity, error) {
+	qa, err := QuantityOf(a)
+	if err != nil {
+		return Quantity{}, err
+	}
+	qb, err := QuantityOf(b)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return qa.Multiply(qb), nil
+}
+
+// DivideMeasurements returns the quotient of two typed measurements
+func DivideMeasurements(a, b Measurement) (Quantity, error) {
+	qa, err := QuantityOf(a)
+	if err != nil {
+		return Quantity{}, err
+	}
+	qb, err := QuantityOf(b)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return qa.Divide(qb), nil
+}
+
+// Multiply returns the product of two quantities
+func (a Quantity) Multiply(b Quantity) Quantity {
+	return Quantity{Value: a.Value * b.Value, Dim: a.Dim.Multiply(b.Dim)}
+}
+
+// Divide returns the quotient of two quantities
+func (a Quantity) Divide(b Quantity) Quantity {
+	return Quantity{Value: a.Value / b.Value, Dim: a.Dim.Divide(b.Dim)}
+}
+
+// Pow returns a quantity raised to the power n
+func (a Quantity) Pow(n int) Quantity {
+	return Quantity{Value: math.Pow(a.Value, float64(n)), Dim: a.Dim.Pow(n)}
+}
+
+// MultiplyBy returns a quantity scaled by a dimensionless factor
+func (a Quantity) MultiplyBy(factor float64) Quantity {
+	return Quantity{Value: a.Value * factor, Dim: a.Dim}
+}
+
+// Add returns the sum of two quantities of the same dimension
+func (a Quantity) Add(b Quantity) (Quantity, error) {
+	if a.Dim != b.Dim {
+		return Quantity{}, &DimensionError{Have: b.Dim, Want: a.Dim}
+	}
+	return Quantity{Value: a.Value + b.Value, Dim: a.Dim}, nil
+}
+
+// Subtract returns the difference of two quantities of the same dimension
+func (a Quantity) Subtract(b Quantity) (Quantity, error) {
+	if a.Dim != b.Dim {
+		return Quantity{}, &DimensionError{Have: b.Dim, Want: a.Dim}
+	}
+	return Quantity{Value: a.Value - b.Value, Dim: a.Dim}, nil
+}
+
+// In returns the value of a quantity in the given unit, e.g., q.In("mg/ml")
+func (a Quantity) In(unit string) (float64, error) {
+	u, err := ParseUnit(unit)
+	if err != nil {
+		return 0, err
+	}
+	return a.in(u)
+}
+
+func (a Quantity) in(u Quantity) (float64, error) {
+	if a.Dim != u.Dim {
+		return 0, &DimensionError{Have: a.Dim, Want: u.Dim}
+	}
+	return a.Value / u.Value, nil
+}
+
+// String returns the quantity in SI base units
+func (a Quantity) String() string {
+	if a.Dim == Dimensionless {
+		return fmt.Sprintf("%g", a.Value)
+	}
+	return fmt.Sprintf("%g %s", a.Value, a.Dim)
+}
+
+// ToString returns the quantity in SI base units
+func (a Quantity) ToString() string {
+	return a.String()
+}
+
+func (a Quantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+func (a *Quantity) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	q, err := ParseQuantity(s)
+	if err != nil {
+		return err
+	}
+	*a = q
+	return nil
+}
+
+// convertTo converts a quantity into the unit of a typed measurement. The
+// constructors of typed measurements panic on unknown units; these are
+// returned as errors instead.
+func (a Quantity) convertTo(unit string, newM func(v float64, unit string) Measurement) (m Measurement, err error) {
+	defer func() {
+		if res := recover(); res != nil {
+			err = fmt.Errorf("cannot convert to %s: %v", unit, res)
+		}
+	}()
+
+	u, err := QuantityOf(newM(1, unit))
+	if err != nil {
+		return nil, err
+	}
+	v, err := a.in(u)
+	if err != nil {
+		return nil, err
+	}
+	return newM(v, unit), nil
+}
+
+// ToLength converts a quantity into a Length in the given unit
+func (a Quantity) ToLength(unit string) (Length, error) {
+	m, err := a.convertTo(unit, func(v float64, unit string) Measurement {
+		return NewLength(v, unit)
+	})
+	if err != nil {
+		return Length{}, err
+	}
+	return m.(Length), nil
+}
+
+// ToArea converts a quantity into an Area in the given unit
+func (a Quantity) ToArea(unit string) (Area, error) {
+	m, err := a.convertTo(unit, func(v float64, unit string) Measurement {
+		return NewArea(v, unit)
+	})
+	if err != nil {
+		return Area{}, err
+	}
+	return m.(Area), nil
+}
+
+// ToVolume converts a quantity into a Volume in the given unit
+func (a Quantity) ToVolume(unit string) (Volume, error) {
+	m, err := a.convertTo(unit, func(v float64, unit string) Measurement {
+		return NewVolume(v, unit)
+	})
+	if err != nil {
+		return Volume{}, err
+	}
+	return m.(Volume), nil
+}
+
+// ToMass converts a quantity into a Mass in the given unit
+func (a Quantity) ToMass(unit string) (Mass, error) {
+	m, err := a.convertTo(unit, func(v float64, unit string) Measurement {
+		return NewMass(v, unit)
+	})
+	if err != nil {
+		return Mass{}, err
+	}
+	return m.(Mass), nil
+}
+
+// ToMoles converts a quantity into an amount in Moles in the given unit
+func (a Quantity) ToMoles(unit string) (Moles, error) {
+	m, err := a.convertTo(unit, func(v float64, unit string) Measurement {
+		return NewMoles(v, unit)
+	})
+	if err != nil {
+		return Moles{}, err
+	}
+	return m.(Moles), nil
+}
+
+// ToTime converts a quantity into a Time in the given unit
+func (a Quantity) ToTime(unit string) (Time, error) {
+	m, err := a.convertTo(unit, func(v float64, unit string) Measurement {
+		return NewTime(v, unit)
+	})
+	if err != nil {
+		return Time{}, err
+	}
+	return m.(Time), nil
+}
+
+// ToConcentration converts a quantity into a Concentration in the given
+// unit. The unit determines whether the result is a mass, molar, activity
+// or relative (X) concentration.
+func (a Quantity) ToConcentration(unit string) (Concentration, error) {
+	m, err := a.convertTo(unit, func(v float64, unit string) Measurement {
+		return NewConcentration(v, unit)
+	})
+	if err != nil {
+		return Concentration{}, err
+	}
+	return m.(Concentration), nil
+}
+
+// ToRate converts a quantity into a Rate in the given unit
+func (a Quantity) ToRate(unit string) (Rate, error) {
+	m, err := a.convertTo(unit, func(v float64, unit string) Measurement {
+		r, err := NewRate(v, unit)
+		if err != nil {
+			panic(err)
+		}
+		return r
+	})
+	if err != nil {
+		return Rate{}, err
+	}
+	return m.(Rate), nil
+}
+
+// ToFlowRate converts a quantity into a FlowRate (always in ml/min)
+func (a Quantity) ToFlowRate() (FlowRate, error) {
+	m, err := a.convertTo("ml/min", func(v float64, unit string) Measurement {
+		return NewFlowRate(v, unit)
+	})
+	if err != nil {
+		return FlowRate{}, err
+	}
+	return m.(FlowRate), nil
+}
+
+// ToVelocity converts a quantity into a Velocity (always in m/s)
+func (a Quantity) ToVelocity() (Velocity, error) {
+	m, err := a.convertTo("m/s", func(v float64, unit string) Measurement {
+		return NewVelocity(v, unit)
+	})
+	if err != nil {
+		return Velocity{}, err
+	}
+	return m.(Velocity), nil
+}
+
+// ToDensity converts a quantity into a Density (always in kg/m^3)
+func (a Quantity) ToDensity() (Density, error) {
+	m, err := a.convertTo("kg/m^3", func(v float64, unit string) Measurement {
+		return NewDensity(v, unit)
+	})
+	if err != nil {
+		return Density{}, err
+	}
+	return m.(Density), nil
+}
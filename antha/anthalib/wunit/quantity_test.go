@@ -0,0 +1,216 @@
+// wunit/quantity_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wunit
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(math.Abs(a), math.Abs(b))
+}
+
+type parseUnitTest struct {
+	Unit  string
+	Value float64
+	Dim   Dimension
+}
+
+var parseUnitTests = []parseUnitTest{
+	{Unit: "mg/mL", Value: 1, Dim: MassConcentration},
+	{Unit: "µL/s", Value: 1e-9, Dim: FlowRateDimension},
+	{Unit: "uL/s", Value: 1e-9, Dim: FlowRateDimension},
+	{Unit: "nmol/µL", Value: 1, Dim: MolarConcentration},
+	{Unit: "cells/mL", Value: 1e6, Dim: CountConcentration},
+	{Unit: "mM", Value: 1, Dim: MolarConcentration},
+	{Unit: "ml/min", Value: 1e-6 / 60, Dim: FlowRateDimension},
+	{Unit: "kg*m^2/s^2", Value: 1, Dim: EnergyDimension},
+	{Unit: "kg/m^3", Value: 1, Dim: MassConcentration},
+	{Unit: "mm^2", Value: 1e-6, Dim: AreaDimension},
+	{Unit: "/min", Value: 1.0 / 60, Dim: RateDimension},
+	{Unit: "1/h", Value: 1.0 / 3600, Dim: RateDimension},
+	{Unit: "U/ml", Value: 1e-6 / 60 * 1e6, Dim: ActivityConcentration},
+	{Unit: "X", Value: 1, Dim: Dimensionless},
+	{Unit: "", Value: 1, Dim: Dimensionless},
+}
+
+func TestParseUnit(t *testing.T) {
+	for _, test := range parseUnitTests {
+		q, err := ParseUnit(test.Unit)
+		if err != nil {
+			t.Errorf("%q: %s", test.Unit, err)
+			continue
+		}
+		if q.Dim != test.Dim {
+			t.Errorf("%q: expecting dimension %s found %s", test.Unit, test.Dim, q.Dim)
+		}
+		if !closeTo(q.Value, test.Value) {
+			t.Errorf("%q: expecting value %g found %g", test.Unit, test.Value, q.Value)
+		}
+	}
+
+	for _, bad := range []string{"parsecs", "mg/", "ml^x", "qL"} {
+		if _, err := ParseUnit(bad); err == nil {
+			t.Errorf("%q: expecting error", bad)
+		}
+	}
+}
+
+var parseQuantityTests = []parseUnitTest{
+	{Unit: "10 mg/mL", Value: 10, Dim: MassConcentration},
+	{Unit: "2.5µL/s", Value: 2.5e-9, Dim: FlowRateDimension},
+	{Unit: " 3 mM ", Value: 3, Dim: MolarConcentration},
+	{Unit: "4", Value: 4, Dim: Dimensionless},
+}
+
+func TestParseQuantity(t *testing.T) {
+	for _, test := range parseQuantityTests {
+		q, err := ParseQuantity(test.Unit)
+		if err != nil {
+			t.Errorf("%q: %s", test.Unit, err)
+			continue
+		}
+		if q.Dim != test.Dim {
+			t.Errorf("%q: expecting dimension %s found %s", test.Unit, test.Dim, q.Dim)
+		}
+		if !closeTo(q.Value, test.Value) {
+			t.Errorf("%q: expecting value %g found %g", test.Unit, test.Value, q.Value)
+		}
+	}
+
+	for _, bad := range []string{"mg/ml", "", "ten mg", "10 parsecs"} {
+		if _, err := ParseQuantity(bad); err == nil {
+			t.Errorf("%q: expecting error", bad)
+		}
+	}
+}
+
+func TestQuantityArithmetic(t *testing.T) {
+	mass := NewMass(10, "mg")
+	vol := NewVolume(2, "ml")
+
+	conc, err := DivideMeasurements(mass, vol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conc.Dim != MassConcentration {
+		t.Errorf("expecting mass concentration found %s", conc.Dim)
+	}
+	if v, err := conc.In("mg/ml"); err != nil {
+		t.Error(err)
+	} else if !closeTo(v, 5) {
+		t.Errorf("expecting 5 mg/ml found %g", v)
+	}
+
+	flow, err := DivideMeasurements(vol, NewTime(4, "s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flow.Dim != FlowRateDimension {
+		t.Errorf("expecting flow rate found %s", flow.Dim)
+	}
+
+	back := conc.Multiply(flow).Multiply(Quantity{Value: 4, Dim: TimeDimension})
+	if m, err := back.ToMass("mg"); err != nil {
+		t.Error(err)
+	} else if !closeTo(m.RawValue(), 10) {
+		t.Errorf("expecting 10 mg found %s", m.ToString())
+	}
+
+	if _, err := conc.Add(flow); err == nil {
+		t.Errorf("expecting error adding %s to %s", flow, conc)
+	}
+	if _, err := conc.ToVolume("ul"); err == nil {
+		t.Errorf("expecting error converting %s to volume", conc)
+	}
+}
+
+func TestQuantityConversions(t *testing.T) {
+	q, err := ParseQuantity("2.5 nmol/µL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := q.ToConcentration("mM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.EqualTo(NewConcentration(2.5, "mM")) {
+		t.Errorf("expecting 2.5 mM found %s", c.ToString())
+	}
+
+	g, err := QuantityOf(NewConcentration(1, "ng/ul"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, err := g.ToConcentration("ug/ml"); err != nil {
+		t.Error(err)
+	} else if !closeTo(c.SIValue(), NewConcentration(1, "ug/ml").SIValue()) {
+		t.Errorf("expecting 1 ug/ml found %s", c.ToString())
+	}
+
+	flow, err := ParseQuantity("100µL/s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fr, err := flow.ToFlowRate(); err != nil {
+		t.Error(err)
+	} else if !closeTo(fr.RawValue(), 6) {
+		t.Errorf("expecting 6 ml/min found %s", fr.ToString())
+	}
+
+	if r, err := (Quantity{Value: 2, Dim: RateDimension}).ToRate("/min"); err != nil {
+		t.Error(err)
+	} else if !closeTo(r.SIValue(), 2) {
+		t.Errorf("expecting 2 /s found %s", r.ToString())
+	}
+
+	if tm, err := (Quantity{Value: 90, Dim: TimeDimension}).ToTime("min"); err != nil {
+		t.Error(err)
+	} else if !closeTo(tm.Seconds(), 90) {
+		t.Errorf("expecting 90 s found %s", tm.ToString())
+	}
+
+	if _, err := q.ToConcentration("parsecs"); err == nil {
+		t.Errorf("expecting error for unknown unit")
+	}
+}
+
+func TestQuantityJSON(t *testing.T) {
+	q, err := ParseQuantity("10 cells/ml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r Quantity
+	if err := json.Unmarshal(bs, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Dim != q.Dim || !closeTo(r.Value, q.Value) {
+		t.Errorf("expecting %s found %s", q, r)
+	}
+}
@@ -33,26 +33,45 @@ type stringer interface {
 	ToString() string
 }
 
+type uncertainStringer interface {
+	uncertain
+	ToStringWithUncertainty() string
+}
+
+// marshal writes measurements as strings, e.g., "10 ul", or "10 ± 0.5 ul" if
+// they are uncertain
 func marshal(x stringer) ([]byte, error) {
 	var s *string
 	if x != nil {
 		r := x.ToString()
+		if u, ok := x.(uncertainStringer); ok && u.Uncertainty() != 0.0 {
+			r = u.ToStringWithUncertainty()
+		}
 		s = &r
 	}
 	return json.Marshal(s)
 }
 
-func unmarshal(b []byte) (value float64, unit string, err error) {
+func unmarshal(b []byte) (value float64, unit string, uncertainty float64, err error) {
 	var s *string
 	if err = json.Unmarshal(b, &s); err != nil {
 		return
 	} else if s == nil {
 		return
 	}
-	if _, err = fmt.Fscanf(strings.NewReader(*s), "%e%s", &value, &unit); err != nil && err == io.EOF {
+	str := *s
+	if idx := strings.Index(str, "±"); idx >= 0 {
+		var rest string
+		if _, err = fmt.Fscanf(strings.NewReader(str[idx+len("±"):]), "%e%s", &uncertainty, &rest); err != nil && err != io.EOF {
+			return
+		}
+		err = nil
+		str = str[:idx] + rest
+	}
+	if _, err = fmt.Fscanf(strings.NewReader(str), "%e%s", &value, &unit); err != nil && err == io.EOF {
 		err = nil
 		unit = ""
-		if _, err = fmt.Fscanf(strings.NewReader(*s), "%e", &value); err != nil {
+		if _, err = fmt.Fscanf(strings.NewReader(str), "%e", &value); err != nil {
 			return
 		}
 	}
@@ -64,12 +83,13 @@ func (m Volume) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Volume) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewVolume(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Volume{&cm}
 	}
 	return nil
@@ -80,12 +100,13 @@ func (m Temperature) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Temperature) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewTemperature(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Temperature{&cm}
 	}
 	return nil
@@ -96,12 +117,13 @@ func (m Concentration) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Concentration) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewConcentration(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Concentration{&cm}
 	}
 	return nil
@@ -112,12 +134,13 @@ func (m Time) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Time) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewTime(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Time{&cm}
 	}
 	return nil
@@ -128,12 +151,13 @@ func (m Density) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Density) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewDensity(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Density{&cm}
 	}
 	return nil
@@ -144,12 +168,13 @@ func (m Mass) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Mass) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewMass(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Mass{&cm}
 
 	}
@@ -161,12 +186,13 @@ func (m FlowRate) MarshalJSON() ([]byte, error) {
 }
 
 func (m *FlowRate) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewFlowRate(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = FlowRate{&cm}
 
 	}
@@ -178,12 +204,13 @@ func (m Moles) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Moles) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewMoles(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Moles{&cm}
 	}
 	return nil
@@ -194,12 +221,13 @@ func (m Pressure) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Pressure) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewPressure(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Pressure{&cm}
 	}
 	return nil
@@ -210,12 +238,13 @@ func (m Length) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Length) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewLength(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Length{&cm}
 	}
 	return nil
@@ -226,12 +255,13 @@ func (m Area) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Area) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewArea(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Area{&cm}
 	}
 	return nil
@@ -242,12 +272,13 @@ func (m Angle) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Angle) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewAngle(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Angle{&cm}
 	}
 	return nil
@@ -258,12 +289,13 @@ func (m Energy) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Energy) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewEnergy(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Energy{&cm}
 	}
 	return nil
@@ -274,12 +306,13 @@ func (m Force) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Force) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewForce(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Force{&cm}
 	}
 	return nil
@@ -290,12 +323,13 @@ func (m Velocity) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Velocity) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m = NewVelocity(value, unit)
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Velocity{&cm}
 	}
 	return nil
@@ -306,15 +340,16 @@ func (m Rate) MarshalJSON() ([]byte, error) {
 }
 
 func (m *Rate) UnmarshalJSON(b []byte) error {
-	if value, unit, err := unmarshal(b); err != nil {
+	if value, unit, u, err := unmarshal(b); err != nil {
 		return err
 	} else if unit != "" {
 		*m, err = NewRate(value, unit)
 		if err != nil {
 			return err
 		}
+		m.SetUncertainty(u)
 	} else {
-		cm := ConcreteMeasurement{0, nil}
+		cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 		*m = Rate{&cm}
 	}
 	return nil
@@ -48,7 +48,7 @@ func TestVolumeMarshal(t *testing.T) {
 
 	var v3 Volume
 
-	cm := ConcreteMeasurement{0, nil}
+	cm := ConcreteMeasurement{Mvalue: 0, Munit: nil}
 	v = Volume{&cm}
 	if enc, err = json.Marshal(v); err != nil {
 		t.Fatal(err)
@@ -0,0 +1,123 @@
+// wunit/uncertainty.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wunit
+
+import (
+	"fmt"
+	"math"
+)
+
+// Uncertainty returns the standard uncertainty of a measurement in its
+// current units. Zero means the value is taken to be exact.
+func (cm *ConcreteMeasurement) Uncertainty() float64 {
+	if cm == nil {
+		return 0.0
+	}
+	return cm.Muncertainty
+}
+
+// SetUncertainty sets the standard uncertainty of a measurement in its
+// current units
+func (cm *ConcreteMeasurement) SetUncertainty(u float64) {
+	if cm == nil {
+		return
+	}
+	cm.Muncertainty = math.Abs(u)
+}
+
+// RelativeUncertainty returns the uncertainty of a measurement as a
+// fraction of its value, i.e., its coefficient of variation (CV)
+func (cm *ConcreteMeasurement) RelativeUncertainty() float64 {
+	if cm == nil || cm.Muncertainty == 0.0 {
+		return 0.0
+	} else if cm.Mvalue == 0.0 {
+		return math.Inf(1)
+	}
+	return math.Abs(cm.Muncertainty / cm.Mvalue)
+}
+
+// SetRelativeUncertainty sets the uncertainty of a measurement from a
+// coefficient of variation
+func (cm *ConcreteMeasurement) SetRelativeUncertainty(cv float64) {
+	if cm == nil {
+		return
+	}
+	cm.Muncertainty = math.Abs(cv * cm.Mvalue)
+}
+
+// ToStringWithUncertainty returns the measurement as value ± uncertainty,
+// e.g., "10 ± 0.2 ul". If the measurement is exact this is the same as
+// ToString.
+func (cm *ConcreteMeasurement) ToStringWithUncertainty() string {
+	if cm.Uncertainty() == 0.0 {
+		return cm.ToString()
+	}
+	return fmt.Sprintf("%.3g ± %.2g %s", cm.RawValue(), cm.Uncertainty(), cm.Unit().PrefixedSymbol())
+}
+
+type uncertain interface {
+	Uncertainty() float64
+}
+
+// uncertaintyOf returns the uncertainty of any measurement
+func uncertaintyOf(m Measurement) float64 {
+	if u, ok := m.(uncertain); ok {
+		return u.Uncertainty()
+	}
+	return 0.0
+}
+
+// uncertaintyIn returns the uncertainty of a measurement converted into
+// another unit
+func uncertaintyIn(m Measurement, pu PrefixedUnit) float64 {
+	u := uncertaintyOf(m)
+	if u == 0.0 {
+		return 0.0
+	}
+	return u * m.Unit().ConvertTo(pu)
+}
+
+// sumUncertainty returns the uncertainty, in unit pu, of the sum or
+// difference of independent measurements
+func sumUncertainty(pu PrefixedUnit, ms ...Measurement) float64 {
+	var sum float64
+	for _, m := range ms {
+		u := uncertaintyIn(m, pu)
+		sum += u * u
+	}
+	return math.Sqrt(sum)
+}
+
+// productRelativeUncertainty returns the relative uncertainty of the
+// product or quotient of independent measurements. Measurements of zero have
+// no relative uncertainty and are ignored.
+func productRelativeUncertainty(ms ...Measurement) float64 {
+	var sum float64
+	for _, m := range ms {
+		if u := uncertaintyOf(m); u != 0.0 && m.RawValue() != 0.0 {
+			r := u / m.RawValue()
+			sum += r * r
+		}
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,155 @@
+// wunit/uncertainty_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wunit
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func withUncertainty(v Volume, u float64) Volume {
+	v.SetUncertainty(u)
+	return v
+}
+
+func TestUncertaintyArithmetic(t *testing.T) {
+	a := withUncertainty(NewVolume(10, "ul"), 0.3)
+	b := withUncertainty(NewVolume(0.02, "ml"), 0.0004)
+
+	a.Add(b)
+	if e, f := 0.5, a.Uncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting uncertainty %g found %g", e, f)
+	}
+
+	a.MultiplyBy(-2)
+	if e, f := 1.0, a.Uncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting uncertainty %g found %g", e, f)
+	}
+
+	a.DivideBy(4)
+	if e, f := 0.25, a.Uncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting uncertainty %g found %g", e, f)
+	}
+
+	c := withUncertainty(NewVolume(10, "ul"), 0.3)
+	c.Subtract(withUncertainty(NewVolume(4, "ul"), 0.4))
+	if e, f := 0.5, c.Uncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting uncertainty %g found %g", e, f)
+	}
+	if e, f := 0.5/6, c.RelativeUncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting relative uncertainty %g found %g", e, f)
+	}
+
+	exact := NewVolume(10, "ul")
+	exact.Add(NewVolume(5, "ul"))
+	if f := exact.Uncertainty(); f != 0 {
+		t.Errorf("expecting exact value found uncertainty %g", f)
+	}
+}
+
+func TestUncertaintyHelpers(t *testing.T) {
+	sum := AddVolumes(
+		withUncertainty(NewVolume(10, "ul"), 0.3),
+		withUncertainty(NewVolume(20, "ul"), 0.4),
+	)
+	if e, f := 0.5, sum.Uncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting uncertainty %g found %g", e, f)
+	}
+
+	if e, f := 1.5, MultiplyVolume(sum, 3).Uncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting uncertainty %g found %g", e, f)
+	}
+
+	if e, f := 0.5, CopyVolume(sum).Uncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting uncertainty %g found %g", e, f)
+	}
+
+	stock := NewConcentration(100, "mM")
+	stock.SetRelativeUncertainty(0.03)
+	target := NewConcentration(10, "mM")
+	total := NewVolume(100, "ul")
+	total.SetRelativeUncertainty(0.04)
+
+	v, err := VolumeForTargetConcentration(target, stock, total)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.EqualTo(NewVolume(10, "ul")) {
+		t.Errorf("expecting 10 ul found %s", v.ToString())
+	}
+	if e, f := 0.05, v.RelativeUncertainty(); !closeTo(e, f) {
+		t.Errorf("expecting relative uncertainty %g found %g", e, f)
+	}
+	if e, f := "10 ± 0.5 ul", v.ToStringWithUncertainty(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+	if e, f := "10 ul", NewVolume(10, "ul").ToStringWithUncertainty(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+
+	if f := NewVolume(0, "ul").RelativeUncertainty(); f != 0 {
+		t.Errorf("expecting no relative uncertainty for exact zero found %g", f)
+	}
+	zero := withUncertainty(NewVolume(0, "ul"), 1)
+	if f := zero.RelativeUncertainty(); !math.IsInf(f, 1) {
+		t.Errorf("expecting infinite relative uncertainty found %g", f)
+	}
+}
+
+func TestUncertaintyZeroFactor(t *testing.T) {
+	target := NewConcentration(0, "mM")
+	target.SetUncertainty(0.1)
+	stock := NewConcentration(100, "mM")
+	stock.SetRelativeUncertainty(0.03)
+
+	v, err := VolumeForTargetConcentration(target, stock, NewVolume(100, "ul"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := v.Uncertainty(); math.IsNaN(f) || math.IsInf(f, 0) {
+		t.Errorf("expecting finite uncertainty found %g", f)
+	}
+}
+
+func TestUncertaintyJSON(t *testing.T) {
+	for _, v := range []Volume{
+		withUncertainty(NewVolume(10, "ul"), 0.5),
+		NewVolume(10, "ul"),
+	} {
+		bs, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Volume
+		if err := json.Unmarshal(bs, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !got.EqualTo(v) {
+			t.Errorf("expecting %s found %s", v.ToString(), got.ToString())
+		}
+		if e, f := v.Uncertainty(), got.Uncertainty(); !closeTo(e, f) {
+			t.Errorf("%s: expecting uncertainty %g found %g", bs, e, f)
+		}
+	}
+}
@@ -102,6 +102,7 @@ func NewVolume(v float64, unit string) (o Volume) {
 
 func CopyVolume(v Volume) Volume {
 	ret := NewVolume(v.RawValue(), v.Unit().PrefixedSymbol())
+	ret.SetUncertainty(v.Uncertainty())
 	return ret
 }
 
@@ -119,6 +120,12 @@ func AddVolumes(vols ...Volume) (newvolume Volume) {
 			newvolume = tempvol
 		}
 	}
+
+	ms := make([]Measurement, 0, len(vols))
+	for _, vol := range vols {
+		ms = append(ms, vol)
+	}
+	newvolume.SetUncertainty(sumUncertainty(newvolume.Unit(), ms...))
 	return
 
 }
@@ -142,6 +149,7 @@ func SubtractVolumes(OriginalVol Volume, subtractvols ...Volume) (newvolume Volu
 func MultiplyVolume(v Volume, factor float64) (newvolume Volume) {
 
 	newvolume = NewVolume(v.RawValue()*float64(factor), v.Unit().PrefixedSymbol())
+	newvolume.SetUncertainty(v.Uncertainty() * factor)
 	return
 
 }
@@ -150,6 +158,7 @@ func MultiplyVolume(v Volume, factor float64) (newvolume Volume) {
 func DivideVolume(v Volume, factor float64) (newvolume Volume) {
 
 	newvolume = NewVolume(v.RawValue()/float64(factor), v.Unit().PrefixedSymbol())
+	newvolume.SetUncertainty(v.Uncertainty() / factor)
 	return
 
 }
@@ -177,6 +186,7 @@ func DivideVolumes(vol1, vol2 Volume) (factor float64, err error) {
 
 func CopyConcentration(v Concentration) Concentration {
 	ret := NewConcentration(v.RawValue(), v.Unit().PrefixedSymbol())
+	ret.SetUncertainty(v.Uncertainty())
 	return ret
 }
 
@@ -184,6 +194,7 @@ func CopyConcentration(v Concentration) Concentration {
 func MultiplyConcentration(v Concentration, factor float64) (newconc Concentration) {
 
 	newconc = NewConcentration(v.RawValue()*float64(factor), v.Unit().PrefixedSymbol())
+	newconc.SetUncertainty(v.Uncertainty() * factor)
 	return
 
 }
@@ -192,6 +203,7 @@ func MultiplyConcentration(v Concentration, factor float64) (newconc Concentrati
 func DivideConcentration(v Concentration, factor float64) (newconc Concentration) {
 
 	newconc = NewConcentration(v.RawValue()/float64(factor), v.Unit().PrefixedSymbol())
+	newconc.SetUncertainty(v.Uncertainty() / factor)
 	return
 
 }
@@ -239,6 +251,14 @@ func AddConcentrations(concs ...Concentration) (newconc Concentration, err error
 			newconc = tempconc
 		}
 	}
+
+	if err == nil {
+		ms := make([]Measurement, 0, len(concs))
+		for _, conc := range concs {
+			ms = append(ms, conc)
+		}
+		newconc.SetUncertainty(sumUncertainty(newconc.Unit(), ms...))
+	}
 	return
 
 }
@@ -265,6 +285,7 @@ func SubtractConcentrations(originalConc Concentration, subtractConcs ...Concent
 
 func (v Volume) Dup() Volume {
 	ret := NewVolume(v.RawValue(), v.Unit().PrefixedSymbol())
+	ret.SetUncertainty(v.Uncertainty())
 	return ret
 }
 
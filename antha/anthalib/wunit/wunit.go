@@ -105,6 +105,8 @@ type ConcreteMeasurement struct {
 	Mvalue float64
 	// the relevant units
 	Munit *GenericPrefixedUnit
+	// the standard uncertainty of the raw value, zero if the value is exact
+	Muncertainty float64 `json:",omitempty"`
 }
 
 /*
@@ -184,6 +186,7 @@ func (cm *ConcreteMeasurement) Add(m Measurement) {
 	// ideally should check these have the same Dimension
 	// need to improve this
 
+	cm.Muncertainty = math.Hypot(cm.Muncertainty, uncertaintyIn(m, cm.Unit()))
 	cm.SetValue(m.ConvertTo(cm.Unit()) + cm.RawValue())
 
 }
@@ -201,6 +204,7 @@ func (cm *ConcreteMeasurement) Subtract(m Measurement) {
 	// ideally should check these have the same Dimension
 	// need to improve this
 
+	cm.Muncertainty = math.Hypot(cm.Muncertainty, uncertaintyIn(m, cm.Unit()))
 	cm.SetValue(cm.RawValue() - m.ConvertTo(cm.Unit()))
 
 }
@@ -216,6 +220,7 @@ func (cm *ConcreteMeasurement) MultiplyBy(factor float64) {
 	// ideally should check these have the same Dimension
 	// need to improve this
 
+	cm.Muncertainty *= math.Abs(factor)
 	cm.SetValue(cm.RawValue() * float64(factor))
 
 }
@@ -232,6 +237,7 @@ func (cm *ConcreteMeasurement) DivideBy(factor float64) {
 	// ideally should check these have the same Dimension
 	// need to improve this
 
+	cm.Muncertainty /= math.Abs(factor)
 	cm.SetValue(cm.RawValue() / float64(factor))
 
 }
@@ -395,13 +401,13 @@ func (cm *ConcreteMeasurement) ToString() string {
 /**********/
 
 func NewPMeasurement(v float64, pu string) *ConcreteMeasurement {
-	cm := ConcreteMeasurement{v, ParsePrefixedUnit(pu)}
+	cm := ConcreteMeasurement{Mvalue: v, Munit: ParsePrefixedUnit(pu)}
 	return &cm
 }
 
 // helper function for creating a new measurement
 func NewMeasurement(v float64, prefix string, unit string) *ConcreteMeasurement {
 	gpu := NewPrefixedUnit(prefix, unit)
-	cm := ConcreteMeasurement{v, gpu}
+	cm := ConcreteMeasurement{Mvalue: v, Munit: gpu}
 	return &cm
 }
@@ -61,7 +61,7 @@ func TestJSON(*testing.T) {
 
 func ExampleBasic() {
 	degreeC := GenericPrefixedUnit{GenericUnit{"DegreeC", "C", 1.0, "C"}, SIPrefix{"m", 1e-03}}
-	cm := ConcreteMeasurement{1.0, &degreeC}
+	cm := ConcreteMeasurement{Mvalue: 1.0, Munit: &degreeC}
 	TdegreeC := Temperature{&cm}
 	fmt.Println(TdegreeC.SIValue())
 	// Output:
@@ -69,7 +69,7 @@ func ExampleBasic() {
 }
 func ExampleTwo() {
 	Joule := GenericPrefixedUnit{GenericUnit{"Joule", "J", 1.0, "J"}, SIPrefix{"k", 1e3}}
-	cm := ConcreteMeasurement{23.4, &Joule}
+	cm := ConcreteMeasurement{Mvalue: 23.4, Munit: &Joule}
 	NJoule := Energy{&cm}
 	fmt.Println(NJoule.SIValue())
 	// Output:
@@ -127,9 +127,9 @@ func ExampleEight() {
 	pu := ParsePrefixedUnit("GHz")
 	pu2 := ParsePrefixedUnit("MHz")
 	pu3 := ParsePrefixedUnit("l")
-	meas := ConcreteMeasurement{10, pu}
-	meas2 := ConcreteMeasurement{50, pu2}
-	meas3 := ConcreteMeasurement{10, pu3}
+	meas := ConcreteMeasurement{Mvalue: 10, Munit: pu}
+	meas2 := ConcreteMeasurement{Mvalue: 50, Munit: pu2}
+	meas3 := ConcreteMeasurement{Mvalue: 10, Munit: pu3}
 
 	fmt.Println(meas.ToString(), " is ", meas.ConvertTo(meas.Unit()), " ", pu.PrefixedSymbol())
 	fmt.Println(meas2.ToString(), " is ", meas2.ConvertTo(meas.Unit()), " ", pu.PrefixedSymbol())
@@ -0,0 +1,72 @@
+// doc.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antha-lang/antha/cmd/antha/doc"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var docCmd = &cobra.Command{
+	Use:   "doc",
+	Short: "Generate a catalogue of available antha elements",
+	RunE:  makeDoc,
+}
+
+func makeDoc(cmd *cobra.Command, args []string) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	elems, err := doc.New(library)
+	if err != nil {
+		return err
+	}
+
+	dir := viper.GetString("outputDir")
+	if err := doc.Write(dir, viper.GetString("format"), elems); err != nil {
+		return err
+	}
+
+	_, err = fmt.Printf("Wrote documentation for %d elements to %s\n", len(elems), dir)
+	return err
+}
+
+func init() {
+	c := docCmd
+	flags := c.Flags()
+	RootCmd.AddCommand(c)
+
+	flags.String("outputDir", "elements-doc", "Directory to write documentation to")
+	flags.String(
+		"format",
+		doc.HTMLFormat,
+		fmt.Sprintf("Output format: one of {%s}", strings.Join([]string{
+			doc.HTMLFormat,
+			doc.MarkdownFormat,
+		}, ",")))
+}
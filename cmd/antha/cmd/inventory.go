@@ -0,0 +1,303 @@
+// inventory.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/inventory/fileinventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	"github.com/antha-lang/antha/target"
+	"github.com/ghodss/yaml"
+	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var inventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Manage a persistent inventory of labware and components",
+}
+
+var inventoryAddCmd = &cobra.Command{
+	Use:   "add <kind> <file>",
+	Short: "Add a definition from a json file",
+	RunE:  inventoryAdd,
+}
+
+var inventoryImportCmd = &cobra.Command{
+	Use:   "import [<kind> <file>]",
+	Short: "Import a json array of definitions or the builtin definitions",
+	RunE:  inventoryImport,
+}
+
+var inventoryListCmd = &cobra.Command{
+	Use:   "list [kind]",
+	Short: "List definitions and their stock",
+	RunE:  inventoryList,
+}
+
+var inventoryStockCmd = &cobra.Command{
+	Use:   "stock <kind> <type> <quantity>",
+	Short: "Add stock of a definition; quantities of components are in ul",
+	RunE:  inventoryStock,
+}
+
+func openInventory(cmd *cobra.Command, args []string, nargs int) (*fileinventory.Inventory, error) {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return nil, err
+	}
+	if len(args) > nargs {
+		return nil, fmt.Errorf("expecting at most %d arguments found %d", nargs, len(args))
+	}
+	fn := viper.GetString("inventory")
+	if len(fn) == 0 {
+		return nil, errors.New("no inventory directory given")
+	}
+	return fileinventory.Open(fn)
+}
+
+func inventoryAdd(cmd *cobra.Command, args []string) error {
+	switch len(args) {
+	case 0:
+		return fmt.Errorf("no kind given")
+	case 1:
+		return fmt.Errorf("no definition file given")
+	}
+
+	inv, err := openInventory(cmd, args, 2)
+	if err != nil {
+		return err
+	}
+	defer inv.Close() // nolint: errcheck
+
+	bs, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	_, err = inv.Import(args[0], []byte("["+string(bs)+"]"))
+	return err
+}
+
+func importBuiltin(inv *fileinventory.Inventory) (int, error) {
+	ctx := testinventory.NewContext(context.Background())
+
+	var n int
+	for _, p := range testinventory.GetPlates(ctx) {
+		if err := inv.AddPlate(p); err != nil {
+			return n, err
+		}
+		n++
+	}
+	for _, tb := range testinventory.GetTipboxes(ctx) {
+		if err := inv.AddTipbox(tb); err != nil {
+			return n, err
+		}
+		n++
+	}
+	for _, tw := range testinventory.GetTipwastes(ctx) {
+		if err := inv.AddTipwaste(tw); err != nil {
+			return n, err
+		}
+		n++
+	}
+	for _, c := range testinventory.GetComponents(ctx) {
+		if err := inv.AddComponent(c); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+func inventoryImport(cmd *cobra.Command, args []string) error {
+	inv, err := openInventory(cmd, args, 2)
+	if err != nil {
+		return err
+	}
+	defer inv.Close() // nolint: errcheck
+
+	var n int
+	switch {
+	case viper.GetBool("builtin") && len(args) == 0:
+		n, err = importBuiltin(inv)
+	case len(args) == 2:
+		var bs []byte
+		bs, err = ioutil.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		n, err = inv.Import(args[0], bs)
+	default:
+		return errors.New("expecting either a kind and a file or --builtin")
+	}
+
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Printf("imported %d definitions\n", n)
+	return err
+}
+
+func inventoryList(cmd *cobra.Command, args []string) error {
+	inv, err := openInventory(cmd, args, 1)
+	if err != nil {
+		return err
+	}
+	defer inv.Close() // nolint: errcheck
+
+	kinds := fileinventory.Kinds
+	if len(args) != 0 {
+		kinds = args
+	}
+
+	var entries []fileinventory.Entry
+	for _, kind := range kinds {
+		es, err := inv.List(kind)
+		if err != nil {
+			return fmt.Errorf("cannot list %q: %s", kind, err)
+		}
+		entries = append(entries, es...)
+	}
+
+	red := func(x string) string {
+		return ansi.Color(x, "red")
+	}
+
+	output := viper.GetString("output")
+	switch output {
+	case jsonOutput:
+		bs, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(bs))
+		return err
+	case yamlOutput:
+		bs, err := yaml.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Print(string(bs))
+		return err
+	case textOutput:
+		var lines []string
+		lines = append(lines, red("Type")+" Kind Stock")
+		for _, e := range entries {
+			var lots []string
+			for _, l := range e.Lots {
+				lots = append(lots, fmt.Sprintf("%s:%g", l.Number, l.Quantity))
+			}
+			stock := "untracked"
+			if e.Lots != nil {
+				stock = fmt.Sprintf("%g [%s]", e.Total(), strings.Join(lots, " "))
+			}
+			lines = append(lines, red(e.Type)+" "+e.Kind+" "+stock)
+		}
+		_, err := fmt.Println(strings.Join(lines, "\n"))
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", output)
+	}
+}
+
+func inventoryStock(cmd *cobra.Command, args []string) error {
+	switch len(args) {
+	case 0:
+		return fmt.Errorf("no kind given")
+	case 1:
+		return fmt.Errorf("no type given")
+	case 2:
+		return fmt.Errorf("no quantity given")
+	}
+
+	inv, err := openInventory(cmd, args, 3)
+	if err != nil {
+		return err
+	}
+	defer inv.Close() // nolint: errcheck
+
+	quantity, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		return fmt.Errorf("cannot parse quantity %q: %s", args[2], err)
+	}
+
+	return inv.AddStock(fileinventory.Item{Kind: args[0], Type: args[1]}, viper.GetString("lot"), quantity)
+}
+
+// mixUsage returns the labware and input volumes used by the mixes in a
+// run
+func mixUsage(insts []target.Inst) fileinventory.Usage {
+	usage := make(fileinventory.Usage)
+	for _, inst := range insts {
+		mix, ok := inst.(*target.Mix)
+		if !ok || mix.Request == nil {
+			continue
+		}
+		req := mix.Request
+		for _, p := range req.Input_plates {
+			usage[fileinventory.Item{Kind: fileinventory.PlateKind, Type: p.Type}]++
+		}
+		for _, p := range req.Output_plates {
+			usage[fileinventory.Item{Kind: fileinventory.PlateKind, Type: p.Type}]++
+		}
+		for _, tb := range req.Tips {
+			usage[fileinventory.Item{Kind: fileinventory.TipboxKind, Type: tb.Type}]++
+		}
+		for name, v := range req.Input_vols_required {
+			usage[fileinventory.Item{Kind: fileinventory.ComponentKind, Type: name}] += v.ConvertToString("ul")
+		}
+	}
+	return usage
+}
+
+func init() {
+	c := inventoryCmd
+	RootCmd.AddCommand(c)
+	c.PersistentFlags().String("inventory", "antha-inventory", "Inventory directory")
+
+	c.AddCommand(inventoryAddCmd)
+
+	c.AddCommand(inventoryImportCmd)
+	inventoryImportCmd.Flags().Bool("builtin", false, "Import the builtin definitions")
+
+	c.AddCommand(inventoryListCmd)
+	inventoryListCmd.Flags().String(
+		"output",
+		textOutput,
+		fmt.Sprintf("Output format: one of {%s}", strings.Join([]string{
+			textOutput,
+			yamlOutput,
+			jsonOutput,
+		}, ",")))
+
+	c.AddCommand(inventoryStockCmd)
+	inventoryStockCmd.Flags().String("lot", "", "Lot number")
+}
@@ -25,9 +25,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"sort"
 	"strings"
 
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	"github.com/ghodss/yaml"
 	"github.com/mgutz/ansi"
@@ -65,6 +68,10 @@ func listPolicies(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if viper.GetBool("explain") {
+		return explainPolicies()
+	}
+
 	red := func(x string) string {
 		return ansi.Color(x, "red")
 	}
@@ -132,7 +139,163 @@ func listPolicies(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// loadPolicies returns the default rule set merged with any given in files
+func loadPolicies() (*wtype.LHPolicyRuleSet, error) {
+	lhpr, err := liquidhandling.GetLHPolicyForTest()
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range GetStringSlice("policies") {
+		bs, err := ioutil.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+		rs, err := wtype.UnmarshalLHPolicyRuleSet(bs)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse policies in %s: %s", fn, err)
+		}
+		if err := lhpr.MergeWith(rs); err != nil {
+			return nil, fmt.Errorf("cannot merge policies in %s: %s", fn, err)
+		}
+	}
+	return lhpr, nil
+}
+
+type explainedRule struct {
+	Name       string
+	Priority   int
+	Conditions []string
+}
+
+type explainedItem struct {
+	Name   string
+	Value  interface{}
+	Source string
+}
+
+type explainedStep struct {
+	Step   string
+	Rules  []explainedRule
+	Policy []explainedItem
+}
+
+func makeExplainedStep(step string, exp liquidhandling.PolicyExplanation) explainedStep {
+	ret := explainedStep{Step: step}
+	for _, r := range exp.Rules {
+		er := explainedRule{Name: r.Name, Priority: r.Priority}
+		for _, c := range r.Conditions {
+			switch t := c.Condition.(type) {
+			case wtype.LHCategoryCondition:
+				er.Conditions = append(er.Conditions, fmt.Sprintf("%s = %s", c.TestVariable, t.Category))
+			case wtype.LHNumericCondition:
+				er.Conditions = append(er.Conditions, fmt.Sprintf("%g <= %s <= %g", t.Lower, c.TestVariable, t.Upper))
+			}
+		}
+		ret.Rules = append(ret.Rules, er)
+	}
+	for _, name := range wtype.MakePolicyItems().OrderedList() {
+		v, ok := exp.Policy[name]
+		if !ok {
+			continue
+		}
+		ret.Policy = append(ret.Policy, explainedItem{
+			Name:   name,
+			Value:  v,
+			Source: exp.Source[name],
+		})
+	}
+	return ret
+}
+
+// explainPolicies shows how the policies for aspirating and dispensing a
+// transfer are arrived at
+func explainPolicies() error {
+	lhpr, err := loadPolicies()
+	if err != nil {
+		return err
+	}
+
+	liquid := viper.GetString("liquid")
+	if liquid == "" {
+		return fmt.Errorf("liquid type required to explain policies")
+	}
+
+	head := viper.GetInt("head")
+	multi := viper.GetInt("multi")
+	channel := wtype.NewLHChannelParameter("explain", "", wunit.NewVolume(0.0, "ul"), wunit.NewVolume(1000.0, "ul"), wunit.NewFlowRate(0.0, "ml/min"), wunit.NewFlowRate(100.0, "ml/min"), multi, false, wtype.LHVChannel, head)
+
+	tp := liquidhandling.TransferParams{
+		What:       liquid,
+		Volume:     wunit.NewVolume(viper.GetFloat64("volume"), "ul"),
+		FPlateType: viper.GetString("fromPlateType"),
+		TPlateType: viper.GetString("toPlateType"),
+		FVolume:    wunit.NewVolume(viper.GetFloat64("fromVolume"), "ul"),
+		TVolume:    wunit.NewVolume(viper.GetFloat64("toVolume"), "ul"),
+		Channel:    channel,
+		TipType:    viper.GetString("tipType"),
+	}
+
+	asp, dsp := liquidhandling.ExplainTransfer(lhpr, tp, multi)
+	steps := []explainedStep{
+		makeExplainedStep("aspirate", asp),
+		makeExplainedStep("dispense", dsp),
+	}
+
+	output := viper.GetString("output")
+	switch output {
+	case jsonOutput:
+		bs, err := json.MarshalIndent(steps, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(bs))
+		return err
+	case yamlOutput:
+		bs, err := yaml.Marshal(steps)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Print(string(bs))
+		return err
+	case textOutput:
+		red := func(x string) string {
+			return ansi.Color(x, "red")
+		}
+
+		var lines []string
+		for _, s := range steps {
+			lines = append(lines, red(s.Step))
+			lines = append(lines, "  Matched rules, in order applied:")
+			lines = append(lines, "    default")
+			for _, r := range s.Rules {
+				lines = append(lines, fmt.Sprintf("    %s (priority %d): %s", r.Name, r.Priority, strings.Join(r.Conditions, ", ")))
+			}
+			lines = append(lines, "  Policy:")
+			for _, i := range s.Policy {
+				lines = append(lines, fmt.Sprintf("    %s: %v [%s]", i.Name, i.Value, i.Source))
+			}
+		}
+		_, err := fmt.Println(strings.Join(lines, "\n"))
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", output)
+	}
+}
+
 func init() {
 	c := listPoliciesCmd
+	flags := c.Flags()
 	listCmd.AddCommand(c)
+
+	flags.Bool("explain", false, "Explain which rules determine the policy for a transfer")
+	flags.StringSlice("policies", nil, "Files of liquid handling policy rules (JSON or YAML) to add to the defaults when explaining")
+	flags.String("liquid", "", "Liquid type of the transfer to explain")
+	flags.Float64("volume", 0.0, "Volume (ul) of the transfer to explain")
+	flags.String("fromPlateType", "", "Plate type transferred from")
+	flags.String("toPlateType", "", "Plate type transferred to")
+	flags.Float64("fromVolume", 0.0, "Volume (ul) in the well transferred from")
+	flags.Float64("toVolume", 0.0, "Volume (ul) in the well transferred to")
+	flags.String("tipType", "", "Tip type used for the transfer")
+	flags.Int("head", 0, "Head used for the transfer")
+	flags.Int("multi", 1, "Number of channels used for the transfer")
 }
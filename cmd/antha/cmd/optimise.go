@@ -0,0 +1,492 @@
+// optimise.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/doe"
+	"github.com/antha-lang/antha/execute"
+	"github.com/antha-lang/antha/execute/executeutil"
+	"github.com/antha-lang/antha/workflow"
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var optimiseCmd = &cobra.Command{
+	Use:   "optimise",
+	Short: "Optimise workflow parameters over several runs",
+	Long: `Optimise workflow parameters over several runs.
+
+The state of an optimisation is kept in a yaml or json file which gives the
+workflow, the parameters to vary and the response to optimise, e.g.,
+
+  response: Yield
+  maximise: true
+  workflow: workflow.json
+  parameters: parameters.json
+  factors:
+  - name: Glucose
+    process: MakeMedia
+    parameter: GlucoseVolume
+    low: 5
+    high: 50
+    unit: ul
+
+Each round, propose writes a bundle per run to execute with antha run, and
+ingest reads the measured responses back. Proposed and measured runs are
+recorded in the state file.`,
+}
+
+var optimiseProposeCmd = &cobra.Command{
+	Use:   "propose <state file>",
+	Short: "Propose the next batch of runs and write their bundles",
+	RunE:  optimisePropose,
+}
+
+var optimiseIngestCmd = &cobra.Command{
+	Use:   "ingest <state file> <results csv>",
+	Short: "Record the responses of runs from a csv file of run numbers and responses",
+	RunE:  optimiseIngest,
+}
+
+// An optimiseFactor is a workflow parameter to optimise
+type optimiseFactor struct {
+	Name      string  `json:"name"`
+	Process   string  `json:"process"`
+	Parameter string  `json:"parameter"`
+	Low       float64 `json:"low"`
+	High      float64 `json:"high"`
+	// Unit, if given, is appended to values, e.g., 10ul
+	Unit string `json:"unit,omitempty"`
+}
+
+// An optimiseRun is a proposed run and, once measured, its response
+type optimiseRun struct {
+	Run      int                `json:"run"`
+	Round    int                `json:"round"`
+	Bundle   string             `json:"bundle"`
+	Factors  map[string]float64 `json:"factors"`
+	Response *float64           `json:"response,omitempty"`
+}
+
+// optimiseState is the state of an optimisation. Files are relative to the
+// state file.
+type optimiseState struct {
+	Response   string           `json:"response"`
+	Maximise   bool             `json:"maximise"`
+	Bundle     string           `json:"bundle,omitempty"`
+	Workflow   string           `json:"workflow,omitempty"`
+	Parameters string           `json:"parameters,omitempty"`
+	Factors    []optimiseFactor `json:"factors"`
+	Seed       int64            `json:"seed,omitempty"`
+	Runs       []optimiseRun    `json:"runs,omitempty"`
+}
+
+func readOptimiseState(fn string) (*optimiseState, error) {
+	bs, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	var s optimiseState
+	if err := yaml.Unmarshal(bs, &s); err != nil {
+		return nil, fmt.Errorf("cannot parse state file %q: %s", fn, err)
+	}
+	if len(s.Response) == 0 {
+		return nil, errors.New("no response given")
+	}
+	if len(s.Factors) == 0 {
+		return nil, errors.New("no factors given")
+	}
+	for _, f := range s.Factors {
+		if len(f.Name) == 0 || len(f.Process) == 0 || len(f.Parameter) == 0 {
+			return nil, errors.New("factors need a name, process and parameter")
+		}
+		if f.Low >= f.High {
+			return nil, fmt.Errorf("factor %s: low must be less than high", f.Name)
+		}
+	}
+	return &s, nil
+}
+
+func writeOptimiseState(fn string, s *optimiseState) error {
+	var bs []byte
+	var err error
+	if strings.ToLower(filepath.Ext(fn)) == ".json" {
+		bs, err = json.MarshalIndent(s, "", "  ")
+	} else {
+		bs, err = yaml.Marshal(s)
+	}
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fn, bs, 0666)
+}
+
+func (s *optimiseState) doeFactors() []doe.DOEPair {
+	var factors []doe.DOEPair
+	for _, f := range s.Factors {
+		factors = append(factors, doe.DOEPair{Factor: f.Name, Levels: []interface{}{f.Low, f.High}})
+	}
+	return factors
+}
+
+// history returns the runs so far as DOE runs
+func (s *optimiseState) history() []doe.Run {
+	var runs []doe.Run
+	for _, r := range s.Runs {
+		var run doe.Run
+		for _, f := range s.Factors {
+			run = doe.AddNewFactorFieldandValue(run, f.Name, r.Factors[f.Name])
+		}
+		if r.Response != nil {
+			run = doe.AddNewResponseFieldandValue(run, s.Response, *r.Response)
+		}
+		run.RunNumber = r.Run
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+func (s *optimiseState) pending() []int {
+	var runs []int
+	for _, r := range s.Runs {
+		if r.Response == nil {
+			runs = append(runs, r.Run)
+		}
+	}
+	return runs
+}
+
+// relativeTo returns a file name relative to the directory of the state file
+func relativeTo(stateFile, fn string) string {
+	if len(fn) == 0 || filepath.IsAbs(fn) {
+		return fn
+	}
+	return filepath.Join(filepath.Dir(stateFile), fn)
+}
+
+// relativeFrom returns a file name relative to the working directory as one
+// relative to the directory of the state file
+func relativeFrom(stateFile, fn string) (string, error) {
+	if filepath.IsAbs(fn) {
+		return fn, nil
+	}
+	dir, err := filepath.Abs(filepath.Dir(stateFile))
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(fn)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return abs, nil
+	}
+	return rel, nil
+}
+
+// withFactors returns a bundle with the parameters of the factors set to
+// their values in a run
+func (s *optimiseState) withFactors(base *executeutil.Bundle, values map[string]float64) (interface{}, error) {
+	params := make(map[string]map[string]json.RawMessage)
+	for process, ps := range base.Parameters {
+		params[process] = make(map[string]json.RawMessage)
+		for name, v := range ps {
+			params[process][name] = v
+		}
+	}
+
+	for _, f := range s.Factors {
+		if _, seen := base.Processes[f.Process]; !seen {
+			return nil, fmt.Errorf("factor %s: no process %q in workflow", f.Name, f.Process)
+		}
+		var value interface{} = values[f.Name]
+		if len(f.Unit) != 0 {
+			value = strconv.FormatFloat(values[f.Name], 'g', 6, 64) + f.Unit
+		}
+		bs, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if params[f.Process] == nil {
+			params[f.Process] = make(map[string]json.RawMessage)
+		}
+		params[f.Process][f.Parameter] = bs
+	}
+
+	type bundle struct {
+		workflow.Desc
+		execute.RawParams
+	}
+	return &bundle{
+		Desc: base.Desc,
+		RawParams: execute.RawParams{
+			Parameters: params,
+			Config:     base.Config,
+		},
+	}, nil
+}
+
+func optimisePropose(cmd *cobra.Command, args []string) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("expecting a state file found %d arguments", len(args))
+	}
+	fn := args[0]
+
+	s, err := readOptimiseState(fn)
+	if err != nil {
+		return err
+	}
+	if p := s.pending(); len(p) != 0 {
+		if !viper.GetBool("force") {
+			return fmt.Errorf("runs %v have no response; ingest their results or use --force", p)
+		}
+		if _, err := fmt.Fprintf(os.Stderr, "warning: runs %v have no response and are left out of the model\n", p); err != nil {
+			return err
+		}
+	}
+
+	base, err := unmarshalRunInput(&runInput{
+		BundleFile:     relativeTo(fn, s.Bundle),
+		ParametersFile: relativeTo(fn, s.Parameters),
+		WorkflowFile:   relativeTo(fn, s.Workflow),
+	})
+	if err != nil {
+		return err
+	}
+
+	opt := doe.SequentialOptimiser{
+		Factors:  s.doeFactors(),
+		Response: s.Response,
+		Maximise: s.Maximise,
+		Seed:     s.Seed,
+	}
+	runs, err := opt.Propose(s.history(), viper.GetInt("batch"))
+	if err != nil {
+		return err
+	}
+
+	round := 1
+	for _, r := range s.Runs {
+		if r.Round >= round {
+			round = r.Round + 1
+		}
+	}
+
+	outdir := viper.GetString("outputDir")
+	if err := os.MkdirAll(outdir, 0777); err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		values := make(map[string]float64)
+		for _, f := range s.Factors {
+			v, err := run.GetFactorValue(f.Name)
+			if err != nil {
+				return err
+			}
+			values[f.Name] = v.(float64)
+		}
+
+		b, err := s.withFactors(base, values)
+		if err != nil {
+			return err
+		}
+		bs, err := json.MarshalIndent(b, "", "  ")
+		if err != nil {
+			return err
+		}
+		bfn := filepath.Join(outdir, fmt.Sprintf("run%d.bundle.json", run.RunNumber))
+		if err := ioutil.WriteFile(bfn, bs, 0666); err != nil {
+			return fmt.Errorf("cannot write file %q: %s", bfn, err)
+		}
+
+		rel, err := relativeFrom(fn, bfn)
+		if err != nil {
+			return err
+		}
+		s.Runs = append(s.Runs, optimiseRun{
+			Run:     run.RunNumber,
+			Round:   round,
+			Bundle:  rel,
+			Factors: values,
+		})
+		if _, err := fmt.Println(bfn); err != nil {
+			return err
+		}
+	}
+
+	return writeOptimiseState(fn, s)
+}
+
+// readResults reads responses by run number from a csv file with a header
+// naming the run and response columns
+func readResults(fn, response string) (map[int]float64, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint: errcheck
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %q: %s", fn, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no results in %q", fn)
+	}
+
+	runCol, responseCol := -1, -1
+	for i, h := range records[0] {
+		h = strings.TrimSpace(h)
+		switch {
+		case strings.EqualFold(h, "run") || strings.EqualFold(h, "runnumber"):
+			runCol = i
+		case strings.EqualFold(h, response):
+			responseCol = i
+		}
+	}
+	if runCol < 0 {
+		return nil, fmt.Errorf("no run column in %q", fn)
+	}
+	if responseCol < 0 {
+		return nil, fmt.Errorf("no %s column in %q", response, fn)
+	}
+
+	results := make(map[int]float64)
+	for i, rec := range records[1:] {
+		if len(rec) <= runCol || len(rec) <= responseCol {
+			continue
+		}
+		rs, vs := strings.TrimSpace(rec[runCol]), strings.TrimSpace(rec[responseCol])
+		if len(rs) == 0 || len(vs) == 0 {
+			continue
+		}
+		run, err := strconv.Atoi(rs)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: cannot parse run %q", i+2, rs)
+		}
+		v, err := strconv.ParseFloat(vs, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: cannot parse response %q", i+2, vs)
+		}
+		results[run] = v
+	}
+	return results, nil
+}
+
+func optimiseIngest(cmd *cobra.Command, args []string) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+	switch len(args) {
+	case 0:
+		return fmt.Errorf("no state file given")
+	case 1:
+		return fmt.Errorf("no results file given")
+	case 2:
+	default:
+		return fmt.Errorf("expecting at most 2 arguments found %d", len(args))
+	}
+	fn := args[0]
+
+	s, err := readOptimiseState(fn)
+	if err != nil {
+		return err
+	}
+
+	column := viper.GetString("column")
+	if len(column) == 0 {
+		column = s.Response
+	}
+	results, err := readResults(args[1], column)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[int]int)
+	for i, r := range s.Runs {
+		index[r.Run] = i
+	}
+	for run, v := range results {
+		i, seen := index[run]
+		if !seen {
+			return fmt.Errorf("unknown run %d", run)
+		}
+		v := v
+		s.Runs[i].Response = &v
+	}
+
+	best := -1
+	for i, r := range s.Runs {
+		if r.Response == nil {
+			continue
+		}
+		if best < 0 || (s.Maximise && *r.Response > *s.Runs[best].Response) || (!s.Maximise && *r.Response < *s.Runs[best].Response) {
+			best = i
+		}
+	}
+	if _, err := fmt.Printf("recorded %d responses, %d runs pending\n", len(results), len(s.pending())); err != nil {
+		return err
+	}
+	if best >= 0 {
+		r := s.Runs[best]
+		var fs []string
+		for _, f := range s.Factors {
+			fs = append(fs, fmt.Sprintf("%s=%g%s", f.Name, r.Factors[f.Name], f.Unit))
+		}
+		if _, err := fmt.Printf("best so far: run %d %s=%g %s\n", r.Run, s.Response, *r.Response, strings.Join(fs, " ")); err != nil {
+			return err
+		}
+	}
+
+	return writeOptimiseState(fn, s)
+}
+
+func init() {
+	c := optimiseCmd
+	RootCmd.AddCommand(c)
+
+	c.AddCommand(optimiseProposeCmd)
+	flags := optimiseProposeCmd.Flags()
+	flags.Int("batch", 8, "Number of runs to propose")
+	flags.String("outputDir", ".", "Directory to write bundles to")
+	flags.Bool("force", false, "Propose runs even if earlier runs have no response")
+
+	c.AddCommand(optimiseIngestCmd)
+	optimiseIngestCmd.Flags().String("column", "", "Column of the response in the results file (default: the response name)")
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRelativeFrom(t *testing.T) {
+	state := filepath.Join("experiments", "state.yaml")
+	bundle := filepath.Join("experiments", "round1", "run1.bundle.json")
+
+	rel, err := relativeFrom(state, bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := filepath.Join("round1", "run1.bundle.json"), rel; e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+	if e, f := bundle, relativeTo(state, rel); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/antha-lang/antha/execute"
 	"github.com/antha-lang/antha/execute/executeutil"
 	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/inventory/fileinventory"
 	"github.com/antha-lang/antha/inventory/testinventory"
 	"github.com/antha-lang/antha/target"
 	"github.com/antha-lang/antha/target/auto"
@@ -78,6 +79,14 @@ func makeMixerOpt(ctx context.Context) (mixer.Opt, error) {
 		opt.InputPlates = append(opt.InputPlates, p)
 	}
 
+	for _, fn := range GetStringSlice("policies") {
+		bs, err := ioutil.ReadFile(fn)
+		if err != nil {
+			return opt, err
+		}
+		opt.PolicyData = append(opt.PolicyData, bs)
+	}
+
 	opt.OutputSort = viper.GetBool("outputSort")
 
 	executionPlannerVersion := ""
@@ -92,11 +101,16 @@ func makeMixerOpt(ctx context.Context) (mixer.Opt, error) {
 	opt.LegacyVolume = viper.GetBool("legacyVolumeTracking")
 
 	opt.FixVolumes = viper.GetBool("fixVolumes")
+	opt.MaxDilutionCV = viper.GetFloat64("maxDilutionCV")
+	opt.ModelEvaporation = viper.GetBool("modelEvaporation")
+	opt.MaxEvaporationPercent = viper.GetFloat64("maxEvaporationPercent")
 
 	return opt, nil
 }
 
-func makeContext() (context.Context, error) {
+// makeContext returns a context with the component library and either the
+// given inventory or, if nil, the test inventory
+func makeContext(inv *fileinventory.Inventory) (context.Context, error) {
 	ctx := inject.NewContext(context.Background())
 	for _, desc := range library {
 		obj := desc.Constructor()
@@ -108,6 +122,9 @@ func makeContext() (context.Context, error) {
 			return nil, fmt.Errorf("error adding protocol %q: %s", desc.Name, err)
 		}
 	}
+	if inv != nil {
+		return fileinventory.NewContext(ctx, inv), nil
+	}
 	return testinventory.NewContext(ctx), nil
 }
 
@@ -120,7 +137,10 @@ type runOpt struct {
 	WorkflowFile           string
 	MixInstructionFileName string
 	TestBundleFileName     string
+	SampleLineageFileName  string
 	RunTest                bool
+	InventoryFile          string
+	CommitInventory        bool
 }
 
 type runInput struct {
@@ -215,7 +235,16 @@ func (a *runOpt) Run() error {
 	}
 	defer fe.Shutdown() // nolint: errcheck
 
-	ctx, err := makeContext()
+	var inv *fileinventory.Inventory
+	if len(a.InventoryFile) != 0 {
+		inv, err = fileinventory.Open(a.InventoryFile)
+		if err != nil {
+			return err
+		}
+		defer inv.Close() // nolint: errcheck
+	}
+
+	ctx, err := makeContext(inv)
 	if err != nil {
 		return err
 	}
@@ -248,6 +277,17 @@ func (a *runOpt) Run() error {
 		}
 	}
 
+	// if option is set, save where samples ended up and how they were made
+	if a.SampleLineageFileName != "" {
+		bs, err := json.MarshalIndent(rout.Samples, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(a.SampleLineageFileName, bs, 0666); err != nil {
+			return err
+		}
+	}
+
 	// if option is set, cache outputs for testing
 
 	if a.TestBundleFileName != "" {
@@ -288,6 +328,12 @@ func (a *runOpt) Run() error {
 		return err
 	}
 
+	if inv != nil && a.CommitInventory {
+		if err := inv.Commit(mixUsage(rout.Insts)); err != nil {
+			return fmt.Errorf("cannot update inventory stock: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -343,7 +389,10 @@ func runWorkflow(cmd *cobra.Command, args []string) error {
 		TargetConfigFile:       viper.GetString("target"),
 		MixInstructionFileName: viper.GetString("mixInstructionFileName"),
 		TestBundleFileName:     viper.GetString("makeTestBundle"),
+		SampleLineageFileName:  viper.GetString("sampleLineageFileName"),
 		RunTest:                viper.GetBool("RunTest"),
+		InventoryFile:          viper.GetString("inventory"),
+		CommitInventory:        viper.GetBool("commitInventory"),
 	}
 
 	return opt.Run()
@@ -366,13 +415,20 @@ func init() {
 	flags.String("makeTestBundle", "", "Generate json format bundle for testing and put it here")
 	flags.String("mixInstructionFileName", "", "Name of instructions files to output to for mixes")
 	flags.String("parameters", "parameters.json", "Parameters to workflow")
+	flags.String("sampleLineageFileName", "", "Name of json file to output sample locations and lineage to")
 	flags.String("workflow", "workflow.json", "Workflow definition file")
 	flags.String("target", "", "Mock target definition file")
+	flags.String("inventory", "", "Inventory directory to use (default is the builtin inventory)")
+	flags.Bool("commitInventory", false, "Take the labware and components used by the run out of stock in the inventory")
 	flags.StringSlice("component", nil, "Uris of remote components ({tcp,go}://...); use multiple flags for multiple components")
 	flags.StringSlice("driver", nil, "Uris of remote drivers ({tcp,go}://...); use multiple flags for multiple drivers")
 	flags.StringSlice("inputPlateType", nil, "Default input plate types (in order of preference)")
 	flags.StringSlice("inputPlates", nil, "File containing input plates")
 	flags.StringSlice("outputPlateType", nil, "Default output plate types (in order of preference)")
+	flags.StringSlice("policies", nil, "Files of liquid handling policy rules (JSON or YAML) to add to the defaults; use multiple flags for multiple files")
 	flags.StringSlice("tipType", nil, "Names of permitted tip types")
 	flags.Bool("fixVolumes", true, "Make all volumes sufficient for later uses")
+	flags.Bool("modelEvaporation", false, "Add volume to inputs to make up for evaporation during the run")
+	flags.Float64("maxEvaporationPercent", 0.0, "Warn if any well is expected to lose more than this percentage of its volume to evaporation (0 to disable)")
+	flags.Float64("maxDilutionCV", 0.0, "Warn if pipetting error is expected to vary any concentration by more than this fraction (0 to disable)")
 }
@@ -0,0 +1,296 @@
+// doc.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package doc builds a browsable catalogue of antha elements from their
+// component descriptions.
+package doc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/component"
+	"github.com/antha-lang/antha/workflow"
+)
+
+const wunitPkg = "github.com/antha-lang/antha/antha/anthalib/wunit"
+
+// A Link is a reference from a parameter to a compatible parameter of
+// another element.
+type Link struct {
+	Element string `json:"element"`
+	Param   string `json:"param"`
+}
+
+// A Param describes a single input or output of an element
+type Param struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Kind        string   `json:"kind"`
+	Type        string   `json:"type"`
+	Units       []string `json:"units,omitempty"`
+	// ZeroValue is the serialized value an element sees if the parameter is
+	// not set; elements do not declare defaults of their own
+	ZeroValue string `json:"zero_value,omitempty"`
+	// Links to elements that produce (for inputs) or consume (for outputs)
+	// values of the same type
+	Links []Link `json:"links,omitempty"`
+}
+
+// IsInput returns true if the parameter is set before an element runs
+func (a Param) IsInput() bool {
+	return a.Kind == "Inputs" || a.Kind == "Parameters"
+}
+
+// ShortType returns the type name of a parameter without package paths
+func (a Param) ShortType() string {
+	return shortType(a.Type)
+}
+
+// An Element is the catalogue entry of an element
+type Element struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Path        string   `json:"path"`
+	Stages      []string `json:"stages"`
+	Inputs      []Param  `json:"inputs"`
+	Outputs     []Param  `json:"outputs"`
+	// Example workflow and parameters using this element
+	ExampleWorkflow   string `json:"example_workflow"`
+	ExampleParameters string `json:"example_parameters"`
+}
+
+// Summary returns the first sentence of the element description
+func (a Element) Summary() string {
+	s := strings.TrimSpace(a.Description)
+	if idx := strings.Index(s, ". "); idx >= 0 {
+		s = s[:idx+1]
+	}
+	if idx := strings.Index(s, "\n"); idx >= 0 {
+		s = s[:idx]
+	}
+	return s
+}
+
+// shortType strips package paths from a full go type name, e.g.,
+// "[]github.com/antha-lang/antha/antha/anthalib/wtype.LHComponent" becomes
+// "[]wtype.LHComponent".
+func shortType(t string) string {
+	switch {
+	case strings.HasPrefix(t, "[]"):
+		return "[]" + shortType(t[2:])
+	case strings.HasPrefix(t, "*"):
+		return "*" + shortType(t[1:])
+	case strings.HasPrefix(t, "map["):
+		if end := strings.Index(t, "]"); end >= 0 {
+			return "map[" + shortType(t[4:end]) + "]" + shortType(t[end+1:])
+		}
+	}
+	if idx := strings.LastIndex(t, "/"); idx >= 0 {
+		t = t[idx+1:]
+	}
+	return t
+}
+
+// units returns the approved units of a wunit measurement type
+func units(t string) []string {
+	t = strings.TrimLeft(t, "[]*")
+	if !strings.HasPrefix(t, wunitPkg+".") {
+		return nil
+	}
+	var us []string
+	for u := range wunit.UnitMap[strings.TrimPrefix(t, wunitPkg+".")] {
+		us = append(us, u)
+	}
+	sort.Strings(us)
+	return us
+}
+
+// zeroValue returns the serialized zero value of a parameter
+func zeroValue(v interface{}) (s string) {
+	defer func() {
+		if res := recover(); res != nil {
+			s = ""
+		}
+	}()
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(bs)
+}
+
+func stageName(stage api.ElementStage) string {
+	switch stage {
+	case api.ElementStage_STEPS:
+		return "Steps"
+	case api.ElementStage_ANALYSIS:
+		return "Analysis"
+	}
+	return stage.String()
+}
+
+func makeExample(elem *Element, zeros map[string]string) error {
+	process := elem.Name + "1"
+	wdesc := workflow.Desc{
+		Processes: map[string]workflow.Process{
+			process: {Component: elem.Name},
+		},
+		Connections: []workflow.Connection{},
+	}
+	bs, err := json.MarshalIndent(wdesc, "", "  ")
+	if err != nil {
+		return err
+	}
+	elem.ExampleWorkflow = string(bs)
+
+	values := make(map[string]*json.RawMessage)
+	for _, p := range elem.Inputs {
+		v := zeros[p.Name]
+		if len(v) == 0 {
+			v = "null"
+		}
+		raw := json.RawMessage(v)
+		values[p.Name] = &raw
+	}
+	params := map[string]map[string]map[string]*json.RawMessage{
+		"parameters": {process: values},
+	}
+	bs, err = json.MarshalIndent(params, "", "  ")
+	if err != nil {
+		return err
+	}
+	elem.ExampleParameters = string(bs)
+	return nil
+}
+
+// New creates the catalogue entries of a component library. Components
+// registered for more than one stage are merged into a single element.
+func New(lib []component.Component) ([]Element, error) {
+	byName := make(map[string]*Element)
+	zeroValues := make(map[string]map[string]string)
+	var names []string
+
+	for i := range lib {
+		c := &lib[i]
+		elem, seen := byName[c.Name]
+		if !seen {
+			elem = &Element{Name: c.Name}
+			byName[c.Name] = elem
+			zeroValues[c.Name] = make(map[string]string)
+			names = append(names, c.Name)
+		}
+		elem.Stages = append(elem.Stages, stageName(c.Stage))
+		if len(elem.Description) == 0 {
+			elem.Description = c.Description.Desc
+		}
+		if len(elem.Path) == 0 {
+			elem.Path = c.Description.Path
+		}
+		if len(c.Description.Params) == 0 {
+			continue
+		} else if len(elem.Inputs)+len(elem.Outputs) != 0 {
+			// Parameters are the same for every stage
+			continue
+		}
+
+		if err := component.UpdateParamTypes(c); err != nil {
+			return nil, fmt.Errorf("cannot get types of element %q: %s", c.Name, err)
+		}
+		zeros, err := c.NewParams()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get parameters of element %q: %s", c.Name, err)
+		}
+
+		for _, p := range c.Description.Params {
+			param := Param{
+				Name:        p.Name,
+				Description: p.Desc,
+				Kind:        p.Kind,
+				Type:        p.Type,
+				Units:       units(p.Type),
+			}
+			switch p.Kind {
+			case "Inputs", "Parameters":
+				param.ZeroValue = zeroValue(zeros[p.Name])
+				zeroValues[c.Name][p.Name] = param.ZeroValue
+				elem.Inputs = append(elem.Inputs, param)
+			case "Outputs", "Data":
+				elem.Outputs = append(elem.Outputs, param)
+			default:
+				return nil, fmt.Errorf("unknown parameter kind %q", p.Kind)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	var elems []Element
+	for _, name := range names {
+		elem := byName[name]
+		if err := makeExample(elem, zeroValues[name]); err != nil {
+			return nil, err
+		}
+		elems = append(elems, *elem)
+	}
+
+	addLinks(elems)
+
+	return elems, nil
+}
+
+// addLinks cross-links outputs of elements with inputs of other elements
+// that accept the same type
+func addLinks(elems []Element) {
+	consumers := make(map[string][]Link)
+	producers := make(map[string][]Link)
+	for _, e := range elems {
+		for _, p := range e.Inputs {
+			consumers[p.Type] = append(consumers[p.Type], Link{Element: e.Name, Param: p.Name})
+		}
+		for _, p := range e.Outputs {
+			producers[p.Type] = append(producers[p.Type], Link{Element: e.Name, Param: p.Name})
+		}
+	}
+
+	others := func(name string, links []Link) (ret []Link) {
+		for _, l := range links {
+			if l.Element != name {
+				ret = append(ret, l)
+			}
+		}
+		return
+	}
+
+	for i := range elems {
+		e := &elems[i]
+		for j := range e.Inputs {
+			e.Inputs[j].Links = others(e.Name, producers[e.Inputs[j].Type])
+		}
+		for j := range e.Outputs {
+			e.Outputs[j].Links = others(e.Name, consumers[e.Outputs[j].Type])
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// doc_test.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package doc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/component"
+	"github.com/antha-lang/antha/inject"
+)
+
+type makeInput struct {
+	Volume wunit.Volume
+	Name   string
+}
+
+type makeOutput struct {
+	Result int
+}
+
+type useInput struct {
+	Result int
+}
+
+type useOutput struct{}
+
+func makeLib() []component.Component {
+	runner := func(in, out interface{}) func() interface{} {
+		return func() interface{} {
+			return &inject.CheckedRunner{In: in, Out: out}
+		}
+	}
+	return []component.Component{
+		{
+			Name:        "Use",
+			Stage:       api.ElementStage_STEPS,
+			Constructor: runner(&useInput{}, &useOutput{}),
+			Description: component.Description{
+				Desc: "Use a result",
+				Params: []component.ParamDesc{
+					{Name: "Result", Desc: "to use", Kind: "Inputs"},
+				},
+			},
+		},
+		{
+			Name:        "Make",
+			Stage:       api.ElementStage_STEPS,
+			Constructor: runner(&makeInput{}, &makeOutput{}),
+			Description: component.Description{
+				Desc: "Make a result. Longer description",
+				Path: "Make.an",
+				Params: []component.ParamDesc{
+					{Name: "Volume", Desc: "how much", Kind: "Parameters"},
+					{Name: "Name", Desc: "what", Kind: "Parameters"},
+					{Name: "Result", Desc: "made", Kind: "Data"},
+				},
+			},
+		},
+		{
+			Name:        "Make",
+			Stage:       api.ElementStage_ANALYSIS,
+			Constructor: runner(&makeInput{}, &makeOutput{}),
+			Description: component.Description{
+				Desc: "Make a result. Longer description",
+				Path: "Make.an",
+			},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	elems, err := New(makeLib())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(elems); l != 2 {
+		t.Fatalf("expecting 2 elements found %d", l)
+	}
+
+	first := elems[0]
+	if first.Name != "Make" {
+		t.Fatalf("expecting elements sorted by name found %q first", first.Name)
+	}
+	if e, f := []string{"Steps", "Analysis"}, first.Stages; !reflect.DeepEqual(e, f) {
+		t.Errorf("expecting stages %v found %v", e, f)
+	}
+	if e, f := "Make a result.", first.Summary(); e != f {
+		t.Errorf("expecting summary %q found %q", e, f)
+	}
+	if l := len(first.Inputs); l != 2 {
+		t.Fatalf("expecting 2 inputs found %d", l)
+	}
+
+	vol := first.Inputs[0]
+	if e, f := "wunit.Volume", vol.ShortType(); e != f {
+		t.Errorf("expecting type %q found %q", e, f)
+	}
+	if len(vol.Units) == 0 {
+		t.Errorf("expecting units for %s", vol.Type)
+	}
+	if e, f := `""`, first.Inputs[1].ZeroValue; e != f {
+		t.Errorf("expecting zero value %s found %s", e, f)
+	}
+
+	if e, f := []Link{{Element: "Use", Param: "Result"}}, first.Outputs[0].Links; !reflect.DeepEqual(e, f) {
+		t.Errorf("expecting links %v found %v", e, f)
+	}
+	if e, f := []Link{{Element: "Make", Param: "Result"}}, elems[1].Inputs[0].Links; !reflect.DeepEqual(e, f) {
+		t.Errorf("expecting links %v found %v", e, f)
+	}
+
+	if !strings.Contains(first.ExampleParameters, `"Make1"`) {
+		t.Errorf("expecting process in example parameters found %s", first.ExampleParameters)
+	}
+}
+
+func TestShortType(t *testing.T) {
+	for in, out := range map[string]string{
+		"int": "int",
+		"[]github.com/antha-lang/antha/antha/anthalib/wtype.LHComponent":                   "[]wtype.LHComponent",
+		"map[string]*github.com/antha-lang/antha/antha/anthalib/wtype.LHComponent":         "map[string]*wtype.LHComponent",
+		"map[github.com/a/b.Key][]github.com/antha-lang/antha/antha/anthalib/wunit.Volume": "map[b.Key][]wunit.Volume",
+	} {
+		if f := shortType(in); f != out {
+			t.Errorf("expecting %q found %q", out, f)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	elems, err := New(makeLib())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	for _, format := range []string{MarkdownFormat, HTMLFormat} {
+		if err := Write(dir, format, elems); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, fn := range []string{"index.md", "Make.md", "Use.md", "index.html", "Make.html", "Use.html"} {
+		bs, err := ioutil.ReadFile(filepath.Join(dir, fn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(bs), "Make") {
+			t.Errorf("expecting %s to mention element Make", fn)
+		}
+	}
+
+	if err := Write(dir, "pdf", elems); err == nil {
+		t.Errorf("expecting error for unknown format")
+	}
+}
@@ -0,0 +1,209 @@
+// render.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package doc
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Formats supported by Write
+const (
+	MarkdownFormat = "markdown"
+	HTMLFormat     = "html"
+)
+
+var funcs = map[string]interface{}{
+	"join":     strings.Join,
+	"mdEscape": mdEscape,
+}
+
+// mdEscape escapes characters that break markdown table cells
+func mdEscape(s string) string {
+	s = strings.Replace(s, "|", "\\|", -1)
+	return strings.Replace(strings.TrimSpace(s), "\n", " ", -1)
+}
+
+const mdIndex = `# Antha Elements
+{{range .}}
+- [{{.Name}}]({{.Name}}.md) ({{join .Stages ", "}}){{with .Summary}}: {{mdEscape .}}{{end}}{{end}}
+`
+
+const mdElement = `# {{.Name}}
+
+{{.Description}}
+
+- Stages: {{join .Stages ", "}}
+- Source: ` + "`{{.Path}}`" + `
+
+{{define "params"}}| Name | Kind | Type | Units | Zero value | Description | Links |
+| ---- | ---- | ---- | ----- | ---------- | ----------- | ----- |
+{{range .}}| <a name="{{.Name}}"></a>{{.Name}} | {{.Kind}} | ` + "`{{.ShortType}}`" + ` | {{join .Units ", "}} | {{with .ZeroValue}}` + "`{{mdEscape .}}`" + `{{end}} | {{mdEscape .Description}} | {{range $i, $l := .Links}}{{if $i}}, {{end}}[{{$l.Element}}.{{$l.Param}}]({{$l.Element}}.md#{{$l.Param}}){{end}} |
+{{end}}{{end}}## Inputs
+
+{{template "params" .Inputs}}
+## Outputs
+
+{{template "params" .Outputs}}
+## Example
+
+Workflow:
+
+` + "```json" + `
+{{.ExampleWorkflow}}
+` + "```" + `
+
+Parameters:
+
+` + "```json" + `
+{{.ExampleParameters}}
+` + "```" + `
+
+[Index](index.md)
+`
+
+const htmlIndex = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Antha Elements</title></head>
+<body>
+<h1>Antha Elements</h1>
+<table>
+<tr><th>Element</th><th>Stages</th><th>Summary</th></tr>
+{{range .}}<tr><td><a href="{{.Name}}.html">{{.Name}}</a></td><td>{{join .Stages ", "}}</td><td>{{.Summary}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`
+
+const htmlElement = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Name}}</title></head>
+<body>
+<p><a href="index.html">Index</a></p>
+<h1>{{.Name}}</h1>
+<pre>{{.Description}}</pre>
+<ul>
+<li>Stages: {{join .Stages ", "}}</li>
+<li>Source: <code>{{.Path}}</code></li>
+</ul>
+{{define "params"}}<table>
+<tr><th>Name</th><th>Kind</th><th>Type</th><th>Units</th><th>Zero value</th><th>Description</th><th>Links</th></tr>
+{{range .}}<tr id="{{.Name}}"><td>{{.Name}}</td><td>{{.Kind}}</td><td><code>{{.ShortType}}</code></td><td>{{join .Units ", "}}</td><td><code>{{.ZeroValue}}</code></td><td>{{.Description}}</td><td>{{range $i, $l := .Links}}{{if $i}}, {{end}}<a href="{{$l.Element}}.html#{{$l.Param}}">{{$l.Element}}.{{$l.Param}}</a>{{end}}</td></tr>
+{{end}}</table>{{end}}<h2>Inputs</h2>
+{{template "params" .Inputs}}
+<h2>Outputs</h2>
+{{template "params" .Outputs}}
+<h2>Example</h2>
+<h3>Workflow</h3>
+<pre>{{.ExampleWorkflow}}</pre>
+<h3>Parameters</h3>
+<pre>{{.ExampleParameters}}</pre>
+</body>
+</html>
+`
+
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+type templates struct {
+	Ext     string
+	Index   executor
+	Element executor
+}
+
+func newTemplates(format string) (*templates, error) {
+	switch format {
+	case MarkdownFormat:
+		index, err := template.New("index").Funcs(funcs).Parse(mdIndex)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := template.New("element").Funcs(funcs).Parse(mdElement)
+		if err != nil {
+			return nil, err
+		}
+		return &templates{Ext: ".md", Index: index, Element: elem}, nil
+	case HTMLFormat:
+		index, err := htmltemplate.New("index").Funcs(funcs).Parse(htmlIndex)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := htmltemplate.New("element").Funcs(funcs).Parse(htmlElement)
+		if err != nil {
+			return nil, err
+		}
+		return &templates{Ext: ".html", Index: index, Element: elem}, nil
+	default:
+		return nil, &unknownFormat{Format: format}
+	}
+}
+
+type unknownFormat struct {
+	Format string
+}
+
+func (a *unknownFormat) Error() string {
+	return "unknown documentation format " + a.Format
+}
+
+func writeFile(fn string, t executor, data interface{}) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	if err := t.Execute(f, data); err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	return f.Close()
+}
+
+// Write renders a catalogue of elements into directory dir in the given
+// format (markdown or html). The catalogue consists of an index page and
+// one page per element.
+func Write(dir, format string, elems []Element) error {
+	ts, err := newTemplates(format)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+
+	if err := writeFile(filepath.Join(dir, "index"+ts.Ext), ts.Index, elems); err != nil {
+		return err
+	}
+
+	for _, e := range elems {
+		if err := writeFile(filepath.Join(dir, e.Name+ts.Ext), ts.Element, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -906,11 +906,11 @@ func DecodeLHComponent(arg *pb.LHComponentMessage) wtype.LHComponent {
 	return ret
 }
 func EncodeConcreteMeasurement(arg wunit.ConcreteMeasurement) *pb.ConcreteMeasurementMessage {
-	ret := pb.ConcreteMeasurementMessage{(float64)(arg.Mvalue), EncodePtrToGenericPrefixedUnit(arg.Munit)}
+	ret := pb.ConcreteMeasurementMessage{(float64)(arg.Mvalue), EncodePtrToGenericPrefixedUnit(arg.Munit), (float64)(arg.Muncertainty)}
 	return &ret
 }
 func DecodeConcreteMeasurement(arg *pb.ConcreteMeasurementMessage) wunit.ConcreteMeasurement {
-	ret := wunit.ConcreteMeasurement{(float64)(arg.Arg_1), (*wunit.GenericPrefixedUnit)(DecodePtrToGenericPrefixedUnit(arg.Arg_2))}
+	ret := wunit.ConcreteMeasurement{(float64)(arg.Arg_1), (*wunit.GenericPrefixedUnit)(DecodePtrToGenericPrefixedUnit(arg.Arg_2)), (float64)(arg.Arg_3)}
 	return ret
 }
 func EncodePtrToShape(arg *wtype.Shape) *pb.PtrToShapeMessage {
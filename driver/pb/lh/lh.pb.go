@@ -3832,6 +3832,7 @@ func (m *PtrToShapeMessage) GetArg_1() *ShapeMessage {
 type ConcreteMeasurementMessage struct {
 	Arg_1 float64                          `protobuf:"fixed64,1,opt,name=Arg_1,json=Arg1" json:"Arg_1,omitempty"`
 	Arg_2 *PtrToGenericPrefixedUnitMessage `protobuf:"bytes,2,opt,name=Arg_2,json=Arg2" json:"Arg_2,omitempty"`
+	Arg_3 float64                          `protobuf:"fixed64,3,opt,name=Arg_3,json=Arg3" json:"Arg_3,omitempty"`
 }
 
 func (m *ConcreteMeasurementMessage) Reset()                    { *m = ConcreteMeasurementMessage{} }
@@ -3853,6 +3854,13 @@ func (m *ConcreteMeasurementMessage) GetArg_2() *PtrToGenericPrefixedUnitMessage
 	return nil
 }
 
+func (m *ConcreteMeasurementMessage) GetArg_3() float64 {
+	if m != nil {
+		return m.Arg_3
+	}
+	return 0
+}
+
 type PtrToLHComponentMessage struct {
 	Arg_1 *LHComponentMessage `protobuf:"bytes,1,opt,name=arg_1,json=arg1" json:"arg_1,omitempty"`
 }
@@ -5345,220 +5353,218 @@ var _ExtendedLiquidhandlingDriver_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("lh/lh.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 3426 bytes of a gzipped FileDescriptorProto
+	// 3398 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x1c, 0x4d, 0x73, 0xdb, 0xc6,
-	0xd5, 0x30, 0xec, 0x44, 0x7c, 0xd4, 0xe7, 0x52, 0x94, 0x60, 0xc6, 0x4e, 0x5c, 0xd8, 0x56, 0x14,
-	0xc7, 0x56, 0x44, 0x52, 0xdf, 0x8e, 0x13, 0x33, 0x92, 0x25, 0x3b, 0x95, 0x62, 0x55, 0x94, 0xe3,
-	0xce, 0x64, 0xda, 0x14, 0x36, 0xd7, 0x12, 0x26, 0x10, 0x80, 0x90, 0x90, 0x6c, 0xb7, 0xd7, 0x5e,
-	0x7b, 0xec, 0x4c, 0x7f, 0x47, 0x2f, 0x9d, 0x76, 0xa6, 0xa7, 0x1e, 0x7b, 0xef, 0xa1, 0xd3, 0xff,
-	0xd0, 0x1f, 0xd0, 0x43, 0x3a, 0xd8, 0x0f, 0x70, 0x17, 0x58, 0x80, 0x10, 0x24, 0x77, 0x3a, 0xed,
-	0x25, 0x63, 0x2d, 0xde, 0xd7, 0xbe, 0x7d, 0x5f, 0xfb, 0xb8, 0x2f, 0x50, 0x76, 0x0e, 0x3f, 0x71,
-	0x0e, 0xe7, 0xfc, 0xae, 0x17, 0x78, 0xe8, 0xa2, 0x73, 0x68, 0xfe, 0x5a, 0x03, 0xd8, 0xb1, 0xfc,
-	0x1d, 0xdc, 0xeb, 0x59, 0x07, 0x18, 0xad, 0x42, 0xe9, 0xc8, 0xf2, 0xbf, 0x7d, 0x69, 0x63, 0xa7,
-	0x63, 0x68, 0xd7, 0xf5, 0xd9, 0x72, 0xe3, 0xea, 0x9c, 0x73, 0x38, 0xd7, 0x07, 0x09, 0xff, 0xb9,
-	0x19, 0x7e, 0x7e, 0xe8, 0x06, 0xdd, 0x37, 0x7b, 0x43, 0x47, 0xec, 0xcf, 0xda, 0x3d, 0x18, 0x91,
-	0x3e, 0xa1, 0x71, 0xd0, 0xbf, 0xc3, 0x6f, 0x0c, 0xed, 0xba, 0x36, 0x5b, 0xda, 0x0b, 0xff, 0x89,
-	0x26, 0xe1, 0xf2, 0x89, 0xe5, 0x1c, 0x63, 0xe3, 0x22, 0x59, 0xa3, 0x7f, 0xac, 0x5d, 0x5c, 0xd1,
-	0xcc, 0x1f, 0x01, 0xb4, 0xdc, 0x37, 0x5c, 0x8a, 0x0a, 0x5c, 0x6e, 0x75, 0x0f, 0xbe, 0xad, 0x33,
-	0xdc, 0x4b, 0xad, 0xee, 0x41, 0xdd, 0xac, 0xc0, 0xc4, 0x63, 0xd7, 0x0e, 0x6c, 0xcb, 0xb1, 0x7f,
-	0x89, 0xf7, 0xf0, 0xf7, 0xc7, 0xb8, 0x17, 0x98, 0x0f, 0x60, 0x4c, 0x5c, 0xf4, 0x9d, 0x37, 0xe8,
-	0x2e, 0x5c, 0xde, 0xc3, 0x01, 0x43, 0x2e, 0x37, 0x8c, 0x50, 0xfc, 0x75, 0xef, 0xe8, 0xc8, 0x72,
-	0x3b, 0xed, 0xc0, 0x0a, 0x8e, 0x7b, 0x8c, 0xcb, 0xde, 0xa5, 0x3d, 0x1c, 0xd4, 0xcd, 0x11, 0x28,
-	0x3f, 0xf1, 0xb1, 0xcb, 0x09, 0xae, 0x41, 0x89, 0xfe, 0x59, 0x80, 0xd4, 0xe7, 0x50, 0xd9, 0xc3,
-	0x3d, 0x1c, 0xec, 0xda, 0xbd, 0xc0, 0x73, 0x7b, 0x8c, 0x64, 0xb8, 0x1b, 0x2b, 0xda, 0x8d, 0xbe,
-	0x77, 0xc9, 0xea, 0x1e, 0xd4, 0xf9, 0x62, 0x83, 0xa8, 0x82, 0x2e, 0x36, 0xcc, 0x2f, 0x60, 0x42,
-	0x26, 0x50, 0x40, 0x88, 0x51, 0x18, 0x5e, 0x77, 0xbc, 0x5e, 0xa4, 0xa1, 0x7b, 0x00, 0xec, 0xef,
-	0x02, 0xc4, 0xfe, 0x78, 0x11, 0xca, 0x3b, 0xde, 0x09, 0x27, 0x86, 0x66, 0xc4, 0xad, 0x94, 0x1b,
-	0x13, 0x21, 0x7a, 0xab, 0xdb, 0xb5, 0xde, 0x3c, 0x79, 0xd9, 0x0b, 0xba, 0xb6, 0x7b, 0xc0, 0x76,
-	0x37, 0x23, 0xee, 0x2e, 0x15, 0xae, 0x81, 0x6e, 0x51, 0xb8, 0xa6, 0xa1, 0x13, 0xb8, 0x71, 0x01,
-	0xce, 0x76, 0x83, 0xa5, 0x05, 0x02, 0xd6, 0xe4, 0xe4, 0x16, 0x8c, 0x4b, 0x09, 0x72, 0x1d, 0xef,
-	0xf8, 0xb9, 0x83, 0x09, 0xdc, 0x02, 0x87, 0x5b, 0x34, 0x2e, 0x67, 0xc1, 0x2d, 0x72, 0xb8, 0x25,
-	0xe3, 0x9d, 0x2c, 0xb8, 0x25, 0x0e, 0xb7, 0x6c, 0xbc, 0x9b, 0xb5, 0x8d, 0x65, 0x7e, 0x98, 0x2b,
-	0xc6, 0x50, 0x74, 0x98, 0x2b, 0xa1, 0x25, 0x51, 0xd5, 0x15, 0xd0, 0xfb, 0x2c, 0x4c, 0x3c, 0x75,
-	0x1d, 0xcf, 0xea, 0x3c, 0xc2, 0x56, 0x27, 0xcb, 0x8e, 0x42, 0x07, 0x10, 0x21, 0x0b, 0xf0, 0xfa,
-	0x41, 0x83, 0xb1, 0x0d, 0xbb, 0xe7, 0x63, 0xb7, 0x97, 0xe7, 0x9c, 0x05, 0x05, 0xd5, 0xd1, 0x4d,
-	0xf9, 0x9c, 0xc7, 0x04, 0xb8, 0xe7, 0x9e, 0xe7, 0xb0, 0x53, 0xae, 0x88, 0xa7, 0xac, 0xb3, 0x33,
-	0xad, 0x88, 0x67, 0xaa, 0x0f, 0x3e, 0x40, 0x41, 0xe1, 0x99, 0x07, 0x28, 0xc0, 0x2d, 0x71, 0xf9,
-	0xf8, 0x01, 0x2a, 0xe5, 0x5b, 0x36, 0x3f, 0x83, 0x91, 0xbe, 0x02, 0x0a, 0x68, 0x70, 0x02, 0xc6,
-	0x36, 0x6d, 0x57, 0x8a, 0x4b, 0x9f, 0xc1, 0x48, 0x7f, 0xa9, 0x00, 0xc9, 0x79, 0xb8, 0xb2, 0x85,
-	0x83, 0xf5, 0xe3, 0x6e, 0x17, 0xbb, 0xc1, 0xae, 0xd7, 0xb3, 0x03, 0xdb, 0x73, 0x33, 0x0d, 0xe1,
-	0x67, 0x30, 0xad, 0xc2, 0x08, 0x79, 0x57, 0x44, 0xde, 0x25, 0xca, 0x81, 0x0b, 0xc4, 0x8f, 0x2e,
-	0x5b, 0xa0, 0x86, 0x89, 0x60, 0x7c, 0x0b, 0x07, 0xf4, 0x0b, 0xdf, 0x64, 0x00, 0xa3, 0xc2, 0x5a,
-	0xc8, 0xa9, 0x29, 0xef, 0xf2, 0x7d, 0x96, 0x3a, 0xe8, 0x99, 0xf4, 0x03, 0xbc, 0xb4, 0xd7, 0xd3,
-	0x4a, 0xf2, 0x0c, 0xa6, 0xda, 0x61, 0x88, 0xf4, 0x71, 0x10, 0xe0, 0xb6, 0x8f, 0x71, 0xe7, 0xd4,
-	0x81, 0x56, 0xb6, 0x48, 0x8d, 0x5a, 0xa4, 0xf9, 0x10, 0x26, 0x13, 0x84, 0x8b, 0x25, 0x94, 0x76,
-	0xe0, 0xf9, 0x42, 0x42, 0xa1, 0x7f, 0x16, 0x20, 0x65, 0x42, 0xf9, 0x99, 0x65, 0x07, 0xca, 0xfd,
-	0x69, 0xec, 0xdc, 0xd7, 0xa0, 0x44, 0x61, 0x0a, 0xba, 0x7e, 0xab, 0xe7, 0xdb, 0x5d, 0x2b, 0xf8,
-	0xff, 0x75, 0xfd, 0xbe, 0x02, 0x0a, 0x68, 0x70, 0x8e, 0x78, 0x1d, 0x77, 0xb7, 0x10, 0x02, 0x2b,
-	0x4f, 0xab, 0xc4, 0x4e, 0xeb, 0x1b, 0xa8, 0x26, 0xe1, 0xcf, 0xcb, 0x47, 0xa7, 0x60, 0x72, 0x0b,
-	0x07, 0x4f, 0x8e, 0x03, 0xff, 0x38, 0xd8, 0xb4, 0x9d, 0x28, 0x18, 0xfd, 0x14, 0x50, 0x6c, 0xfd,
-	0xbc, 0x38, 0x96, 0xa1, 0xb4, 0xe5, 0x71, 0x36, 0x2b, 0xf0, 0x6e, 0xf8, 0x47, 0x01, 0x2d, 0xfe,
-	0x2b, 0x2c, 0x42, 0xed, 0xd7, 0x99, 0x7e, 0x9c, 0x51, 0x52, 0x08, 0x76, 0xd9, 0xe0, 0x70, 0xbc,
-	0xa4, 0x48, 0xb1, 0x8f, 0x26, 0x2f, 0x3d, 0x78, 0x4d, 0xa1, 0x2e, 0x3d, 0x16, 0xb8, 0x2c, 0xd4,
-	0x2c, 0xf5, 0xb7, 0x62, 0x83, 0xab, 0x30, 0x44, 0x36, 0x5f, 0x2c, 0xf3, 0x6c, 0xdb, 0x07, 0x87,
-	0x41, 0xef, 0x89, 0x2b, 0x64, 0x9e, 0xfe, 0x52, 0x01, 0x92, 0x1d, 0x18, 0x25, 0x65, 0x8b, 0xf5,
-	0x2a, 0x7f, 0x58, 0xd5, 0x32, 0xc2, 0xaa, 0xec, 0xed, 0x74, 0x71, 0xc1, 0xbc, 0x0f, 0xc3, 0x11,
-	0x97, 0x02, 0x42, 0x1a, 0x30, 0xb5, 0x87, 0x8f, 0xbc, 0x13, 0xdc, 0x72, 0x9c, 0x5d, 0xc7, 0x0a,
-	0x70, 0x94, 0x93, 0x1e, 0xc2, 0x64, 0xe2, 0x4b, 0x01, 0x06, 0x1f, 0x73, 0x32, 0x84, 0x46, 0x2b,
-	0xc8, 0x74, 0xea, 0x75, 0x40, 0x31, 0xe0, 0x62, 0x1c, 0x69, 0x21, 0xd7, 0xea, 0x58, 0x7e, 0xe0,
-	0x75, 0x33, 0x93, 0xfd, 0x3a, 0xa0, 0x18, 0x70, 0x01, 0x8e, 0xcf, 0x61, 0xa2, 0xd5, 0xe9, 0x10,
-	0x99, 0xf7, 0xbd, 0xac, 0x0d, 0xa2, 0x1b, 0xb2, 0xef, 0x8d, 0x12, 0x3b, 0x8e, 0xb2, 0xb9, 0xea,
-	0xf0, 0x4b, 0x2c, 0xa7, 0x3e, 0x80, 0x31, 0x91, 0x47, 0x01, 0x29, 0x6f, 0x43, 0x65, 0x0b, 0x07,
-	0x61, 0x75, 0x9b, 0x1e, 0x5d, 0xb9, 0x5a, 0x9e, 0xc1, 0x84, 0x0c, 0x7b, 0x5e, 0x71, 0xee, 0x23,
-	0x40, 0xdb, 0x39, 0x8f, 0xa6, 0x05, 0xe3, 0xdb, 0x67, 0x3c, 0x98, 0x19, 0x18, 0xdb, 0xce, 0x53,
-	0xfb, 0x87, 0xae, 0x7e, 0x96, 0xca, 0xff, 0x4b, 0xa8, 0x3e, 0xf5, 0x3b, 0x56, 0x80, 0x77, 0x70,
-	0x60, 0x6d, 0x58, 0x81, 0xc5, 0xb9, 0xd5, 0xe5, 0x1a, 0x80, 0x74, 0x00, 0x76, 0x83, 0xee, 0xbe,
-	0xb7, 0xfd, 0x68, 0xb7, 0xeb, 0xf9, 0xb8, 0x1b, 0xd8, 0xb8, 0x27, 0x9e, 0x7e, 0xdd, 0xdc, 0x80,
-	0x4a, 0x9c, 0x56, 0x31, 0xbf, 0x0e, 0x8b, 0x58, 0xcb, 0xb7, 0x9e, 0xdb, 0x8e, 0x1d, 0x72, 0xe1,
-	0x7e, 0xdd, 0x23, 0xb9, 0x4d, 0xfe, 0x12, 0x32, 0xb8, 0x23, 0x33, 0x98, 0x0e, 0x19, 0x28, 0xa5,
-	0x2c, 0x58, 0xf4, 0xb2, 0x58, 0xfa, 0xf2, 0x25, 0x17, 0xe4, 0x73, 0x18, 0x15, 0xd6, 0x0a, 0xec,
-	0xf1, 0x05, 0x4c, 0xb7, 0x4f, 0x51, 0x32, 0x84, 0x35, 0xb5, 0xe8, 0x7c, 0x03, 0x6b, 0x6a, 0xd2,
-	0x49, 0xd8, 0x84, 0x6a, 0x5b, 0x59, 0x67, 0x9c, 0x52, 0xd8, 0x7f, 0x68, 0xfc, 0x26, 0xba, 0x6f,
-	0xfb, 0x51, 0x47, 0xe3, 0x96, 0x6c, 0x1f, 0xea, 0xdc, 0x99, 0xa7, 0xf4, 0xd6, 0x07, 0x5f, 0xf0,
-	0x85, 0x84, 0x7a, 0xee, 0x45, 0x62, 0xff, 0xf6, 0x4c, 0x77, 0x57, 0x40, 0x41, 0x7f, 0xd7, 0xa8,
-	0xb3, 0xfe, 0x4f, 0xaa, 0x87, 0x05, 0x98, 0xc2, 0xca, 0x09, 0x6b, 0x09, 0xb6, 0x90, 0xbb, 0x96,
-	0x28, 0x65, 0xa4, 0x13, 0xb9, 0x96, 0x18, 0x12, 0x6a, 0x09, 0xce, 0xa5, 0x80, 0x90, 0x0f, 0xc8,
-	0xb5, 0x6f, 0xa3, 0x6b, 0x9f, 0x64, 0xdc, 0x26, 0x4b, 0x19, 0x65, 0x4f, 0x98, 0x8d, 0x63, 0x14,
-	0x0a, 0x88, 0xf1, 0x9b, 0x32, 0x54, 0x14, 0x81, 0x4b, 0xd9, 0x0b, 0xe5, 0x8b, 0x91, 0xe9, 0xb4,
-	0x42, 0x8d, 0x7d, 0x4e, 0x17, 0x79, 0xe5, 0x7b, 0x5b, 0x0a, 0x14, 0x3c, 0x7c, 0xb3, 0x88, 0x10,
-	0x0f, 0x1a, 0xad, 0x50, 0xbb, 0x4d, 0x4a, 0x80, 0x9b, 0xd9, 0xc0, 0x48, 0xd3, 0x0a, 0x6d, 0xee,
-	0x13, 0x8a, 0xc4, 0x6d, 0xae, 0x26, 0x21, 0xd1, 0xff, 0x8a, 0x08, 0x8b, 0x1c, 0x81, 0x1b, 0xdf,
-	0x20, 0x84, 0x25, 0xf4, 0x29, 0x45, 0xe0, 0x55, 0xf4, 0x87, 0xca, 0x7d, 0x39, 0x24, 0xf5, 0x24,
-	0xe4, 0x5b, 0x46, 0xf7, 0x29, 0x36, 0xed, 0xcd, 0x95, 0x1b, 0xb3, 0x2a, 0xec, 0x7d, 0xdb, 0x7f,
-	0xee, 0xbd, 0x56, 0xa0, 0xaf, 0x70, 0xa5, 0xae, 0x1a, 0xa5, 0x74, 0xa5, 0xee, 0xdb, 0xfe, 0x2b,
-	0xab, 0xa7, 0xe4, 0xbf, 0x8a, 0x3e, 0x83, 0x77, 0xc8, 0xf9, 0xcd, 0x1b, 0x70, 0x3a, 0xf1, 0x43,
-	0xbe, 0xf5, 0xf9, 0x08, 0xbf, 0x6e, 0x94, 0x0b, 0xe0, 0xd7, 0x51, 0x9d, 0xe1, 0x37, 0x8c, 0xe1,
-	0x81, 0xfa, 0x26, 0x28, 0x0d, 0x54, 0x65, 0x28, 0x4d, 0x63, 0x84, 0xf6, 0xe9, 0xc3, 0xe5, 0x66,
-	0xb4, 0xbc, 0x60, 0x8c, 0xf6, 0x97, 0x17, 0xa2, 0xe5, 0x45, 0x63, 0xac, 0xbf, 0xbc, 0x18, 0x2d,
-	0x2f, 0x19, 0xe3, 0xfd, 0xe5, 0x25, 0xb4, 0xc0, 0x96, 0x97, 0x8d, 0x09, 0x22, 0xce, 0x35, 0x21,
-	0xf6, 0xb0, 0xcd, 0x84, 0xf5, 0x8c, 0x24, 0xd1, 0x72, 0x84, 0xb5, 0x62, 0xa0, 0xdc, 0x58, 0x2b,
-	0x68, 0x99, 0x61, 0xad, 0x1a, 0x15, 0x82, 0x75, 0x3d, 0x89, 0xc5, 0xca, 0x34, 0x09, 0x71, 0x15,
-	0x35, 0x29, 0x62, 0x63, 0xde, 0x98, 0xec, 0x17, 0x40, 0x32, 0xe2, 0xbe, 0xed, 0x8b, 0x48, 0x8d,
-	0x79, 0x34, 0xcb, 0x90, 0xea, 0x46, 0x35, 0x2d, 0xaa, 0x12, 0xc8, 0x7a, 0x04, 0xd9, 0x30, 0xa6,
-	0x32, 0x21, 0x1b, 0x11, 0x64, 0xd3, 0x98, 0xce, 0x84, 0x6c, 0x46, 0x90, 0x0b, 0x86, 0x91, 0x09,
-	0xb9, 0x10, 0x41, 0x2e, 0x1a, 0x57, 0x32, 0x21, 0x17, 0x23, 0xc8, 0x25, 0xa3, 0x96, 0x09, 0xb9,
-	0x84, 0x56, 0x19, 0xe4, 0xb2, 0xf1, 0x1e, 0x81, 0x34, 0x85, 0x8a, 0x71, 0xfd, 0xd0, 0x72, 0x5d,
-	0xec, 0xec, 0x5a, 0x5d, 0xeb, 0x08, 0x07, 0x58, 0xd2, 0x75, 0x63, 0x19, 0x3d, 0x60, 0xa8, 0x2b,
-	0xc6, 0x55, 0x82, 0xfa, 0x51, 0x52, 0xd7, 0x99, 0x14, 0x56, 0xd0, 0x7d, 0x46, 0x61, 0xd5, 0xb8,
-	0x46, 0x28, 0xcc, 0x48, 0x16, 0xbe, 0xee, 0x79, 0xdd, 0x8e, 0xed, 0x86, 0x37, 0x41, 0x85, 0x83,
-	0x34, 0x56, 0xb9, 0xa1, 0x36, 0xe7, 0x8d, 0xf7, 0x49, 0x30, 0x0d, 0x97, 0x9b, 0xf3, 0xe6, 0x23,
-	0x30, 0xd2, 0x4a, 0xde, 0xb0, 0xe8, 0x14, 0x13, 0x7c, 0x7a, 0xd1, 0x49, 0x4a, 0xe3, 0x9b, 0x30,
-	0x22, 0x29, 0x4d, 0xcc, 0x2c, 0x7a, 0x74, 0x89, 0xbc, 0x01, 0xc3, 0x62, 0x8d, 0x20, 0x03, 0xe9,
-	0x09, 0x52, 0xb4, 0xe7, 0x21, 0x43, 0xf1, 0x96, 0xa0, 0x09, 0x65, 0xa1, 0xcb, 0x20, 0xc3, 0x0c,
-	0x31, 0x98, 0x9f, 0xc3, 0xcd, 0xf4, 0xd0, 0x9e, 0xf9, 0x23, 0xde, 0x4d, 0xf1, 0x47, 0xbc, 0xe4,
-	0x65, 0x90, 0x7e, 0x34, 0x5f, 0x40, 0x2d, 0x9d, 0x3e, 0x7a, 0x98, 0xfc, 0x99, 0x71, 0x36, 0x3b,
-	0xdb, 0xa8, 0x7e, 0x72, 0x34, 0x9f, 0xc2, 0xa4, 0x2a, 0xa3, 0xca, 0x39, 0x73, 0x28, 0x2b, 0x67,
-	0x56, 0xc4, 0x9c, 0x49, 0xb3, 0x6b, 0xd3, 0x3c, 0x81, 0xf9, 0x7c, 0x79, 0x33, 0x53, 0x4f, 0xf3,
-	0xb2, 0x9e, 0x6a, 0xe2, 0x25, 0x4a, 0xa6, 0xc6, 0x75, 0xf6, 0x2b, 0x98, 0xc9, 0xc7, 0x17, 0xfd,
-	0x24, 0xa9, 0xbf, 0x85, 0xfc, 0xe9, 0x5e, 0xa9, 0xcb, 0xbf, 0xe8, 0x30, 0x16, 0xcb, 0x84, 0x39,
-	0x6a, 0x8f, 0x52, 0x86, 0x1e, 0xf9, 0x22, 0xad, 0x27, 0x4a, 0xac, 0x5e, 0xa8, 0x88, 0xf5, 0x82,
-	0xce, 0x6a, 0x82, 0x8a, 0x58, 0x13, 0xe8, 0x2c, 0xef, 0x57, 0xc4, 0xbc, 0xaf, 0xb1, 0x74, 0x7e,
-	0x5b, 0x4e, 0xe7, 0x55, 0x41, 0xab, 0x42, 0x48, 0xa6, 0xb9, 0xfb, 0x63, 0x39, 0x77, 0x4f, 0x09,
-	0xb0, 0xcf, 0xb0, 0xe3, 0xc8, 0x79, 0xba, 0x2a, 0xe5, 0x69, 0x9d, 0xa7, 0xdf, 0xb5, 0x58, 0xfa,
-	0xbd, 0x21, 0x84, 0xa7, 0xcc, 0x8c, 0x50, 0xaf, 0x47, 0x24, 0x69, 0xea, 0xd5, 0xd4, 0xe9, 0x55,
-	0x53, 0xa7, 0x57, 0x4d, 0x9d, 0x5e, 0x35, 0x75, 0x7a, 0x65, 0xcb, 0x4b, 0xe6, 0x5f, 0x35, 0x18,
-	0x8f, 0x67, 0xb5, 0xb3, 0x1e, 0xe3, 0xb2, 0x5c, 0x16, 0xe6, 0x89, 0xed, 0x19, 0x47, 0x7d, 0x5b,
-	0x2e, 0xff, 0x32, 0x0e, 0x70, 0xc9, 0x5c, 0x83, 0x89, 0xc4, 0x27, 0xe5, 0xed, 0x4a, 0xc6, 0x25,
-	0x01, 0xce, 0x85, 0x3b, 0x83, 0x73, 0x40, 0xa6, 0x03, 0xdf, 0x91, 0x1d, 0x78, 0x8a, 0x16, 0xf0,
-	0x71, 0x4a, 0xdc, 0x79, 0x7b, 0x60, 0x0e, 0xe6, 0x87, 0x76, 0x92, 0x8e, 0x3b, 0x9f, 0x2f, 0x5d,
-	0x29, 0x9d, 0x76, 0x03, 0xa6, 0xd4, 0x9e, 0x1e, 0xaa, 0x59, 0xd4, 0x52, 0x95, 0xa5, 0xa8, 0x58,
-	0xe0, 0xa1, 0xaa, 0xda, 0x04, 0x23, 0xcd, 0x94, 0x45, 0x3a, 0x7a, 0xc6, 0x71, 0xb1, 0x7e, 0x54,
-	0x45, 0x51, 0x8f, 0xa2, 0x0f, 0x65, 0x51, 0x10, 0x13, 0x45, 0x00, 0x61, 0xf8, 0x3e, 0xdc, 0xcd,
-	0x51, 0xd8, 0x66, 0x9e, 0xd9, 0x5d, 0xf9, 0xcc, 0xa6, 0xc5, 0xa0, 0x2b, 0x32, 0x64, 0x87, 0x76,
-	0x0c, 0x37, 0x72, 0x70, 0x44, 0x5f, 0x25, 0x4f, 0xad, 0x9e, 0xb3, 0x0c, 0x57, 0x1e, 0xdb, 0x17,
-	0x50, 0x55, 0xd6, 0x9f, 0xe8, 0x23, 0x59, 0x55, 0x93, 0x54, 0x55, 0xb1, 0x22, 0x95, 0x2a, 0xeb,
-	0x77, 0x1a, 0x4c, 0x24, 0x8f, 0xbd, 0xa0, 0xab, 0xeb, 0xcc, 0xd5, 0x3f, 0x91, 0x5d, 0xbd, 0x26,
-	0x04, 0xbb, 0xed, 0x47, 0x1b, 0xf8, 0xc4, 0x7e, 0x81, 0x33, 0x5c, 0x9c, 0xc6, 0xe8, 0x45, 0x73,
-	0x1f, 0xde, 0xcf, 0x8e, 0x0f, 0xa8, 0x21, 0xef, 0xf3, 0x1a, 0xdd, 0x67, 0x6a, 0x34, 0x21, 0x1b,
-	0xfe, 0x05, 0xdc, 0xca, 0x55, 0x16, 0x86, 0xf1, 0x4a, 0x34, 0xd9, 0x5c, 0xf1, 0x8a, 0x70, 0xf8,
-	0x0a, 0xae, 0x66, 0xdd, 0x0e, 0xd0, 0x9c, 0x4c, 0xf8, 0x8a, 0x40, 0x58, 0x79, 0x44, 0x0f, 0x60,
-	0x52, 0x75, 0xc1, 0x44, 0xb3, 0xf2, 0xee, 0x2b, 0x51, 0x04, 0xeb, 0xc3, 0x30, 0x0a, 0x3f, 0x90,
-	0x43, 0x8e, 0xdd, 0x2f, 0xcf, 0x35, 0x2d, 0xeb, 0xb9, 0xd3, 0xb2, 0x96, 0x95, 0x96, 0x2b, 0x62,
-	0x5a, 0xd6, 0x58, 0xfe, 0xad, 0x88, 0xf9, 0x57, 0x63, 0x79, 0xf6, 0x6e, 0xec, 0x3e, 0x9c, 0x96,
-	0x95, 0x69, 0xfe, 0x35, 0xff, 0xa0, 0xc1, 0x88, 0x74, 0xb9, 0x3b, 0xeb, 0xee, 0xe7, 0x64, 0x13,
-	0xcf, 0x3a, 0x44, 0xa2, 0x98, 0x65, 0xb9, 0xbf, 0x91, 0x37, 0xfb, 0x2d, 0x9a, 0x6d, 0x40, 0xc9,
-	0x60, 0x2e, 0x4b, 0xaf, 0xa9, 0xa4, 0xd7, 0x54, 0xd2, 0x6b, 0xac, 0x34, 0xed, 0x42, 0xae, 0xe6,
-	0x45, 0x66, 0x8c, 0x9c, 0x93, 0x63, 0xa4, 0x21, 0x87, 0x74, 0xc1, 0x06, 0x59, 0x90, 0x7c, 0x25,
-	0x5c, 0x15, 0x32, 0x78, 0xa2, 0x27, 0xc9, 0x28, 0xd9, 0xc8, 0xdb, 0x6d, 0x19, 0x90, 0xdd, 0xe2,
-	0x1e, 0xa0, 0xce, 0x6e, 0x31, 0x28, 0xe6, 0x43, 0x9f, 0x02, 0x4a, 0xb6, 0x08, 0x94, 0xcf, 0x1c,
-	0xe4, 0x26, 0x02, 0xc5, 0xfe, 0xbd, 0x06, 0xc3, 0x52, 0x42, 0x3c, 0x4f, 0xe7, 0x1b, 0x62, 0x36,
-	0x36, 0x23, 0xdb, 0x18, 0x11, 0xe6, 0x6b, 0xcf, 0x39, 0x3e, 0xc2, 0x72, 0xeb, 0x6c, 0x46, 0xae,
-	0x9d, 0x52, 0xe0, 0x96, 0xcc, 0xdf, 0x5e, 0x86, 0xd1, 0x58, 0x12, 0x7e, 0xeb, 0xa5, 0x7c, 0x49,
-	0x15, 0x33, 0x4a, 0xaa, 0x98, 0xa1, 0xab, 0x62, 0x86, 0xae, 0x8a, 0x19, 0x3a, 0x8b, 0x19, 0xf7,
-	0x63, 0x31, 0x63, 0x46, 0x65, 0x56, 0x42, 0xf0, 0x88, 0xb5, 0xd0, 0xaa, 0x52, 0x0d, 0xaf, 0xa9,
-	0xcb, 0xf3, 0x12, 0x2f, 0xcf, 0xef, 0x49, 0xe5, 0x79, 0xb9, 0x71, 0x33, 0xb5, 0xe2, 0x4f, 0x84,
-	0xab, 0x66, 0x84, 0x4c, 0x8b, 0xf8, 0x53, 0x21, 0x2f, 0x44, 0xa1, 0x91, 0x96, 0xfa, 0x03, 0x42,
-	0xe3, 0x62, 0xa4, 0x15, 0x7a, 0x05, 0x38, 0xa5, 0x56, 0x96, 0xa2, 0xed, 0xd3, 0x4e, 0x9c, 0xc6,
-	0x5b, 0x6d, 0x55, 0xa9, 0xd5, 0xa6, 0xf1, 0x5e, 0x5a, 0x55, 0xea, 0xa5, 0x69, 0xbc, 0x53, 0x56,
-	0x95, 0x3a, 0x65, 0x1a, 0xef, 0x85, 0x55, 0xa5, 0x5e, 0x18, 0x5b, 0xae, 0x9b, 0x8f, 0xe0, 0x4a,
-	0x6a, 0xd3, 0x2e, 0xbc, 0xad, 0x89, 0xd9, 0x55, 0xdc, 0x7c, 0xd2, 0x2d, 0xff, 0x76, 0x11, 0xae,
-	0xa4, 0x57, 0x00, 0x67, 0x34, 0xf6, 0x19, 0x39, 0x45, 0xa4, 0xb8, 0x59, 0x7e, 0xb7, 0x9d, 0x95,
-	0xdd, 0x96, 0xe4, 0xfb, 0x4d, 0xc7, 0x7b, 0xb5, 0x27, 0x56, 0xc0, 0xc4, 0x4f, 0x66, 0xe5, 0x56,
-	0x77, 0x2a, 0x64, 0x1e, 0xe7, 0x19, 0xca, 0xbe, 0xd8, 0xca, 0x4e, 0xc1, 0x96, 0xeb, 0xaa, 0xde,
-	0x87, 0xba, 0xbd, 0x5d, 0xb4, 0xf7, 0x11, 0x0f, 0xd2, 0xe9, 0xbd, 0x0f, 0x35, 0xdf, 0xdc, 0xbd,
-	0x8f, 0x41, 0x62, 0x0b, 0x89, 0xe6, 0x4b, 0xb8, 0xae, 0xee, 0x88, 0x9f, 0xfe, 0x35, 0xbb, 0xf9,
-	0x0d, 0x4c, 0xa9, 0x69, 0xa1, 0x56, 0x52, 0xf0, 0x9b, 0xe9, 0xcd, 0xf8, 0x94, 0x8b, 0xc3, 0x94,
-	0xba, 0x1c, 0x17, 0x6b, 0x4a, 0xbd, 0x5f, 0x53, 0xc6, 0x4a, 0xf6, 0x58, 0x3e, 0x14, 0x62, 0x43,
-	0x4a, 0x3e, 0x94, 0xda, 0x24, 0x04, 0x7b, 0x1f, 0x3e, 0x18, 0xd0, 0xfd, 0x10, 0x5f, 0x0f, 0xe8,
-	0x03, 0x9b, 0xe7, 0x94, 0x6a, 0x22, 0x30, 0x88, 0xa2, 0x65, 0x04, 0x86, 0xa4, 0x7c, 0xff, 0xd4,
-	0xc3, 0x7a, 0x51, 0x44, 0xff, 0x2f, 0xca, 0x7c, 0xca, 0x6a, 0xb9, 0xc4, 0x9c, 0x77, 0x5e, 0xee,
-	0x56, 0xbd, 0x27, 0x16, 0x9a, 0xde, 0x91, 0xef, 0xb9, 0xd8, 0x0d, 0xb2, 0x5a, 0x56, 0x1a, 0xf7,
-	0xec, 0x3b, 0xb1, 0x96, 0x55, 0xff, 0xce, 0xde, 0x3e, 0xb4, 0x7c, 0x9c, 0xd9, 0xa4, 0xd2, 0xff,
-	0x03, 0x4d, 0xaa, 0x58, 0xe6, 0x29, 0xf1, 0xcc, 0xb3, 0x18, 0xfb, 0x91, 0x67, 0xd0, 0xef, 0x8f,
-	0x34, 0x33, 0x49, 0x8d, 0x9e, 0xd4, 0xa4, 0x77, 0xea, 0x46, 0x8f, 0x2a, 0xed, 0x26, 0x1b, 0x3d,
-	0xa9, 0xfc, 0x06, 0x37, 0x7a, 0x72, 0x88, 0x2a, 0x38, 0xfe, 0x2e, 0x8c, 0xc5, 0x3d, 0xfe, 0x6c,
-	0x76, 0x6d, 0x7e, 0x0d, 0xd7, 0x07, 0xd5, 0x25, 0xe2, 0x35, 0x5d, 0x57, 0xff, 0xea, 0x96, 0x74,
-	0xc0, 0xc7, 0x30, 0x22, 0xe5, 0x40, 0xb4, 0x22, 0xca, 0xc9, 0x1a, 0xa8, 0x04, 0x7b, 0xdd, 0x73,
-	0x5f, 0x74, 0x71, 0x18, 0xa0, 0xad, 0xde, 0x71, 0x17, 0x1f, 0xc5, 0xec, 0xbb, 0x6e, 0xfe, 0x18,
-	0xc6, 0x62, 0xc9, 0xef, 0x0c, 0xc4, 0xfe, 0xac, 0x03, 0x4a, 0x7a, 0x92, 0x5a, 0x8b, 0x1f, 0x8a,
-	0x5a, 0x64, 0x1d, 0xab, 0x2f, 0x1c, 0xef, 0xc5, 0x77, 0x8f, 0x37, 0x44, 0xa2, 0xe7, 0x1b, 0x31,
-	0x94, 0x6d, 0xef, 0x52, 0xee, 0x74, 0xaf, 0x65, 0x07, 0x05, 0x39, 0xdd, 0x97, 0x06, 0xd4, 0xc0,
-	0x6f, 0xd3, 0xfb, 0x17, 0x63, 0xbf, 0x00, 0xe7, 0x72, 0xf3, 0x65, 0xf3, 0x15, 0x0c, 0x8b, 0xe1,
-	0xab, 0xa8, 0xf9, 0x6b, 0xaa, 0x43, 0xd2, 0xb2, 0xba, 0x59, 0xbc, 0x09, 0x2d, 0x71, 0x57, 0x35,
-	0xa1, 0xa5, 0xe8, 0x4a, 0x9d, 0xe1, 0x3b, 0xa8, 0xa5, 0x1b, 0xa6, 0xba, 0x17, 0xb0, 0x22, 0xdb,
-	0x5e, 0xdf, 0xc2, 0xb7, 0xb0, 0x8b, 0xbb, 0xf6, 0x8b, 0xdd, 0x2e, 0x7e, 0x69, 0xbf, 0xc6, 0x9d,
-	0xa7, 0xae, 0x2d, 0x59, 0x78, 0xc3, 0xdc, 0x84, 0xe9, 0x94, 0x7c, 0x21, 0xa6, 0xd0, 0x28, 0xc2,
-	0xa9, 0xd2, 0x0a, 0x11, 0xfa, 0x6b, 0xf8, 0x60, 0x80, 0x4b, 0xf1, 0x37, 0x69, 0xd2, 0x9c, 0x47,
-	0x96, 0x07, 0x12, 0xba, 0xb7, 0x60, 0x54, 0x76, 0x22, 0xf5, 0x9c, 0x5f, 0x0f, 0x6a, 0xe9, 0x5b,
-	0xe5, 0xbf, 0xe9, 0x48, 0x3b, 0x61, 0xe0, 0x71, 0x8d, 0xd4, 0x79, 0x39, 0xcd, 0x75, 0x49, 0x8a,
-	0xa2, 0xf6, 0x63, 0x4a, 0x56, 0xd6, 0x1d, 0xdf, 0x73, 0x06, 0x67, 0xd5, 0x9e, 0xb3, 0xce, 0x84,
-	0xec, 0xf9, 0x1e, 0x8c, 0xc5, 0x18, 0xe6, 0x30, 0x5c, 0xd6, 0x01, 0x32, 0x0f, 0x01, 0x25, 0xb7,
-	0x76, 0xae, 0x86, 0xcf, 0xa2, 0x53, 0xe3, 0x4f, 0xe3, 0x70, 0xf5, 0xe1, 0xeb, 0x00, 0xbb, 0x1d,
-	0xdc, 0xd9, 0xb6, 0xbf, 0x3f, 0xb6, 0x3b, 0x87, 0x96, 0xdb, 0x71, 0x6c, 0xf7, 0x80, 0xbc, 0x6a,
-	0xea, 0xa2, 0x35, 0x80, 0xfe, 0x3b, 0x5e, 0x44, 0x2a, 0x8a, 0xc4, 0xdb, 0xe1, 0x5a, 0x25, 0xbe,
-	0xec, 0x3b, 0x6f, 0xcc, 0x0b, 0x68, 0x01, 0x86, 0xf8, 0x8c, 0x05, 0xa2, 0x20, 0xf2, 0xc8, 0x49,
-	0x6d, 0x42, 0x5e, 0xa4, 0x58, 0x1f, 0xc3, 0x65, 0x32, 0xb7, 0x88, 0x88, 0x6f, 0x89, 0x23, 0x8d,
-	0xb5, 0x51, 0x61, 0x25, 0x62, 0xc1, 0x27, 0xb8, 0x28, 0x8b, 0xd8, 0x40, 0x1b, 0x65, 0x21, 0x0d,
-	0x79, 0x51, 0x2c, 0x3e, 0xa4, 0x45, 0xb1, 0x62, 0x53, 0x5c, 0x14, 0x4b, 0x9a, 0xe3, 0x32, 0x2f,
-	0xa0, 0xc7, 0x30, 0x16, 0x7b, 0x89, 0x8a, 0x6a, 0xd4, 0x16, 0x54, 0x0f, 0x57, 0x6b, 0x86, 0xf2,
-	0x1b, 0x25, 0xb5, 0x47, 0x06, 0x33, 0x62, 0x33, 0x5b, 0xe8, 0x1a, 0xc7, 0x50, 0x4e, 0x7f, 0xd5,
-	0xde, 0x4b, 0xfb, 0x4c, 0x69, 0x3e, 0x80, 0x61, 0xf1, 0x0d, 0x34, 0x9a, 0x66, 0xe0, 0xf1, 0x17,
-	0xd4, 0xb5, 0x6a, 0xf2, 0x03, 0xa5, 0xb0, 0x4d, 0x46, 0xbd, 0xa4, 0xb7, 0xa3, 0x88, 0x33, 0x55,
-	0x3d, 0x5b, 0xad, 0x5d, 0x51, 0x7f, 0xa4, 0xd4, 0xd6, 0x61, 0x44, 0x1a, 0x3e, 0x41, 0x5c, 0x21,
-	0x89, 0x39, 0x95, 0xda, 0x94, 0xe2, 0x0b, 0x25, 0xb2, 0x0c, 0xa5, 0x68, 0xd2, 0x0c, 0x4d, 0x32,
-	0x30, 0x69, 0x18, 0xad, 0x86, 0x62, 0xab, 0x14, 0xd1, 0x84, 0x8b, 0x5b, 0x1e, 0x1a, 0x21, 0xdf,
-	0x22, 0x3b, 0x2d, 0xf3, 0x3f, 0x29, 0xcc, 0x1a, 0x40, 0x7f, 0x86, 0x98, 0xda, 0x76, 0x62, 0xd0,
-	0x98, 0xda, 0x76, 0x6c, 0xd4, 0x98, 0x0a, 0x16, 0xbd, 0x06, 0xa6, 0x82, 0xc5, 0x1f, 0x0c, 0x53,
-	0xc1, 0xe4, 0x27, 0xc3, 0xd4, 0xf6, 0xf8, 0x98, 0x06, 0xb5, 0xbd, 0xd8, 0x1c, 0x07, 0xb5, 0x3d,
-	0x69, 0x92, 0xc3, 0xbc, 0x80, 0xee, 0x43, 0x59, 0x78, 0x5c, 0x8e, 0x68, 0x1c, 0x4f, 0x3c, 0x4c,
-	0xaf, 0x4d, 0x26, 0xd6, 0xfb, 0x4c, 0xd9, 0x83, 0x71, 0xc6, 0x54, 0x7e, 0x66, 0xce, 0x98, 0x8a,
-	0x6f, 0xca, 0xfb, 0x58, 0xfb, 0xb6, 0xdf, 0xeb, 0x63, 0x09, 0xef, 0x5d, 0xfb, 0x58, 0xd1, 0x43,
-	0x51, 0xf3, 0x02, 0xaa, 0xc3, 0xbb, 0x3c, 0x62, 0x11, 0x0d, 0xc8, 0x0f, 0x41, 0x6b, 0xe3, 0xd2,
-	0x1a, 0x45, 0xb9, 0x05, 0xfa, 0x8e, 0xfd, 0x1a, 0x11, 0xf7, 0xee, 0x8f, 0x03, 0xd5, 0x86, 0xa3,
-	0xbf, 0x29, 0xd8, 0x2c, 0x5c, 0xda, 0xf1, 0x4e, 0x30, 0x22, 0xe3, 0x34, 0xc2, 0x74, 0x72, 0x6d,
-	0xa4, 0xbf, 0xd0, 0x97, 0x81, 0x4e, 0x99, 0x30, 0x19, 0xa4, 0xc1, 0x16, 0x26, 0x83, 0x30, 0x86,
-	0x42, 0x89, 0x3f, 0xf1, 0xb1, 0x4b, 0x89, 0x0b, 0x83, 0xe1, 0x94, 0x78, 0x34, 0x1a, 0x4e, 0xe3,
-	0x40, 0x6c, 0xd2, 0x84, 0xc6, 0x01, 0xf5, 0x60, 0x0a, 0x8d, 0x03, 0xaa, 0xd1, 0x14, 0xea, 0x23,
-	0xd2, 0x00, 0x09, 0x12, 0x80, 0xe5, 0x01, 0x14, 0xea, 0x23, 0xc9, 0x69, 0x13, 0xea, 0xf8, 0xe2,
-	0xf0, 0x38, 0x75, 0x7c, 0xc5, 0x3c, 0x3a, 0x75, 0xfc, 0xc4, 0x9c, 0x39, 0x15, 0x43, 0x7a, 0xc7,
-	0x4a, 0xc5, 0x50, 0x3d, 0x8e, 0xa5, 0x62, 0x24, 0x1f, 0xbd, 0x52, 0xb5, 0xc4, 0xa6, 0x28, 0xa9,
-	0x5a, 0xd4, 0x33, 0x9b, 0x35, 0x43, 0xf9, 0x2d, 0x0a, 0x44, 0x6d, 0x65, 0x20, 0x6a, 0x67, 0x05,
-	0xa2, 0x76, 0x4a, 0x20, 0x9a, 0x85, 0x4b, 0xed, 0xc0, 0xf3, 0xe9, 0xc9, 0x0a, 0x13, 0x9a, 0xf4,
-	0x64, 0xa3, 0x19, 0x4d, 0xaa, 0x07, 0x69, 0xba, 0x86, 0xea, 0x41, 0x35, 0x9d, 0x43, 0xf5, 0x90,
-	0x1c, 0xc5, 0xa1, 0x51, 0xa5, 0x3f, 0x98, 0x4d, 0xa3, 0x4a, 0x62, 0xa4, 0x9b, 0x46, 0x95, 0xd8,
-	0xfc, 0xb6, 0x88, 0x4b, 0x7c, 0x4e, 0xc0, 0x15, 0xbd, 0xae, 0x12, 0x5f, 0xa6, 0xb8, 0x9b, 0x30,
-	0x2a, 0x0f, 0x62, 0x20, 0xa2, 0x15, 0xe5, 0xa0, 0x47, 0x6d, 0x5a, 0xf5, 0x29, 0x52, 0xd7, 0x33,
-	0xcb, 0x0e, 0xa8, 0xba, 0x84, 0x29, 0x54, 0xaa, 0xae, 0x68, 0xe4, 0xd4, 0xbc, 0xf0, 0xfc, 0x1d,
-	0xf2, 0x7f, 0x93, 0x68, 0xfe, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x54, 0xd3, 0xa2, 0x84, 0x5c, 0x42,
-	0x00, 0x00,
+	0xb5, 0x10, 0xec, 0x44, 0x7c, 0x14, 0x25, 0x71, 0x29, 0x4a, 0x30, 0x62, 0x3b, 0x2e, 0x2c, 0x2b,
+	0x8a, 0x3f, 0x14, 0x91, 0x94, 0x44, 0x49, 0x8e, 0x13, 0x33, 0x92, 0x25, 0x3b, 0x95, 0x63, 0x55,
+	0x92, 0xe3, 0xce, 0x64, 0xda, 0x14, 0x36, 0x61, 0x09, 0x13, 0x8a, 0x60, 0x48, 0xc8, 0x1f, 0xed,
+	0xa5, 0x87, 0x5e, 0x7b, 0xec, 0x4c, 0x7f, 0x47, 0x2f, 0x9d, 0x76, 0xa6, 0xa7, 0x1e, 0x7b, 0xef,
+	0xa1, 0xd3, 0xff, 0xd0, 0x1f, 0xd0, 0x43, 0x3a, 0xc0, 0xee, 0x02, 0xfb, 0x80, 0x05, 0x08, 0x41,
+	0x4a, 0xa7, 0xd3, 0x5e, 0x32, 0xd6, 0xc3, 0x7b, 0x6f, 0xdf, 0xbe, 0x7d, 0x5f, 0xfb, 0xb8, 0x2f,
+	0x50, 0xec, 0x1c, 0x7d, 0xd4, 0x39, 0x5a, 0xe8, 0xf5, 0x1d, 0xd7, 0x21, 0x23, 0x9d, 0x23, 0xe3,
+	0xd7, 0x0a, 0xc0, 0x63, 0xb3, 0xf7, 0xd8, 0x1a, 0x0c, 0xcc, 0x43, 0x8b, 0xac, 0x41, 0xe1, 0xd8,
+	0xec, 0x7d, 0xfd, 0xd2, 0xb6, 0x3a, 0x6d, 0x4d, 0xb9, 0xa6, 0xce, 0x17, 0xeb, 0x97, 0x17, 0x3a,
+	0x47, 0x0b, 0x21, 0x8a, 0xf7, 0xcf, 0x2d, 0xef, 0xf3, 0x83, 0xae, 0xdb, 0x7f, 0xbb, 0x37, 0x7a,
+	0xcc, 0xfe, 0xd4, 0xef, 0x42, 0x09, 0x7d, 0x22, 0x93, 0xa0, 0x7e, 0x63, 0xbd, 0xd5, 0x94, 0x6b,
+	0xca, 0x7c, 0x61, 0xcf, 0xfb, 0x27, 0x99, 0x82, 0x8b, 0xaf, 0xcc, 0xce, 0x89, 0xa5, 0x8d, 0xf8,
+	0x30, 0xfa, 0xc7, 0xfa, 0xc8, 0xaa, 0x62, 0xfc, 0x10, 0xa0, 0xd5, 0x7d, 0xcb, 0xa5, 0xa8, 0xc0,
+	0xc5, 0x56, 0xff, 0xf0, 0xeb, 0x1a, 0xa3, 0xbd, 0xd0, 0xea, 0x1f, 0xd6, 0x8c, 0x0a, 0x94, 0x1f,
+	0x75, 0x6d, 0xd7, 0x36, 0x3b, 0xf6, 0x2f, 0xac, 0x3d, 0xeb, 0xdb, 0x13, 0x6b, 0xe0, 0x1a, 0xf7,
+	0x61, 0x42, 0x04, 0xf6, 0x3a, 0x6f, 0xc9, 0x1d, 0xb8, 0xb8, 0x67, 0xb9, 0x8c, 0xb8, 0x58, 0xd7,
+	0x3c, 0xf1, 0x37, 0x9c, 0xe3, 0x63, 0xb3, 0xdb, 0xde, 0x77, 0x4d, 0xf7, 0x64, 0xc0, 0x56, 0xd9,
+	0xbb, 0xb0, 0x67, 0xb9, 0x35, 0xa3, 0x04, 0xc5, 0x27, 0x3d, 0xab, 0xcb, 0x19, 0xae, 0x43, 0x81,
+	0xfe, 0x99, 0x83, 0xd5, 0xa7, 0x50, 0xd9, 0xb3, 0x06, 0x96, 0xbb, 0x6b, 0x0f, 0x5c, 0xa7, 0x3b,
+	0x60, 0x2c, 0xbd, 0xdd, 0x98, 0xc1, 0x6e, 0xd4, 0xbd, 0x0b, 0x66, 0xff, 0xb0, 0xc6, 0x81, 0x75,
+	0x6d, 0x24, 0x00, 0xd6, 0x8d, 0xcf, 0xa0, 0x8c, 0x19, 0xe4, 0x10, 0x62, 0x1c, 0xc6, 0x36, 0x3a,
+	0xce, 0x20, 0xd0, 0xd0, 0x5d, 0x00, 0xf6, 0x77, 0x0e, 0x66, 0x7f, 0x1c, 0x81, 0xe2, 0x63, 0xe7,
+	0x15, 0x67, 0x46, 0xe6, 0xc4, 0xad, 0x14, 0xeb, 0x65, 0x8f, 0xbc, 0xd5, 0xef, 0x9b, 0x6f, 0x9f,
+	0xbc, 0x1c, 0xb8, 0x7d, 0xbb, 0x7b, 0xc8, 0x76, 0x37, 0x27, 0xee, 0x2e, 0x11, 0xaf, 0x4e, 0x6e,
+	0x50, 0xbc, 0x86, 0xa6, 0xfa, 0x78, 0x93, 0x02, 0x9e, 0xdd, 0x75, 0x57, 0x96, 0x7c, 0xb4, 0x06,
+	0x67, 0xb7, 0xa4, 0x5d, 0x88, 0xb1, 0x6b, 0x3b, 0x27, 0xcf, 0x3b, 0x96, 0x8f, 0xb7, 0xc4, 0xf1,
+	0x96, 0xb5, 0x8b, 0x69, 0x78, 0xcb, 0x1c, 0x6f, 0x45, 0x7b, 0x27, 0x0d, 0x6f, 0x85, 0xe3, 0x35,
+	0xb5, 0x77, 0xd3, 0xb6, 0xd1, 0xe4, 0x87, 0xb9, 0xaa, 0x8d, 0x06, 0x87, 0xb9, 0xea, 0x59, 0x12,
+	0x55, 0x5d, 0x0e, 0xbd, 0xcf, 0x43, 0xf9, 0x69, 0xb7, 0xe3, 0x98, 0xed, 0x87, 0x96, 0xd9, 0x4e,
+	0xb3, 0x23, 0xcf, 0x01, 0x44, 0xcc, 0x1c, 0x6b, 0x7d, 0xa7, 0xc0, 0xc4, 0xa6, 0x3d, 0xe8, 0x59,
+	0xdd, 0x41, 0x96, 0x73, 0x16, 0x14, 0x54, 0x23, 0xb3, 0xf8, 0x9c, 0x27, 0x04, 0xbc, 0xe7, 0x8e,
+	0xd3, 0x61, 0xa7, 0x5c, 0x11, 0x4f, 0x59, 0x65, 0x67, 0x5a, 0x11, 0xcf, 0x54, 0x1d, 0x7e, 0x80,
+	0x82, 0xc2, 0x53, 0x0f, 0x50, 0xc0, 0x5b, 0x21, 0xb3, 0xf8, 0x00, 0xa5, 0xf2, 0x35, 0x8d, 0x4f,
+	0xa0, 0x14, 0x2a, 0x20, 0x87, 0x06, 0xcb, 0x30, 0xb1, 0x65, 0x77, 0x51, 0x5c, 0xfa, 0x04, 0x4a,
+	0x21, 0x28, 0x07, 0xcb, 0x45, 0xb8, 0xb4, 0x6d, 0xb9, 0x1b, 0x27, 0xfd, 0xbe, 0xd5, 0x75, 0x77,
+	0x9d, 0x81, 0xed, 0xda, 0x4e, 0x37, 0xd5, 0x10, 0x7e, 0x0a, 0x33, 0x32, 0x0a, 0x6f, 0xed, 0x8a,
+	0xb8, 0x76, 0x81, 0xae, 0xc0, 0x05, 0xe2, 0x47, 0x97, 0x2e, 0x50, 0xdd, 0x20, 0x30, 0xb9, 0x6d,
+	0xb9, 0xf4, 0x0b, 0xdf, 0xa4, 0x0b, 0xe3, 0x02, 0xcc, 0x5b, 0xa9, 0x81, 0x77, 0x79, 0x95, 0xa5,
+	0x0e, 0x7a, 0x26, 0x61, 0x80, 0x47, 0x7b, 0x3d, 0xad, 0x24, 0xcf, 0x60, 0x7a, 0xdf, 0x0b, 0x91,
+	0x3d, 0xcb, 0x75, 0xad, 0xfd, 0x9e, 0x65, 0xb5, 0x4f, 0x1d, 0x68, 0xb1, 0x45, 0x2a, 0xd4, 0x22,
+	0x8d, 0x07, 0x30, 0x15, 0x63, 0x9c, 0x2f, 0xa1, 0xec, 0xbb, 0x4e, 0x4f, 0x48, 0x28, 0xf4, 0xcf,
+	0x1c, 0xac, 0x0c, 0x28, 0x3e, 0x33, 0x6d, 0x57, 0xba, 0x3f, 0x85, 0x9d, 0xfb, 0x3a, 0x14, 0x28,
+	0x4e, 0x4e, 0xd7, 0x6f, 0x0d, 0x7a, 0x76, 0xdf, 0x74, 0xff, 0x7f, 0x5d, 0x3f, 0x54, 0x40, 0x0e,
+	0x0d, 0x2e, 0xf8, 0x5e, 0xc7, 0xdd, 0x6d, 0xdf, 0x15, 0x14, 0x89, 0x4e, 0xab, 0xc0, 0x4e, 0xeb,
+	0x2b, 0xa8, 0xc6, 0xf1, 0xcf, 0xcb, 0x47, 0xa7, 0x61, 0x6a, 0xdb, 0x72, 0x9f, 0x9c, 0xb8, 0xbd,
+	0x13, 0x77, 0xcb, 0xee, 0x04, 0xc1, 0xe8, 0x27, 0x40, 0x22, 0xf0, 0xf3, 0x5a, 0xb1, 0x08, 0x85,
+	0x6d, 0x87, 0x2f, 0xb3, 0x0a, 0xef, 0x6e, 0x3b, 0x94, 0xf7, 0x29, 0xb5, 0xf8, 0x2f, 0xaf, 0x08,
+	0xb5, 0xdf, 0xa4, 0xfa, 0x71, 0x4a, 0x49, 0x21, 0xd8, 0x65, 0x9d, 0xcc, 0x89, 0x16, 0x97, 0x68,
+	0x1f, 0x0d, 0x72, 0x43, 0x34, 0xc2, 0xa4, 0xd2, 0x63, 0x89, 0x54, 0x44, 0xb3, 0x54, 0xbf, 0x17,
+	0x1b, 0x5c, 0x83, 0x51, 0x7f, 0xf3, 0xf9, 0x32, 0xcf, 0x8e, 0x7d, 0x78, 0xe4, 0x0e, 0x9e, 0x74,
+	0x85, 0xcc, 0x13, 0x82, 0x72, 0xb0, 0x6c, 0xc3, 0xb8, 0x5f, 0xb6, 0x98, 0xaf, 0xb3, 0x87, 0x55,
+	0x25, 0x25, 0xac, 0x62, 0x6f, 0xa7, 0xc0, 0x25, 0xe3, 0x1e, 0x8c, 0x05, 0xab, 0xe4, 0x10, 0x52,
+	0x83, 0xe9, 0x3d, 0xeb, 0xd8, 0x79, 0x65, 0xb5, 0x3a, 0x9d, 0xdd, 0x8e, 0xe9, 0x5a, 0x41, 0x4e,
+	0x7a, 0x00, 0x53, 0xb1, 0x2f, 0x39, 0x16, 0xb8, 0xc5, 0xd9, 0xf8, 0x3c, 0x5a, 0x6e, 0xaa, 0x53,
+	0x6f, 0x00, 0x89, 0x20, 0xe7, 0x5b, 0x91, 0x16, 0x72, 0xad, 0xb6, 0xd9, 0x73, 0x9d, 0x7e, 0x6a,
+	0xb2, 0xdf, 0x00, 0x12, 0x41, 0xce, 0xb1, 0xe2, 0x73, 0x28, 0xb7, 0xda, 0x6d, 0x5f, 0xe6, 0x03,
+	0x27, 0x6d, 0x83, 0xe4, 0x3a, 0xf6, 0xbd, 0x71, 0xdf, 0x8e, 0x83, 0x6c, 0x2e, 0x3b, 0xfc, 0x02,
+	0xcb, 0xa9, 0xf7, 0x61, 0x42, 0x5c, 0x23, 0x87, 0x94, 0x37, 0xa1, 0xb2, 0x6d, 0xb9, 0x5e, 0x75,
+	0x9b, 0x1c, 0x5d, 0xb9, 0x5a, 0x9e, 0x41, 0x19, 0xe3, 0x9e, 0x57, 0x9c, 0xfb, 0x10, 0xc8, 0x4e,
+	0xc6, 0xa3, 0x69, 0xc1, 0xe4, 0xce, 0x19, 0x0f, 0x66, 0x0e, 0x26, 0x76, 0xb2, 0xd4, 0xfe, 0x9e,
+	0xab, 0x9f, 0xa5, 0xf2, 0xff, 0x1c, 0xaa, 0x4f, 0x7b, 0x6d, 0xd3, 0xb5, 0x1e, 0x5b, 0xae, 0xb9,
+	0x69, 0xba, 0x26, 0x5f, 0xad, 0x86, 0x6b, 0x00, 0xbf, 0x03, 0xb0, 0xeb, 0xf6, 0x0f, 0x9c, 0x9d,
+	0x87, 0xbb, 0x7d, 0xa7, 0x67, 0xf5, 0x5d, 0xdb, 0x1a, 0x88, 0xa7, 0x5f, 0x33, 0x36, 0xa1, 0x12,
+	0xe5, 0x95, 0xcf, 0xaf, 0xbd, 0x22, 0xd6, 0xec, 0x99, 0xcf, 0xed, 0x8e, 0xed, 0xad, 0xc2, 0xfd,
+	0x7a, 0x00, 0x53, 0xb1, 0x2f, 0xde, 0x02, 0xb7, 0xf1, 0x02, 0x33, 0xde, 0x02, 0x52, 0x29, 0x73,
+	0x16, 0xbd, 0x2c, 0x96, 0xbe, 0x7c, 0xc9, 0x05, 0xf9, 0x14, 0xc6, 0x05, 0x58, 0x8e, 0x3d, 0xbe,
+	0x80, 0x99, 0xfd, 0x53, 0x94, 0x0c, 0xa4, 0x41, 0x81, 0x5c, 0xe6, 0xa1, 0x35, 0xb5, 0xdf, 0x49,
+	0xd8, 0x82, 0xea, 0xbe, 0xb4, 0xce, 0x38, 0xa5, 0xb0, 0xff, 0x50, 0xf8, 0x4d, 0xf4, 0xc0, 0xee,
+	0x05, 0x1d, 0x8d, 0x1b, 0xd8, 0x3e, 0xe4, 0xb9, 0x33, 0x4b, 0xe9, 0xad, 0x0e, 0xbf, 0xe0, 0x0b,
+	0x09, 0xf5, 0xdc, 0x8b, 0xc4, 0xf0, 0xf6, 0x4c, 0x77, 0x97, 0x43, 0x41, 0x7f, 0x57, 0xa8, 0xb3,
+	0xfe, 0x4f, 0xaa, 0x87, 0x05, 0x98, 0xdc, 0xca, 0xf1, 0x6a, 0x09, 0x06, 0xc8, 0x5c, 0x4b, 0x14,
+	0x52, 0xd2, 0x09, 0xae, 0x25, 0x46, 0x85, 0x5a, 0x82, 0xaf, 0x92, 0x43, 0xc8, 0xfb, 0xfe, 0xb5,
+	0x6f, 0xb3, 0x6f, 0xbf, 0x4a, 0xb9, 0x4d, 0x16, 0x52, 0xca, 0x1e, 0x2f, 0x1b, 0x47, 0x38, 0xe4,
+	0x10, 0xe3, 0x37, 0x45, 0xa8, 0x48, 0x02, 0x97, 0xb4, 0x17, 0xca, 0x81, 0x81, 0xe9, 0xb4, 0x3c,
+	0x8d, 0x7d, 0x4a, 0x81, 0xbc, 0xf2, 0xbd, 0x89, 0x02, 0x05, 0x0f, 0xdf, 0x2c, 0x22, 0x44, 0x83,
+	0x46, 0xcb, 0xd3, 0x6e, 0x83, 0x32, 0xe0, 0x66, 0x36, 0x34, 0xd2, 0xb4, 0x3c, 0x9b, 0xfb, 0x88,
+	0x12, 0x71, 0x9b, 0xd3, 0x11, 0x11, 0xfd, 0xaf, 0x48, 0xb0, 0xcc, 0x09, 0xb8, 0xf1, 0x0d, 0x23,
+	0x58, 0x21, 0x1f, 0x53, 0x02, 0x5e, 0x45, 0x7f, 0x20, 0xdd, 0x57, 0xc7, 0x4f, 0x3d, 0x31, 0xf9,
+	0x9a, 0xe4, 0x1e, 0xa5, 0xa6, 0xbd, 0xb9, 0x62, 0x7d, 0x5e, 0x46, 0x7d, 0x60, 0xf7, 0x9e, 0x3b,
+	0x6f, 0x24, 0xe4, 0xab, 0x5c, 0xa9, 0x6b, 0x5a, 0x21, 0x59, 0xa9, 0x07, 0x76, 0xef, 0xb5, 0x39,
+	0x90, 0xae, 0xbf, 0x46, 0x3e, 0x81, 0x77, 0xfc, 0xf3, 0x5b, 0xd4, 0xe0, 0x74, 0xe2, 0x7b, 0xeb,
+	0xd6, 0x16, 0x03, 0xfa, 0x9a, 0x56, 0xcc, 0x41, 0x5f, 0x23, 0x35, 0x46, 0x5f, 0xd7, 0xc6, 0x86,
+	0xea, 0xdb, 0x27, 0xa9, 0x93, 0x2a, 0x23, 0x69, 0x68, 0x25, 0xda, 0xa7, 0xf7, 0xc0, 0x8d, 0x00,
+	0xbc, 0xa4, 0x8d, 0x87, 0xe0, 0xa5, 0x00, 0xbc, 0xac, 0x4d, 0x84, 0xe0, 0xe5, 0x00, 0xbc, 0xa2,
+	0x4d, 0x86, 0xe0, 0x15, 0xb2, 0xc4, 0xc0, 0x4d, 0xad, 0xec, 0x8b, 0x73, 0x45, 0x88, 0x3d, 0x6c,
+	0x33, 0x5e, 0x3d, 0x83, 0x24, 0x6a, 0x06, 0x54, 0xab, 0x1a, 0xc9, 0x4c, 0xb5, 0x4a, 0x9a, 0x8c,
+	0x6a, 0x4d, 0xab, 0xf8, 0x54, 0xd7, 0xe2, 0x54, 0xac, 0x4c, 0x43, 0x84, 0x6b, 0xa4, 0x41, 0x09,
+	0xeb, 0x8b, 0xda, 0x54, 0x58, 0x00, 0x61, 0xc2, 0x03, 0xbb, 0x27, 0x12, 0xd5, 0x17, 0xc9, 0x3c,
+	0x23, 0xaa, 0x69, 0xd5, 0xa4, 0xa8, 0xea, 0x63, 0xd6, 0x02, 0xcc, 0xba, 0x36, 0x9d, 0x8a, 0x59,
+	0x0f, 0x30, 0x1b, 0xda, 0x4c, 0x2a, 0x66, 0x23, 0xc0, 0x5c, 0xd2, 0xb4, 0x54, 0xcc, 0xa5, 0x00,
+	0x73, 0x59, 0xbb, 0x94, 0x8a, 0xb9, 0x1c, 0x60, 0xae, 0x68, 0x7a, 0x2a, 0xe6, 0x0a, 0x59, 0x63,
+	0x98, 0x4d, 0xed, 0x3d, 0x1f, 0xd3, 0x10, 0x2a, 0xc6, 0x8d, 0x23, 0xb3, 0xdb, 0xb5, 0x3a, 0xbb,
+	0x66, 0xdf, 0x3c, 0xb6, 0x5c, 0x0b, 0xe9, 0xba, 0xde, 0x24, 0xf7, 0x19, 0xe9, 0xaa, 0x76, 0xd9,
+	0x27, 0xfd, 0x30, 0xae, 0xeb, 0x54, 0x0e, 0xab, 0xe4, 0x1e, 0xe3, 0xb0, 0xa6, 0x5d, 0xf1, 0x39,
+	0xcc, 0x21, 0x0b, 0xdf, 0x70, 0x9c, 0x7e, 0xdb, 0xee, 0x7a, 0x37, 0x41, 0x89, 0x83, 0xd4, 0xd7,
+	0xb8, 0xa1, 0x36, 0x16, 0xb5, 0xab, 0x7e, 0x30, 0xf5, 0xc0, 0x8d, 0x45, 0xe3, 0x21, 0x68, 0x49,
+	0x25, 0x2f, 0xb9, 0x4d, 0xb3, 0xc0, 0xf0, 0xa2, 0xd3, 0x2f, 0x8d, 0x67, 0xa1, 0x84, 0x94, 0x26,
+	0x66, 0x16, 0x35, 0xb8, 0x44, 0x5e, 0x87, 0x31, 0xb1, 0x46, 0xc0, 0x48, 0x6a, 0x8c, 0x15, 0xed,
+	0x79, 0x60, 0x2c, 0xde, 0x12, 0x34, 0xa0, 0x28, 0x74, 0x19, 0x30, 0xce, 0x28, 0xc3, 0xf9, 0x19,
+	0xcc, 0x26, 0x87, 0xf6, 0xd4, 0x1f, 0xf1, 0x66, 0xc5, 0x1f, 0xf1, 0xe2, 0x97, 0x41, 0xfa, 0xd1,
+	0x78, 0x01, 0x7a, 0x32, 0x7f, 0xf2, 0x20, 0xfe, 0x33, 0xe3, 0x7c, 0x7a, 0xb6, 0x91, 0xfd, 0xe4,
+	0x68, 0x3c, 0x85, 0x29, 0x59, 0x46, 0xc5, 0x39, 0x73, 0x34, 0x2d, 0x67, 0x56, 0xc4, 0x9c, 0x49,
+	0xb3, 0x6b, 0xc3, 0x78, 0x05, 0x8b, 0xd9, 0xf2, 0x66, 0xaa, 0x9e, 0x16, 0xb1, 0x9e, 0x74, 0xf1,
+	0x12, 0x85, 0xb9, 0x71, 0x9d, 0xfd, 0x12, 0xe6, 0xb2, 0xad, 0x4b, 0x7e, 0x1c, 0xd7, 0xdf, 0x52,
+	0xf6, 0x74, 0x2f, 0xd5, 0xe5, 0x5f, 0x54, 0x98, 0x88, 0x64, 0xc2, 0x0c, 0xb5, 0x47, 0x21, 0x45,
+	0x8f, 0xa4, 0x22, 0xd6, 0x13, 0x05, 0x56, 0x2f, 0x54, 0xc4, 0x7a, 0x41, 0x65, 0x35, 0x41, 0x45,
+	0xac, 0x09, 0x54, 0x96, 0xf7, 0x2b, 0x62, 0xde, 0x57, 0x58, 0x3a, 0xbf, 0x89, 0xd3, 0x79, 0x55,
+	0xd0, 0xaa, 0x10, 0x92, 0x69, 0xee, 0xbe, 0x85, 0x73, 0xf7, 0xb4, 0x80, 0xfb, 0xcc, 0xea, 0x74,
+	0x70, 0x9e, 0xae, 0xa2, 0x3c, 0xad, 0xf2, 0xf4, 0xbb, 0x1e, 0x49, 0xbf, 0xd7, 0x85, 0xf0, 0x94,
+	0x9a, 0x11, 0x6a, 0x35, 0x52, 0x45, 0xa9, 0x57, 0x91, 0xa7, 0x57, 0x45, 0x9e, 0x5e, 0x15, 0x79,
+	0x7a, 0x55, 0xe4, 0xe9, 0x95, 0x81, 0x57, 0x8c, 0xbf, 0x2a, 0x30, 0x19, 0xcd, 0x6a, 0x67, 0x3d,
+	0xc6, 0x26, 0x2e, 0x0b, 0xb3, 0xc4, 0xf6, 0x94, 0xa3, 0xbe, 0x89, 0xcb, 0xbf, 0x94, 0x03, 0x5c,
+	0x31, 0xd6, 0xa1, 0x1c, 0xfb, 0x24, 0xbd, 0x5d, 0x61, 0x5a, 0x3f, 0xc0, 0x75, 0xe1, 0xf6, 0xf0,
+	0x1c, 0x90, 0xea, 0xc0, 0xb7, 0xb1, 0x03, 0x4f, 0xd3, 0x02, 0x3e, 0xca, 0x89, 0x3b, 0xef, 0x00,
+	0x8c, 0xe1, 0xeb, 0x91, 0xc7, 0x71, 0xc7, 0x5d, 0xcc, 0x96, 0xae, 0xa4, 0x4e, 0xbb, 0x09, 0xd3,
+	0x72, 0x4f, 0x27, 0x37, 0xb1, 0x96, 0xaa, 0x2c, 0x45, 0x61, 0x2c, 0xa6, 0xaa, 0x2d, 0xd0, 0x92,
+	0x4c, 0x59, 0xe4, 0xa3, 0xa6, 0x1c, 0x17, 0xeb, 0x47, 0x55, 0x24, 0xf5, 0x28, 0xf9, 0x00, 0x8b,
+	0x42, 0x98, 0x28, 0x02, 0x0a, 0xa3, 0xef, 0xc1, 0x9d, 0x0c, 0x85, 0x6d, 0xea, 0x99, 0xdd, 0xc1,
+	0x67, 0x36, 0x23, 0x06, 0x5d, 0x71, 0x41, 0x76, 0x68, 0x27, 0x70, 0x3d, 0xc3, 0x8a, 0xe4, 0x8b,
+	0xf8, 0xa9, 0xd5, 0x32, 0x96, 0xe1, 0xd2, 0x63, 0xfb, 0x0c, 0xaa, 0xd2, 0xfa, 0x93, 0x7c, 0x88,
+	0x55, 0x35, 0x45, 0x55, 0x85, 0x91, 0x98, 0xb2, 0x7e, 0xa7, 0x40, 0x39, 0x7e, 0xec, 0x39, 0x5d,
+	0x5d, 0x65, 0xae, 0xfe, 0x11, 0x76, 0x75, 0x5d, 0x08, 0x76, 0x3b, 0x0f, 0x37, 0xad, 0x57, 0xf6,
+	0x0b, 0x2b, 0xc5, 0xc5, 0x69, 0x8c, 0x5e, 0x36, 0x0e, 0xe0, 0x6a, 0x7a, 0x7c, 0x20, 0x75, 0xbc,
+	0xcf, 0x2b, 0x74, 0x9f, 0x89, 0xd1, 0xc4, 0xdf, 0xf0, 0xcf, 0xe1, 0x46, 0xa6, 0xb2, 0x90, 0x34,
+	0x43, 0xe6, 0x6a, 0xd6, 0x78, 0xe5, 0xaf, 0xf0, 0x05, 0x5c, 0x4e, 0xbb, 0x1d, 0x90, 0x05, 0xcc,
+	0xf8, 0x92, 0xc0, 0x58, 0x7a, 0x44, 0xf7, 0x61, 0x4a, 0x76, 0xc1, 0x24, 0xf3, 0x78, 0xf7, 0x95,
+	0x20, 0x82, 0x85, 0x38, 0x8c, 0xc3, 0x77, 0xfe, 0x21, 0x47, 0xee, 0x97, 0xe7, 0x9a, 0x96, 0xd5,
+	0xcc, 0x69, 0x59, 0x49, 0x4b, 0xcb, 0x15, 0x31, 0x2d, 0x2b, 0x2c, 0xff, 0x56, 0xc4, 0xfc, 0xab,
+	0xb0, 0x3c, 0x7b, 0x27, 0x72, 0x1f, 0x4e, 0xca, 0xca, 0x34, 0xff, 0x1a, 0x7f, 0x50, 0xa0, 0x84,
+	0x2e, 0x77, 0x67, 0xdd, 0xfd, 0x02, 0x36, 0xf1, 0xb4, 0x43, 0xf4, 0x15, 0xd3, 0xc4, 0xfd, 0x8d,
+	0xac, 0xd9, 0x6f, 0xd9, 0xd8, 0x07, 0x12, 0x0f, 0xe6, 0x58, 0x7a, 0x45, 0x26, 0xbd, 0x22, 0x93,
+	0x5e, 0x61, 0xa5, 0x69, 0x1f, 0x32, 0x35, 0x2f, 0x52, 0x63, 0xe4, 0x02, 0x8e, 0x91, 0x1a, 0x0e,
+	0xe9, 0x82, 0x0d, 0xb2, 0x20, 0xf9, 0x5a, 0xb8, 0x2a, 0xa4, 0xac, 0x49, 0x9e, 0xc4, 0xa3, 0x64,
+	0x3d, 0x6b, 0xb7, 0x65, 0x48, 0x76, 0x8b, 0x7a, 0x80, 0x3c, 0xbb, 0x45, 0xb0, 0x98, 0x0f, 0x7d,
+	0x0c, 0x24, 0xde, 0x22, 0x90, 0x3e, 0x73, 0x40, 0x18, 0x8c, 0xfa, 0xf7, 0x0a, 0x8c, 0xa1, 0x84,
+	0x78, 0x9e, 0xce, 0x37, 0xca, 0x6c, 0x6c, 0x0e, 0xdb, 0x98, 0x2f, 0xcc, 0x97, 0x4e, 0xe7, 0xe4,
+	0xd8, 0xc2, 0xad, 0xb3, 0x39, 0x5c, 0x3b, 0x25, 0xe0, 0xad, 0x18, 0xbf, 0xbd, 0x08, 0xe3, 0x91,
+	0x24, 0xfc, 0xbd, 0x97, 0xf2, 0x05, 0x59, 0xcc, 0x28, 0xc8, 0x62, 0x86, 0x2a, 0x8b, 0x19, 0xaa,
+	0x2c, 0x66, 0xa8, 0x2c, 0x66, 0xdc, 0x8b, 0xc4, 0x8c, 0x39, 0x99, 0x59, 0x09, 0xc1, 0x23, 0xd2,
+	0x42, 0xab, 0xa2, 0x1a, 0x5e, 0x91, 0x97, 0xe7, 0x05, 0x5e, 0x9e, 0xdf, 0x45, 0xe5, 0x79, 0xb1,
+	0x3e, 0x9b, 0x58, 0xf1, 0xc7, 0xc2, 0x55, 0x83, 0xdc, 0x45, 0x45, 0xfc, 0xa9, 0x88, 0x97, 0xc8,
+	0x1d, 0x54, 0xea, 0x0f, 0x09, 0x8d, 0xcb, 0xe4, 0x1e, 0xba, 0x02, 0x9c, 0x52, 0x2b, 0x2b, 0xa4,
+	0x8a, 0x3a, 0x71, 0x0a, 0x6f, 0xb5, 0x55, 0x51, 0xab, 0x4d, 0xe1, 0xbd, 0xb4, 0x2a, 0xea, 0xa5,
+	0x29, 0xbc, 0x53, 0x56, 0x45, 0x9d, 0x32, 0x85, 0xf7, 0xc2, 0xaa, 0xa8, 0x17, 0xc6, 0xc0, 0x35,
+	0xe3, 0x21, 0x5c, 0x4a, 0x6c, 0xda, 0x91, 0x5b, 0xa1, 0x47, 0xaa, 0x91, 0xcd, 0xc7, 0xdd, 0xf2,
+	0x6f, 0x23, 0x70, 0x29, 0xb9, 0x02, 0x38, 0xa3, 0xb1, 0xcf, 0xe1, 0x14, 0x91, 0xe0, 0x66, 0xd9,
+	0xdd, 0x76, 0x1e, 0xbb, 0xad, 0x9f, 0xef, 0xb7, 0x3a, 0xce, 0xeb, 0x3d, 0xb1, 0x02, 0xf6, 0xfd,
+	0x64, 0x1e, 0xb7, 0xba, 0x13, 0x31, 0xb3, 0x38, 0xcf, 0x68, 0xfa, 0xc5, 0x16, 0x3b, 0x05, 0x03,
+	0xd7, 0x64, 0xbd, 0x0f, 0x79, 0x7b, 0x3b, 0x6f, 0xef, 0x23, 0x1a, 0xa4, 0x93, 0x7b, 0x1f, 0xf2,
+	0x75, 0x33, 0xf7, 0x3e, 0x86, 0x89, 0x2d, 0x24, 0x9a, 0xcf, 0xe1, 0x9a, 0xbc, 0x23, 0x7e, 0xfa,
+	0xd7, 0xec, 0xc6, 0x57, 0x30, 0x2d, 0xe7, 0x45, 0x5a, 0x71, 0xc1, 0x67, 0x93, 0x9b, 0xf1, 0x09,
+	0x17, 0x87, 0x69, 0x79, 0x39, 0x2e, 0xd6, 0x94, 0x6a, 0x58, 0x53, 0x46, 0x4a, 0xf6, 0x48, 0x3e,
+	0x14, 0x62, 0x43, 0x42, 0x3e, 0x44, 0x6d, 0x12, 0x9f, 0xfa, 0x00, 0xde, 0x1f, 0xd2, 0xfd, 0x20,
+	0x35, 0x2c, 0x4a, 0x7a, 0xf3, 0x9c, 0x72, 0x8d, 0x05, 0x06, 0x51, 0xb4, 0x94, 0xc0, 0x10, 0x97,
+	0xef, 0x9f, 0x2a, 0x94, 0x10, 0xfc, 0xbf, 0x29, 0xf3, 0x49, 0xab, 0xe5, 0x02, 0x73, 0xde, 0x45,
+	0xdc, 0xad, 0x7a, 0x4f, 0x2c, 0x34, 0x9d, 0xe3, 0x9e, 0xd3, 0xb5, 0xba, 0x6e, 0x5a, 0xcb, 0x4a,
+	0xe1, 0x9e, 0x7d, 0x3b, 0xd2, 0xb2, 0x0a, 0xef, 0xec, 0xfb, 0x47, 0x66, 0xcf, 0x4a, 0x6d, 0x52,
+	0xa9, 0xff, 0x81, 0x26, 0x55, 0x24, 0xf3, 0x14, 0x78, 0xe6, 0x59, 0x8e, 0xfc, 0xc8, 0x33, 0xec,
+	0xf7, 0x47, 0x9a, 0x99, 0x50, 0xa3, 0x27, 0x31, 0xe9, 0x9d, 0xba, 0xd1, 0x23, 0x4b, 0xbb, 0xf1,
+	0x46, 0x4f, 0xe2, 0x7a, 0xc3, 0x1b, 0x3d, 0x19, 0x44, 0x15, 0x1c, 0x7f, 0x17, 0x26, 0x22, 0xde,
+	0x7c, 0x46, 0xbb, 0x36, 0xbe, 0x84, 0x6b, 0xc3, 0xea, 0x12, 0xf1, 0x9a, 0xae, 0xca, 0x7f, 0x75,
+	0x8b, 0x3b, 0xe0, 0x23, 0x28, 0xa1, 0x1c, 0x48, 0x56, 0x45, 0x39, 0x59, 0x03, 0xd5, 0xa7, 0xde,
+	0x70, 0xba, 0x2f, 0xfa, 0x96, 0x17, 0xa0, 0xcd, 0xc1, 0x49, 0xdf, 0x3a, 0x8e, 0xd8, 0x77, 0xcd,
+	0xf8, 0x11, 0x4c, 0x44, 0x92, 0xdf, 0x19, 0x98, 0xfd, 0x59, 0x05, 0x12, 0xf7, 0x24, 0xb9, 0x16,
+	0x3f, 0x10, 0xb5, 0xc8, 0x3a, 0x56, 0x9f, 0x75, 0x9c, 0x17, 0xdf, 0x3c, 0xda, 0x14, 0x99, 0x9e,
+	0x6f, 0xc4, 0x90, 0xb6, 0xbd, 0x0b, 0x99, 0xd3, 0xbd, 0x92, 0x1e, 0x14, 0x70, 0xba, 0x2f, 0x0c,
+	0xa9, 0x81, 0xbf, 0x4f, 0xef, 0x5f, 0x8e, 0xfc, 0x02, 0x9c, 0xc9, 0xcd, 0x9b, 0xc6, 0x6b, 0x18,
+	0x13, 0xc3, 0x57, 0x5e, 0xf3, 0x57, 0x64, 0x87, 0xa4, 0xa4, 0x75, 0xb3, 0x78, 0x13, 0x1a, 0xad,
+	0x2e, 0x6b, 0x42, 0x8b, 0x08, 0xcc, 0x19, 0x7e, 0xa5, 0x80, 0x9e, 0x6c, 0x99, 0xf2, 0x66, 0xc0,
+	0x2a, 0x36, 0xbe, 0xd0, 0xc4, 0xb7, 0xad, 0xae, 0xd5, 0xb7, 0x5f, 0xec, 0xf6, 0xad, 0x97, 0xf6,
+	0x1b, 0xab, 0xfd, 0xb4, 0x6b, 0xbb, 0x29, 0xd6, 0xc8, 0x3b, 0x06, 0x5b, 0x30, 0x93, 0x90, 0x45,
+	0xc4, 0xc4, 0x1a, 0xc4, 0x3d, 0x59, 0xb2, 0xf1, 0xb7, 0xf2, 0x25, 0xbc, 0x3f, 0xc4, 0xd1, 0x48,
+	0x03, 0xf3, 0xbb, 0x4a, 0x1b, 0xe6, 0xc9, 0x7e, 0xe9, 0xf3, 0xbd, 0x01, 0xe3, 0xd8, 0xb5, 0xe4,
+	0xd3, 0x7f, 0x03, 0xd0, 0x93, 0xf7, 0x4f, 0x6e, 0x89, 0x24, 0x6c, 0x27, 0x0c, 0x3d, 0xaa, 0xa6,
+	0x1a, 0x99, 0xc7, 0x0a, 0xf6, 0x4b, 0xa5, 0xfd, 0x47, 0x94, 0x2d, 0x52, 0x68, 0xb0, 0xe7, 0x94,
+	0x95, 0x65, 0x7b, 0x4e, 0x3b, 0x28, 0x7f, 0xcf, 0x77, 0x61, 0x22, 0xb2, 0x60, 0x06, 0x73, 0x66,
+	0x7d, 0x21, 0xe3, 0x08, 0x08, 0x5b, 0x40, 0x94, 0xe3, 0x3c, 0xdd, 0x81, 0xc5, 0xac, 0xfa, 0x9f,
+	0x26, 0xe1, 0xf2, 0x83, 0x37, 0xae, 0xd5, 0x6d, 0x5b, 0xed, 0x1d, 0xfb, 0xdb, 0x13, 0xbb, 0x7d,
+	0x64, 0x76, 0xdb, 0x1d, 0xbb, 0x7b, 0xe8, 0xbf, 0x75, 0xea, 0x93, 0x75, 0x80, 0xf0, 0x75, 0x2f,
+	0xf1, 0xeb, 0x8c, 0xd8, 0x8b, 0x62, 0xbd, 0x12, 0x05, 0xf7, 0x3a, 0x6f, 0x8d, 0x1f, 0x90, 0x25,
+	0x18, 0xe5, 0x93, 0x17, 0x84, 0xa2, 0xe0, 0x41, 0x14, 0xbd, 0x8c, 0x81, 0x94, 0xea, 0x16, 0x5c,
+	0xf4, 0xa7, 0x19, 0x89, 0xef, 0x71, 0xe2, 0xa0, 0xa3, 0x3e, 0x2e, 0x40, 0x82, 0x25, 0xf8, 0x5c,
+	0x17, 0x5d, 0x22, 0x32, 0xe6, 0xa6, 0x97, 0x31, 0x30, 0xa0, 0xe2, 0xa3, 0x5b, 0x94, 0x2a, 0x32,
+	0xdb, 0xa5, 0x97, 0x31, 0x90, 0x52, 0x3d, 0x82, 0x89, 0xc8, 0xfb, 0x54, 0xa2, 0x53, 0x5b, 0x90,
+	0x3d, 0x67, 0xd5, 0x35, 0xe9, 0x37, 0xca, 0x6a, 0x0f, 0x48, 0x7c, 0x92, 0x8b, 0x5c, 0xe1, 0x14,
+	0xd2, 0x99, 0x30, 0xfd, 0xbd, 0xa4, 0xcf, 0x94, 0xe7, 0x7d, 0x18, 0x13, 0x5f, 0x46, 0x93, 0x19,
+	0x86, 0x1e, 0x7d, 0x57, 0xad, 0x57, 0xe3, 0x1f, 0x28, 0x87, 0x1d, 0x7f, 0x00, 0x0c, 0xbd, 0x28,
+	0x25, 0x7c, 0x51, 0xd9, 0x63, 0x56, 0xfd, 0x92, 0xfc, 0x23, 0xe5, 0xb6, 0x01, 0x25, 0x34, 0x92,
+	0x42, 0xb8, 0x42, 0x62, 0xd3, 0x2b, 0xfa, 0xb4, 0xe4, 0x0b, 0x65, 0xd2, 0x84, 0x42, 0x30, 0x7f,
+	0x46, 0xa6, 0x18, 0x1a, 0x1a, 0x51, 0xd3, 0x49, 0x04, 0x4a, 0x09, 0x0d, 0x18, 0xd9, 0x76, 0x48,
+	0xc9, 0xff, 0x16, 0xd8, 0x69, 0x91, 0xff, 0x49, 0x71, 0xd6, 0x01, 0xc2, 0xc9, 0x62, 0x6a, 0xdb,
+	0xb1, 0xf1, 0x63, 0xbd, 0x12, 0x05, 0x07, 0x82, 0x05, 0x6f, 0x84, 0xa9, 0x60, 0xd1, 0x67, 0xc4,
+	0x3a, 0x89, 0x40, 0x03, 0xdb, 0x63, 0xb0, 0x2e, 0xb5, 0xbd, 0xc8, 0x74, 0x87, 0x5e, 0xc6, 0x40,
+	0x4a, 0x75, 0x0f, 0x8a, 0xc2, 0x93, 0x73, 0x42, 0xe3, 0x78, 0xec, 0xb9, 0xba, 0x3e, 0x15, 0x83,
+	0x87, 0x8b, 0xb2, 0x67, 0xe4, 0x6c, 0x51, 0xfc, 0xf8, 0x5c, 0x2f, 0x63, 0x20, 0xa2, 0xf2, 0xde,
+	0x86, 0x86, 0x54, 0xc2, 0x2b, 0x58, 0xbd, 0x8c, 0x81, 0x94, 0xaa, 0x06, 0xef, 0xf2, 0x88, 0xe5,
+	0x6b, 0x00, 0x3f, 0x0f, 0xd5, 0x27, 0x11, 0x8c, 0x92, 0xdc, 0x00, 0xf5, 0xb1, 0xfd, 0x86, 0xf8,
+	0xee, 0x1d, 0x0e, 0x09, 0xe9, 0x63, 0xc1, 0xdf, 0x14, 0x6d, 0x1e, 0x2e, 0x78, 0x13, 0x25, 0xc4,
+	0x1f, 0xb2, 0x11, 0x66, 0x96, 0xf5, 0x52, 0x08, 0x08, 0x65, 0xa0, 0xb3, 0x27, 0x4c, 0x06, 0x34,
+	0xee, 0xa2, 0x4f, 0x22, 0x58, 0xc0, 0xdc, 0x9b, 0x0a, 0xa7, 0xcc, 0x85, 0x71, 0x71, 0xbd, 0x14,
+	0x02, 0x82, 0x38, 0x10, 0x99, 0x3f, 0xa1, 0x71, 0x40, 0x3e, 0xae, 0xa2, 0x6b, 0xd2, 0x6f, 0x81,
+	0x8f, 0xa0, 0xb1, 0x12, 0x22, 0x20, 0xe3, 0xb1, 0x14, 0x7d, 0x5a, 0xf2, 0x25, 0x70, 0x7c, 0x71,
+	0xa4, 0x9c, 0x3a, 0xbe, 0x64, 0x4a, 0x5d, 0xaf, 0xc6, 0x3f, 0x04, 0x62, 0xa0, 0xd7, 0xad, 0x54,
+	0x0c, 0xd9, 0x93, 0x59, 0x7d, 0x5a, 0xf2, 0x25, 0x50, 0x4b, 0x64, 0xb6, 0x92, 0xaa, 0x45, 0x3e,
+	0xc9, 0xa9, 0x6b, 0xd2, 0x6f, 0x41, 0x20, 0xda, 0x97, 0x06, 0xa2, 0xfd, 0xb4, 0x40, 0xb4, 0x9f,
+	0x10, 0x88, 0xe6, 0xe1, 0x82, 0x37, 0x9e, 0x49, 0x4f, 0x56, 0x98, 0xdb, 0xd4, 0x4b, 0x21, 0x20,
+	0xd0, 0x03, 0x9a, 0xb9, 0xa1, 0x7a, 0x90, 0xcd, 0xec, 0xe8, 0xd3, 0x92, 0x2f, 0x41, 0x54, 0x09,
+	0xc7, 0xb5, 0x69, 0x54, 0x89, 0x0d, 0x7a, 0xeb, 0x95, 0x28, 0x38, 0x42, 0xeb, 0xfb, 0x9c, 0x40,
+	0x2b, 0x7a, 0x5d, 0x25, 0x0a, 0xa6, 0xb4, 0x5b, 0x30, 0x8e, 0xc7, 0x33, 0x88, 0xaf, 0x15, 0xe9,
+	0xf8, 0x87, 0x3e, 0x23, 0xfb, 0x14, 0xa8, 0xcb, 0x9b, 0x36, 0xa5, 0xea, 0x12, 0x66, 0x53, 0xf5,
+	0x52, 0x08, 0xf0, 0x31, 0x9f, 0xbf, 0xe3, 0xff, 0x3f, 0x26, 0x1a, 0xff, 0x1e, 0x00, 0xd3, 0x17,
+	0x6a, 0x51, 0x72, 0x42, 0x00, 0x00,
 }
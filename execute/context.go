@@ -2,6 +2,8 @@ package execute
 
 import (
 	"context"
+
+	"github.com/antha-lang/antha/microArch/sampletracker"
 )
 
 type contextKey int
@@ -25,8 +27,10 @@ func getID(ctx context.Context) string {
 	return v.ID
 }
 
+// withID returns a new execution context. Each execution has its own sample
+// tracker.
 func withID(parent context.Context, id string) context.Context {
-	return context.WithValue(parent, theContextKey, &withExecute{
+	return context.WithValue(sampletracker.NewContext(parent), theContextKey, &withExecute{
 		ID:    id,
 		Maker: newMaker(),
 	})
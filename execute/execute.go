@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/microArch/sampletracker"
 	"github.com/antha-lang/antha/target"
 	"github.com/antha-lang/antha/trace"
 	"github.com/antha-lang/antha/workflow"
@@ -16,6 +17,8 @@ type Result struct {
 	Workflow *workflow.Workflow
 	Input    []ast.Node
 	Insts    []target.Inst
+	// Lineage and final locations of the samples in the run
+	Samples []sampletracker.Sample
 }
 
 // An Opt are options for Run.
@@ -58,6 +61,7 @@ func Run(parent context.Context, opt Opt) (*Result, error) {
 			Workflow: w,
 			Input:    r.nodes,
 			Insts:    r.insts,
+			Samples:  sampletracker.GetSampleTracker(ctx).Lineage(),
 		}, nil
 	}
 
@@ -29,7 +29,7 @@ type commandInst struct {
 
 // SetInputPlate notifies the planner about an input plate
 func SetInputPlate(ctx context.Context, plate *wtype.LHPlate) {
-	st := sampletracker.GetSampleTracker()
+	st := sampletracker.GetSampleTracker(ctx)
 	st.SetInputPlate(plate)
 }
 
@@ -55,7 +55,7 @@ type IncubateOpt struct {
 }
 
 func newCompFromComp(ctx context.Context, in *wtype.LHComponent) *wtype.LHComponent {
-	st := sampletracker.GetSampleTracker()
+	st := sampletracker.GetSampleTracker(ctx)
 	comp := in.Dup()
 	comp.ID = wtype.GetUUID()
 	comp.BlockID = wtype.NewBlockID(getID(ctx))
@@ -278,6 +278,7 @@ func mix(ctx context.Context, inst *wtype.LHInstruction) *commandInst {
 
 	mx := 0
 	var reqs []ast.Request
+	var parents []string
 	// from the protocol POV components need to be passed by value
 	for i, c := range wtype.CopyComponentArray(inst.Components) {
 		if c.CName == "" {
@@ -295,8 +296,11 @@ func mix(ctx context.Context, inst *wtype.LHInstruction) *commandInst {
 			mx = c.Generation()
 		}
 		getMaker(ctx).UpdateAfterInst(c.ID, result.ID)
+		parents = append(parents, c.ID)
 	}
 
+	sampletracker.GetSampleTracker(ctx).SetParentsOf(result.ID, parents...)
+
 	inst.SetGeneration(mx)
 	result.SetGeneration(mx + 1)
 	result.DeclareInstance()
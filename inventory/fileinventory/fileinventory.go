@@ -0,0 +1,545 @@
+// Package fileinventory is a persistent inventory stored in plain JSON files.
+// Besides the definitions of plates, tipboxes, tipwastes and components, it
+// tracks how much of each is physically in stock, by lot.
+package fileinventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory"
+)
+
+// Kinds of items in an inventory
+const (
+	PlateKind     = "plate"
+	TipboxKind    = "tipbox"
+	TipwasteKind  = "tipwaste"
+	ComponentKind = "component"
+)
+
+// Kinds lists every kind of item in an inventory
+var Kinds = []string{PlateKind, TipboxKind, TipwasteKind, ComponentKind}
+
+// ErrUnknownKind is returned for kinds of items not in Kinds
+var ErrUnknownKind = errors.New("unknown kind")
+
+// An Item identifies a definition in an inventory
+type Item struct {
+	Kind string `json:"kind"`
+	Type string `json:"type"`
+}
+
+func (a Item) String() string {
+	return a.Kind + " " + a.Type
+}
+
+func (a Item) key() string {
+	return a.Kind + "/" + a.Type
+}
+
+// A Lot is a batch of an item in stock. Quantities of labware are counts
+// and quantities of components are volumes in ul.
+type Lot struct {
+	Number   string    `json:"number"`
+	Quantity float64   `json:"quantity"`
+	Added    time.Time `json:"added"`
+}
+
+// An Entry is an item together with its stock
+type Entry struct {
+	Item
+	Lots []Lot `json:"lots,omitempty"`
+}
+
+// Total returns the total quantity in stock across lots
+func (a Entry) Total() (total float64) {
+	for _, l := range a.Lots {
+		total += l.Quantity
+	}
+	return
+}
+
+// Usage is the quantity of each item consumed by a run
+type Usage map[Item]float64
+
+// An InsufficientStock error is returned when consuming more of an item
+// than is in stock
+type InsufficientStock struct {
+	Item      Item
+	Required  float64
+	Available float64
+}
+
+func (a *InsufficientStock) Error() string {
+	return fmt.Sprintf("insufficient stock of %s: require %g have %g", a.Item, a.Required, a.Available)
+}
+
+// Inventory is a persistent inventory. It implements inventory.Inventory.
+//
+// An inventory is a directory with a JSON file for each definition, in a
+// subdirectory per kind, and a single JSON file of stock. Files are replaced
+// atomically as they change. An inventory should only be opened by one
+// process at a time.
+type Inventory struct {
+	dir  string
+	lock sync.Mutex
+}
+
+// stockFile is the file, in the inventory directory, of lots in stock by
+// item key; items without an entry are not tracked
+const stockFile = "stock.json"
+
+// Open opens, or creates if it does not exist, the inventory stored in
+// the directory path
+func Open(path string) (*Inventory, error) {
+	for _, kind := range Kinds {
+		if err := os.MkdirAll(filepath.Join(path, kind), 0777); err != nil {
+			return nil, err
+		}
+	}
+	return &Inventory{dir: path}, nil
+}
+
+// Close closes the inventory. Changes have already been saved.
+func (a *Inventory) Close() error {
+	return nil
+}
+
+// writeFile writes data to a temporary file and renames it to path so that
+// files are never partially written
+func writeFile(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()           // nolint: errcheck
+		os.Remove(f.Name()) // nolint: errcheck
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name()) // nolint: errcheck
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name()) // nolint: errcheck
+		return err
+	}
+	return nil
+}
+
+// definitionFile returns the file of the definition of an item. Types are
+// escaped as they may contain path separators.
+func (a *Inventory) definitionFile(item Item) string {
+	return filepath.Join(a.dir, item.Kind, url.PathEscape(item.Type)+".json")
+}
+
+// NewContext returns a context with the given inventory
+func NewContext(ctx context.Context, inv *Inventory) context.Context {
+	return inventory.NewContext(ctx, inv)
+}
+
+func isKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Inventory) put(item Item, value interface{}) error {
+	if !isKind(item.Kind) {
+		return ErrUnknownKind
+	} else if len(item.Type) == 0 {
+		return fmt.Errorf("%s has no type", item.Kind)
+	}
+
+	bs, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return writeFile(a.definitionFile(item), bs)
+}
+
+func (a *Inventory) has(item Item) bool {
+	_, err := os.Stat(a.definitionFile(item))
+	return err == nil
+}
+
+// get decodes the definition of an item into value
+func (a *Inventory) get(item Item, value interface{}) error {
+	if !isKind(item.Kind) {
+		return ErrUnknownKind
+	}
+	bs, err := ioutil.ReadFile(a.definitionFile(item))
+	if os.IsNotExist(err) {
+		return inventory.ErrUnknownType
+	} else if err != nil {
+		return err
+	}
+	return json.Unmarshal(bs, value)
+}
+
+// types returns the types of every item of a kind in order
+func (a *Inventory) types(kind string) ([]string, error) {
+	if !isKind(kind) {
+		return nil, ErrUnknownKind
+	}
+	fis, err := ioutil.ReadDir(filepath.Join(a.dir, kind))
+	if err != nil {
+		return nil, err
+	}
+
+	var types []string
+	for _, fi := range fis {
+		name := fi.Name()
+		if fi.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		typ, err := url.PathUnescape(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+// each calls fn with the raw definition of every item of a kind in order of
+// type
+func (a *Inventory) each(kind string, fn func(typ string, bs []byte) error) error {
+	types, err := a.types(kind)
+	if err != nil {
+		return err
+	}
+	for _, typ := range types {
+		bs, err := ioutil.ReadFile(a.definitionFile(Item{Kind: kind, Type: typ}))
+		if err != nil {
+			return err
+		}
+		if err := fn(typ, bs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddPlate adds or replaces a plate definition
+func (a *Inventory) AddPlate(p *wtype.LHPlate) error {
+	return a.put(Item{Kind: PlateKind, Type: p.Type}, p)
+}
+
+// AddTipbox adds or replaces a tipbox definition
+func (a *Inventory) AddTipbox(tb *wtype.LHTipbox) error {
+	return a.put(Item{Kind: TipboxKind, Type: tb.Type}, tb)
+}
+
+// AddTipwaste adds or replaces a tipwaste definition
+func (a *Inventory) AddTipwaste(tw *wtype.LHTipwaste) error {
+	return a.put(Item{Kind: TipwasteKind, Type: tw.Type}, tw)
+}
+
+// AddComponent adds or replaces a component definition
+func (a *Inventory) AddComponent(c *wtype.LHComponent) error {
+	return a.put(Item{Kind: ComponentKind, Type: c.CName}, c)
+}
+
+// Import adds definitions of the given kind from a JSON array and returns
+// the number of definitions added
+func (a *Inventory) Import(kind string, data []byte) (int, error) {
+	var items []Item
+	var values []interface{}
+
+	switch kind {
+	case PlateKind:
+		var ps []*wtype.LHPlate
+		if err := json.Unmarshal(data, &ps); err != nil {
+			return 0, err
+		}
+		for _, p := range ps {
+			items = append(items, Item{Kind: kind, Type: p.Type})
+			values = append(values, p)
+		}
+	case TipboxKind:
+		var tbs []*wtype.LHTipbox
+		if err := json.Unmarshal(data, &tbs); err != nil {
+			return 0, err
+		}
+		for _, tb := range tbs {
+			items = append(items, Item{Kind: kind, Type: tb.Type})
+			values = append(values, tb)
+		}
+	case TipwasteKind:
+		var tws []*wtype.LHTipwaste
+		if err := json.Unmarshal(data, &tws); err != nil {
+			return 0, err
+		}
+		for _, tw := range tws {
+			items = append(items, Item{Kind: kind, Type: tw.Type})
+			values = append(values, tw)
+		}
+	case ComponentKind:
+		var cs []*wtype.LHComponent
+		if err := json.Unmarshal(data, &cs); err != nil {
+			return 0, err
+		}
+		for _, c := range cs {
+			items = append(items, Item{Kind: kind, Type: c.CName})
+			values = append(values, c)
+		}
+	default:
+		return 0, ErrUnknownKind
+	}
+
+	for i, item := range items {
+		if err := a.put(item, values[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(items), nil
+}
+
+// NewComponent implements inventory.Inventory
+func (a *Inventory) NewComponent(ctx context.Context, name string) (*wtype.LHComponent, error) {
+	var c wtype.LHComponent
+	if err := a.get(Item{Kind: ComponentKind, Type: name}, &c); err != nil {
+		return nil, err
+	}
+	return c.Dup(), nil
+}
+
+// NewPlate implements inventory.Inventory
+func (a *Inventory) NewPlate(ctx context.Context, typ string) (*wtype.LHPlate, error) {
+	var p wtype.LHPlate
+	if err := a.get(Item{Kind: PlateKind, Type: typ}, &p); err != nil {
+		return nil, err
+	}
+	return p.Dup(), nil
+}
+
+// NewTipbox implements inventory.Inventory. Tipboxes can be found by their
+// own type or by the type of tip they hold.
+func (a *Inventory) NewTipbox(ctx context.Context, typ string) (*wtype.LHTipbox, error) {
+	var tb wtype.LHTipbox
+	err := a.get(Item{Kind: TipboxKind, Type: typ}, &tb)
+	if err == inventory.ErrUnknownType {
+		err = a.each(TipboxKind, func(_ string, bs []byte) error {
+			var x wtype.LHTipbox
+			if err := json.Unmarshal(bs, &x); err != nil {
+				return err
+			}
+			if x.Tiptype != nil && x.Tiptype.Type == typ {
+				tb = x
+				return errFound
+			}
+			return nil
+		})
+		switch err {
+		case errFound:
+			err = nil
+		case nil:
+			err = inventory.ErrUnknownType
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tb.Dup(), nil
+}
+
+var errFound = errors.New("found")
+
+// NewTipwaste implements inventory.Inventory
+func (a *Inventory) NewTipwaste(ctx context.Context, typ string) (*wtype.LHTipwaste, error) {
+	var tw wtype.LHTipwaste
+	if err := a.get(Item{Kind: TipwasteKind, Type: typ}, &tw); err != nil {
+		return nil, err
+	}
+	return tw.Dup(), nil
+}
+
+// XXXGetPlates returns every plate in the inventory
+func (a *Inventory) XXXGetPlates(ctx context.Context) ([]*wtype.LHPlate, error) {
+	var ps []*wtype.LHPlate
+	err := a.each(PlateKind, func(_ string, bs []byte) error {
+		var p wtype.LHPlate
+		if err := json.Unmarshal(bs, &p); err != nil {
+			return err
+		}
+		ps = append(ps, p.Dup())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].Type < ps[j].Type
+	})
+
+	return ps, nil
+}
+
+// readStock returns the lots in stock by item key
+func (a *Inventory) readStock() (map[string][]Lot, error) {
+	stock := make(map[string][]Lot)
+	bs, err := ioutil.ReadFile(filepath.Join(a.dir, stockFile))
+	if os.IsNotExist(err) {
+		return stock, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &stock); err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+func (a *Inventory) writeStock(stock map[string][]Lot) error {
+	bs, err := json.MarshalIndent(stock, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(a.dir, stockFile), bs)
+}
+
+// updateStock calls fn with the stock and saves the changes it makes. If fn
+// fails the stock is unchanged.
+func (a *Inventory) updateStock(fn func(stock map[string][]Lot) error) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	stock, err := a.readStock()
+	if err != nil {
+		return err
+	}
+	if err := fn(stock); err != nil {
+		return err
+	}
+	return a.writeStock(stock)
+}
+
+// AddStock records that quantity of an item with the given lot number has
+// been added to stock. The item must already be defined.
+func (a *Inventory) AddStock(item Item, lot string, quantity float64) error {
+	if quantity <= 0 {
+		return fmt.Errorf("cannot add non-positive quantity %g of %s", quantity, item)
+	} else if !isKind(item.Kind) {
+		return ErrUnknownKind
+	} else if !a.has(item) {
+		return inventory.ErrUnknownType
+	}
+
+	return a.updateStock(func(stock map[string][]Lot) error {
+		lots := stock[item.key()]
+		var found bool
+		for i := range lots {
+			if lots[i].Number == lot {
+				lots[i].Quantity += quantity
+				found = true
+				break
+			}
+		}
+		if !found {
+			lots = append(lots, Lot{Number: lot, Quantity: quantity, Added: time.Now()})
+		}
+
+		stock[item.key()] = lots
+		return nil
+	})
+}
+
+// Stock returns the lots of an item in stock, oldest first
+func (a *Inventory) Stock(item Item) ([]Lot, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	stock, err := a.readStock()
+	if err != nil {
+		return nil, err
+	}
+	return stock[item.key()], nil
+}
+
+// Commit decrements stock by the quantities used by a run. Stock is taken
+// from the oldest lots first. Items whose stock has never been added are
+// not tracked and are ignored. Either all tracked items are decremented
+// or, if there is insufficient stock of any item, none are.
+func (a *Inventory) Commit(usage Usage) error {
+	var items []Item
+	for item := range usage {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].key() < items[j].key()
+	})
+
+	return a.updateStock(func(stock map[string][]Lot) error {
+		for _, item := range items {
+			lots, tracked := stock[item.key()]
+			if !tracked {
+				continue
+			}
+
+			need := usage[item]
+			total := Entry{Item: item, Lots: lots}.Total()
+			if need > total {
+				return &InsufficientStock{Item: item, Required: need, Available: total}
+			}
+
+			for need > 0 && len(lots) != 0 {
+				if lots[0].Quantity > need {
+					lots[0].Quantity -= need
+					need = 0
+				} else {
+					need -= lots[0].Quantity
+					lots = lots[1:]
+				}
+			}
+
+			stock[item.key()] = append([]Lot{}, lots...)
+		}
+		return nil
+	})
+}
+
+// List returns the items of a kind together with their stock, sorted by
+// type
+func (a *Inventory) List(kind string) ([]Entry, error) {
+	types, err := a.types(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	stock, err := a.readStock()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, typ := range types {
+		item := Item{Kind: kind, Type: typ}
+		entries = append(entries, Entry{Item: item, Lots: stock[item.key()]})
+	}
+	return entries, nil
+}
@@ -0,0 +1,174 @@
+package fileinventory
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func openTemp(t *testing.T) (*Inventory, func()) {
+	dir, err := ioutil.TempDir("", "fileinventory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv, err := Open(filepath.Join(dir, "inventory"))
+	if err != nil {
+		os.RemoveAll(dir) // nolint: errcheck
+		t.Fatal(err)
+	}
+	return inv, func() {
+		inv.Close()       // nolint: errcheck
+		os.RemoveAll(dir) // nolint: errcheck
+	}
+}
+
+func TestDefinitions(t *testing.T) {
+	inv, done := openTemp(t)
+	defer done()
+
+	tctx := testinventory.NewContext(context.Background())
+	for _, p := range testinventory.GetPlates(tctx) {
+		if err := inv.AddPlate(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tb := range testinventory.GetTipboxes(tctx) {
+		if err := inv.AddTipbox(tb); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cs := testinventory.GetComponents(tctx)
+	bs, err := json.Marshal(cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := inv.Import(ComponentKind, bs); err != nil {
+		t.Fatal(err)
+	} else if e, f := len(cs), n; e != f {
+		t.Errorf("expecting %d components imported found %d", e, f)
+	}
+
+	ctx := NewContext(context.Background(), inv)
+
+	p, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 96, p.WellsX()*p.WellsY(); e != f {
+		t.Errorf("expecting %d wells found %d", e, f)
+	}
+
+	tb := testinventory.GetTipboxes(tctx)[0]
+	if _, err := inventory.NewTipbox(ctx, tb.Tiptype.Type); err != nil {
+		t.Errorf("cannot find tipbox by tip type %q: %s", tb.Tiptype.Type, err)
+	}
+
+	if _, err := inventory.NewComponent(ctx, "water"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := inventory.NewPlate(ctx, "not a plate"); err != inventory.ErrUnknownType {
+		t.Errorf("expecting %v found %v", inventory.ErrUnknownType, err)
+	}
+}
+
+func TestStock(t *testing.T) {
+	inv, done := openTemp(t)
+	defer done()
+
+	tctx := testinventory.NewContext(context.Background())
+	p, err := inventory.NewPlate(tctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.AddPlate(p); err != nil {
+		t.Fatal(err)
+	}
+
+	plate := Item{Kind: PlateKind, Type: p.Type}
+	if err := inv.AddStock(Item{Kind: PlateKind, Type: "not a plate"}, "L0", 1); err != inventory.ErrUnknownType {
+		t.Errorf("expecting %v found %v", inventory.ErrUnknownType, err)
+	}
+	if err := inv.AddStock(plate, "L1", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.AddStock(plate, "L2", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	untracked := Item{Kind: ComponentKind, Type: "water"}
+	if err := inv.Commit(Usage{plate: 3, untracked: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	lots, err := inv.Stock(plate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 1, len(lots); e != f {
+		t.Fatalf("expecting %d lots found %d", e, f)
+	}
+	if e, f := "L2", lots[0].Number; e != f {
+		t.Errorf("expecting lot %q found %q", e, f)
+	}
+	if e, f := 4.0, lots[0].Quantity; e != f {
+		t.Errorf("expecting quantity %g found %g", e, f)
+	}
+
+	err = inv.Commit(Usage{plate: 5})
+	if _, ok := err.(*InsufficientStock); !ok {
+		t.Errorf("expecting *InsufficientStock found %T: %v", err, err)
+	}
+
+	entries, err := inv.List(PlateKind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 1, len(entries); e != f {
+		t.Fatalf("expecting %d entries found %d", e, f)
+	}
+	if e, f := 4.0, entries[0].Total(); e != f {
+		t.Errorf("expecting total %g after failed commit found %g", e, f)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	inv, done := openTemp(t)
+	defer done()
+
+	tctx := testinventory.NewContext(context.Background())
+	p, err := inventory.NewPlate(tctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.AddPlate(p); err != nil {
+		t.Fatal(err)
+	}
+	plate := Item{Kind: PlateKind, Type: p.Type}
+	if err := inv.AddStock(plate, "L1", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := inv.Commit(Usage{plate: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(inv.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.NewPlate(context.Background(), p.Type); err != nil {
+		t.Error(err)
+	}
+
+	// Stock of the plate is used up but is still tracked
+	if err := reopened.Commit(Usage{plate: 1}); err == nil {
+		t.Errorf("expecting insufficient stock after reopening")
+	}
+}
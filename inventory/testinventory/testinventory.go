@@ -141,3 +141,18 @@ func GetComponents(ctx context.Context) []*wtype.LHComponent {
 
 	return cs
 }
+
+// GetTipwastes returns the tipwastes in a test inventory context
+func GetTipwastes(ctx context.Context) []*wtype.LHTipwaste {
+	inv := inventory.GetInventory(ctx).(*testInventory)
+	var tws []*wtype.LHTipwaste
+	for _, tw := range inv.tipwasteByType {
+		tws = append(tws, tw)
+	}
+
+	sort.Slice(tws, func(i, j int) bool {
+		return tws[i].Type < tws[j].Type
+	})
+
+	return tws
+}
@@ -0,0 +1,413 @@
+package liquidhandling
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	. "github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/doe"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// A VolumeRange is a range of transfer volumes in ul
+type VolumeRange struct {
+	Lower float64
+	Upper float64
+}
+
+func (r VolumeRange) String() string {
+	return fmt.Sprintf("%g-%gul", r.Lower, r.Upper)
+}
+
+// VolumeRanges returns the consecutive ranges between boundaries, e.g.,
+// VolumeRanges(0, 20, 200) is 0-20ul and 20-200ul. Ranges are half-open:
+// each includes its lower bound and only the last includes its upper bound,
+// so every volume is in exactly one range.
+func VolumeRanges(boundaries ...float64) []VolumeRange {
+	var ret []VolumeRange
+	for i := 1; i < len(boundaries); i++ {
+		ret = append(ret, VolumeRange{Lower: boundaries[i-1], Upper: boundaries[i]})
+	}
+	return ret
+}
+
+// rangeOf returns the first range containing v. Ranges include their lower
+// bound and only the last range includes its upper bound.
+func rangeOf(ranges []VolumeRange, v float64) (VolumeRange, bool) {
+	for i, r := range ranges {
+		if v >= r.Lower && (v < r.Upper || (i == len(ranges)-1 && v == r.Upper)) {
+			return r, true
+		}
+	}
+	return VolumeRange{}, false
+}
+
+// A Measurement is the volume actually delivered by one transfer made with
+// the settings of a run of a policy design
+type Measurement struct {
+	Run      int     // run number of the design
+	Volume   float64 // target volume in ul
+	Measured float64 // measured volume in ul
+}
+
+// Gravimetric converts a mass in mg to a volume in ul for a liquid of
+// density in g/ml
+func Gravimetric(density float64) func(float64) float64 {
+	return func(mg float64) float64 {
+		return mg / density
+	}
+}
+
+// DyeStandardCurve converts a dye signal, e.g., absorbance, to a volume in ul
+// using a linear standard curve signal = slope * volume + intercept
+func DyeStandardCurve(slope, intercept float64) func(float64) float64 {
+	return func(signal float64) float64 {
+		return (signal - intercept) / slope
+	}
+}
+
+// ReadMeasurements reads measurements from CSV with columns run number,
+// target volume in ul and raw value, e.g., mass or absorbance. Raw values
+// are converted to volumes by convert. A header row is skipped if present.
+func ReadMeasurements(r io.Reader, convert func(float64) float64) ([]Measurement, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []Measurement
+	for i, rec := range records {
+		if len(rec) == 0 || (len(rec) == 1 && strings.TrimSpace(rec[0]) == "") {
+			continue
+		}
+		if len(rec) < 3 {
+			return nil, fmt.Errorf("line %d: expecting run, volume and value found %d fields", i+1, len(rec))
+		}
+		run, err := strconv.Atoi(strings.TrimSpace(rec[0]))
+		if err != nil {
+			if i == 0 {
+				// header
+				continue
+			}
+			return nil, fmt.Errorf("line %d: invalid run number %q", i+1, rec[0])
+		}
+		vol, err := strconv.ParseFloat(strings.TrimSpace(rec[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid volume %q", i+1, rec[1])
+		}
+		raw, err := strconv.ParseFloat(strings.TrimSpace(rec[2]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid value %q", i+1, rec[2])
+		}
+		ret = append(ret, Measurement{
+			Run:      run,
+			Volume:   vol,
+			Measured: convert(raw),
+		})
+	}
+
+	return ret, nil
+}
+
+// Performance summarises how accurately and precisely one run of a design
+// delivered volumes in a range
+type Performance struct {
+	Run   int
+	Range VolumeRange
+	N     int
+	// Bias is the mean error as a percentage of the target volume
+	Bias float64
+	// CV is the coefficient of variation of the delivered fraction of the
+	// target volume as a percentage
+	CV float64
+	// Slope and Intercept are the least squares fit of measured against
+	// target volume; Slope is 1 and Intercept 0 if there is only one target
+	// volume
+	Slope     float64
+	Intercept float64
+}
+
+// TotalError combines accuracy and precision as |Bias| + 2 CV
+func (p Performance) TotalError() float64 {
+	return math.Abs(p.Bias) + 2.0*p.CV
+}
+
+func fitPerformance(run int, r VolumeRange, ms []Measurement) Performance {
+	n := float64(len(ms))
+
+	var sumFrac, sumX, sumY float64
+	for _, m := range ms {
+		sumFrac += m.Measured / m.Volume
+		sumX += m.Volume
+		sumY += m.Measured
+	}
+	meanFrac := sumFrac / n
+	meanX, meanY := sumX/n, sumY/n
+
+	var ssFrac, sxx, sxy float64
+	for _, m := range ms {
+		d := m.Measured/m.Volume - meanFrac
+		ssFrac += d * d
+		sxx += (m.Volume - meanX) * (m.Volume - meanX)
+		sxy += (m.Volume - meanX) * (m.Measured - meanY)
+	}
+
+	p := Performance{
+		Run:   run,
+		Range: r,
+		N:     len(ms),
+		Bias:  100.0 * (meanFrac - 1.0),
+		Slope: 1.0,
+	}
+	if len(ms) > 1 && meanFrac != 0.0 {
+		p.CV = 100.0 * math.Sqrt(ssFrac/(n-1)) / math.Abs(meanFrac)
+	}
+	if sxx > 0.0 {
+		p.Slope = sxy / sxx
+		p.Intercept = meanY - p.Slope*meanX
+	}
+	return p
+}
+
+// FitPerformance fits the accuracy and precision of each run in each volume
+// range. Measurements outside every range or of zero volume are ignored. The
+// result is sorted by range and then by run.
+func FitPerformance(ms []Measurement, ranges []VolumeRange) []Performance {
+	type key struct {
+		run int
+		r   VolumeRange
+	}
+	groups := make(map[key][]Measurement)
+	for _, m := range ms {
+		if m.Volume <= 0.0 {
+			continue
+		}
+		r, ok := rangeOf(ranges, m.Volume)
+		if !ok {
+			continue
+		}
+		k := key{run: m.Run, r: r}
+		groups[k] = append(groups[k], m)
+	}
+
+	var ret []Performance
+	for k, g := range groups {
+		ret = append(ret, fitPerformance(k.run, k.r, g))
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Range != ret[j].Range {
+			return ret[i].Range.Lower < ret[j].Range.Lower
+		}
+		return ret[i].Run < ret[j].Run
+	})
+
+	return ret
+}
+
+// A Calibration fits policy settings for a liquid class from measurements of
+// the runs of a policy design, e.g., one made by PolicyMakerfromDesign.
+type Calibration struct {
+	// LiquidClass is the name of the policy being calibrated
+	LiquidClass string
+	// Base is the policy used outside the calibrated ranges
+	Base wtype.LHPolicy
+	// Ranges are the volume ranges to calibrate separately
+	Ranges []VolumeRange
+	// MinReplicates is the least number of measurements of a run in a range
+	// for it to be considered; a minimum of 2 is needed to estimate CV
+	MinReplicates int
+	// Interpolate is the number of best runs whose numeric settings are
+	// averaged, weighted by the inverse of their total error. Settings which
+	// are not continuous, such as numbers of mix cycles, are taken from the
+	// best run. Values less than 2 select the best run.
+	Interpolate int
+}
+
+// policyValue converts a setpoint to the type of a policy item
+func policyValue(item wtype.AParam, v interface{}) (interface{}, bool) {
+	switch item.Type {
+	case reflect.TypeOf(0.0):
+		f, ok := toFloat(v)
+		return f, ok
+	case reflect.TypeOf(0):
+		switch t := v.(type) {
+		case int:
+			return t, true
+		case float64:
+			return int(math.Floor(t + 0.5)), true
+		}
+		return nil, false
+	default:
+		return v, reflect.TypeOf(v) == item.Type
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case int:
+		return float64(t), true
+	}
+	return 0.0, false
+}
+
+// settings returns the policy items set by a run
+func settings(run Run) (wtype.LHPolicy, error) {
+	items := wtype.MakePolicyItems()
+	pol := wtype.NewLHPolicy()
+	for i, desc := range run.Factordescriptors {
+		item, ok := items[desc]
+		if !ok || i >= len(run.Setpoints) {
+			continue
+		}
+		v, ok := policyValue(item, run.Setpoints[i])
+		if !ok {
+			return nil, fmt.Errorf("run %d: policy item %s needs value of type %s not %T", run.RunNumber, desc, item.Type, run.Setpoints[i])
+		}
+		pol[desc] = v
+	}
+	return pol, nil
+}
+
+// interpolate averages the numeric settings of policies weighted by weights;
+// other settings come from the first policy
+func interpolate(policies []wtype.LHPolicy, weights []float64) wtype.LHPolicy {
+	ret := wtype.DupLHPolicy(policies[0])
+	for name, v := range policies[0] {
+		if _, ok := v.(float64); !ok {
+			continue
+		}
+		var sum, wsum float64
+		for i, p := range policies {
+			f, ok := p[name].(float64)
+			if !ok {
+				continue
+			}
+			sum += weights[i] * f
+			wsum += weights[i]
+		}
+		if wsum > 0.0 {
+			ret[name] = sum / wsum
+		}
+	}
+	return ret
+}
+
+// choose sorts the performance of runs in a range, best first, and returns
+// the settings to use in that range
+func (c Calibration) choose(perfs []Performance, byRun map[int]wtype.LHPolicy) wtype.LHPolicy {
+	sort.SliceStable(perfs, func(i, j int) bool {
+		return perfs[i].TotalError() < perfs[j].TotalError()
+	})
+
+	n := c.Interpolate
+	if n < 1 {
+		n = 1
+	}
+	if n > len(perfs) {
+		n = len(perfs)
+	}
+	if n == 1 {
+		return wtype.DupLHPolicy(byRun[perfs[0].Run])
+	}
+
+	policies := make([]wtype.LHPolicy, 0, n)
+	weights := make([]float64, 0, n)
+	for _, p := range perfs[:n] {
+		policies = append(policies, byRun[p.Run])
+		// guard against a perfect run
+		weights = append(weights, 1.0/(p.TotalError()+1.0e-6))
+	}
+
+	return interpolate(policies, weights)
+}
+
+// Fit returns a rule set with a rule for the liquid class using the base
+// policy and, for each volume range with enough measurements, a rule
+// conditioned on the transfer volume with the best settings found. The
+// performance of every run in every range is also returned.
+func (c Calibration) Fit(runs []Run, ms []Measurement) (*wtype.LHPolicyRuleSet, []Performance, error) {
+	if c.LiquidClass == "" {
+		return nil, nil, fmt.Errorf("no liquid class to calibrate")
+	}
+	if len(c.Ranges) == 0 {
+		return nil, nil, fmt.Errorf("no volume ranges to calibrate")
+	}
+
+	byRun := make(map[int]wtype.LHPolicy)
+	for _, run := range runs {
+		s, err := settings(run)
+		if err != nil {
+			return nil, nil, err
+		}
+		byRun[run.RunNumber] = s
+	}
+	for _, m := range ms {
+		if _, ok := byRun[m.Run]; !ok {
+			return nil, nil, fmt.Errorf("measurement of run %d which is not in the design", m.Run)
+		}
+	}
+
+	minReps := c.MinReplicates
+	if minReps < 2 {
+		minReps = 2
+	}
+
+	perfs := FitPerformance(ms, c.Ranges)
+	byRange := make(map[VolumeRange][]Performance)
+	for _, p := range perfs {
+		if p.N >= minReps {
+			byRange[p.Range] = append(byRange[p.Range], p)
+		}
+	}
+
+	lhpr := wtype.NewLHPolicyRuleSet()
+
+	base := wtype.DupLHPolicy(c.Base)
+	if base == nil {
+		base = wtype.NewLHPolicy()
+	}
+	rule := wtype.NewLHPolicyRule(c.LiquidClass)
+	if err := rule.AddCategoryConditionOn("LIQUIDCLASS", c.LiquidClass); err != nil {
+		return nil, nil, err
+	}
+	lhpr.AddRule(rule, base)
+
+	for i, r := range c.Ranges {
+		rperfs, ok := byRange[r]
+		if !ok {
+			continue
+		}
+		pol := c.choose(rperfs, byRun)
+		best := rperfs[0]
+		pol["DESCRIPTION"] = fmt.Sprintf("%s calibrated for %s from run %d: bias %.2f%%, CV %.2f%%", c.LiquidClass, r, best.Run, best.Bias, best.CV)
+
+		// numeric conditions include both bounds so stop short of the
+		// next range
+		upper := r.Upper
+		if i < len(c.Ranges)-1 {
+			upper = math.Nextafter(upper, math.Inf(-1))
+		}
+		rule := wtype.NewLHPolicyRule(c.LiquidClass + "_" + r.String())
+		if err := rule.AddCategoryConditionOn("LIQUIDCLASS", c.LiquidClass); err != nil {
+			return nil, nil, err
+		}
+		if err := rule.AddNumericConditionOn("VOLUME", r.Lower, upper); err != nil {
+			return nil, nil, err
+		}
+		lhpr.AddRule(rule, pol)
+	}
+
+	return lhpr, perfs, nil
+}
@@ -0,0 +1,157 @@
+package liquidhandling
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	. "github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/doe"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func calibrationRuns() []Run {
+	factors := []string{"ASPSPEED", "POST_MIX"}
+	return []Run{
+		{RunNumber: 1, Factordescriptors: factors, Setpoints: []interface{}{1.0, 0}},
+		{RunNumber: 2, Factordescriptors: factors, Setpoints: []interface{}{3.0, 3}},
+	}
+}
+
+func TestReadMeasurements(t *testing.T) {
+	csv := "run,volume,mass\n1,10,9.5\n2, 10, 12\n"
+	ms, err := ReadMeasurements(strings.NewReader(csv), Gravimetric(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 2, len(ms); e != f {
+		t.Fatalf("expecting %d measurements found %d", e, f)
+	}
+	if e, f := (Measurement{Run: 2, Volume: 10, Measured: 24}), ms[1]; e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	if _, err := ReadMeasurements(strings.NewReader("1,10,x\n"), Gravimetric(1)); err == nil {
+		t.Error("expecting error for invalid value")
+	}
+}
+
+func TestFitPerformance(t *testing.T) {
+	ms := []Measurement{
+		{Run: 1, Volume: 10, Measured: 9},
+		{Run: 1, Volume: 10, Measured: 11},
+		{Run: 1, Volume: 20, Measured: 20},
+		{Run: 1, Volume: 500, Measured: 500},
+	}
+	perfs := FitPerformance(ms, VolumeRanges(0, 50))
+	if e, f := 1, len(perfs); e != f {
+		t.Fatalf("expecting %d fits found %d", e, f)
+	}
+	p := perfs[0]
+	if e, f := 3, p.N; e != f {
+		t.Errorf("expecting %d measurements found %d", e, f)
+	}
+	if math.Abs(p.Bias) > 1.0e-9 {
+		t.Errorf("expecting no bias found %g", p.Bias)
+	}
+	if e, f := 10.0, p.CV; math.Abs(e-f) > 1.0e-9 {
+		t.Errorf("expecting CV %g found %g", e, f)
+	}
+}
+
+func TestCalibrationFit(t *testing.T) {
+	var ms []Measurement
+	// run 1 is accurate for small volumes, run 2 for large ones
+	for _, d := range []float64{-0.1, 0.1} {
+		ms = append(ms,
+			Measurement{Run: 1, Volume: 5, Measured: 5 + d},
+			Measurement{Run: 2, Volume: 5, Measured: 4 + 5*d},
+			Measurement{Run: 1, Volume: 100, Measured: 90 + 10*d},
+			Measurement{Run: 2, Volume: 100, Measured: 100 + d},
+		)
+	}
+
+	c := Calibration{
+		LiquidClass: "water",
+		Base:        MakeDefaultPolicy(),
+		Ranges:      VolumeRanges(0, 20, 200, 1000),
+	}
+	lhpr, perfs, err := c.Fit(calibrationRuns(), ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 4, len(perfs); e != f {
+		t.Errorf("expecting %d fits found %d", e, f)
+	}
+
+	// base rule plus two calibrated ranges; 200-1000ul has no data
+	if e, f := 3, len(lhpr.Rules); e != f {
+		t.Fatalf("expecting %d rules found %d", e, f)
+	}
+
+	small, ok := lhpr.Policies["water_0-20ul"]
+	if !ok {
+		t.Fatal("missing rule for 0-20ul")
+	}
+	if e, f := 1.0, small["ASPSPEED"]; e != f {
+		t.Errorf("expecting ASPSPEED %v found %v", e, f)
+	}
+	if e, f := 0, small["POST_MIX"]; e != f {
+		t.Errorf("expecting POST_MIX %v found %v", e, f)
+	}
+
+	large := lhpr.Policies["water_20-200ul"]
+	if e, f := 3.0, large["ASPSPEED"]; e != f {
+		t.Errorf("expecting ASPSPEED %v found %v", e, f)
+	}
+
+	rule := lhpr.Rules["water_20-200ul"]
+	if e, f := 2, len(rule.Conditions); e != f {
+		t.Fatalf("expecting %d conditions found %d", e, f)
+	}
+	vol := []wunit.Volume{wunit.NewVolume(50, "ul")}
+	if cond := rule.Conditions[1]; cond.TestVariable != "VOLUME" || !cond.Condition.Match(vol) {
+		t.Errorf("expecting rule to match a 50ul transfer: %v", cond)
+	}
+
+	// 20ul is in 20-200ul only
+	boundary := []wunit.Volume{wunit.NewVolume(20, "ul")}
+	if cond := rule.Conditions[1]; !cond.Condition.Match(boundary) {
+		t.Errorf("expecting 20-200ul to match a 20ul transfer: %v", cond)
+	}
+	if cond := lhpr.Rules["water_0-20ul"].Conditions[1]; cond.Condition.Match(boundary) {
+		t.Errorf("expecting 0-20ul not to match a 20ul transfer: %v", cond)
+	}
+
+	if _, _, err := c.Fit(calibrationRuns(), []Measurement{{Run: 3, Volume: 5, Measured: 5}}); err == nil {
+		t.Error("expecting error for measurement of unknown run")
+	}
+}
+
+func TestCalibrationInterpolate(t *testing.T) {
+	var ms []Measurement
+	// both runs equally good
+	for _, run := range []int{1, 2} {
+		ms = append(ms,
+			Measurement{Run: run, Volume: 10, Measured: 9.9},
+			Measurement{Run: run, Volume: 10, Measured: 10.1},
+		)
+	}
+
+	c := Calibration{
+		LiquidClass: "water",
+		Ranges:      VolumeRanges(0, 20),
+		Interpolate: 2,
+	}
+	lhpr, _, err := c.Fit(calibrationRuns(), ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pol := lhpr.Policies["water_0-20ul"]
+	if e, f := 2.0, pol["ASPSPEED"].(float64); math.Abs(e-f) > 1.0e-6 {
+		t.Errorf("expecting interpolated ASPSPEED %g found %g", e, f)
+	}
+	if _, ok := pol["POST_MIX"].(int); !ok {
+		t.Errorf("expecting POST_MIX to be an int found %T", pol["POST_MIX"])
+	}
+}
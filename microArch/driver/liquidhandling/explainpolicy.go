@@ -0,0 +1,60 @@
+package liquidhandling
+
+import (
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// A PolicyExplanation shows how the policy for an instruction is arrived at
+type PolicyExplanation struct {
+	// Rules are the rules which match the instruction in the order in which
+	// their policies are merged; later rules take precedence
+	Rules []wtype.LHPolicyRule
+	// Policy is the merged policy
+	Policy wtype.LHPolicy
+	// Source is the name of the rule which set each item of the merged
+	// policy; items from the default policy have source "default"
+	Source map[string]string
+}
+
+// ExplainPolicyFor returns the policy for an instruction as GetPolicyFor does
+// along with the rules which produced it
+func ExplainPolicyFor(lhpr *wtype.LHPolicyRuleSet, ins RobotInstruction) PolicyExplanation {
+	rules := matchingRules(lhpr, ins)
+
+	exp := PolicyExplanation{
+		Rules:  rules,
+		Policy: wtype.DupLHPolicy(lhpr.Policies["default"]),
+		Source: make(map[string]string),
+	}
+	for k := range exp.Policy {
+		exp.Source[k] = "default"
+	}
+
+	for _, rule := range rules {
+		for k, v := range lhpr.Policies[rule.Name] {
+			exp.Policy[k] = v
+			exp.Source[k] = rule.Name
+		}
+	}
+
+	return exp
+}
+
+// ExplainTransfer returns the explanations of the policies used to aspirate
+// and dispense a transfer using multi channels
+func ExplainTransfer(lhpr *wtype.LHPolicyRuleSet, tp TransferParams, multi int) (aspirate, dispense PolicyExplanation) {
+	if multi < 1 {
+		multi = 1
+	}
+
+	suck := NewSuckInstruction()
+	blow := NewBlowInstruction()
+	for i := 0; i < multi; i++ {
+		suck.AddTransferParams(tp)
+		blow.AddTransferParams(tp)
+	}
+	suck.Multi = multi
+	blow.Multi = multi
+
+	return ExplainPolicyFor(lhpr, suck), ExplainPolicyFor(lhpr, blow)
+}
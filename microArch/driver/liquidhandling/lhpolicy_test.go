@@ -96,3 +96,47 @@ func TestPPPolicy(t *testing.T) {
 	}
 
 }
+
+func TestExplainPolicy(t *testing.T) {
+	pft, _ := GetLHPolicyForTest()
+
+	tp := TransferParams{
+		What:    "dna",
+		Volume:  wunit.NewVolume(1.99, "ul"),
+		Channel: getChannelForTest(),
+	}
+
+	asp, dsp := ExplainTransfer(pft, tp, 1)
+
+	for _, exp := range []PolicyExplanation{asp, dsp} {
+		if len(exp.Rules) == 0 {
+			t.Fatal("expecting some rules to match")
+		}
+		for i := 1; i < len(exp.Rules); i++ {
+			if exp.Rules[i-1].Priority > exp.Rules[i].Priority {
+				t.Errorf("rules not in order of priority: %s before %s", exp.Rules[i-1].Name, exp.Rules[i].Name)
+			}
+		}
+		for k, src := range exp.Source {
+			if src == "default" {
+				continue
+			}
+			if v, ok := pft.Policies[src][k]; !ok || v != exp.Policy[k] {
+				t.Errorf("%s: expecting value %v from rule %s found %v", k, exp.Policy[k], src, v)
+			}
+		}
+	}
+
+	ins := NewSuckInstruction()
+	ins.AddTransferParams(tp)
+	ins.Multi = 1
+	if p := GetPolicyFor(pft, ins); !p.IsEqualTo(asp.Policy) || !asp.Policy.IsEqualTo(p) {
+		t.Errorf("explained policy differs from policy used: %v != %v", asp.Policy, p)
+	}
+
+	if m, ok := dsp.Policy["POST_MIX"]; !ok || m.(int) != 1 {
+		t.Errorf("expecting 1 post mix found %v", m)
+	} else if dsp.Source["POST_MIX"] == "default" {
+		t.Error("expecting post mix to come from a rule")
+	}
+}
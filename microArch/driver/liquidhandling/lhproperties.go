@@ -626,7 +626,7 @@ func (lhp *LHProperties) AddWashTo(pos string, wash *wtype.LHPlate) bool {
 	return true
 }
 
-func GetLocTox(cmp *wtype.LHComponent) ([]string, error) {
+func GetLocTox(ctx context.Context, cmp *wtype.LHComponent) ([]string, error) {
 	// try the cmp's own loc
 
 	if cmp.Loc != "" {
@@ -634,7 +634,7 @@ func GetLocTox(cmp *wtype.LHComponent) ([]string, error) {
 	} else {
 		// try the ID of the thing
 
-		tx, err := getSTLocTox(cmp.ID)
+		tx, err := getSTLocTox(ctx, cmp.ID)
 
 		if err == nil {
 			return tx, err
@@ -642,7 +642,7 @@ func GetLocTox(cmp *wtype.LHComponent) ([]string, error) {
 
 		// now try its parent
 
-		tx, err = getSTLocTox(cmp.ParentID)
+		tx, err = getSTLocTox(ctx, cmp.ParentID)
 
 		if err == nil {
 			return tx, err
@@ -652,8 +652,8 @@ func GetLocTox(cmp *wtype.LHComponent) ([]string, error) {
 	return []string{}, fmt.Errorf("No location found")
 }
 
-func getSTLocTox(ID string) ([]string, error) {
-	st := sampletracker.GetSampleTracker()
+func getSTLocTox(ctx context.Context, ID string) ([]string, error) {
+	st := sampletracker.GetSampleTracker(ctx)
 	loc, ok := st.GetLocationOf(ID)
 
 	if !ok {
@@ -763,106 +763,6 @@ func (lhp *LHProperties) GetComponentsSingle(cmps []*wtype.LHComponent, carryvol
 	return plateIDs, wellCoords, vols, nil
 }
 
-// GetComponents takes requests for components at particular volumes
-// + a measure of carry volume
-// returns lists of plate IDs + wells from which to get components or error
-
-func (lhp *LHProperties) legacyGetComponentsSingle(cmps []*wtype.LHComponent, carryvol wunit.Volume, legacyVolume bool) ([][]string, [][]string, [][]wunit.Volume, error) {
-	plateIDs := make([][]string, len(cmps))
-	wellCoords := make([][]string, len(cmps))
-	vols := make([][]wunit.Volume, len(cmps))
-
-	// groan
-
-	localplates := make(map[string]*wtype.LHPlate, len(lhp.Plates))
-
-	for k, v := range lhp.Plates {
-		localplates[k] = v.DupKeepIDs()
-	}
-
-	// need to disentangle some stuff here
-
-	for i, v := range cmps {
-		plateIDs[i] = make([]string, 0, 1)
-		wellCoords[i] = make([]string, 0, 1)
-		vols[i] = make([]wunit.Volume, 0, 1)
-		foundIt := false
-
-		vdup := v.Dup()
-		if v.IsInstance() {
-			/*
-					tx := strings.Split(v.Loc, ":")
-
-					if len(tx) < 2 || len(v.Loc) == 0 {
-						st := sampletracker.GetSampleTracker()
-						loc, _ := st.GetLocationOf(v.ID)
-						tx = strings.Split(loc, ":")
-					}
-
-
-				if len(tx) < 2 {
-					panic(fmt.Sprintf("NO LOCATION FOUND FOR COMPONENT: %s %s %s", v.ID, v.CName, v.Volume().ToString()))
-				}
-			*/
-
-			tx, err := GetLocTox(v)
-			if err != nil || len(tx) != 2 {
-				panic(fmt.Sprintf("NO LOCATION FOUND FOR COMPONENT: %s %s %s", v.ID, v.CName, v.Volume().ToString()))
-			}
-
-			plateIDs[i] = append(plateIDs[i], tx[0])
-			wellCoords[i] = append(wellCoords[i], tx[1])
-			vols[i] = append(vols[i], v.Volume().Dup())
-
-			//vol := v.Volume().Dup()
-			//vol.Add(carryvol)
-			/// XXX -- adding carry volumes is all very well but
-			// assumes we have made more of this component than we really need!
-			// -- this may just need to be removed pending a better fix
-			//lhp.RemoveComponent(tx[0], tx[1], vol)
-
-			foundIt = true
-
-		} else {
-			for _, ipref := range lhp.Input_preferences {
-				// check if the plate at position ipref has the
-				// component we seek
-
-				p, ok := localplates[ipref]
-				if ok {
-					// whaddya got?
-					// nb this won't work if we need to split a volume across several plates
-					wcarr, varr, ok := p.BetterGetComponent(vdup, lhp.MinPossibleVolume(), legacyVolume)
-
-					if ok {
-						foundIt = true
-						for ix, _ := range wcarr {
-							wc := wcarr[ix].FormatA1()
-							vl := varr[ix].Dup()
-							plateIDs[i] = append(plateIDs[i], p.ID)
-							wellCoords[i] = append(wellCoords[i], wc)
-							vols[i] = append(vols[i], vl)
-							vl = vl.Dup()
-							vl.Add(carryvol)
-							//lhp.RemoveComponent(p.ID, wc, vl)
-							p.RemoveComponent(wc, vl)
-						}
-						break
-					}
-				}
-			}
-
-			if !foundIt {
-				err := wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprint("NO SOURCE FOR ", v.CName, " at volume ", v.Volume().ToString()))
-				return plateIDs, wellCoords, vols, err
-			}
-
-		}
-	}
-
-	return plateIDs, wellCoords, vols, nil
-}
-
 func (lhp *LHProperties) GetCleanTips(ctx context.Context, tiptype []string, channel []*wtype.LHChannelParameter, usetiptracking bool) (wells, positions, boxtypes [][]string, err error) {
 
 	// these are merged into subsets with tip and channel types in common here
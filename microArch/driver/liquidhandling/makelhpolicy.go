@@ -23,7 +23,6 @@
 package liquidhandling
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -35,7 +34,6 @@ import (
 	. "github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/doe"
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
-	"github.com/ghodss/yaml"
 )
 
 type PolicyFile struct {
@@ -1190,6 +1188,8 @@ func GetLHPolicyForTest() (*wtype.LHPolicyRuleSet, error) {
 
 }
 
+// LoadLHPoliciesFromFile reads a rule set, in JSON or YAML, from the file
+// named by the environment variable ANTHA_LHPOLICIES_FILE
 func LoadLHPoliciesFromFile() (*wtype.LHPolicyRuleSet, error) {
 	lhPoliciesFileName := os.Getenv("ANTHA_LHPOLICIES_FILE")
 	if lhPoliciesFileName == "" {
@@ -1199,27 +1199,5 @@ func LoadLHPoliciesFromFile() (*wtype.LHPolicyRuleSet, error) {
 	if err != nil {
 		return nil, err
 	}
-	lhprs := wtype.NewLHPolicyRuleSet()
-	lhprs.Policies = make(map[string]wtype.LHPolicy)
-	lhprs.Rules = make(map[string]wtype.LHPolicyRule)
-	//	err = readYAML(contents, lhprs)
-	err = readJSON(contents, lhprs)
-	if err != nil {
-		return nil, err
-	}
-	return lhprs, nil
-}
-
-func readYAML(fileContents []byte, ruleSet *wtype.LHPolicyRuleSet) error {
-	if err := yaml.Unmarshal(fileContents, ruleSet); err != nil {
-		return err
-	}
-	return nil
-}
-
-func readJSON(fileContents []byte, ruleSet *wtype.LHPolicyRuleSet) error {
-	if err := json.Unmarshal(fileContents, ruleSet); err != nil {
-		return err
-	}
-	return nil
+	return wtype.UnmarshalLHPolicyRuleSet(contents)
 }
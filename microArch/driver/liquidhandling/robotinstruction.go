@@ -263,7 +263,9 @@ func printPolicyForDebug(ins RobotInstruction, rules []wtype.LHPolicyRule, pol w
 
 }
 
-func GetPolicyFor(lhpr *wtype.LHPolicyRuleSet, ins RobotInstruction) wtype.LHPolicy {
+// matchingRules returns the rules which apply to an instruction in the order
+// in which they are applied
+func matchingRules(lhpr *wtype.LHPolicyRuleSet, ins RobotInstruction) []wtype.LHPolicyRule {
 	// find the set of matching rules
 	rules := make([]wtype.LHPolicyRule, 0, len(lhpr.Rules))
 	for _, rule := range lhpr.Rules {
@@ -272,8 +274,18 @@ func GetPolicyFor(lhpr *wtype.LHPolicyRuleSet, ins RobotInstruction) wtype.LHPol
 		}
 	}
 
-	// sort rules by priority
-	sort.Sort(wtype.SortableRules(rules))
+	// sort rules by priority; ties are broken by name so that the order
+	// does not depend on map iteration
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	sort.Stable(wtype.SortableRules(rules))
+
+	return rules
+}
+
+func GetPolicyFor(lhpr *wtype.LHPolicyRuleSet, ins RobotInstruction) wtype.LHPolicy {
+	rules := matchingRules(lhpr, ins)
 
 	// we might prefer to just merge this in
 
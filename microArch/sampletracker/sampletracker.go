@@ -1,19 +1,52 @@
+// Package sampletracker records where samples are and how they were made.
+// A tracker is scoped to a context, so that concurrent runs do not share
+// plates or locations.
 package sampletracker
 
 import (
+	"context"
+	"sort"
 	"sync"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 )
 
-var stLock sync.Mutex
-var st *SampleTracker
+type ctxKey string
+
+const theCtxKey ctxKey = "sampletracker"
+
+// NewContext returns a context with a new, empty sample tracker
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, theCtxKey, newSampleTracker())
+}
+
+// WithSampleTracker returns the context unchanged if it already has a
+// sample tracker and otherwise a context with a new one
+func WithSampleTracker(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(theCtxKey).(*SampleTracker); ok {
+		return ctx
+	}
+	return NewContext(ctx)
+}
+
+// GetSampleTracker returns the sample tracker of a context. It panics if the
+// context was not made by NewContext or WithSampleTracker.
+func GetSampleTracker(ctx context.Context) *SampleTracker {
+	st, ok := ctx.Value(theCtxKey).(*SampleTracker)
+	if !ok {
+		panic("sampletracker: context has no sample tracker")
+	}
+	return st
+}
 
 type SampleTracker struct {
 	lock     sync.Mutex
 	records  map[string]string
 	forwards map[string]string
 	plates   map[string]*wtype.LHPlate
+	parents  map[string][]string // sample ID to IDs it was made from
+	children map[string][]string // sample ID to IDs made from it
+	next     map[string]string   // sample ID to its ID after an update
 }
 
 func newSampleTracker() *SampleTracker {
@@ -21,21 +54,13 @@ func newSampleTracker() *SampleTracker {
 		records:  make(map[string]string),
 		forwards: make(map[string]string),
 		plates:   make(map[string]*wtype.LHPlate),
+		parents:  make(map[string][]string),
+		children: make(map[string][]string),
+		next:     make(map[string]string),
 	}
 	return &st
 }
 
-func GetSampleTracker() *SampleTracker {
-	stLock.Lock()
-	defer stLock.Unlock()
-
-	if st == nil {
-		st = newSampleTracker()
-	}
-
-	return st
-}
-
 func (st *SampleTracker) SetInputPlate(p *wtype.LHPlate) {
 	st.lock.Lock()
 	defer st.lock.Unlock()
@@ -105,6 +130,11 @@ func (st *SampleTracker) GetLocationOf(ID string) (string, bool) {
 	return st.getLocationOf(ID)
 }
 
+func (st *SampleTracker) addEdge(parent, child string) {
+	st.parents[child] = append(st.parents[child], parent)
+	st.children[parent] = append(st.children[parent], child)
+}
+
 func (st *SampleTracker) UpdateIDOf(ID string, newID string) {
 	st.lock.Lock()
 	defer st.lock.Unlock()
@@ -116,4 +146,131 @@ func (st *SampleTracker) UpdateIDOf(ID string, newID string) {
 		// actually a backward...
 		st.forwards[newID] = ID
 	}
+	st.next[ID] = newID
+	st.addEdge(ID, newID)
+}
+
+// SetParentsOf records that a sample was made by mixing together parents
+func (st *SampleTracker) SetParentsOf(ID string, parents ...string) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	for _, p := range parents {
+		st.addEdge(p, ID)
+	}
+}
+
+// CurrentLocationOf returns the location of a sample after following any
+// updates to its ID, e.g., by incubation
+func (st *SampleTracker) CurrentLocationOf(ID string) (string, bool) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	seen := make(map[string]bool)
+	cur := ID
+	for !seen[cur] {
+		seen[cur] = true
+		n, ok := st.next[cur]
+		if !ok {
+			break
+		}
+		cur = n
+	}
+
+	return st.getLocationOf(cur)
+}
+
+// walk returns the IDs reachable from ID in edges in sorted order
+func walk(edges map[string][]string, ID string) []string {
+	seen := map[string]bool{ID: true}
+	var ret []string
+	queue := []string{ID}
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, n := range edges[cur] {
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			ret = append(ret, n)
+			queue = append(queue, n)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Ancestors returns the IDs of all the samples a sample was made from
+func (st *SampleTracker) Ancestors(ID string) []string {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	return walk(st.parents, ID)
+}
+
+// Descendants returns the IDs of all the samples made from a sample
+func (st *SampleTracker) Descendants(ID string) []string {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	return walk(st.children, ID)
+}
+
+// DescendantLocations returns the locations, as plate ID and well, of all
+// samples made from a sample
+func (st *SampleTracker) DescendantLocations(ID string) []string {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	seen := make(map[string]bool)
+	var ret []string
+	for _, d := range walk(st.children, ID) {
+		if loc, ok := st.records[d]; ok && !seen[loc] {
+			seen[loc] = true
+			ret = append(ret, loc)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// A Sample is the exported lineage of one sample
+type Sample struct {
+	ID       string   `json:"id"`
+	Location string   `json:"location,omitempty"`
+	Parents  []string `json:"parents,omitempty"`
+}
+
+// Lineage returns every sample known to the tracker with its location and
+// parents, sorted by ID
+func (st *SampleTracker) Lineage() []Sample {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	ids := make(map[string]bool)
+	for id := range st.records {
+		ids[id] = true
+	}
+	for id, parents := range st.parents {
+		ids[id] = true
+		for _, p := range parents {
+			ids[p] = true
+		}
+	}
+
+	var ret []Sample
+	for id := range ids {
+		loc, _ := st.getLocationOf(id)
+		parents := append([]string(nil), st.parents[id]...)
+		sort.Strings(parents)
+		ret = append(ret, Sample{
+			ID:       id,
+			Location: loc,
+			Parents:  parents,
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+
+	return ret
 }
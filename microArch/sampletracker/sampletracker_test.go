@@ -0,0 +1,66 @@
+package sampletracker
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestScopedToContext(t *testing.T) {
+	ctx1 := NewContext(context.Background())
+	ctx2 := NewContext(context.Background())
+
+	GetSampleTracker(ctx1).SetLocationOf("a", "plate1:A1")
+
+	if _, ok := GetSampleTracker(ctx2).GetLocationOf("a"); ok {
+		t.Error("location leaked between contexts")
+	}
+	if GetSampleTracker(WithSampleTracker(ctx1)) != GetSampleTracker(ctx1) {
+		t.Error("expecting existing tracker to be kept")
+	}
+}
+
+func TestPlainContext(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expecting panic for a context without a tracker")
+		}
+	}()
+	GetSampleTracker(context.Background())
+}
+
+func TestLineage(t *testing.T) {
+	st := GetSampleTracker(NewContext(context.Background()))
+
+	st.SetLocationOf("water", "input:A1")
+	st.SetLocationOf("dye", "input:B1")
+	st.SetParentsOf("mix", "water", "dye")
+	st.SetLocationOf("mix", "output:A1")
+	st.UpdateIDOf("mix", "incubated")
+	st.SetParentsOf("dilution", "incubated", "water")
+	st.SetLocationOf("dilution", "output:A2")
+
+	if loc, ok := st.CurrentLocationOf("mix"); !ok || loc != "output:A1" {
+		t.Errorf("expecting mix at output:A1 found %q", loc)
+	}
+
+	if e, f := []string{"dilution", "incubated", "mix"}, st.Descendants("dye"); !reflect.DeepEqual(e, f) {
+		t.Errorf("expecting descendants %v found %v", e, f)
+	}
+
+	if e, f := []string{"output:A1", "output:A2"}, st.DescendantLocations("water"); !reflect.DeepEqual(e, f) {
+		t.Errorf("expecting descendant locations %v found %v", e, f)
+	}
+
+	if e, f := []string{"dye", "incubated", "mix", "water"}, st.Ancestors("dilution"); !reflect.DeepEqual(e, f) {
+		t.Errorf("expecting ancestors %v found %v", e, f)
+	}
+
+	samples := st.Lineage()
+	if e, f := 5, len(samples); e != f {
+		t.Fatalf("expecting %d samples found %d", e, f)
+	}
+	if e, f := (Sample{ID: "dilution", Location: "output:A2", Parents: []string{"incubated", "water"}}), samples[0]; !reflect.DeepEqual(e, f) {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
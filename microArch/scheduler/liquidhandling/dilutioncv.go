@@ -0,0 +1,102 @@
+package liquidhandling
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/antha/anthalib/wutil"
+)
+
+// PipettingError models the precision of a single pipetting step as a
+// relative error plus an absolute error, so that small volumes are
+// relatively less precise than large ones
+type PipettingError struct {
+	// coefficient of variation which applies at every volume
+	Relative float64
+	// standard deviation which applies at every volume
+	Absolute wunit.Volume
+}
+
+// DefaultPipettingError is a typical error for air displacement pipetting
+var DefaultPipettingError = PipettingError{
+	Relative: 0.01,
+	Absolute: wunit.NewVolume(0.1, "ul"),
+}
+
+// Volume returns a copy of v with its uncertainty set to the expected
+// pipetting error
+func (a PipettingError) Volume(v wunit.Volume) wunit.Volume {
+	ret := wunit.CopyVolume(v)
+	abs := a.Absolute.ConvertTo(v.Unit())
+	rel := a.Relative * v.RawValue()
+	ret.SetUncertainty(math.Hypot(abs, rel))
+	return ret
+}
+
+// dilutionCVs returns the expected CV of the fraction of a mix made up by
+// each volume. For fraction f = v / (v + r), where r is the sum of the other
+// volumes, first order propagation gives
+//
+//	CV(f) = sqrt((r CV(v))^2 + sd(r)^2) / (v + r)
+func dilutionCVs(vols []wunit.Volume) []float64 {
+	var total, variance float64
+	for _, v := range vols {
+		total += v.SIValue()
+		sd := v.Uncertainty() * v.Unit().BaseSIConversionFactor()
+		variance += sd * sd
+	}
+
+	cvs := make([]float64, len(vols))
+	for i, v := range vols {
+		value := v.SIValue()
+		sd := v.Uncertainty() * v.Unit().BaseSIConversionFactor()
+		if value == 0.0 || total == 0.0 {
+			continue
+		}
+		rest := total - value
+		restVariance := math.Max(variance-sd*sd, 0)
+		cvs[i] = math.Sqrt(math.Pow(rest*sd/value, 2)+restVariance) / total
+	}
+	return cvs
+}
+
+// checkDilutionCVs warns about components of mixes whose final
+// concentration is expected to vary by more than Options.MaxDilutionCV
+// because of pipetting error. It returns the warnings given.
+func checkDilutionCVs(request *LHRequest, perr PipettingError) []string {
+	maxCV := request.Options.MaxDilutionCV
+	if maxCV <= 0.0 {
+		return nil
+	}
+
+	var warnings []string
+	for _, insID := range request.Output_order {
+		ins, ok := request.LHInstructions[insID]
+		if !ok || ins.Type != wtype.LHIMIX || len(ins.Components) < 2 {
+			continue
+		}
+
+		vols := make([]wunit.Volume, 0, len(ins.Components))
+		for _, cmp := range ins.Components {
+			vols = append(vols, perr.Volume(cmp.Volume()))
+		}
+
+		product := ins.ProductID
+		if ins.Result != nil {
+			product = ins.Result.CName
+		}
+
+		for i, cv := range dilutionCVs(vols) {
+			if cv <= maxCV {
+				continue
+			}
+			cmp := ins.Components[i]
+			w := fmt.Sprintf("expected CV of %s in %s is %.1f%% which exceeds %.1f%%: pipetting %s into a total of %s", cmp.CName, product, cv*100, maxCV*100, vols[i].ToStringWithUncertainty(), wunit.AddVolumes(vols...).ToString())
+			wutil.Warn(w)
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
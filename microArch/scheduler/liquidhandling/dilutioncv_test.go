@@ -0,0 +1,51 @@
+package liquidhandling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func TestDilutionCVs(t *testing.T) {
+	perr := PipettingError{Relative: 0.01}
+	vols := []wunit.Volume{
+		perr.Volume(wunit.NewVolume(10, "ul")),
+		perr.Volume(wunit.NewVolume(90, "ul")),
+	}
+	cvs := dilutionCVs(vols)
+
+	// f = 0.1, sd(v) = 0.1ul, sd(r) = 0.9ul
+	expected := math.Sqrt(math.Pow(90*0.1/10, 2)+0.9*0.9) / 100
+	if f := cvs[0]; math.Abs(f-expected) > 1e-9 {
+		t.Errorf("expecting CV %g found %g", expected, f)
+	}
+
+	if cvs := dilutionCVs([]wunit.Volume{wunit.NewVolume(10, "ul"), wunit.NewVolume(90, "ul")}); cvs[0] != 0 || cvs[1] != 0 {
+		t.Errorf("expecting exact volumes to give zero CV found %v", cvs)
+	}
+}
+
+func TestCheckDilutionCVs(t *testing.T) {
+	req := NewLHRequest()
+
+	ins := wtype.NewLHMixInstruction()
+	ins.Components = []*wtype.LHComponent{
+		getComponentWithNameVolume("dye", 0.5),
+		getComponentWithNameVolume("water", 199.5),
+	}
+	ins.Result = getComponentWithNameVolume("diluted dye", 200)
+	req.LHInstructions[ins.ID] = ins
+	req.Output_order = []string{ins.ID}
+
+	if ws := checkDilutionCVs(req, DefaultPipettingError); len(ws) != 0 {
+		t.Errorf("expecting no warnings when disabled found %v", ws)
+	}
+
+	req.Options.MaxDilutionCV = 0.05
+	ws := checkDilutionCVs(req, DefaultPipettingError)
+	if len(ws) != 1 {
+		t.Fatalf("expecting 1 warning found %d: %v", len(ws), ws)
+	}
+}
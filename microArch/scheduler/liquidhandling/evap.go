@@ -1,12 +1,317 @@
 package liquidhandling
 
 import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/antha/anthalib/wutil"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// molar mass of dry air in kg/mol
+const airMolarMass = 0.028964
+
+// a volatileLiquid is the part of a liquid which evaporates
+type volatileLiquid struct {
+	MolarMass float64 // kg/mol
+	Density   float64 // kg/m^3
+	// Antoine coefficients for vapour pressure in mmHg and temperature in C
+	A, B, C float64
+	// true if ambient humidity reduces evaporation, i.e., if the vapour is
+	// water
+	Aqueous bool
+}
+
+var (
+	water = volatileLiquid{
+		MolarMass: 0.018015,
+		Density:   997.0,
+		A:         8.07131,
+		B:         1730.63,
+		C:         233.426,
+		Aqueous:   true,
+	}
+	ethanol = volatileLiquid{
+		MolarMass: 0.046069,
+		Density:   789.0,
+		A:         8.20417,
+		B:         1642.89,
+		C:         230.3,
+	}
 )
 
-func calc_evap(well *wtype.LHWell, time wunit.Time) {
+// vapourPressure returns the saturated vapour pressure in Pa at a
+// temperature in C
+func (a volatileLiquid) vapourPressure(tempC float64) float64 {
+	const mmHg = 133.322
+	return math.Pow(10, a.A-a.B/(a.C+tempC)) * mmHg
+}
+
+// humidityRatio returns the mass of vapour per mass of dry air for a
+// partial pressure of vapour p in air at pressure pa
+func (a volatileLiquid) humidityRatio(p, pa float64) float64 {
+	if p >= pa {
+		return math.Inf(1)
+	}
+	return a.MolarMass / airMolarMass * p / (pa - p)
+}
+
+// volatileLiquidOf returns the volatile part of a liquid type; ok is false
+// for liquids which do not noticeably evaporate, such as glycerol. Liquids
+// other than those listed are treated as aqueous.
+func volatileLiquidOf(lt wtype.LiquidType) (liquid volatileLiquid, ok bool) {
+	switch lt {
+	case wtype.LTGlycerol:
+		return volatileLiquid{}, false
+	case wtype.LTEthanol:
+		return ethanol, true
+	default:
+		return water, true
+	}
+}
+
+// EvaporationModel estimates the loss of liquid from open wells using the
+// empirical formula for evaporation from a free surface
+//
+//	g = Θ A (xs - x)
+//
+// where g is in kg/h, Θ = 25 + 19 v for air speed v in m/s, A is the
+// surface area of the liquid in m^2 and xs and x are the humidity ratios of
+// saturated air at the liquid surface and of the ambient air. The surface
+// is assumed to be at ambient temperature.
+type EvaporationModel struct {
+	Env wtype.Environment
+}
+
+// Rate returns the rate of loss of liquid from a well in ul/s
+func (a EvaporationModel) Rate(well *wtype.LHWell) float64 {
+	if well == nil || well.Empty() {
+		return 0.0
+	}
+
+	liquid, ok := volatileLiquidOf(well.WContents.Type)
+	if !ok {
+		return 0.0
+	}
+
+	// temperatures are measured in C
+	temp := a.Env.Temperature.SIValue()
+	pa := a.Env.Pressure.SIValue()
+	area := well.AreaForVolume().SIValue()
+
+	ps := liquid.vapourPressure(temp)
+	xs := liquid.humidityRatio(ps, pa)
+	var x float64
+	if liquid.Aqueous {
+		x = liquid.humidityRatio(a.Env.Humidity*ps, pa)
+	}
+	if xs <= x || math.IsInf(xs, 1) {
+		return 0.0
+	}
+
+	var speed float64
+	if !a.Env.MeanAirFlowVelocity.IsNil() {
+		speed = a.Env.MeanAirFlowVelocity.SIValue()
+	}
+	theta := 25.0 + 19.0*speed
+
+	kgPerSecond := theta * area * (xs - x) / 3600.0
+	return kgPerSecond / liquid.Density * 1.0e9
+}
+
+// VolumeLost returns the volume lost from a well over a period of time. It
+// never exceeds the volume of the well.
+func (a EvaporationModel) VolumeLost(well *wtype.LHWell, d time.Duration) wunit.Volume {
+	lost := a.Rate(well) * d.Seconds()
+	if well != nil && !well.Empty() {
+		lost = math.Min(lost, well.WContents.Volume().ConvertToString("ul"))
+	}
+	return wunit.NewVolume(lost, "ul")
+}
+
+// evaporationLog accumulates losses by location across a timeline
+type evaporationLog struct {
+	lost map[string]float64 // ul
+	peak map[string]float64 // ul
+}
+
+func newEvaporationLog() *evaporationLog {
+	return &evaporationLog{
+		lost: make(map[string]float64),
+		peak: make(map[string]float64),
+	}
+}
+
+// evaporate removes the liquid lost from every well of a robot over a period
+// of time and returns the corresponding volume corrections
+func (a EvaporationModel) evaporate(robot *liquidhandling.LHProperties, d time.Duration, log *evaporationLog) []wtype.VolumeCorrection {
+	var ret []wtype.VolumeCorrection
+	if d <= 0 {
+		return ret
+	}
+
+	for _, plate := range robot.Plates {
+		for _, well := range plate.Wellcoords {
+			if well.Empty() {
+				continue
+			}
+			loc := well.WContents.Loc
+			before := well.WContents.Volume().ConvertToString("ul")
+			vol := a.VolumeLost(well, d)
+			if vol.RawValue() <= 0.0 {
+				continue
+			}
+			if r := well.Remove(vol); r == nil {
+				well.WContents.Vol = 0.0
+			}
+
+			log.peak[loc] = math.Max(log.peak[loc], before)
+			log.lost[loc] += vol.ConvertToString("ul")
+
+			ret = append(ret, wtype.VolumeCorrection{
+				Type:     "Evaporation",
+				Volume:   vol,
+				Location: loc,
+			})
+		}
+	}
+
+	return ret
+}
+
+// warnings returns a warning for every location which lost more than
+// maxPercent of its volume
+func (a *evaporationLog) warnings(maxPercent float64) []string {
+	if maxPercent <= 0.0 {
+		return nil
+	}
+
+	var locs []string
+	for loc := range a.lost {
+		locs = append(locs, loc)
+	}
+	sort.Strings(locs)
+
+	var ret []string
+	for _, loc := range locs {
+		peak := a.peak[loc]
+		if peak <= 0.0 {
+			continue
+		}
+		percent := 100.0 * a.lost[loc] / peak
+		if percent <= maxPercent {
+			continue
+		}
+		w := fmt.Sprintf("well %s is expected to lose %.2f ul of %.2f ul (%.1f%%) to evaporation which exceeds %.1f%%", loc, a.lost[loc], peak, percent, maxPercent)
+		wutil.Warn(w)
+		ret = append(ret, w)
+	}
+	return ret
+}
+
+// a wellTimeline is the simulated volume of a well over a run
+type wellTimeline struct {
+	well  *wtype.LHWell // private copy used to find surface area
+	vol   float64       // ul
+	since time.Duration // time up to which loss has been accounted
+}
+
+// advance accounts for evaporation from a well up to time now
+func (a EvaporationModel) advance(loc string, w *wellTimeline, now time.Duration, log *evaporationLog) {
+	d := now - w.since
+	w.since = now
+	if d <= 0 || w.vol <= 0.0 {
+		return
+	}
+	w.well.WContents.SetVolume(wunit.NewVolume(w.vol, "ul"))
+	lost := math.Min(a.Rate(w.well)*d.Seconds(), w.vol)
+	log.peak[loc] = math.Max(log.peak[loc], w.vol)
+	log.lost[loc] += lost
+	w.vol -= lost
+}
+
+// timeline estimates evaporation across a planned run from the initial state
+// of a robot and the instructions it will execute. Wells lose liquid from
+// the start of the run, if they already hold liquid, or from when liquid is
+// first dispensed into them until the end of the run. The time of each
+// instruction is given by the robot's timer.
+func (a EvaporationModel) timeline(robot *liquidhandling.LHProperties, instructions []liquidhandling.TerminalRobotInstruction, log *evaporationLog) []wtype.VolumeCorrection {
+	timer := robot.GetTimer()
+	if timer == nil {
+		return nil
+	}
+
+	wells := make(map[string]*wellTimeline)
+	for _, plate := range robot.Plates {
+		for crds, well := range plate.Wellcoords {
+			if well.Empty() {
+				continue
+			}
+			wells[plate.ID+":"+crds] = &wellTimeline{
+				well: well.Dup(),
+				vol:  well.WContents.Volume().ConvertToString("ul"),
+			}
+		}
+	}
+
+	var now time.Duration
+	var lastPos, lastWell []string
+	for _, ins := range instructions {
+		switch ins.InstructionType() {
+		case liquidhandling.MOV:
+			lastPos, _ = ins.GetParameter("POSTO").([]string)
+			lastWell, _ = ins.GetParameter("WELLTO").([]string)
+		case liquidhandling.ASP, liquidhandling.DSP:
+			vols, _ := ins.GetParameter("VOLUME").([]wunit.Volume)
+			sign := 1.0
+			if ins.InstructionType() == liquidhandling.ASP {
+				sign = -1.0
+			}
+			for i, v := range vols {
+				if i >= len(lastPos) || i >= len(lastWell) || v.IsNil() {
+					continue
+				}
+				plate, ok := robot.Plates[lastPos[i]]
+				if !ok {
+					continue
+				}
+				well, ok := plate.Wellcoords[lastWell[i]]
+				if !ok {
+					continue
+				}
+				loc := plate.ID + ":" + lastWell[i]
+				w, ok := wells[loc]
+				if !ok {
+					w = &wellTimeline{well: well.Dup(), since: now}
+					wells[loc] = w
+				}
+				a.advance(loc, w, now, log)
+				w.vol = math.Max(0.0, w.vol+sign*v.ConvertToString("ul"))
+			}
+		}
+		now += timer.TimeFor(ins)
+	}
+
+	var locs []string
+	for loc, w := range wells {
+		a.advance(loc, w, now, log)
+		if log.lost[loc] > 0.0 {
+			locs = append(locs, loc)
+		}
+	}
+	sort.Strings(locs)
 
-	// hmmm... should do things proper like here
-	// however...
+	ret := make([]wtype.VolumeCorrection, 0, len(locs))
+	for _, loc := range locs {
+		ret = append(ret, wtype.VolumeCorrection{
+			Type:     "Evaporation",
+			Volume:   wunit.NewVolume(log.lost[loc], "ul"),
+			Location: loc,
+		})
+	}
+	return ret
 }
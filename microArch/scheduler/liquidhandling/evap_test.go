@@ -0,0 +1,192 @@
+package liquidhandling
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+func filledWell(t *testing.T, lt wtype.LiquidType, ul float64) *wtype.LHWell {
+	ctx := testinventory.NewContext(context.Background())
+	plate, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := wtype.NewLHComponent()
+	c.CName = "liquid"
+	c.Type = lt
+	c.Vol = ul
+	c.Vunit = "ul"
+	well := plate.Wellcoords["A1"]
+	well.Add(c)
+	return well
+}
+
+func testEnvironment(humidity, airflow float64) wtype.Environment {
+	return wtype.Environment{
+		Temperature:         wunit.NewTemperature(25, "C"),
+		Pressure:            wunit.NewPressure(101325, "Pa"),
+		Humidity:            humidity,
+		MeanAirFlowVelocity: wunit.NewVelocity(airflow, "m/s"),
+	}
+}
+
+func TestEvaporationRate(t *testing.T) {
+	water := filledWell(t, wtype.LTWater, 100)
+
+	still := EvaporationModel{Env: testEnvironment(0.35, 0)}.Rate(water)
+	// open 96 well plates lose of the order of microlitres an hour
+	if perHour := still * 3600; perHour < 1 || perHour > 100 {
+		t.Errorf("expecting rate of the order of ul/h found %g ul/h", perHour)
+	}
+
+	if humid := (EvaporationModel{Env: testEnvironment(0.9, 0)}).Rate(water); humid >= still {
+		t.Errorf("expecting humid air to slow evaporation: %g >= %g", humid, still)
+	}
+	if saturated := (EvaporationModel{Env: testEnvironment(1, 0)}).Rate(water); saturated != 0 {
+		t.Errorf("expecting no evaporation into saturated air found %g", saturated)
+	}
+	if windy := (EvaporationModel{Env: testEnvironment(0.35, 1)}).Rate(water); windy <= still {
+		t.Errorf("expecting airflow to speed evaporation: %g <= %g", windy, still)
+	}
+
+	model := EvaporationModel{Env: testEnvironment(0.35, 0)}
+	if r := model.Rate(filledWell(t, wtype.LTGlycerol, 100)); r != 0 {
+		t.Errorf("expecting glycerol not to evaporate found %g", r)
+	}
+	if r := model.Rate(filledWell(t, wtype.LTEthanol, 100)); r <= still {
+		t.Errorf("expecting ethanol to evaporate faster than water: %g <= %g", r, still)
+	}
+}
+
+func TestVolumeLost(t *testing.T) {
+	model := EvaporationModel{Env: testEnvironment(0.0, 1)}
+	well := filledWell(t, wtype.LTWater, 5)
+
+	if e, f := 5.0, model.VolumeLost(well, 1000*time.Hour).ConvertToString("ul"); e != f {
+		t.Errorf("expecting loss to be limited to %g ul found %g ul", e, f)
+	}
+}
+
+func TestEvaporationWarnings(t *testing.T) {
+	log := newEvaporationLog()
+	log.lost["p:A1"] = 1
+	log.peak["p:A1"] = 10
+	log.lost["p:A2"] = 1
+	log.peak["p:A2"] = 100
+
+	if ws := log.warnings(5); len(ws) != 1 {
+		t.Errorf("expecting 1 warning found %d: %q", len(ws), ws)
+	}
+	if ws := log.warnings(0); len(ws) != 0 {
+		t.Errorf("expecting warnings to be disabled found %q", ws)
+	}
+}
+
+func TestEvaporationTimeline(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+	robot := makeGilson(ctx)
+	if robot.GetTimer() == nil {
+		t.Fatal("expecting test robot to have a timer")
+	}
+
+	plate, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := wtype.NewLHComponent()
+	c.CName = "water"
+	c.Type = wtype.LTWater
+	c.Vol = 100
+	c.Vunit = "ul"
+	plate.Wellcoords["A1"].Add(c)
+	if err := robot.AddPlate("position_4", plate); err != nil {
+		t.Fatal(err)
+	}
+
+	move := func(well string) liquidhandling.TerminalRobotInstruction {
+		mov := liquidhandling.NewMoveInstruction()
+		mov.Pos = []string{"position_4"}
+		mov.Well = []string{well}
+		return mov
+	}
+	asp := liquidhandling.NewAspirateInstruction()
+	asp.Volume = []wunit.Volume{wunit.NewVolume(10, "ul")}
+	dsp := liquidhandling.NewDispenseInstruction()
+	dsp.Volume = []wunit.Volume{wunit.NewVolume(10, "ul")}
+
+	instructions := []liquidhandling.TerminalRobotInstruction{move("A1"), asp, move("B1"), dsp}
+
+	log := newEvaporationLog()
+	evaps := EvaporationModel{Env: testEnvironment(0.35, 0)}.timeline(robot, instructions, log)
+
+	lost := make(map[string]float64)
+	for _, vc := range evaps {
+		lost[vc.Location] = vc.Volume.ConvertToString("ul")
+	}
+	a1, b1 := lost[plate.ID+":A1"], lost[plate.ID+":B1"]
+	if a1 <= 0 || b1 <= 0 {
+		t.Fatalf("expecting losses from A1 and B1 found %v", lost)
+	}
+	if a1 <= b1 {
+		t.Errorf("expecting A1, which is filled for longer, to lose more than B1: %g <= %g", a1, b1)
+	}
+	if e, f := 10.0, log.peak[plate.ID+":B1"]; e != f {
+		t.Errorf("expecting peak volume of B1 %g found %g", e, f)
+	}
+}
+
+// inputVolumes returns the total volume in ul of each component in the
+// starting layout of a planned run
+func inputVolumes(lh *Liquidhandler) map[string]float64 {
+	vols := make(map[string]float64)
+	for _, plate := range lh.Properties.Plates {
+		for _, well := range plate.Wellcoords {
+			if !well.Empty() {
+				vols[well.WContents.CName] += well.CurrentVolume().ConvertToString("ul")
+			}
+		}
+	}
+	return vols
+}
+
+func TestEvaporationCompensated(t *testing.T) {
+	plan := func(modelEvaporation bool) (*Liquidhandler, *LHRequest) {
+		ctx := testinventory.NewContext(context.Background())
+		lh := GetLiquidHandlerForTest(ctx)
+		rq := GetLHRequestForTest()
+		configure_request_simple(ctx, rq)
+		rq.Input_platetypes = append(rq.Input_platetypes, GetPlateForTest())
+		rq.Output_platetypes = append(rq.Output_platetypes, GetPlateForTest())
+		rq.Options.ModelEvaporation = modelEvaporation
+		rq.ConfigureYourself()
+		if err := lh.Plan(ctx, rq); err != nil {
+			t.Fatal(err)
+		}
+		return lh, rq
+	}
+
+	lh, rq := plan(true)
+	if len(rq.Evaps) == 0 {
+		t.Fatal("expecting evaporation to be modelled")
+	}
+	for _, vc := range rq.Evaps {
+		id := strings.Split(vc.Location, ":")[0]
+		if _, ok := lh.Properties.PlateIDLookup[id]; !ok {
+			t.Fatalf("evaporation from %s is not on a plate of the run", vc.Location)
+		}
+	}
+
+	exact, _ := plan(false)
+	with, without := inputVolumes(lh), inputVolumes(exact)
+	if with["water"] <= without["water"] {
+		t.Errorf("expecting more water to be loaded to make up for evaporation: %g <= %g", with["water"], without["water"])
+	}
+}
@@ -34,6 +34,12 @@ import (
 func ExecutionPlanner3(ctx context.Context, request *LHRequest, robot *liquidhandling.LHProperties) (*LHRequest, error) {
 	ch := request.InstructionChain
 
+	// keep the starting state to assess evaporation
+	var initial *liquidhandling.LHProperties
+	if request.Options.ModelEvaporation || request.Options.MaxEvaporationPercent > 0.0 {
+		initial = robot.DupKeepIDs()
+	}
+
 	for {
 		if ch == nil {
 			break
@@ -76,7 +82,14 @@ func ExecutionPlanner3(ctx context.Context, request *LHRequest, robot *liquidhan
 	}
 	request.Instructions = instrx
 
-	// TODO -- pass evaporation info back up to request
+	if initial != nil {
+		evapLog := newEvaporationLog()
+		evaps := EvaporationModel{Env: initial.GetEnvironment()}.timeline(initial, instrx, evapLog)
+		if request.Options.ModelEvaporation {
+			request.Evaps = evaps
+		}
+		evapLog.warnings(request.Options.MaxEvaporationPercent)
+	}
 
 	return request, nil
 }
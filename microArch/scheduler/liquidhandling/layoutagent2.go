@@ -39,6 +39,8 @@ import (
 func ImprovedLayoutAgent(ctx context.Context, request *LHRequest, params *liquidhandling.LHProperties) (*LHRequest, error) {
 	// do this multiply based on the order in the chain
 
+	ctx = sampletracker.WithSampleTracker(ctx)
+
 	ch := request.InstructionChain
 	pc := make([]PlateChoice, 0, 3)
 	mp := make(map[string]string)
@@ -176,7 +178,7 @@ func LayoutStage(ctx context.Context, request *LHRequest, params *liquidhandling
 
 	// find existing assignments and copy into the plate_choices structure
 	// this may be because 1) the user has set the assignment 2) the assignment derives from a component
-	plate_choices, mapchoices, err := get_and_complete_assignments(request, chain.ValueIDs(), plate_choices, mapchoices)
+	plate_choices, mapchoices, err := get_and_complete_assignments(ctx, request, chain.ValueIDs(), plate_choices, mapchoices)
 
 	// map choices maps layout groups to (temp)plate IDs
 
@@ -268,7 +270,7 @@ func LayoutStage(ctx context.Context, request *LHRequest, params *liquidhandling
 		lkp[v.ID] = append(lkp[v.ID], v.Result)
 	}
 
-	sampletracker := sampletracker.GetSampleTracker()
+	sampletracker := sampletracker.GetSampleTracker(ctx)
 
 	// now map the output assignments in
 	for k, v := range request.Output_assignments {
@@ -312,11 +314,11 @@ type PlateChoice struct {
 	Output    []bool
 }
 
-func get_and_complete_assignments(request *LHRequest, order []string, s []PlateChoice, m map[string]string) ([]PlateChoice, map[string]string, error) {
+func get_and_complete_assignments(ctx context.Context, request *LHRequest, order []string, s []PlateChoice, m map[string]string) ([]PlateChoice, map[string]string, error) {
 	//s := make([]PlateChoice, 0, 3)
 	//m := make(map[int]string)
 
-	st := sampletracker.GetSampleTracker()
+	st := sampletracker.GetSampleTracker(ctx)
 
 	// inconsistent plate types will be assigned randomly!
 	//	for k, v := range request.LHInstructions {
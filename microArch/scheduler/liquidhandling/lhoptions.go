@@ -8,6 +8,8 @@ type LHOptions struct {
 	LegacyVolume            bool
 	FixVolumes              bool
 	UseLLF                  bool
+	MaxDilutionCV           float64
+	MaxEvaporationPercent   float64
 }
 
 func NewLHOptions() LHOptions {
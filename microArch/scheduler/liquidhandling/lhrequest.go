@@ -240,14 +240,18 @@ type LHPolicyManager struct {
 	UserPolicies   *wtype.LHPolicyRuleSet
 }
 
-func (mgr *LHPolicyManager) MergePolicies(protocolpolicies *wtype.LHPolicyRuleSet) *wtype.LHPolicyRuleSet {
+func (mgr *LHPolicyManager) MergePolicies(protocolpolicies *wtype.LHPolicyRuleSet) (*wtype.LHPolicyRuleSet, error) {
 	ret := wtype.CloneLHPolicyRuleSet(mgr.SystemPolicies)
 
 	// things coming in take precedence over things already there
-	ret.MergeWith(mgr.UserPolicies)
-	ret.MergeWith(protocolpolicies)
+	if err := ret.MergeWith(mgr.UserPolicies); err != nil {
+		return nil, err
+	}
+	if err := ret.MergeWith(protocolpolicies); err != nil {
+		return nil, err
+	}
 
-	return ret
+	return ret, nil
 }
 
 /*
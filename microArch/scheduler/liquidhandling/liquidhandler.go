@@ -36,6 +36,7 @@ import (
 	"github.com/antha-lang/antha/microArch/driver"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	"github.com/antha-lang/antha/microArch/logger"
+	"github.com/antha-lang/antha/microArch/sampletracker"
 )
 
 // the liquid handler structure defines the interface to a particular liquid handling
@@ -622,6 +623,8 @@ func forceSanity(request *LHRequest) {
 }
 
 func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
+	ctx = sampletracker.WithSampleTracker(ctx)
+
 	// figure out the output order
 
 	err := set_output_order(request)
@@ -705,6 +708,9 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 	}
 	checkSanityIns(request)
 
+	// warn about dilutions which pipetting error makes imprecise
+	checkDilutionCVs(request, DefaultPipettingError)
+
 	// looks at components, determines what inputs are required
 	request, err = this.GetInputs(request)
 
@@ -47,6 +47,8 @@ func ImprovedExecutionPlanner(ctx context.Context, request *LHRequest, robot *li
 
 	instrx := make([]liquidhandling.RobotInstruction, 0, len(request.LHInstructions))
 	evaps := make([]wtype.VolumeCorrection, 0, 10)
+	evapModel := EvaporationModel{Env: robot.GetEnvironment()}
+	evapLog := newEvaporationLog()
 
 	for ix, insID := range request.Output_order {
 		//	request.InstructionSet.Add(ConvertInstruction(request.LHInstructions[insID], robot))
@@ -102,7 +104,7 @@ func ImprovedExecutionPlanner(ctx context.Context, request *LHRequest, robot *li
 
 				// evaporate stuff
 
-				myevap := robot.Evaporate(totaltime)
+				myevap := evapModel.evaporate(robot, totaltime, evapLog)
 				evaps = append(evaps, myevap...)
 			}
 		}
@@ -134,6 +136,7 @@ func ImprovedExecutionPlanner(ctx context.Context, request *LHRequest, robot *li
 	request.Instructions = finalInstrx
 
 	request.Evaps = evaps
+	evapLog.warnings(request.Options.MaxEvaporationPercent)
 
 	return request, nil
 }
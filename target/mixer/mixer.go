@@ -86,6 +86,17 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 
 	/// TODO --> a.opt.Destination isn't being passed through, this makes MixInto redundant
 
+	for idx, bs := range a.opt.PolicyData {
+		rs, err := wtype.UnmarshalLHPolicyRuleSet(bs)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse policies at idx %d: %s", idx, err)
+		}
+		if err := req.Policies.MergeWith(rs); err != nil {
+			return nil, fmt.Errorf("cannot merge policies at idx %d: %s", idx, err)
+		}
+	}
+
+	// options given on the command line take precedence over policy files
 	if err := req.Policies.SetOption("USE_DRIVER_TIP_TRACKING", a.opt.UseDriverTipTracking); err != nil {
 		return nil, err
 	}
@@ -170,7 +181,7 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 
 	// add plates requested via protocol
 
-	st := sampletracker.GetSampleTracker()
+	st := sampletracker.GetSampleTracker(ctx)
 
 	parr := st.GetInputPlates()
 
@@ -209,6 +220,14 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 
 	req.Options.FixVolumes = a.opt.FixVolumes
 
+	// dilution precision
+
+	req.Options.MaxDilutionCV = a.opt.MaxDilutionCV
+
+	// evaporation limit
+
+	req.Options.MaxEvaporationPercent = a.opt.MaxEvaporationPercent
+
 	return &lhreq{
 		LHRequest:     req,
 		LHProperties:  prop,
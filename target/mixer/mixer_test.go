@@ -0,0 +1,38 @@
+package mixer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	driver "github.com/antha-lang/antha/microArch/driver/liquidhandling"
+	"github.com/antha-lang/antha/microArch/sampletracker"
+)
+
+func TestPolicyOptionsOverridden(t *testing.T) {
+	ctx := sampletracker.NewContext(testinventory.NewContext(context.Background()))
+
+	policies := []byte("version: 1\noptions:\n  USE_LLF: false\n  USE_DRIVER_TIP_TRACKING: true\n")
+
+	m := &Mixer{
+		properties: driver.NewLHProperties(1, "test", "test", "discrete", "disposable", map[string]wtype.Coordinates{}),
+		opt: Opt{
+			PolicyData:           [][]byte{policies},
+			UseLLF:               true,
+			UseDriverTipTracking: false,
+		},
+	}
+
+	req, err := m.makeLhreq(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e, f := true, req.Policies.Options["USE_LLF"]; e != f {
+		t.Errorf("expecting USE_LLF %v from the command line found %v", e, f)
+	}
+	if e, f := false, req.Policies.Options["USE_DRIVER_TIP_TRACKING"]; e != f {
+		t.Errorf("expecting USE_DRIVER_TIP_TRACKING %v from the command line found %v", e, f)
+	}
+}
@@ -42,6 +42,10 @@ type Opt struct {
 	// Direct specification of Output plates
 	OutputPlates []*wtype.LHPlate
 
+	// Liquid handling policy rule sets, in JSON or YAML, merged in order
+	// into the default rule set
+	PolicyData [][]byte
+
 	// Specify file name in the instruction stream of any driver generated file
 	DriverOutputFileName string
 
@@ -53,13 +57,15 @@ type Opt struct {
 	DriverSpecificTipWastePreferences []string
 	DriverSpecificWashPreferences     []string
 
-	ModelEvaporation     bool
-	OutputSort           bool
-	PrintInstructions    bool
-	UseDriverTipTracking bool
-	UseLLF               bool // allow the use of LLF
-	LegacyVolume         bool // don't track volumes for intermediates
-	FixVolumes           bool // aim to revise requested volumes to service requirements
+	ModelEvaporation      bool
+	OutputSort            bool
+	PrintInstructions     bool
+	UseDriverTipTracking  bool
+	UseLLF                bool    // allow the use of LLF
+	LegacyVolume          bool    // don't track volumes for intermediates
+	FixVolumes            bool    // aim to revise requested volumes to service requirements
+	MaxDilutionCV         float64 // warn if the expected CV of any dilution exceeds this; zero disables
+	MaxEvaporationPercent float64 // warn if any well is expected to lose more than this percentage to evaporation; zero disables
 }
 
 // Merge two configs together and return the result. Values in the argument
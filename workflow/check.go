@@ -0,0 +1,258 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/component"
+)
+
+const wunitPkg = "github.com/antha-lang/antha/antha/anthalib/wunit"
+
+// A Problem is an issue with a workflow found before execution
+type Problem struct {
+	Port    Port
+	Message string
+}
+
+// String returns a string representation of a problem
+func (a Problem) String() string {
+	switch {
+	case len(a.Port.Port) != 0:
+		return fmt.Sprintf("%s: %s", a.Port, a.Message)
+	case len(a.Port.Process) != 0:
+		return fmt.Sprintf("%s: %s", a.Port.Process, a.Message)
+	default:
+		return a.Message
+	}
+}
+
+// A CheckError is the set of problems found by Check
+type CheckError struct {
+	Problems []Problem
+}
+
+// Error returns one line per problem
+func (a *CheckError) Error() string {
+	var lines []string
+	for _, p := range a.Problems {
+		lines = append(lines, p.String())
+	}
+	return fmt.Sprintf("%d problems found in workflow:\n\t%s", len(a.Problems), strings.Join(lines, "\n\t"))
+}
+
+// CheckOpt are options for Check
+type CheckOpt struct {
+	Desc *Desc
+	// Parameter values by process and port
+	Params map[string]map[string]json.RawMessage
+	// Components available to the workflow
+	Library []component.Component
+}
+
+type portDesc struct {
+	Kind string
+	Type reflect.Type
+}
+
+func (a portDesc) isInput() bool {
+	return a.Kind == "Inputs" || a.Kind == "Parameters"
+}
+
+type checker struct {
+	problems []Problem
+	ports    map[string]map[string]portDesc // by process and then port
+	sets     map[Port][]string              // how each input port is set
+}
+
+func (a *checker) addf(port Port, format string, args ...interface{}) {
+	a.problems = append(a.problems, Problem{Port: port, Message: fmt.Sprintf(format, args...)})
+}
+
+func makePorts(c component.Component) (map[string]portDesc, error) {
+	if err := component.UpdateParamTypes(&c); err != nil {
+		return nil, err
+	}
+	values, err := c.NewParams()
+	if err != nil {
+		return nil, err
+	}
+	ports := make(map[string]portDesc)
+	for _, p := range c.Description.Params {
+		v, ok := values[p.Name]
+		if !ok {
+			return nil, fmt.Errorf("no type for parameter %q", p.Name)
+		}
+		ports[p.Name] = portDesc{
+			Kind: p.Kind,
+			Type: reflect.TypeOf(v).Elem(),
+		}
+	}
+	return ports, nil
+}
+
+func (a *checker) checkProcesses(desc *Desc, lib []component.Component) {
+	comps := make(map[string]component.Component)
+	for _, c := range lib {
+		if c.Stage != api.ElementStage_STEPS {
+			continue
+		}
+		comps[c.Name] = c
+	}
+
+	for name, process := range desc.Processes {
+		c, ok := comps[process.Component]
+		if !ok {
+			a.addf(Port{Process: name}, "unknown component %q", process.Component)
+			continue
+		}
+		ports, err := makePorts(c)
+		if err != nil {
+			a.addf(Port{Process: name}, "cannot get parameters of component %q: %s", process.Component, err)
+			continue
+		}
+		a.ports[name] = ports
+	}
+}
+
+// lookup returns the description of a port; ok is false if the process is
+// unknown, which has already been reported.
+func (a *checker) lookup(port Port) (desc portDesc, found, ok bool) {
+	ports, ok := a.ports[port.Process]
+	if !ok {
+		return
+	}
+	desc, found = ports[port.Port]
+	return
+}
+
+func (a *checker) checkConnections(desc *Desc) {
+	for _, c := range desc.Connections {
+		if _, known := desc.Processes[c.Src.Process]; !known {
+			a.addf(c.Src, "unknown source process")
+		}
+		if _, known := desc.Processes[c.Tgt.Process]; !known {
+			a.addf(c.Tgt, "unknown target process")
+		}
+
+		src, sfound, sok := a.lookup(c.Src)
+		tgt, tfound, tok := a.lookup(c.Tgt)
+		if sok && !sfound {
+			a.addf(c.Src, "unknown port")
+		} else if sok && src.isInput() {
+			a.addf(c.Src, "source of connection to %s is not an output", c.Tgt)
+		}
+		if tok && !tfound {
+			a.addf(c.Tgt, "unknown port")
+		} else if tok && !tgt.isInput() {
+			a.addf(c.Tgt, "target of connection from %s is not an input", c.Src)
+		} else if tok {
+			a.sets[c.Tgt] = append(a.sets[c.Tgt], "connection from "+c.Src.String())
+		}
+
+		if sfound && tfound && !src.Type.AssignableTo(tgt.Type) {
+			a.addf(c.Tgt, "cannot connect %s of type %s to input of type %s", c.Src, src.Type, tgt.Type)
+		}
+	}
+}
+
+// parseUnit checks that a value of a unit-bearing type can be parsed. wunit
+// constructors panic on unknown units so recover and report those too.
+func parseUnit(t reflect.Type, data []byte) (err error) {
+	defer func() {
+		if res := recover(); res != nil {
+			err = fmt.Errorf("%v", res)
+		}
+	}()
+	return json.Unmarshal(data, reflect.New(t).Interface())
+}
+
+func isUnit(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Map || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t.PkgPath() == wunitPkg
+}
+
+func (a *checker) checkParams(desc *Desc, params map[string]map[string]json.RawMessage) {
+	for process, values := range params {
+		if _, known := desc.Processes[process]; !known {
+			a.addf(Port{Process: process}, "parameters given for unknown process")
+			continue
+		}
+		for name, data := range values {
+			port := Port{Process: process, Port: name}
+			pdesc, found, ok := a.lookup(port)
+			if !ok {
+				continue
+			} else if !found {
+				a.addf(port, "unknown parameter")
+				continue
+			} else if !pdesc.isInput() {
+				a.addf(port, "cannot set value of output")
+				continue
+			}
+			a.sets[port] = append(a.sets[port], "parameter")
+
+			if isUnit(pdesc.Type) {
+				if err := parseUnit(pdesc.Type, data); err != nil {
+					a.addf(port, "cannot parse %s as %s: %s", string(data), pdesc.Type, err)
+				}
+			}
+		}
+	}
+}
+
+func (a *checker) checkAssignments() {
+	for process, ports := range a.ports {
+		for name, pdesc := range ports {
+			if !pdesc.isInput() {
+				continue
+			}
+			port := Port{Process: process, Port: name}
+			switch sets := a.sets[port]; {
+			case len(sets) > 1:
+				a.addf(port, "input set more than once: %s", strings.Join(sets, ", "))
+			case len(sets) == 0 && pdesc.Kind == "Inputs":
+				a.addf(port, "required input not set")
+			}
+		}
+	}
+}
+
+// Check verifies a workflow against a component library without executing
+// it. It checks that every process refers to a known component, every
+// connection joins an output to an input of an assignable type, every input
+// (but not necessarily every parameter) is set exactly once and that
+// parameters with units can be parsed. All problems found are returned
+// together as a *CheckError.
+func Check(opt CheckOpt) error {
+	desc := opt.Desc
+	if desc == nil {
+		desc = &Desc{}
+	}
+
+	c := &checker{
+		ports: make(map[string]map[string]portDesc),
+		sets:  make(map[Port][]string),
+	}
+
+	c.checkProcesses(desc, opt.Library)
+	c.checkConnections(desc)
+	c.checkParams(desc, opt.Params)
+	c.checkAssignments()
+
+	if len(c.problems) == 0 {
+		return nil
+	}
+
+	sort.Slice(c.problems, func(i, j int) bool {
+		return c.problems[i].String() < c.problems[j].String()
+	})
+
+	return &CheckError{Problems: c.problems}
+}
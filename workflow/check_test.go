@@ -0,0 +1,118 @@
+package workflow
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/component"
+	"github.com/antha-lang/antha/inject"
+)
+
+type mixInput struct {
+	Sample string
+	Volume wunit.Volume
+}
+
+type mixOutput struct {
+	Mixed string
+	Count int
+}
+
+func checkLibrary() []component.Component {
+	return []component.Component{
+		{
+			Name:  "Mix",
+			Stage: api.ElementStage_STEPS,
+			Constructor: func() interface{} {
+				return &inject.CheckedRunner{In: &mixInput{}, Out: &mixOutput{}}
+			},
+			Description: component.Description{
+				Params: []component.ParamDesc{
+					{Name: "Sample", Kind: "Inputs"},
+					{Name: "Volume", Kind: "Parameters"},
+					{Name: "Mixed", Kind: "Outputs"},
+					{Name: "Count", Kind: "Data"},
+				},
+			},
+		},
+	}
+}
+
+func TestCheckValid(t *testing.T) {
+	desc := &Desc{
+		Processes: map[string]Process{
+			"A": {Component: "Mix"},
+			"B": {Component: "Mix"},
+		},
+		Connections: []Connection{
+			{Src: Port{Process: "A", Port: "Mixed"}, Tgt: Port{Process: "B", Port: "Sample"}},
+		},
+	}
+	params := map[string]map[string]json.RawMessage{
+		"A": {
+			"Sample": json.RawMessage(`"water"`),
+			"Volume": json.RawMessage(`"10ul"`),
+		},
+	}
+
+	if err := Check(CheckOpt{Desc: desc, Params: params, Library: checkLibrary()}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckProblems(t *testing.T) {
+	desc := &Desc{
+		Processes: map[string]Process{
+			"A": {Component: "Mix"},
+			"B": {Component: "Mix"},
+			"C": {Component: "Unknown"},
+		},
+		Connections: []Connection{
+			{Src: Port{Process: "A", Port: "Count"}, Tgt: Port{Process: "B", Port: "Sample"}},
+			{Src: Port{Process: "A", Port: "Missing"}, Tgt: Port{Process: "B", Port: "Volume"}},
+		},
+	}
+	params := map[string]map[string]json.RawMessage{
+		"A": {
+			"Volume": json.RawMessage(`"10 parsecs"`),
+		},
+		"B": {
+			"Sample": json.RawMessage(`"water"`),
+		},
+		"D": {},
+	}
+
+	err := Check(CheckOpt{Desc: desc, Params: params, Library: checkLibrary()})
+	cerr, ok := err.(*CheckError)
+	if !ok {
+		t.Fatalf("expecting *CheckError found %T: %v", err, err)
+	}
+
+	expected := []string{
+		"A.Missing: unknown port",
+		"A.Sample: required input not set",
+		"A.Volume: cannot parse",
+		"B.Sample: cannot connect A.Count of type int to input of type string",
+		"B.Sample: input set more than once",
+		"C: unknown component",
+		"D: parameters given for unknown process",
+	}
+	if e, f := len(expected), len(cerr.Problems); e != f {
+		t.Errorf("expecting %d problems found %d: %s", e, f, cerr)
+	}
+	for _, e := range expected {
+		var found bool
+		for _, p := range cerr.Problems {
+			if strings.HasPrefix(p.String(), e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expecting problem %q in: %s", e, cerr)
+		}
+	}
+}